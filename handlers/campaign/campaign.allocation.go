@@ -0,0 +1,27 @@
+package campaign
+
+import (
+	"errors"
+
+	"github.com/jackgihokim/coupon-issuance-system/handlers/coupon"
+	couponv1 "github.com/jackgihokim/coupon-issuance-system/protos/coupon/v1"
+)
+
+// WithAllocation splits the coupon limit of the campaign across the channels of the allocation policy.
+func WithAllocation(p *couponv1.AllocationPolicy) Option {
+	return func(c *Campaign) {
+		c.Allocation = p
+	}
+}
+
+// validateAllocation checks the allocations against the coupon limit and that they can spill over during the
+// campaign. The winners of a draw are issued their coupons through no channel.
+func (c *Campaign) validateAllocation() error {
+	if c.Lottery != nil {
+		return errors.New("lottery campaign cannot allocate its coupons to channels")
+	}
+	if at := c.Allocation.SpilloverAt; at != nil && !at.AsTime().Before(c.EndAt) {
+		return errors.New("spillover must be before the end of the campaign")
+	}
+	return coupon.ValidateAllocation(c.Allocation, c.CouponLimit)
+}
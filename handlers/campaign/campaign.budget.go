@@ -0,0 +1,58 @@
+package campaign
+
+import (
+	"fmt"
+
+	"github.com/jackgihokim/coupon-issuance-system/handlers/discount"
+	couponv1 "github.com/jackgihokim/coupon-issuance-system/protos/coupon/v1"
+)
+
+// WithBudget caps what the coupons of the campaign cost by the budget.
+func WithBudget(budget *couponv1.Budget) Option {
+	return func(c *Campaign) {
+		c.Budget = budget
+	}
+}
+
+// validateBudget checks the budget and that whatever the coupons of the campaign are worth is in its currency.
+func (c *Campaign) validateBudget() error {
+	if err := discount.ValidateBudget(c.Budget); err != nil {
+		return err
+	}
+
+	currency := c.Budget.Amount.Currency
+	for _, d := range c.discounts() {
+		if other := discount.Currency(d); other != "" && other != currency {
+			return fmt.Errorf("discount in %s cannot be spent from a budget in %s", other, currency)
+		}
+	}
+	if c.StoredValue != nil && c.StoredValue.Currency != currency {
+		return fmt.Errorf("stored value in %s cannot be spent from a budget in %s", c.StoredValue.Currency, currency)
+	}
+	return nil
+}
+
+// couponCost returns what a coupon of the campaign can cost when redeemed in full: its stored value, or the largest
+// fixed amount of the discounts its coupons can be issued with, or 0 if it depends on the order.
+func (c *Campaign) couponCost() int64 {
+	if c.StoredValue != nil {
+		return c.StoredValue.Amount
+	}
+	var cost int64
+	for _, d := range c.discounts() {
+		cost = max(cost, d.GetFixedAmount().GetAmount().GetAmount())
+	}
+	return cost
+}
+
+// discounts returns the discounts the coupons of the campaign can be issued with, some of which may be nil.
+func (c *Campaign) discounts() []*couponv1.Discount {
+	discounts := []*couponv1.Discount{c.Discount, c.Referral.GetReward()}
+	for _, t := range c.Tiers {
+		discounts = append(discounts, t.Discount)
+	}
+	for _, v := range c.Variants {
+		discounts = append(discounts, v.Discount)
+	}
+	return discounts
+}
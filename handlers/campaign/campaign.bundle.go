@@ -0,0 +1,86 @@
+package campaign
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/jackgihokim/coupon-issuance-system/common/id"
+)
+
+// Bundle issues one coupon of each of its campaigns together.
+type Bundle struct {
+	Id          uint32
+	Name        string
+	Description string
+	CampaignIds []uint32
+	CreatedAt   time.Time
+}
+
+var (
+	bundleId *id.ID = id.NewID()
+	bundles         = struct {
+		mu sync.Mutex
+		m  map[uint32]*Bundle
+	}{m: make(map[uint32]*Bundle)}
+)
+
+// NewBundle creates a bundle of the campaigns and stores it.
+// Returns the bundle or an error if a campaign is unknown, listed twice or issues its coupons on its own terms.
+func NewBundle(name, desc string, campaignIds []uint32) (*Bundle, error) {
+	if len(campaignIds) == 0 {
+		return nil, errors.New("bundle needs at least one campaign")
+	}
+	seen := make(map[uint32]struct{}, len(campaignIds))
+	for _, campId := range campaignIds {
+		if _, ok := seen[campId]; ok {
+			return nil, fmt.Errorf("campaign %d is in the bundle twice", campId)
+		}
+		seen[campId] = struct{}{}
+
+		camp, err := GetCampaign(campId)
+		if err != nil {
+			return nil, fmt.Errorf("campaign %d: %w", campId, err)
+		}
+		if err := camp.validateBundled(); err != nil {
+			return nil, fmt.Errorf("campaign %d: %w", campId, err)
+		}
+	}
+
+	b := &Bundle{
+		Id:          bundleId.Next(),
+		Name:        name,
+		Description: desc,
+		CampaignIds: append([]uint32(nil), campaignIds...),
+		CreatedAt:   time.Now().UTC(), // must use UTC for being the same as timestamppb.
+	}
+	bundles.mu.Lock()
+	defer bundles.mu.Unlock()
+	bundles.m[b.Id] = b
+	return b, nil
+}
+
+// GetBundle returns the bundle with the specified ID. Returns an error if the bundle is not found.
+func GetBundle(id uint32) (*Bundle, error) {
+	bundles.mu.Lock()
+	defer bundles.mu.Unlock()
+	b, ok := bundles.m[id]
+	if !ok {
+		return nil, errors.New("bundle not found")
+	}
+	return b, nil
+}
+
+// validateBundled checks that the campaign issues its coupons on request, so they can be issued in a bundle.
+func (c *Campaign) validateBundled() error {
+	switch {
+	case c.Lottery != nil:
+		return errors.New("coupons of a lottery campaign are issued by its draw")
+	case c.Referrals != nil:
+		return errors.New("coupons of a referral campaign are issued for referrals")
+	case c.WaitingRoom != nil:
+		return errors.New("campaign with a waiting room cannot be bundled")
+	}
+	return nil
+}
@@ -0,0 +1,45 @@
+package campaign
+
+import (
+	"testing"
+	"time"
+
+	couponv1 "github.com/jackgihokim/coupon-issuance-system/protos/coupon/v1"
+)
+
+func TestNewBundle(t *testing.T) {
+	now := time.Now().UTC()
+	a, _ := NewCampaign(10, "a", "desc", now, now.Add(time.Hour))
+	defer store.delete(a.Id)
+	b, _ := NewCampaign(10, "b", "desc", now, now.Add(time.Hour))
+	defer store.delete(b.Id)
+	referral, _ := NewCampaign(10, "referral", "desc", now, now.Add(time.Hour), WithReferral(&couponv1.ReferralPolicy{}))
+	defer store.delete(referral.Id)
+
+	testCases := []struct {
+		name        string
+		campaignIds []uint32
+		wantErr     bool
+	}{
+		{"two campaigns", []uint32{a.Id, b.Id}, false},
+		{"no campaign", nil, true},
+		{"campaign twice", []uint32{a.Id, a.Id}, true},
+		{"unknown campaign", []uint32{a.Id, 0}, true},
+		{"referral campaign", []uint32{a.Id, referral.Id}, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			bundle, err := NewBundle("welcome pack", "desc", tc.campaignIds)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("NewBundle() error = %v, wantErr %v", err, tc.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if got, err := GetBundle(bundle.Id); err != nil || got != bundle {
+				t.Errorf("GetBundle() = %v, %v", got, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,137 @@
+package campaign
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxCronSearch bounds how far ahead the next occurrence of a schedule is searched.
+const maxCronSearch = 5 * 366 * 24 * time.Hour
+
+// cronSchedule is a cron expression of five fields: minute, hour, day of month, month and day of week.
+// Each field is *, a value, a range a-b, a step */n or a-b/n, or a comma-separated list of them.
+// Days of week are 0 to 6 from Sunday, and 7 is Sunday too.
+type cronSchedule struct {
+	minute, hour, dom, month, dow uint64
+	// domAny and dowAny tell whether the day fields are *. If both are restricted, a day matching either is taken.
+	domAny, dowAny bool
+}
+
+// parseCron parses the cron expression. Returns an error describing the first invalid field.
+func parseCron(expr string) (*cronSchedule, error) {
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("cron schedule must have 5 fields, got %d", len(fields))
+	}
+
+	s := &cronSchedule{domAny: fields[2] == "*", dowAny: fields[4] == "*"}
+	for _, f := range []struct {
+		name        string
+		src         string
+		first, last int
+		bits        *uint64
+	}{
+		{"minute", fields[0], 0, 59, &s.minute},
+		{"hour", fields[1], 0, 23, &s.hour},
+		{"day of month", fields[2], 1, 31, &s.dom},
+		{"month", fields[3], 1, 12, &s.month},
+		{"day of week", fields[4], 0, 7, &s.dow},
+	} {
+		bits, err := parseCronField(f.src, f.first, f.last)
+		if err != nil {
+			return nil, fmt.Errorf("invalid cron %s %q: %w", f.name, f.src, err)
+		}
+		*f.bits = bits
+	}
+	if s.dow&(1<<7) != 0 {
+		s.dow |= 1
+	}
+	return s, nil
+}
+
+// parseCronField parses a field into a bit set of the values it matches.
+func parseCronField(src string, first, last int) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(src, ",") {
+		rng, step := part, 1
+		if i := strings.IndexByte(part, '/'); i >= 0 {
+			n, err := strconv.Atoi(part[i+1:])
+			if err != nil || n <= 0 {
+				return 0, fmt.Errorf("invalid step %s", part[i+1:])
+			}
+			rng, step = part[:i], n
+		}
+
+		lo, hi := first, last
+		switch {
+		case rng == "*":
+		case strings.Contains(rng, "-"):
+			a, b, _ := strings.Cut(rng, "-")
+			var err1, err2 error
+			lo, err1 = strconv.Atoi(a)
+			hi, err2 = strconv.Atoi(b)
+			if err1 != nil || err2 != nil {
+				return 0, fmt.Errorf("invalid range %s", rng)
+			}
+		default:
+			n, err := strconv.Atoi(rng)
+			if err != nil {
+				return 0, fmt.Errorf("invalid value %s", rng)
+			}
+			lo, hi = n, n
+			if step > 1 {
+				hi = last
+			}
+		}
+		if lo < first || hi > last || lo > hi {
+			return 0, fmt.Errorf("values must be between %d and %d", first, last)
+		}
+
+		for v := lo; v <= hi; v += step {
+			bits |= 1 << v
+		}
+	}
+	return bits, nil
+}
+
+// next returns the first time after t which matches the schedule in the location.
+// Returns the zero time if there is none within maxCronSearch.
+func (s *cronSchedule) next(t time.Time, loc *time.Location) time.Time {
+	t = t.In(loc).Truncate(time.Minute).Add(time.Minute)
+	limit := t.Add(maxCronSearch)
+
+	for t.Before(limit) {
+		y, m, d := t.Date()
+		var n time.Time
+		switch {
+		case s.month&(1<<m) == 0:
+			n = time.Date(y, m+1, 1, 0, 0, 0, 0, loc)
+		case !s.matchDay(t):
+			n = time.Date(y, m, d+1, 0, 0, 0, 0, loc)
+		case s.hour&(1<<t.Hour()) == 0:
+			n = time.Date(y, m, d, t.Hour()+1, 0, 0, 0, loc)
+		case s.minute&(1<<t.Minute()) == 0:
+			n = t.Add(time.Minute)
+		default:
+			return t
+		}
+		// Daylight saving time can normalize a wall clock time backwards, so always move forward
+		if !n.After(t) {
+			n = t.Add(time.Minute)
+		}
+		t = n
+	}
+	return time.Time{}
+}
+
+// matchDay reports whether the day of t matches the day of month and day of week fields.
+func (s *cronSchedule) matchDay(t time.Time) bool {
+	dom := s.dom&(1<<t.Day()) != 0
+	dow := s.dow&(1<<t.Weekday()) != 0
+	if s.domAny || s.dowAny {
+		return dom && dow
+	}
+	return dom || dow
+}
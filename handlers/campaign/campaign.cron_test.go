@@ -0,0 +1,78 @@
+package campaign
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseCron(t *testing.T) {
+	testCases := []struct {
+		name    string
+		expr    string
+		wantErr bool
+	}{
+		{"every day at 10", "0 10 * * *", false},
+		{"weekdays every 15 minutes", "*/15 9-18 * * 1-5", false},
+		{"lists and steps", "0,30 8-20/4 1,15 * 7", false},
+		{"too few fields", "0 10 * *", true},
+		{"minute out of range", "60 10 * * *", true},
+		{"reversed range", "0 10 20-10 * *", true},
+		{"zero step", "*/0 * * * *", true},
+		{"not a number", "0 ten * * *", true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := parseCron(tc.expr)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("parseCron() error = %v, wantErr %v", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestCronSchedule_Next(t *testing.T) {
+	seoul, _ := time.LoadLocation("Asia/Seoul")
+	newYork, _ := time.LoadLocation("America/New_York")
+
+	testCases := []struct {
+		name string
+		expr string
+		loc  *time.Location
+		from time.Time
+		want time.Time
+	}{
+		{"later today", "0 10 * * *", seoul,
+			time.Date(2025, 5, 1, 9, 30, 0, 0, seoul), time.Date(2025, 5, 1, 10, 0, 0, 0, seoul)},
+		{"strictly after", "0 10 * * *", seoul,
+			time.Date(2025, 5, 1, 10, 0, 0, 0, seoul), time.Date(2025, 5, 2, 10, 0, 0, 0, seoul)},
+		{"in the time zone", "0 10 * * *", seoul,
+			time.Date(2025, 5, 1, 0, 30, 0, 0, time.UTC), time.Date(2025, 5, 1, 1, 0, 0, 0, time.UTC)},
+		{"steps", "*/15 * * * *", time.UTC,
+			time.Date(2025, 5, 1, 9, 16, 30, 0, time.UTC), time.Date(2025, 5, 1, 9, 30, 0, 0, time.UTC)},
+		{"next weekday", "0 9 * * 1-5", time.UTC,
+			time.Date(2025, 5, 2, 9, 0, 0, 0, time.UTC), time.Date(2025, 5, 5, 9, 0, 0, 0, time.UTC)},
+		{"Sunday as 7", "0 0 * * 7", time.UTC,
+			time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC), time.Date(2025, 5, 4, 0, 0, 0, 0, time.UTC)},
+		{"day of month or week", "0 0 13 * 5", time.UTC,
+			time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC), time.Date(2025, 6, 6, 0, 0, 0, 0, time.UTC)},
+		{"leap day", "0 0 29 2 *", time.UTC,
+			time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC), time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC)},
+		{"skipped by daylight saving time", "30 2 * * *", newYork,
+			time.Date(2025, 3, 9, 0, 0, 0, 0, newYork), time.Date(2025, 3, 10, 2, 30, 0, 0, newYork)},
+		{"never", "0 0 31 2 *", time.UTC,
+			time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), time.Time{}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			s, err := parseCron(tc.expr)
+			if err != nil {
+				t.Fatalf("parseCron() error = %v", err)
+			}
+			if got := s.next(tc.from, tc.loc); !got.Equal(tc.want) {
+				t.Errorf("next() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
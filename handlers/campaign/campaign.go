@@ -1,10 +1,19 @@
 package campaign
 
 import (
+	"errors"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/jackgihokim/coupon-issuance-system/common/id"
 	"github.com/jackgihokim/coupon-issuance-system/handlers/coupon"
+	"github.com/jackgihokim/coupon-issuance-system/handlers/discount"
+	"github.com/jackgihokim/coupon-issuance-system/handlers/eligibility"
+	"github.com/jackgihokim/coupon-issuance-system/handlers/lottery"
+	"github.com/jackgihokim/coupon-issuance-system/handlers/userlist"
+	"github.com/jackgihokim/coupon-issuance-system/handlers/waitingroom"
+	couponv1 "github.com/jackgihokim/coupon-issuance-system/protos/coupon/v1"
 )
 
 type Campaign struct {
@@ -16,6 +25,134 @@ type Campaign struct {
 	StartAt     time.Time
 	EndAt       time.Time
 	Coupons     *coupon.Coupons
+	History     *History
+	// ExpiryPolicy decides when the issued coupons expire. Coupons expire at EndAt if it is nil.
+	ExpiryPolicy *couponv1.ExpiryPolicy
+	// Discount describes what the coupons of the campaign are worth. Issued coupons keep a snapshot of it.
+	Discount *couponv1.Discount
+	// Tiers give the coupons their discounts by the order of issuance instead of Discount. Nil issues them untiered.
+	Tiers []*couponv1.Tier
+	// Variants give the coupons the discounts of the variants their users are assigned instead of Discount.
+	// Nil issues them without an A/B test.
+	Variants []*couponv1.Variant
+	// StoredValue makes the coupons gift card style, starting with the balance, instead of giving a discount.
+	StoredValue *couponv1.Money
+	// Budget caps what the coupons cost as they are redeemed. Nil issues them whatever they cost.
+	Budget *couponv1.Budget
+	// Allocation splits CouponLimit across the channels issuing the coupons. Nil lets any caller issue them.
+	Allocation *couponv1.AllocationPolicy
+	// RestorePolicy decides whether reversing a redemption of the coupons makes them usable again.
+	RestorePolicy couponv1.RestorePolicy
+	// Transfer lets the owners of the coupons transfer them to other users. Nil makes them not transferable.
+	Transfer *couponv1.TransferPolicy
+	// Applicability limits the products, channels and payment methods the coupons can be used for.
+	Applicability *couponv1.Applicability
+	// Stacking decides which coupons of other campaigns the coupons can be combined with in a cart.
+	Stacking *couponv1.StackingPolicy
+	// Eligibility decides which users can be issued the coupons. Every user is eligible if it is nil.
+	Eligibility *eligibility.Rule
+	// Recurrence opens the campaign in recurring occurrences, with CouponLimit per occurrence. Nil opens it once.
+	Recurrence *couponv1.Recurrence
+	recurrence *recurrence
+	// Throttle spreads CouponLimit over time slices with a quota each. Nil issues without slices.
+	Throttle *couponv1.Throttle
+	// WaitingRoom makes users queue in Queue for admission before they can be issued coupons. Nil lets anyone in.
+	WaitingRoom *couponv1.WaitingRoom
+	Queue       *waitingroom.Queue
+	// Lottery draws the users who are issued the coupons among its entries at EndAt. Nil issues them first come,
+	// first served.
+	Lottery *lottery.Lottery
+	// Waitlist queues users for the slots given back once the campaign is sold out. Nil has no waitlist.
+	Waitlist *Waitlist
+	// Referral issues the coupons to users referred with the referral codes in Referrals, and rewards their
+	// referrers. Nil issues them without referrals.
+	Referral  *couponv1.ReferralPolicy
+	Referrals *Referrals
+	// allowlist and blocklist restrict which users can be issued the coupons. They are uploaded after creation
+	// and replaced as a whole, so readers never see a list which is still being uploaded.
+	allowlist atomic.Pointer[userlist.List]
+	blocklist atomic.Pointer[userlist.List]
+
+	// issueMu is held for reading while coupons are issued in the state checked, and for writing by the transitions,
+	// so a pause or close cannot land between the check and the coupons issued.
+	issueMu sync.RWMutex
+	stateMu sync.Mutex
+	// setState is the state operators set: draft, paused, ended or cancelled, or unspecified to follow the period.
+	setState couponv1.CampaignState
+	closedAt time.Time
+}
+
+// Option configures optional settings of a campaign on creation.
+type Option func(*Campaign)
+
+// WithExpiryPolicy sets the policy which decides when the coupons of the campaign expire.
+func WithExpiryPolicy(policy *couponv1.ExpiryPolicy) Option {
+	return func(c *Campaign) {
+		c.ExpiryPolicy = policy
+	}
+}
+
+// WithDiscount sets what the coupons of the campaign are worth.
+func WithDiscount(d *couponv1.Discount) Option {
+	return func(c *Campaign) {
+		c.Discount = d
+	}
+}
+
+// WithStoredValue makes the campaign issue stored-value coupons starting with the balance.
+func WithStoredValue(balance *couponv1.Money) Option {
+	return func(c *Campaign) {
+		c.StoredValue = balance
+	}
+}
+
+// WithRestorePolicy sets whether reversing a redemption of the coupons of the campaign makes them usable again.
+func WithRestorePolicy(p couponv1.RestorePolicy) Option {
+	return func(c *Campaign) {
+		c.RestorePolicy = p
+	}
+}
+
+// WithTransfer lets the owners of the coupons of the campaign transfer them to other users under the policy.
+func WithTransfer(p *couponv1.TransferPolicy) Option {
+	return func(c *Campaign) {
+		c.Transfer = p
+	}
+}
+
+// WithApplicability limits what the coupons of the campaign can be used for.
+func WithApplicability(a *couponv1.Applicability) Option {
+	return func(c *Campaign) {
+		c.Applicability = a
+	}
+}
+
+// WithStacking sets which coupons the coupons of the campaign can be combined with.
+func WithStacking(p *couponv1.StackingPolicy) Option {
+	return func(c *Campaign) {
+		c.Stacking = p
+	}
+}
+
+// WithThrottle spreads the issuance of the campaign over time slices with a quota each.
+func WithThrottle(t *couponv1.Throttle) Option {
+	return func(c *Campaign) {
+		c.Throttle = t
+	}
+}
+
+// WithWaitingRoom makes users queue for admission before they can be issued coupons of the campaign.
+func WithWaitingRoom(w *couponv1.WaitingRoom) Option {
+	return func(c *Campaign) {
+		c.WaitingRoom = w
+	}
+}
+
+// WithEligibility sets the rule which decides which users can be issued the coupons of the campaign.
+func WithEligibility(rule *eligibility.Rule) Option {
+	return func(c *Campaign) {
+		c.Eligibility = rule
+	}
 }
 
 var (
@@ -23,9 +160,10 @@ var (
 	store      *Store = newCampaignStore()
 )
 
-// NewCampaign creates a new campaign with the provided parameters and stores it.
-// Returns a pointer to the newly created Campaign object or an error if the campaign could not be stored.
-func NewCampaign(limit uint32, name, desc string, start, end time.Time) (*Campaign, error) {
+// NewCampaign creates a new campaign with the provided parameters and options, and stores it.
+// Returns a pointer to the newly created Campaign object or an error if the options are invalid
+// or the campaign could not be stored.
+func NewCampaign(limit uint32, name, desc string, start, end time.Time, opts ...Option) (*Campaign, error) {
 	camp := &Campaign{
 		Id:          campaignId.Next(),
 		CouponLimit: limit,
@@ -35,6 +173,124 @@ func NewCampaign(limit uint32, name, desc string, start, end time.Time) (*Campai
 		StartAt:     start,
 		EndAt:       end,
 		Coupons:     coupon.NewCoupons(limit),
+		History:     newHistory(),
+	}
+	for _, opt := range opts {
+		opt(camp)
+	}
+
+	if camp.ExpiryPolicy != nil {
+		if err := coupon.ValidateExpiryPolicy(camp.ExpiryPolicy); err != nil {
+			return nil, err
+		}
+	}
+
+	if camp.Discount != nil {
+		if err := discount.Validate(camp.Discount); err != nil {
+			return nil, err
+		}
+	}
+
+	if camp.StoredValue != nil {
+		if err := discount.ValidateStoredValue(camp.StoredValue); err != nil {
+			return nil, err
+		}
+		if camp.Discount != nil {
+			return nil, errors.New("stored-value campaign cannot have a discount")
+		}
+	}
+
+	if _, ok := couponv1.RestorePolicy_name[int32(camp.RestorePolicy)]; !ok {
+		return nil, errors.New("unknown restore policy")
+	}
+
+	if camp.Transfer != nil {
+		if err := coupon.ValidateTransferPolicy(camp.Transfer); err != nil {
+			return nil, err
+		}
+	}
+
+	if camp.Applicability != nil {
+		if err := discount.ValidateApplicability(camp.Applicability); err != nil {
+			return nil, err
+		}
+	}
+
+	if camp.Stacking != nil {
+		if err := discount.ValidateStacking(camp.Stacking); err != nil {
+			return nil, err
+		}
+	}
+
+	if camp.Recurrence != nil {
+		r, err := newRecurrence(camp.Recurrence, camp.StartAt, camp.EndAt)
+		if err != nil {
+			return nil, err
+		}
+		camp.recurrence = r
+	}
+
+	if camp.Throttle != nil {
+		if err := coupon.ValidateThrottle(camp.Throttle); err != nil {
+			return nil, err
+		}
+		camp.Coupons.SetThrottle(camp.StartAt, camp.Throttle.Slice.AsDuration(), camp.Throttle.Quota, camp.Throttle.Rollover)
+	}
+
+	if camp.WaitingRoom != nil {
+		if err := waitingroom.Validate(camp.WaitingRoom); err != nil {
+			return nil, err
+		}
+		camp.Queue = waitingroom.NewQueue(camp.WaitingRoom, camp.CreatedAt)
+	}
+
+	if camp.Lottery != nil {
+		if err := camp.validateLottery(); err != nil {
+			return nil, err
+		}
+	}
+
+	if camp.Waitlist != nil {
+		if err := camp.validateWaitlist(); err != nil {
+			return nil, err
+		}
+		camp.Coupons.WatchExpiry()
+	}
+
+	if camp.Referral != nil {
+		if err := camp.validateReferral(); err != nil {
+			return nil, err
+		}
+	}
+
+	if camp.Tiers != nil {
+		if err := camp.validateTiers(); err != nil {
+			return nil, err
+		}
+		camp.Coupons.SetTiers(camp.Tiers)
+	}
+
+	if camp.Variants != nil {
+		if err := camp.validateVariants(); err != nil {
+			return nil, err
+		}
+		camp.Coupons.SetVariants(camp.Variants)
+	}
+
+	if camp.Budget != nil {
+		if err := camp.validateBudget(); err != nil {
+			return nil, err
+		}
+		camp.Coupons.SetBudget(camp.Budget, camp.couponCost())
+		// The coupons expiring unused give back what they reserved of the budget
+		camp.Coupons.WatchExpiry()
+	}
+
+	if camp.Allocation != nil {
+		if err := camp.validateAllocation(); err != nil {
+			return nil, err
+		}
+		camp.Coupons.SetAllocation(camp.Allocation)
 	}
 
 	err := store.add(camp)
@@ -45,6 +301,12 @@ func NewCampaign(limit uint32, name, desc string, start, end time.Time) (*Campai
 	return camp, nil
 }
 
+// ListCampaigns returns all the stored campaigns in no particular order.
+func ListCampaigns() []*Campaign {
+	list, _ := store.list()
+	return list
+}
+
 // GetCampaign retrieves a campaign by its unique ID from the store.
 // Returns the campaign details or an error if the campaign does not exist.
 func GetCampaign(id uint32) (*Campaign, error) {
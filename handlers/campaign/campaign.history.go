@@ -0,0 +1,31 @@
+package campaign
+
+import (
+	"sync"
+
+	couponv1 "github.com/jackgihokim/coupon-issuance-system/protos/coupon/v1"
+)
+
+type History struct {
+	mu   sync.Mutex
+	list []*couponv1.CampaignEvent
+}
+
+// newHistory initializes and returns a new instance of History with no events.
+func newHistory() *History {
+	return &History{}
+}
+
+// Record appends the event to the history in a thread-safe manner.
+func (h *History) Record(event *couponv1.CampaignEvent) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.list = append(h.list, event)
+}
+
+// List returns the recorded events in the order they occurred.
+func (h *History) List() []*couponv1.CampaignEvent {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.list
+}
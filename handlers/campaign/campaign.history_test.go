@@ -0,0 +1,43 @@
+package campaign
+
+import (
+	"testing"
+
+	couponv1 "github.com/jackgihokim/coupon-issuance-system/protos/coupon/v1"
+)
+
+func TestHistory_Record(t *testing.T) {
+	history := newHistory()
+	first := &couponv1.CampaignEvent{Type: couponv1.CampaignEventType_CAMPAIGN_EVENT_TYPE_COUPON_REVOKED, Code: "A"}
+	second := &couponv1.CampaignEvent{Type: couponv1.CampaignEventType_CAMPAIGN_EVENT_TYPE_SLOT_RETURNED, Code: "A"}
+
+	history.Record(first)
+	history.Record(second)
+
+	list := history.List()
+	if len(list) != 2 {
+		t.Fatalf("expected event count: 2, actual: %d", len(list))
+	}
+	if list[0] != first || list[1] != second {
+		t.Errorf("events are not listed in the recorded order")
+	}
+}
+
+func TestHistory_ConcurrentRecord(t *testing.T) {
+	history := newHistory()
+	done := make(chan bool)
+
+	for i := 0; i < 10; i++ {
+		go func() {
+			history.Record(&couponv1.CampaignEvent{})
+			done <- true
+		}()
+	}
+	for i := 0; i < 10; i++ {
+		<-done
+	}
+
+	if len(history.List()) != 10 {
+		t.Errorf("expected event count: 10, actual: %d", len(history.List()))
+	}
+}
@@ -0,0 +1,48 @@
+package campaign
+
+import (
+	"errors"
+	"time"
+
+	"github.com/jackgihokim/coupon-issuance-system/handlers/coupon"
+	"github.com/jackgihokim/coupon-issuance-system/handlers/lottery"
+)
+
+// WithLottery makes the campaign collect entries in the lottery until EndAt and draw the users it issues
+// the coupons to, instead of issuing them first come, first served.
+func WithLottery(l *lottery.Lottery) Option {
+	return func(c *Campaign) {
+		c.Lottery = l
+	}
+}
+
+// validateLottery checks that the settings of the campaign work with a lottery.
+// Its coupons are issued as of EndAt, so it needs an expiry policy which expires them after EndAt, and it cannot
+// pace or gate the issuance.
+func (c *Campaign) validateLottery() error {
+	if c.ExpiryPolicy == nil {
+		return errors.New("lottery campaign needs an expiry policy")
+	}
+	expiresAt, err := coupon.Expiration(c.ExpiryPolicy, c.EndAt, c.EndAt)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case !expiresAt.After(c.EndAt):
+		return errors.New("lottery campaign's coupons must expire after its end")
+	case c.Recurrence != nil:
+		return errors.New("lottery campaign cannot recur")
+	case c.Throttle != nil:
+		return errors.New("lottery campaign cannot be throttled")
+	case c.WaitingRoom != nil:
+		return errors.New("lottery campaign cannot have a waiting room")
+	}
+	return nil
+}
+
+// LotteryDue reports whether the lottery of the campaign is to be drawn at now, which is once EndAt passed.
+// A campaign closed early is never drawn, as closing a campaign invalidates its coupons.
+func (c *Campaign) LotteryDue(now time.Time) bool {
+	return c.Lottery != nil && c.EndAt.Before(now) && c.ClosedAt().IsZero()
+}
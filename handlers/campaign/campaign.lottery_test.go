@@ -0,0 +1,81 @@
+package campaign
+
+import (
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/jackgihokim/coupon-issuance-system/handlers/lottery"
+	couponv1 "github.com/jackgihokim/coupon-issuance-system/protos/coupon/v1"
+)
+
+func TestNewCampaign_WithLottery(t *testing.T) {
+	now := time.Now().UTC()
+	policy := WithExpiryPolicy(&couponv1.ExpiryPolicy{Policy: &couponv1.ExpiryPolicy_Ttl{Ttl: durationpb.New(72 * time.Hour)}})
+	fixedAt := func(t time.Time) Option {
+		return WithExpiryPolicy(&couponv1.ExpiryPolicy{Policy: &couponv1.ExpiryPolicy_FixedAt{FixedAt: timestamppb.New(t)}})
+	}
+
+	testCases := []struct {
+		name    string
+		opts    []Option
+		wantErr bool
+	}{
+		{"with an expiry policy", []Option{policy}, false},
+		{"without an expiry policy", nil, true},
+		{"expiring after the end", []Option{fixedAt(now.Add(2 * time.Hour))}, false},
+		{"expiring at the end", []Option{fixedAt(now.Add(time.Hour))}, true},
+		{"expiring before the end", []Option{fixedAt(now.Add(time.Minute))}, true},
+		{"recurring", []Option{policy, WithRecurrence(&couponv1.Recurrence{Schedule: "0 10 * * *"})}, true},
+		{"throttled", []Option{policy, WithThrottle(&couponv1.Throttle{Slice: durationpb.New(time.Minute), Quota: 1})}, true},
+		{"with a waiting room", []Option{policy, WithWaitingRoom(&couponv1.WaitingRoom{AdmissionsPerSecond: 1})}, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			l, err := lottery.New()
+			if err != nil {
+				t.Fatalf("lottery.New() error = %v", err)
+			}
+			camp, err := NewCampaign(10, "name", "desc", now, now.Add(time.Hour), append(tc.opts, WithLottery(l))...)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("NewCampaign() error = %v, wantErr %v", err, tc.wantErr)
+			}
+			if err == nil {
+				store.delete(camp.Id)
+			}
+		})
+	}
+}
+
+func TestCampaign_LotteryDue(t *testing.T) {
+	now := time.Now().UTC()
+	policy := WithExpiryPolicy(&couponv1.ExpiryPolicy{Policy: &couponv1.ExpiryPolicy_Ttl{Ttl: durationpb.New(72 * time.Hour)}})
+	newLottery := func() *Campaign {
+		l, _ := lottery.New()
+		camp, err := NewCampaign(10, "name", "desc", now, now.Add(time.Hour), policy, WithLottery(l))
+		if err != nil {
+			t.Fatalf("NewCampaign() error = %v", err)
+		}
+		t.Cleanup(func() { store.delete(camp.Id) })
+		return camp
+	}
+
+	camp := newLottery()
+	if camp.LotteryDue(now.Add(time.Minute)) {
+		t.Errorf("LotteryDue() before EndAt = true")
+	}
+	if !camp.LotteryDue(now.Add(2 * time.Hour)) {
+		t.Errorf("LotteryDue() after EndAt = false")
+	}
+
+	closed := newLottery()
+	if err := closed.Close(now.Add(time.Minute)); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	if closed.LotteryDue(now.Add(2 * time.Hour)) {
+		t.Errorf("LotteryDue() of a campaign closed early = true")
+	}
+}
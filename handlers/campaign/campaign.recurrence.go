@@ -0,0 +1,119 @@
+package campaign
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	couponv1 "github.com/jackgihokim/coupon-issuance-system/protos/coupon/v1"
+)
+
+// WithRecurrence opens the campaign in recurring occurrences of the schedule, with the coupon limit per occurrence.
+func WithRecurrence(r *couponv1.Recurrence) Option {
+	return func(c *Campaign) {
+		c.Recurrence = r
+	}
+}
+
+// recurrence finds the occurrences of a recurring campaign. It remembers the latest occurrence it has found,
+// so finding the one open at now only walks the schedule from there as time goes by.
+type recurrence struct {
+	schedule *cronSchedule
+	loc      *time.Location
+	window   time.Duration // zero keeps each occurrence open until the next one.
+	endAt    time.Time
+
+	mu    sync.Mutex
+	start time.Time // the latest occurrence which has opened, or the first one if none has.
+	next  time.Time // the occurrence after start, or the zero time if there is none.
+}
+
+// newRecurrence validates the settings and finds the first occurrence of the campaign period.
+// Returns an error if the settings are invalid or no occurrence opens in the period.
+func newRecurrence(settings *couponv1.Recurrence, startAt, endAt time.Time) (*recurrence, error) {
+	schedule, err := parseCron(settings.Schedule)
+	if err != nil {
+		return nil, err
+	}
+	loc, err := time.LoadLocation(settings.TimeZone)
+	if err != nil {
+		return nil, fmt.Errorf("invalid time zone %q", settings.TimeZone)
+	}
+	var window time.Duration
+	if settings.Window != nil {
+		if err := settings.Window.CheckValid(); err != nil {
+			return nil, err
+		}
+		window = settings.Window.AsDuration()
+		if window <= 0 {
+			return nil, errors.New("recurrence window must be positive")
+		}
+	}
+
+	r := &recurrence{schedule: schedule, loc: loc, window: window, endAt: endAt}
+	r.start = schedule.next(startAt.Add(-time.Minute), loc)
+	if r.start.IsZero() || !r.start.Before(endAt) {
+		return nil, errors.New("recurrence has no occurrence in the campaign period")
+	}
+	r.next = schedule.next(r.start, loc)
+	return r, nil
+}
+
+// advance moves to the latest occurrence which has opened at now, and returns it along with the one after it.
+func (r *recurrence) advance(now time.Time) (start, next time.Time) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for !r.next.IsZero() && r.next.Before(r.endAt) && !r.next.After(now) {
+		r.start = r.next
+		r.next = r.schedule.next(r.start, r.loc)
+	}
+	return r.start, r.next
+}
+
+// end returns when the occurrence which opens at start and is followed by next closes.
+func (r *recurrence) end(start, next time.Time) time.Time {
+	end := r.endAt
+	if !next.IsZero() && next.Before(end) {
+		end = next
+	}
+	if r.window > 0 && start.Add(r.window).Before(end) {
+		end = start.Add(r.window)
+	}
+	return end.UTC()
+}
+
+// Occurrence returns when the occurrence open at now opens and closes.
+// Returns false if the campaign does not recur or no occurrence is open at now.
+func (c *Campaign) Occurrence(now time.Time) (start, end time.Time, ok bool) {
+	if c.recurrence == nil {
+		return time.Time{}, time.Time{}, false
+	}
+	start, next := c.recurrence.advance(now)
+	if now.Before(start) {
+		return time.Time{}, time.Time{}, false
+	}
+	end = c.recurrence.end(start, next)
+	if !now.Before(end) {
+		return time.Time{}, time.Time{}, false
+	}
+	return start.UTC(), end, true
+}
+
+// NextOccurrence returns when the first occurrence opening after now opens and closes.
+// Returns false if the campaign does not recur or no occurrence opens after now in the campaign period.
+func (c *Campaign) NextOccurrence(now time.Time) (start, end time.Time, ok bool) {
+	if c.recurrence == nil {
+		return time.Time{}, time.Time{}, false
+	}
+	start, next := c.recurrence.advance(now)
+	if !now.Before(start) {
+		start = next
+	}
+	if start.IsZero() || !start.Before(c.recurrence.endAt) {
+		return time.Time{}, time.Time{}, false
+	}
+	end = c.recurrence.end(start, c.recurrence.schedule.next(start, c.recurrence.loc))
+	return start.UTC(), end, true
+}
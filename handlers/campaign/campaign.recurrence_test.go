@@ -0,0 +1,90 @@
+package campaign
+
+import (
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/jackgihokim/coupon-issuance-system/handlers/coupon"
+	couponv1 "github.com/jackgihokim/coupon-issuance-system/protos/coupon/v1"
+)
+
+func TestNewRecurrence(t *testing.T) {
+	startAt := time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC)
+	endAt := startAt.Add(7 * 24 * time.Hour)
+
+	testCases := []struct {
+		name     string
+		settings *couponv1.Recurrence
+		wantErr  bool
+	}{
+		{"valid recurrence", &couponv1.Recurrence{Schedule: "0 10 * * *", TimeZone: "Asia/Seoul"}, false},
+		{"UTC by default", &couponv1.Recurrence{Schedule: "0 10 * * *"}, false},
+		{"invalid schedule", &couponv1.Recurrence{Schedule: "every day"}, true},
+		{"invalid time zone", &couponv1.Recurrence{Schedule: "0 10 * * *", TimeZone: "Mars/Olympus"}, true},
+		{"zero window", &couponv1.Recurrence{Schedule: "0 10 * * *", Window: durationpb.New(0)}, true},
+		{"no occurrence in the period", &couponv1.Recurrence{Schedule: "0 10 1 1 *"}, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := newRecurrence(tc.settings, startAt, endAt)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("newRecurrence() error = %v, wantErr %v", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestCampaign_Occurrence(t *testing.T) {
+	seoul, _ := time.LoadLocation("Asia/Seoul")
+	startAt := time.Date(2025, 5, 1, 0, 0, 0, 0, seoul)
+	endAt := time.Date(2025, 5, 3, 12, 0, 0, 0, seoul)
+	r, err := newRecurrence(&couponv1.Recurrence{
+		Schedule: "0 10 * * *",
+		TimeZone: "Asia/Seoul",
+		Window:   durationpb.New(1 * time.Hour),
+	}, startAt, endAt)
+	if err != nil {
+		t.Fatalf("newRecurrence() error = %v", err)
+	}
+	camp := &Campaign{StartAt: startAt, EndAt: endAt, Coupons: coupon.NewCoupons(100), recurrence: r}
+
+	at := func(day, hour, minute int) time.Time {
+		return time.Date(2025, 5, day, hour, minute, 0, 0, seoul)
+	}
+
+	testCases := []struct {
+		name      string
+		now       time.Time
+		wantOpen  bool
+		wantStart time.Time
+		wantNext  time.Time
+		wantState couponv1.CampaignState
+	}{
+		{"before the first occurrence", at(1, 9, 0), false, time.Time{}, at(1, 10, 0), couponv1.CampaignState_CAMPAIGN_STATE_SCHEDULED},
+		{"in the first occurrence", at(1, 10, 30), true, at(1, 10, 0), at(2, 10, 0), couponv1.CampaignState_CAMPAIGN_STATE_ACTIVE},
+		{"after the window", at(1, 11, 0), false, time.Time{}, at(2, 10, 0), couponv1.CampaignState_CAMPAIGN_STATE_SCHEDULED},
+		{"in the last occurrence", at(3, 10, 59), true, at(3, 10, 0), time.Time{}, couponv1.CampaignState_CAMPAIGN_STATE_ACTIVE},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			start, end, ok := camp.Occurrence(tc.now)
+			if ok != tc.wantOpen || !start.Equal(tc.wantStart) {
+				t.Errorf("Occurrence() = %v, %v, want %v, %v", start, ok, tc.wantStart, tc.wantOpen)
+			}
+			if ok && !end.Equal(tc.wantStart.Add(time.Hour)) {
+				t.Errorf("Occurrence() end = %v, want an hour after the start", end)
+			}
+			next, _, _ := camp.NextOccurrence(tc.now)
+			if !next.Equal(tc.wantNext) {
+				t.Errorf("NextOccurrence() = %v, want %v", next, tc.wantNext)
+			}
+			if st := camp.State(tc.now); st != tc.wantState {
+				t.Errorf("State() = %v, want %v", st, tc.wantState)
+			}
+		})
+	}
+}
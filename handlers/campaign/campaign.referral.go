@@ -0,0 +1,203 @@
+package campaign
+
+import (
+	"crypto/rand"
+	"encoding/base32"
+	"errors"
+	"sync"
+
+	"github.com/jackgihokim/coupon-issuance-system/handlers/discount"
+	couponv1 "github.com/jackgihokim/coupon-issuance-system/protos/coupon/v1"
+)
+
+// maxReferralCodeTries bounds the attempts to generate a referral code which is not taken.
+const maxReferralCodeTries = 10
+
+// Referrals tracks the referral codes of a referral campaign, the users referred with them and the rewards owed
+// to their referrers.
+type Referrals struct {
+	max uint32 // how many users each referrer can refer, or 0 for any number.
+
+	mu       sync.Mutex
+	codes    map[string]*referrer // by referral code.
+	users    map[string]string    // the referral code of each referrer.
+	referees map[string]string    // the referral code each referee was referred with.
+	// pending maps the coupons of the referees who have not redeemed them yet to their referral codes, and owed
+	// counts by referral code the redemptions whose rewards are not issued yet.
+	pending map[string]string
+	owed    map[string]uint32
+}
+
+type referrer struct {
+	userId    string
+	referrals uint32
+	rewards   uint32
+}
+
+// WithReferral makes the campaign issue its coupons to referred users and rewards to their referrers.
+func WithReferral(p *couponv1.ReferralPolicy) Option {
+	return func(c *Campaign) {
+		c.Referral = p
+		c.Referrals = &Referrals{
+			max:      p.MaxReferrals,
+			codes:    make(map[string]*referrer),
+			users:    make(map[string]string),
+			referees: make(map[string]string),
+			pending:  make(map[string]string),
+			owed:     make(map[string]uint32),
+		}
+	}
+}
+
+// validateReferral checks the reward of the referral policy and that the settings of the campaign work with it.
+// A lottery issues its coupons by its draw, so it cannot issue them for referrals.
+func (c *Campaign) validateReferral() error {
+	if c.Lottery != nil {
+		return errors.New("lottery campaign cannot issue coupons for referrals")
+	}
+	if c.Referral.Reward != nil {
+		if c.StoredValue != nil {
+			return errors.New("stored-value campaign cannot have a reward discount")
+		}
+		if err := discount.Validate(c.Referral.Reward); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Code returns the referral code of the user, creating it on the first call.
+// Returns an error if the user ID is empty or no unique code could be generated.
+func (r *Referrals) Code(campaignId uint32, userId string) (*couponv1.ReferralCode, error) {
+	if userId == "" {
+		return nil, errors.New("user ID is required")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if code, ok := r.users[userId]; ok {
+		return r.message(campaignId, code), nil
+	}
+	for i := 0; i < maxReferralCodeTries; i++ {
+		code, err := newReferralCode()
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := r.codes[code]; ok {
+			continue
+		}
+		r.codes[code] = &referrer{userId: userId}
+		r.users[userId] = code
+		return r.message(campaignId, code), nil
+	}
+	return nil, errors.New("failed to generate a unique referral code")
+}
+
+// Refer issues a coupon to the referee with issue for the referral code, which returns the code of the coupon.
+// A user can be referred once, not with their own code nor with the code of a user they referred, and each
+// referrer can refer up to the maximum number of referrals.
+// Returns an error if the referee cannot be referred with the code, or the error of issue.
+func (r *Referrals) Refer(code, refereeId string, issue func() (string, error)) error {
+	if code == "" {
+		return errors.New("referral code is required for the campaign")
+	}
+	if refereeId == "" {
+		return errors.New("user ID is required for a referral")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	ref, ok := r.codes[code]
+	switch {
+	case !ok:
+		return errors.New("referral code not found")
+	case ref.userId == refereeId:
+		return errors.New("cannot use your own referral code")
+	case r.referredBy(ref.userId) == refereeId:
+		return errors.New("cannot use the referral code of a user you referred")
+	}
+	if _, ok := r.referees[refereeId]; ok {
+		return errors.New("user was already referred")
+	}
+	if r.max > 0 && ref.referrals >= r.max {
+		return errors.New("referrer reached the maximum number of referrals")
+	}
+
+	couponCode, err := issue()
+	if err != nil {
+		return err
+	}
+	ref.referrals++
+	r.referees[refereeId] = code
+	r.pending[couponCode] = code
+	return nil
+}
+
+// Confirm confirms the first redemption of a referee's coupon, so its referrer is owed a reward.
+// Returns the referral code of the coupon and whether the coupon was waiting for its first redemption.
+func (r *Referrals) Confirm(couponCode string) (string, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	code, ok := r.pending[couponCode]
+	if !ok {
+		return "", false
+	}
+	delete(r.pending, couponCode)
+	r.owed[code]++
+	return code, true
+}
+
+// Reward issues the rewards owed to the referrer of the referral code with issue, which returns the code of the
+// reward coupon. A reward whose coupon fails to be issued stays owed for the next call.
+// Returns the codes of the issued reward coupons and the referrer, or the error of issue.
+func (r *Referrals) Reward(code string, issue func(userId string) (string, error)) ([]string, string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	ref, ok := r.codes[code]
+	if !ok {
+		return nil, "", errors.New("referral code not found")
+	}
+
+	var issued []string
+	for r.owed[code] > 0 {
+		couponCode, err := issue(ref.userId)
+		if err != nil {
+			return issued, ref.userId, err
+		}
+		issued = append(issued, couponCode)
+		r.owed[code]--
+		ref.rewards++
+	}
+	delete(r.owed, code)
+	return issued, ref.userId, nil
+}
+
+// referredBy returns the referrer of the user, or empty if the user was not referred. The caller must hold mu.
+func (r *Referrals) referredBy(userId string) string {
+	code, ok := r.referees[userId]
+	if !ok {
+		return ""
+	}
+	return r.codes[code].userId
+}
+
+// message converts the referral code into its protobuf message. The caller must hold mu.
+func (r *Referrals) message(campaignId uint32, code string) *couponv1.ReferralCode {
+	ref := r.codes[code]
+	return &couponv1.ReferralCode{
+		Code:       code,
+		CampaignId: campaignId,
+		UserId:     ref.userId,
+		Referrals:  ref.referrals,
+		Rewards:    ref.rewards,
+	}
+}
+
+// newReferralCode returns a random referral code of 8 characters which is easy to share.
+func newReferralCode() (string, error) {
+	b := make([]byte, 5)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base32.StdEncoding.EncodeToString(b), nil
+}
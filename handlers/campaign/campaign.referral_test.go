@@ -0,0 +1,146 @@
+package campaign
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	couponv1 "github.com/jackgihokim/coupon-issuance-system/protos/coupon/v1"
+)
+
+func TestReferrals_Refer(t *testing.T) {
+	now := time.Now().UTC()
+	camp, err := NewCampaign(10, "name", "desc", now, now.Add(time.Hour), WithReferral(&couponv1.ReferralPolicy{MaxReferrals: 2}))
+	if err != nil {
+		t.Fatalf("NewCampaign() error = %v", err)
+	}
+	defer store.delete(camp.Id)
+	r := camp.Referrals
+
+	alice, err := r.Code(camp.Id, "alice")
+	if err != nil {
+		t.Fatalf("Code() error = %v", err)
+	}
+	if again, _ := r.Code(camp.Id, "alice"); again.Code != alice.Code {
+		t.Errorf("Code() = %q and then %q for the same user", alice.Code, again.Code)
+	}
+
+	n := 0
+	issue := func() (string, error) {
+		n++
+		return fmt.Sprintf("coupon-%d", n), nil
+	}
+	testCases := []struct {
+		name    string
+		code    string
+		referee string
+		wantErr string
+	}{
+		{"unknown code", "unknown", "bob", "referral code not found"},
+		{"self-referral", alice.Code, "alice", "cannot use your own referral code"},
+		{"first referral", alice.Code, "bob", ""},
+		{"referred again", alice.Code, "bob", "user was already referred"},
+		{"second referral", alice.Code, "carol", ""},
+		{"over the maximum", alice.Code, "dave", "referrer reached the maximum number of referrals"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := r.Refer(tc.code, tc.referee, issue)
+			if tc.wantErr != "" {
+				if err == nil || err.Error() != tc.wantErr {
+					t.Fatalf("Refer() error = %v, want %q", err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Refer() error = %v", err)
+			}
+		})
+	}
+
+	// Bob cannot refer Alice back
+	bob, _ := r.Code(camp.Id, "bob")
+	if err := r.Refer(bob.Code, "alice", issue); err == nil || err.Error() != "cannot use the referral code of a user you referred" {
+		t.Errorf("Refer() error = %v for a referral back", err)
+	}
+	// A failed issuance does not count as a referral
+	if err := r.Refer(bob.Code, "erin", func() (string, error) { return "", errors.New("failed") }); err == nil {
+		t.Errorf("Refer() returned no error when the issuance failed")
+	}
+	if err := r.Refer(bob.Code, "erin", issue); err != nil {
+		t.Errorf("Refer() error = %v after a failed issuance", err)
+	}
+	if stats, _ := r.Code(camp.Id, "alice"); stats.Referrals != 2 {
+		t.Errorf("Referrals = %d, want 2", stats.Referrals)
+	}
+}
+
+func TestReferrals_Reward(t *testing.T) {
+	now := time.Now().UTC()
+	camp, err := NewCampaign(10, "name", "desc", now, now.Add(time.Hour), WithReferral(&couponv1.ReferralPolicy{}))
+	if err != nil {
+		t.Fatalf("NewCampaign() error = %v", err)
+	}
+	defer store.delete(camp.Id)
+	r := camp.Referrals
+
+	alice, _ := r.Code(camp.Id, "alice")
+	r.Refer(alice.Code, "bob", func() (string, error) { return "bob-coupon", nil })
+
+	if _, ok := r.Confirm("other-coupon"); ok {
+		t.Errorf("Confirm() of a coupon not issued for a referral returned true")
+	}
+	code, ok := r.Confirm("bob-coupon")
+	if !ok || code != alice.Code {
+		t.Fatalf("Confirm() = %q, %v", code, ok)
+	}
+	if _, ok := r.Confirm("bob-coupon"); ok {
+		t.Errorf("Confirm() of a coupon redeemed again returned true")
+	}
+
+	// A failed reward stays owed until it is issued
+	if issued, _, err := r.Reward(code, func(string) (string, error) { return "", errors.New("failed") }); err == nil || len(issued) != 0 {
+		t.Errorf("Reward() = %v, %v, want nothing and an error", issued, err)
+	}
+	issued, userId, err := r.Reward(code, func(userId string) (string, error) { return userId + "-reward", nil })
+	if err != nil || len(issued) != 1 || userId != "alice" {
+		t.Errorf("Reward() = %v, %q, %v", issued, userId, err)
+	}
+	if issued, _, _ := r.Reward(code, func(string) (string, error) { return "again", nil }); len(issued) != 0 {
+		t.Errorf("Reward() issued %v again", issued)
+	}
+	if stats, _ := r.Code(camp.Id, "alice"); stats.Rewards != 1 {
+		t.Errorf("Rewards = %d, want 1", stats.Rewards)
+	}
+}
+
+func TestNewCampaign_WithReferral(t *testing.T) {
+	now := time.Now().UTC()
+	freeShipping := &couponv1.Discount{Kind: &couponv1.Discount_FreeShipping_{FreeShipping: &couponv1.Discount_FreeShipping{}}}
+	balance := &couponv1.Money{Currency: "KRW", Amount: 10000}
+
+	testCases := []struct {
+		name    string
+		opts    []Option
+		wantErr bool
+	}{
+		{"reward discount", []Option{WithReferral(&couponv1.ReferralPolicy{Reward: freeShipping})}, false},
+		{"empty reward discount", []Option{WithReferral(&couponv1.ReferralPolicy{Reward: &couponv1.Discount{}})}, true},
+		{"stored value", []Option{WithStoredValue(balance), WithReferral(&couponv1.ReferralPolicy{})}, false},
+		{"stored value with a reward discount", []Option{WithStoredValue(balance), WithReferral(&couponv1.ReferralPolicy{Reward: freeShipping})}, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			camp, err := NewCampaign(1, "name", "desc", now, now.Add(time.Hour), tc.opts...)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("NewCampaign() error = %v, wantErr %v", err, tc.wantErr)
+			}
+			if camp != nil {
+				store.delete(camp.Id)
+			}
+		})
+	}
+}
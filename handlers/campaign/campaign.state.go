@@ -0,0 +1,147 @@
+package campaign
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	couponv1 "github.com/jackgihokim/coupon-issuance-system/protos/coupon/v1"
+)
+
+// WithDraft creates the campaign unpublished, so no coupon is issued until it is resumed.
+func WithDraft() Option {
+	return func(c *Campaign) {
+		c.setState = couponv1.CampaignState_CAMPAIGN_STATE_DRAFT
+	}
+}
+
+// State returns the state of the campaign at now. A closed campaign stays closed, and a campaign is over at EndAt
+// whatever operators set. Otherwise a draft or paused campaign stays so, and the rest follows from StartAt and
+// the remaining coupons and budget. A recurring campaign is scheduled between its occurrences.
+func (c *Campaign) State(now time.Time) couponv1.CampaignState {
+	c.stateMu.Lock()
+	defer c.stateMu.Unlock()
+	return c.state(now)
+}
+
+// state returns the state of the campaign at now. The caller must hold stateMu.
+func (c *Campaign) state(now time.Time) couponv1.CampaignState {
+	switch c.setState {
+	case couponv1.CampaignState_CAMPAIGN_STATE_ENDED, couponv1.CampaignState_CAMPAIGN_STATE_CANCELLED:
+		return c.setState
+	}
+	if c.EndAt.Before(now) {
+		return couponv1.CampaignState_CAMPAIGN_STATE_ENDED
+	}
+	switch c.setState {
+	case couponv1.CampaignState_CAMPAIGN_STATE_DRAFT, couponv1.CampaignState_CAMPAIGN_STATE_PAUSED:
+		return c.setState
+	}
+	if c.StartAt.After(now) {
+		return couponv1.CampaignState_CAMPAIGN_STATE_SCHEDULED
+	}
+	if c.recurrence != nil {
+		start, _, ok := c.Occurrence(now)
+		if !ok {
+			return couponv1.CampaignState_CAMPAIGN_STATE_SCHEDULED
+		}
+		if c.Coupons.RemainingInOccurrence(start) == 0 || !c.Coupons.BudgetCovers() {
+			return couponv1.CampaignState_CAMPAIGN_STATE_SOLD_OUT
+		}
+		return couponv1.CampaignState_CAMPAIGN_STATE_ACTIVE
+	}
+	if c.Coupons.Remaining() == 0 || !c.Coupons.BudgetCovers() {
+		return couponv1.CampaignState_CAMPAIGN_STATE_SOLD_OUT
+	}
+	return couponv1.CampaignState_CAMPAIGN_STATE_ACTIVE
+}
+
+// Ended reports whether the campaign is over at now, either at EndAt or closed early.
+func (c *Campaign) Ended(now time.Time) bool {
+	switch c.State(now) {
+	case couponv1.CampaignState_CAMPAIGN_STATE_ENDED, couponv1.CampaignState_CAMPAIGN_STATE_CANCELLED:
+		return true
+	}
+	return false
+}
+
+// ClosedAt returns when the campaign was closed, or the zero time if it was not.
+func (c *Campaign) ClosedAt() time.Time {
+	c.stateMu.Lock()
+	defer c.stateMu.Unlock()
+	return c.closedAt
+}
+
+// HoldState holds off pausing, resuming and closing the campaign until the returned function is called, so the
+// state checked while holding it still applies when the coupons are issued. Holders do not block one another.
+// A holder must not change the state of the campaign.
+func (c *Campaign) HoldState() func() {
+	c.issueMu.RLock()
+	return c.issueMu.RUnlock
+}
+
+// Pause stops issuing coupons of a scheduled, active or sold out campaign until it is resumed.
+// Returns an error if the campaign is in any other state.
+func (c *Campaign) Pause(now time.Time) error {
+	c.issueMu.Lock()
+	defer c.issueMu.Unlock()
+	c.stateMu.Lock()
+	defer c.stateMu.Unlock()
+
+	switch st := c.state(now); st {
+	case couponv1.CampaignState_CAMPAIGN_STATE_SCHEDULED,
+		couponv1.CampaignState_CAMPAIGN_STATE_ACTIVE,
+		couponv1.CampaignState_CAMPAIGN_STATE_SOLD_OUT:
+		c.setState = couponv1.CampaignState_CAMPAIGN_STATE_PAUSED
+		return nil
+	default:
+		return fmt.Errorf("cannot pause a campaign which is %s", stateName(st))
+	}
+}
+
+// Resume resumes a paused campaign or publishes a draft, so it follows its period again.
+// Returns an error if the campaign is in any other state.
+func (c *Campaign) Resume(now time.Time) error {
+	c.issueMu.Lock()
+	defer c.issueMu.Unlock()
+	c.stateMu.Lock()
+	defer c.stateMu.Unlock()
+
+	switch st := c.state(now); st {
+	case couponv1.CampaignState_CAMPAIGN_STATE_PAUSED, couponv1.CampaignState_CAMPAIGN_STATE_DRAFT:
+		c.setState = couponv1.CampaignState_CAMPAIGN_STATE_UNSPECIFIED
+		return nil
+	default:
+		return fmt.Errorf("cannot resume a campaign which is %s", stateName(st))
+	}
+}
+
+// Close stops the campaign for good. A draft or a campaign which has not started is cancelled,
+// and any other campaign ends early. Returns an error if the campaign is already over.
+func (c *Campaign) Close(now time.Time) error {
+	c.issueMu.Lock()
+	defer c.issueMu.Unlock()
+	c.stateMu.Lock()
+	defer c.stateMu.Unlock()
+
+	switch st := c.state(now); st {
+	case couponv1.CampaignState_CAMPAIGN_STATE_ENDED, couponv1.CampaignState_CAMPAIGN_STATE_CANCELLED:
+		return fmt.Errorf("cannot close a campaign which is %s", stateName(st))
+	case couponv1.CampaignState_CAMPAIGN_STATE_DRAFT:
+		c.setState = couponv1.CampaignState_CAMPAIGN_STATE_CANCELLED
+	default:
+		if c.StartAt.After(now) {
+			c.setState = couponv1.CampaignState_CAMPAIGN_STATE_CANCELLED
+		} else {
+			c.setState = couponv1.CampaignState_CAMPAIGN_STATE_ENDED
+		}
+	}
+	c.closedAt = now
+	return nil
+}
+
+// stateName returns the state in lower case words for messages, e.g. "sold out".
+func stateName(st couponv1.CampaignState) string {
+	name := strings.TrimPrefix(st.String(), "CAMPAIGN_STATE_")
+	return strings.ToLower(strings.ReplaceAll(name, "_", " "))
+}
@@ -0,0 +1,139 @@
+package campaign
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jackgihokim/coupon-issuance-system/handlers/coupon"
+	couponv1 "github.com/jackgihokim/coupon-issuance-system/protos/coupon/v1"
+)
+
+func newStateTestCampaign(now time.Time, start, end time.Duration, limit uint32) *Campaign {
+	return &Campaign{
+		StartAt: now.Add(start),
+		EndAt:   now.Add(end),
+		Coupons: coupon.NewCoupons(limit),
+	}
+}
+
+func TestCampaign_State(t *testing.T) {
+	now := time.Now().UTC()
+
+	testCases := []struct {
+		name     string
+		campaign *Campaign
+		setState couponv1.CampaignState
+		want     couponv1.CampaignState
+	}{
+		{"scheduled", newStateTestCampaign(now, time.Hour, 2*time.Hour, 1), 0, couponv1.CampaignState_CAMPAIGN_STATE_SCHEDULED},
+		{"active", newStateTestCampaign(now, -time.Hour, time.Hour, 1), 0, couponv1.CampaignState_CAMPAIGN_STATE_ACTIVE},
+		{"sold out", newStateTestCampaign(now, -time.Hour, time.Hour, 0), 0, couponv1.CampaignState_CAMPAIGN_STATE_SOLD_OUT},
+		{"ended", newStateTestCampaign(now, -2*time.Hour, -time.Hour, 1), 0, couponv1.CampaignState_CAMPAIGN_STATE_ENDED},
+		{"draft", newStateTestCampaign(now, -time.Hour, time.Hour, 1), couponv1.CampaignState_CAMPAIGN_STATE_DRAFT, couponv1.CampaignState_CAMPAIGN_STATE_DRAFT},
+		{"paused", newStateTestCampaign(now, -time.Hour, time.Hour, 0), couponv1.CampaignState_CAMPAIGN_STATE_PAUSED, couponv1.CampaignState_CAMPAIGN_STATE_PAUSED},
+		{"paused past its end", newStateTestCampaign(now, -2*time.Hour, -time.Hour, 1), couponv1.CampaignState_CAMPAIGN_STATE_PAUSED, couponv1.CampaignState_CAMPAIGN_STATE_ENDED},
+		{"cancelled", newStateTestCampaign(now, -2*time.Hour, -time.Hour, 1), couponv1.CampaignState_CAMPAIGN_STATE_CANCELLED, couponv1.CampaignState_CAMPAIGN_STATE_CANCELLED},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.campaign.setState = tc.setState
+			if got := tc.campaign.State(now); got != tc.want {
+				t.Errorf("State() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestCampaign_Transitions(t *testing.T) {
+	now := time.Now().UTC()
+
+	t.Run("pause and resume", func(t *testing.T) {
+		camp := newStateTestCampaign(now, -time.Hour, time.Hour, 1)
+		if err := camp.Resume(now); err == nil {
+			t.Errorf("Resume() of an active campaign expected an error")
+		}
+		if err := camp.Pause(now); err != nil {
+			t.Fatalf("Pause() error = %v", err)
+		}
+		if err := camp.Pause(now); err == nil || err.Error() != "cannot pause a campaign which is paused" {
+			t.Errorf("Pause() of a paused campaign error = %v", err)
+		}
+		if err := camp.Resume(now); err != nil {
+			t.Fatalf("Resume() error = %v", err)
+		}
+		if camp.State(now) != couponv1.CampaignState_CAMPAIGN_STATE_ACTIVE {
+			t.Errorf("State() after Resume() = %v, want active", camp.State(now))
+		}
+	})
+
+	t.Run("publish a draft", func(t *testing.T) {
+		camp := newStateTestCampaign(now, time.Hour, 2*time.Hour, 1)
+		WithDraft()(camp)
+		if err := camp.Pause(now); err == nil {
+			t.Errorf("Pause() of a draft expected an error")
+		}
+		if err := camp.Resume(now); err != nil {
+			t.Fatalf("Resume() error = %v", err)
+		}
+		if camp.State(now) != couponv1.CampaignState_CAMPAIGN_STATE_SCHEDULED {
+			t.Errorf("State() after publishing = %v, want scheduled", camp.State(now))
+		}
+	})
+
+	t.Run("close before the start cancels", func(t *testing.T) {
+		camp := newStateTestCampaign(now, time.Hour, 2*time.Hour, 1)
+		if err := camp.Close(now); err != nil {
+			t.Fatalf("Close() error = %v", err)
+		}
+		if camp.State(now) != couponv1.CampaignState_CAMPAIGN_STATE_CANCELLED || !camp.ClosedAt().Equal(now) {
+			t.Errorf("State() after Close() = %v at %v, want cancelled", camp.State(now), camp.ClosedAt())
+		}
+	})
+
+	t.Run("close after the start ends early", func(t *testing.T) {
+		camp := newStateTestCampaign(now, -time.Hour, time.Hour, 1)
+		_ = camp.Pause(now)
+		if err := camp.Close(now); err != nil {
+			t.Fatalf("Close() error = %v", err)
+		}
+		if !camp.Ended(now) || camp.State(now) != couponv1.CampaignState_CAMPAIGN_STATE_ENDED {
+			t.Errorf("State() after Close() = %v, want ended", camp.State(now))
+		}
+		if err := camp.Resume(now); err == nil {
+			t.Errorf("Resume() of a closed campaign expected an error")
+		}
+		if err := camp.Close(now); err == nil || err.Error() != "cannot close a campaign which is ended" {
+			t.Errorf("Close() of a closed campaign error = %v", err)
+		}
+	})
+}
+
+func TestCampaign_HoldState(t *testing.T) {
+	now := time.Now().UTC()
+	camp := newStateTestCampaign(now, -time.Hour, time.Hour, 1)
+
+	release := camp.HoldState()
+	paused := make(chan error)
+	go func() {
+		paused <- camp.Pause(now)
+	}()
+
+	// The pause waits for the holder, which still sees the campaign active
+	select {
+	case err := <-paused:
+		t.Fatalf("Pause() returned %v while the state was held", err)
+	case <-time.After(50 * time.Millisecond):
+	}
+	if camp.State(now) != couponv1.CampaignState_CAMPAIGN_STATE_ACTIVE {
+		t.Errorf("State() while held = %v, want active", camp.State(now))
+	}
+
+	release()
+	if err := <-paused; err != nil {
+		t.Fatalf("Pause() error = %v", err)
+	}
+	if camp.State(now) != couponv1.CampaignState_CAMPAIGN_STATE_PAUSED {
+		t.Errorf("State() after Pause() = %v, want paused", camp.State(now))
+	}
+}
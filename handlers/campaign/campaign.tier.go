@@ -0,0 +1,29 @@
+package campaign
+
+import (
+	"errors"
+
+	"github.com/jackgihokim/coupon-issuance-system/handlers/coupon"
+	couponv1 "github.com/jackgihokim/coupon-issuance-system/protos/coupon/v1"
+)
+
+// WithTiers gives the coupons of the campaign the discounts of the tiers in order of issuance.
+func WithTiers(tiers []*couponv1.Tier) Option {
+	return func(c *Campaign) {
+		c.Tiers = tiers
+	}
+}
+
+// validateTiers checks the tiers against the coupon limit and that the settings of the campaign work with them.
+// The tiers give the discounts, and the slots a waitlist is issued are given back out of the issuance sequence.
+func (c *Campaign) validateTiers() error {
+	switch {
+	case c.Discount != nil:
+		return errors.New("tiered campaign cannot have a discount")
+	case c.StoredValue != nil:
+		return errors.New("stored-value campaign cannot have tiers")
+	case c.Waitlist != nil:
+		return errors.New("tiered campaign cannot have a waitlist")
+	}
+	return coupon.ValidateTiers(c.Tiers, c.CouponLimit)
+}
@@ -0,0 +1,60 @@
+package campaign
+
+import (
+	"errors"
+	"sync/atomic"
+
+	"github.com/jackgihokim/coupon-issuance-system/handlers/userlist"
+	couponv1 "github.com/jackgihokim/coupon-issuance-system/protos/coupon/v1"
+)
+
+// userList returns the slot of the campaign which holds the user list of the kind.
+// Returns an error if the kind is not specified.
+func (c *Campaign) userList(kind couponv1.UserListKind) (*atomic.Pointer[userlist.List], error) {
+	switch kind {
+	case couponv1.UserListKind_USER_LIST_KIND_ALLOWLIST:
+		return &c.allowlist, nil
+	case couponv1.UserListKind_USER_LIST_KIND_BLOCKLIST:
+		return &c.blocklist, nil
+	}
+	return nil, errors.New("user list kind must be specified")
+}
+
+// SetUserList replaces the campaign's user list of the kind with the fully built list.
+// Returns an error if the kind is not specified.
+func (c *Campaign) SetUserList(kind couponv1.UserListKind, list *userlist.List) error {
+	slot, err := c.userList(kind)
+	if err != nil {
+		return err
+	}
+	slot.Store(list)
+	return nil
+}
+
+// UserList returns the campaign's user list of the kind, or nil if none is uploaded.
+func (c *Campaign) UserList(kind couponv1.UserListKind) *userlist.List {
+	slot, err := c.userList(kind)
+	if err != nil {
+		return nil
+	}
+	return slot.Load()
+}
+
+// CheckUser checks the user against the campaign's allowlist and blocklist.
+// Returns an error if the user is not on the allowlist or is on the blocklist.
+func (c *Campaign) CheckUser(userId string) error {
+	allowlist, blocklist := c.allowlist.Load(), c.blocklist.Load()
+	if allowlist == nil && blocklist == nil {
+		return nil
+	}
+	if userId == "" {
+		return errors.New("user ID is required for the campaign")
+	}
+	if allowlist != nil && !allowlist.Contains(userId) {
+		return errors.New("user is not on the campaign's allowlist")
+	}
+	if blocklist != nil && blocklist.Contains(userId) {
+		return errors.New("user is blocked from the campaign")
+	}
+	return nil
+}
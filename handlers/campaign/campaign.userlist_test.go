@@ -0,0 +1,58 @@
+package campaign
+
+import (
+	"testing"
+
+	"github.com/jackgihokim/coupon-issuance-system/handlers/userlist"
+	couponv1 "github.com/jackgihokim/coupon-issuance-system/protos/coupon/v1"
+)
+
+func newUserList(t *testing.T, userIds ...string) *userlist.List {
+	t.Helper()
+	list, err := userlist.New(nil)
+	if err != nil {
+		t.Fatalf("userlist.New() error = %v", err)
+	}
+	for _, id := range userIds {
+		if err := list.Add(id); err != nil {
+			t.Fatalf("Add() error = %v", err)
+		}
+	}
+	return list
+}
+
+func TestCampaign_CheckUser(t *testing.T) {
+	camp := &Campaign{}
+	if err := camp.CheckUser(""); err != nil {
+		t.Errorf("CheckUser() without lists error = %v", err)
+	}
+
+	if err := camp.SetUserList(couponv1.UserListKind_USER_LIST_KIND_UNSPECIFIED, newUserList(t)); err == nil {
+		t.Errorf("SetUserList() with an unspecified kind expected an error")
+	}
+	_ = camp.SetUserList(couponv1.UserListKind_USER_LIST_KIND_ALLOWLIST, newUserList(t, "alice", "bob"))
+	_ = camp.SetUserList(couponv1.UserListKind_USER_LIST_KIND_BLOCKLIST, newUserList(t, "bob"))
+
+	testCases := []struct {
+		name    string
+		userId  string
+		wantErr string
+	}{
+		{"allowed user", "alice", ""},
+		{"user not on the allowlist", "carol", "user is not on the campaign's allowlist"},
+		{"blocked user", "bob", "user is blocked from the campaign"},
+		{"no user", "", "user ID is required for the campaign"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := camp.CheckUser(tc.userId)
+			if tc.wantErr == "" && err != nil {
+				t.Errorf("CheckUser() error = %v", err)
+			}
+			if tc.wantErr != "" && (err == nil || err.Error() != tc.wantErr) {
+				t.Errorf("CheckUser() error = %v, want %s", err, tc.wantErr)
+			}
+		})
+	}
+}
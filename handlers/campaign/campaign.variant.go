@@ -0,0 +1,36 @@
+package campaign
+
+import (
+	"errors"
+
+	"github.com/jackgihokim/coupon-issuance-system/handlers/coupon"
+	couponv1 "github.com/jackgihokim/coupon-issuance-system/protos/coupon/v1"
+)
+
+// WithVariants gives the coupons of the campaign the discounts of the variants assigned to their users.
+func WithVariants(variants []*couponv1.Variant) Option {
+	return func(c *Campaign) {
+		c.Variants = variants
+	}
+}
+
+// validateVariants checks the variants against the coupon limit and that the settings of the campaign work with
+// them. The variants give the discounts, and the coupons issued out of the issuance sequence, to the waitlist, the
+// winners of a draw or the referrers, could not keep to the limits of the variants.
+func (c *Campaign) validateVariants() error {
+	switch {
+	case c.Discount != nil:
+		return errors.New("campaign with variants cannot have a discount")
+	case c.Tiers != nil:
+		return errors.New("tiered campaign cannot have variants")
+	case c.StoredValue != nil:
+		return errors.New("stored-value campaign cannot have variants")
+	case c.Waitlist != nil:
+		return errors.New("campaign with variants cannot have a waitlist")
+	case c.Lottery != nil:
+		return errors.New("lottery campaign cannot have variants")
+	case c.Referral != nil:
+		return errors.New("referral campaign cannot have variants")
+	}
+	return coupon.ValidateVariants(c.Variants, c.CouponLimit)
+}
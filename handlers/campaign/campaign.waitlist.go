@@ -0,0 +1,133 @@
+package campaign
+
+import (
+	"errors"
+	"sync"
+)
+
+// Waitlist queues users for the slots given back to a sold out campaign, first come, first served.
+type Waitlist struct {
+	mu      sync.Mutex
+	users   []string
+	entries map[string]*waitlistEntry
+	issued  uint64
+	// moved is closed when a user is issued a coupon, which moves everyone behind, and then replaced.
+	moved chan struct{}
+}
+
+// waitlistEntry is the place of a user who joined the waitlist.
+type waitlistEntry struct {
+	seq  uint64 // the number of users who joined before.
+	code string // the coupon issued to the user, empty while waiting.
+}
+
+// WaitlistStatus is the place of a user on the waitlist.
+type WaitlistStatus struct {
+	// Position is 1 for the next user to be issued a coupon, and 0 once issued.
+	Position uint64
+	Code     string
+}
+
+// WithWaitlist lets users join a waitlist once the campaign is sold out.
+func WithWaitlist() Option {
+	return func(c *Campaign) {
+		c.Waitlist = &Waitlist{entries: make(map[string]*waitlistEntry), moved: make(chan struct{})}
+	}
+}
+
+// validateWaitlist checks that the settings of the campaign work with a waitlist.
+// A waitlist waits for the slots of a single issuance window, so the campaign cannot recur or draw a lottery.
+func (c *Campaign) validateWaitlist() error {
+	switch {
+	case c.Recurrence != nil:
+		return errors.New("recurring campaign cannot have a waitlist")
+	case c.Lottery != nil:
+		return errors.New("lottery campaign cannot have a waitlist")
+	}
+	return nil
+}
+
+// Join puts the user at the end of the waitlist. A user can join once, even after being issued a coupon.
+// Returns the position of the user, 1 being the next, or an error if the user ID is empty or the user already joined.
+func (w *Waitlist) Join(userId string) (uint64, error) {
+	if userId == "" {
+		return 0, errors.New("user ID is required")
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if _, ok := w.entries[userId]; ok {
+		return 0, errors.New("user already joined the waitlist")
+	}
+	w.entries[userId] = &waitlistEntry{seq: w.issued + uint64(len(w.users))}
+	w.users = append(w.users, userId)
+	return uint64(len(w.users)), nil
+}
+
+// IssueNext issues a coupon to the first user on the waitlist with issue, which returns the code of the coupon,
+// and takes the user off the waitlist if it succeeds. Returns the user and whether anyone was waiting,
+// or the error of issue.
+func (w *Waitlist) IssueNext(issue func(userId string) (string, error)) (string, bool, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if len(w.users) == 0 {
+		return "", false, nil
+	}
+	userId := w.users[0]
+	code, err := issue(userId)
+	if err != nil {
+		return "", false, err
+	}
+	w.users = w.users[1:]
+	w.entries[userId].code = code
+	w.issued++
+	close(w.moved)
+	w.moved = make(chan struct{})
+	return userId, true, nil
+}
+
+// Status returns the place of the user on the waitlist, with the code of the coupon issued to the user if any.
+// Returns an error if the user did not join the waitlist.
+func (w *Waitlist) Status(userId string) (WaitlistStatus, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.status(userId)
+}
+
+// Watch returns the place of the user on the waitlist like Status, with a channel which is closed the next time the
+// waitlist moves, so the caller can tell the user of each move.
+// Returns an error if the user did not join the waitlist.
+func (w *Waitlist) Watch(userId string) (WaitlistStatus, <-chan struct{}, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	status, err := w.status(userId)
+	return status, w.moved, err
+}
+
+// status returns the place of the user on the waitlist. The caller must hold mu.
+func (w *Waitlist) status(userId string) (WaitlistStatus, error) {
+	e, ok := w.entries[userId]
+	if !ok {
+		return WaitlistStatus{}, errors.New("user is not on the waitlist")
+	}
+	if e.code != "" {
+		return WaitlistStatus{Code: e.code}, nil
+	}
+	// Users are issued coupons in the order they joined, so those who joined before are either issued or ahead
+	return WaitlistStatus{Position: e.seq - w.issued + 1}, nil
+}
+
+// Waiting returns the number of users on the waitlist.
+func (w *Waitlist) Waiting() uint64 {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return uint64(len(w.users))
+}
+
+// Issued returns the number of users who were issued a coupon from the waitlist.
+func (w *Waitlist) Issued() uint64 {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.issued
+}
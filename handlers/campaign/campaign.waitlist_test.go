@@ -0,0 +1,102 @@
+package campaign
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	couponv1 "github.com/jackgihokim/coupon-issuance-system/protos/coupon/v1"
+)
+
+func TestWaitlist(t *testing.T) {
+	now := time.Now().UTC()
+	camp, err := NewCampaign(1, "name", "desc", now, now.Add(time.Hour), WithWaitlist())
+	if err != nil {
+		t.Fatalf("NewCampaign() error = %v", err)
+	}
+	defer store.delete(camp.Id)
+	w := camp.Waitlist
+
+	for i, userId := range []string{"a", "b"} {
+		pos, err := w.Join(userId)
+		if err != nil || pos != uint64(i+1) {
+			t.Fatalf("Join(%s) = %d, %v", userId, pos, err)
+		}
+	}
+	if _, err := w.Join("a"); err == nil {
+		t.Errorf("Join() of a user on the waitlist returned no error")
+	}
+	if _, err := w.Join(""); err == nil {
+		t.Errorf("Join() without a user ID returned no error")
+	}
+
+	// A failed issuance keeps the user first in line
+	if _, ok, err := w.IssueNext(func(string) (string, error) { return "", errors.New("failed") }); ok || err == nil {
+		t.Errorf("IssueNext() = %v, %v, want false and an error", ok, err)
+	}
+	if s, err := w.Status("b"); err != nil || s.Position != 2 {
+		t.Errorf("Status(b) = %+v, %v, want position 2", s, err)
+	}
+	for _, want := range []string{"a", "b"} {
+		userId, ok, err := w.IssueNext(func(userId string) (string, error) { return "code-" + userId, nil })
+		if err != nil || !ok || userId != want {
+			t.Errorf("IssueNext() = %q, %v, %v, want %q", userId, ok, err, want)
+		}
+		if s, err := w.Status(want); err != nil || s != (WaitlistStatus{Code: "code-" + want}) {
+			t.Errorf("Status(%s) = %+v, %v, want the issued coupon", want, s, err)
+		}
+	}
+	if _, ok, _ := w.IssueNext(func(string) (string, error) { return "", nil }); ok {
+		t.Errorf("IssueNext() of an empty waitlist issued a coupon")
+	}
+	if _, err := w.Status("c"); err == nil {
+		t.Errorf("Status() of a user who did not join returned no error")
+	}
+	if w.Waiting() != 0 || w.Issued() != 2 {
+		t.Errorf("Waiting() = %d, Issued() = %d, want 0 and 2", w.Waiting(), w.Issued())
+	}
+}
+
+func TestWaitlist_Watch(t *testing.T) {
+	now := time.Now().UTC()
+	camp, err := NewCampaign(1, "name", "desc", now, now.Add(time.Hour), WithWaitlist())
+	if err != nil {
+		t.Fatalf("NewCampaign() error = %v", err)
+	}
+	defer store.delete(camp.Id)
+	w := camp.Waitlist
+	_, _ = w.Join("a")
+	_, _ = w.Join("b")
+
+	s, moved, err := w.Watch("b")
+	if err != nil || s.Position != 2 {
+		t.Fatalf("Watch(b) = %+v, %v, want position 2", s, err)
+	}
+	select {
+	case <-moved:
+		t.Fatalf("the waitlist moved before anyone was issued a coupon")
+	default:
+	}
+
+	// Issuing a coupon to the user ahead moves the waitlist
+	_, _, _ = w.IssueNext(func(userId string) (string, error) { return "code-" + userId, nil })
+	select {
+	case <-moved:
+	default:
+		t.Fatalf("the waitlist did not move when a coupon was issued")
+	}
+	if s, _, _ := w.Watch("b"); s.Position != 1 {
+		t.Errorf("Watch(b) = %+v, want position 1", s)
+	}
+	if _, _, err := w.Watch("c"); err == nil {
+		t.Errorf("Watch() of a user who did not join returned no error")
+	}
+}
+
+func TestNewCampaign_WithWaitlist(t *testing.T) {
+	now := time.Now().UTC()
+	recurrence := WithRecurrence(&couponv1.Recurrence{Schedule: "0 10 * * *"})
+	if _, err := NewCampaign(1, "name", "desc", now, now.Add(time.Hour), recurrence, WithWaitlist()); err == nil {
+		t.Errorf("NewCampaign() of a recurring campaign with a waitlist returned no error")
+	}
+}
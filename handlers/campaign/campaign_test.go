@@ -0,0 +1,273 @@
+package campaign
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	couponv1 "github.com/jackgihokim/coupon-issuance-system/protos/coupon/v1"
+)
+
+func TestNewCampaign(t *testing.T) {
+	now := time.Now()
+	camp, err := NewCampaign(10, "name", "desc", now, now.Add(time.Hour))
+	if err != nil {
+		t.Fatalf("error occurred while creating campaign: %v", err)
+	}
+	defer store.delete(camp.Id)
+
+	if camp.ExpiryPolicy != nil {
+		t.Errorf("expiry policy should be nil without options")
+	}
+	if _, err := GetCampaign(camp.Id); err != nil {
+		t.Errorf("created campaign was not stored: %v", err)
+	}
+}
+
+func TestNewCampaign_WithExpiryPolicy(t *testing.T) {
+	now := time.Now()
+	policy := &couponv1.ExpiryPolicy{Policy: &couponv1.ExpiryPolicy_Ttl{Ttl: durationpb.New(72 * time.Hour)}}
+
+	camp, err := NewCampaign(10, "name", "desc", now, now.Add(time.Hour), WithExpiryPolicy(policy))
+	if err != nil {
+		t.Fatalf("error occurred while creating campaign: %v", err)
+	}
+	defer store.delete(camp.Id)
+
+	if camp.ExpiryPolicy != policy {
+		t.Errorf("expiry policy was not set")
+	}
+
+	// Invalid policies are rejected and not stored
+	invalid := &couponv1.ExpiryPolicy{}
+	if _, err := NewCampaign(10, "name", "desc", now, now.Add(time.Hour), WithExpiryPolicy(invalid)); err == nil {
+		t.Errorf("expected error when creating campaign with an empty expiry policy")
+	}
+}
+
+func TestNewCampaign_WithDiscount(t *testing.T) {
+	now := time.Now()
+	d := &couponv1.Discount{Kind: &couponv1.Discount_FreeShipping_{FreeShipping: &couponv1.Discount_FreeShipping{}}}
+
+	camp, err := NewCampaign(10, "name", "desc", now, now.Add(time.Hour), WithDiscount(d))
+	if err != nil {
+		t.Fatalf("error occurred while creating campaign: %v", err)
+	}
+	defer store.delete(camp.Id)
+
+	if camp.Discount != d {
+		t.Errorf("discount was not set")
+	}
+
+	// Invalid discounts are rejected
+	if _, err := NewCampaign(10, "name", "desc", now, now.Add(time.Hour), WithDiscount(&couponv1.Discount{})); err == nil {
+		t.Errorf("expected error when creating campaign with an empty discount")
+	}
+}
+
+func TestNewCampaign_WithApplicability(t *testing.T) {
+	now := time.Now()
+	a := &couponv1.Applicability{IncludeCategories: []string{"shoes"}}
+
+	camp, err := NewCampaign(10, "name", "desc", now, now.Add(time.Hour), WithApplicability(a))
+	if err != nil {
+		t.Fatalf("error occurred while creating campaign: %v", err)
+	}
+	defer store.delete(camp.Id)
+
+	if camp.Applicability != a {
+		t.Errorf("applicability was not set")
+	}
+
+	// Contradicting rules are rejected
+	invalid := &couponv1.Applicability{IncludeSkus: []string{"A"}, ExcludeSkus: []string{"A"}}
+	if _, err := NewCampaign(10, "name", "desc", now, now.Add(time.Hour), WithApplicability(invalid)); err == nil {
+		t.Errorf("expected error when creating campaign with contradicting applicability rules")
+	}
+}
+
+func TestNewCampaign_WithStacking(t *testing.T) {
+	now := time.Now()
+	p := &couponv1.StackingPolicy{Mode: couponv1.StackingMode_STACKING_MODE_STACKABLE_WITH_GROUP, Group: "members"}
+
+	camp, err := NewCampaign(10, "name", "desc", now, now.Add(time.Hour), WithStacking(p))
+	if err != nil {
+		t.Fatalf("error occurred while creating campaign: %v", err)
+	}
+	defer store.delete(camp.Id)
+
+	if camp.Stacking != p {
+		t.Errorf("stacking policy was not set")
+	}
+
+	// Stacking within a group needs the group
+	invalid := &couponv1.StackingPolicy{Mode: couponv1.StackingMode_STACKING_MODE_STACKABLE_WITH_GROUP}
+	if _, err := NewCampaign(10, "name", "desc", now, now.Add(time.Hour), WithStacking(invalid)); err == nil {
+		t.Errorf("expected error when creating campaign with a stacking group missing")
+	}
+}
+
+func TestNewCampaign_WithStoredValue(t *testing.T) {
+	now := time.Now()
+	balance := &couponv1.Money{Currency: "KRW", Amount: 50000}
+
+	camp, err := NewCampaign(10, "name", "desc", now, now.Add(time.Hour), WithStoredValue(balance))
+	if err != nil {
+		t.Fatalf("error occurred while creating campaign: %v", err)
+	}
+	defer store.delete(camp.Id)
+
+	if camp.StoredValue != balance {
+		t.Errorf("stored value was not set")
+	}
+
+	// Stored-value coupons are worth their balance, not a discount
+	d := &couponv1.Discount{Kind: &couponv1.Discount_FreeShipping_{FreeShipping: &couponv1.Discount_FreeShipping{}}}
+	if _, err := NewCampaign(10, "name", "desc", now, now.Add(time.Hour), WithStoredValue(balance), WithDiscount(d)); err == nil {
+		t.Errorf("expected error when creating a stored-value campaign with a discount")
+	}
+}
+
+func TestNewCampaign_WithRestorePolicy(t *testing.T) {
+	now := time.Now()
+
+	camp, err := NewCampaign(10, "name", "desc", now, now.Add(time.Hour), WithRestorePolicy(couponv1.RestorePolicy_RESTORE_POLICY_NEVER))
+	if err != nil {
+		t.Fatalf("error occurred while creating campaign: %v", err)
+	}
+	defer store.delete(camp.Id)
+
+	if camp.RestorePolicy != couponv1.RestorePolicy_RESTORE_POLICY_NEVER {
+		t.Errorf("restore policy was not set")
+	}
+
+	if _, err := NewCampaign(10, "name", "desc", now, now.Add(time.Hour), WithRestorePolicy(couponv1.RestorePolicy(99))); err == nil {
+		t.Errorf("expected error when creating a campaign with an unknown restore policy")
+	}
+}
+
+func TestNewCampaign_WithTransfer(t *testing.T) {
+	now := time.Now()
+	p := &couponv1.TransferPolicy{MaxTransfers: 1}
+
+	camp, err := NewCampaign(10, "name", "desc", now, now.Add(time.Hour), WithTransfer(p))
+	if err != nil {
+		t.Fatalf("error occurred while creating campaign: %v", err)
+	}
+	defer store.delete(camp.Id)
+
+	if camp.Transfer != p {
+		t.Errorf("transfer policy was not set")
+	}
+
+	invalid := &couponv1.TransferPolicy{ClaimTtl: durationpb.New(-time.Hour)}
+	if _, err := NewCampaign(10, "name", "desc", now, now.Add(time.Hour), WithTransfer(invalid)); err == nil {
+		t.Errorf("expected error when creating a campaign with a negative claim TTL")
+	}
+}
+
+func TestNewCampaign_WithTiers(t *testing.T) {
+	now := time.Now()
+	freeShipping := &couponv1.Discount{Kind: &couponv1.Discount_FreeShipping_{FreeShipping: &couponv1.Discount_FreeShipping{}}}
+	tiers := []*couponv1.Tier{{Name: "first", Limit: 1, Discount: freeShipping}, {Name: "rest", Discount: freeShipping}}
+
+	camp, err := NewCampaign(10, "name", "desc", now, now.Add(time.Hour), WithTiers(tiers))
+	if err != nil {
+		t.Fatalf("error occurred while creating campaign: %v", err)
+	}
+	defer store.delete(camp.Id)
+
+	if len(camp.Tiers) != 2 || len(camp.Coupons.TierStats()) != 2 {
+		t.Errorf("tiers were not set")
+	}
+
+	// The tiers give the discounts
+	if _, err := NewCampaign(10, "name", "desc", now, now.Add(time.Hour), WithTiers(tiers), WithDiscount(freeShipping)); err == nil {
+		t.Errorf("expected error when creating a tiered campaign with a discount")
+	}
+}
+
+func TestNewCampaign_WithVariants(t *testing.T) {
+	now := time.Now()
+	freeShipping := &couponv1.Discount{Kind: &couponv1.Discount_FreeShipping_{FreeShipping: &couponv1.Discount_FreeShipping{}}}
+	variants := []*couponv1.Variant{{Name: "control", Weight: 1, Discount: freeShipping}, {Name: "treatment", Weight: 1, Discount: freeShipping}}
+
+	camp, err := NewCampaign(10, "name", "desc", now, now.Add(time.Hour), WithVariants(variants))
+	if err != nil {
+		t.Fatalf("error occurred while creating campaign: %v", err)
+	}
+	defer store.delete(camp.Id)
+
+	if len(camp.Variants) != 2 || len(camp.Coupons.VariantStats()) != 2 {
+		t.Errorf("variants were not set")
+	}
+
+	// The variants give the discounts
+	if _, err := NewCampaign(10, "name", "desc", now, now.Add(time.Hour), WithVariants(variants), WithDiscount(freeShipping)); err == nil {
+		t.Errorf("expected error when creating a campaign with variants and a discount")
+	}
+}
+
+func TestNewCampaign_WithBudget(t *testing.T) {
+	now := time.Now()
+	budget := &couponv1.Budget{Amount: &couponv1.Money{Currency: "KRW", Amount: 10000}}
+	fixed := func(currency string, amount int64) *couponv1.Discount {
+		return &couponv1.Discount{Kind: &couponv1.Discount_FixedAmount_{FixedAmount: &couponv1.Discount_FixedAmount{
+			Amount: &couponv1.Money{Currency: currency, Amount: amount},
+		}}}
+	}
+
+	camp, err := NewCampaign(10, "name", "desc", now, now.Add(time.Hour), WithBudget(budget), WithDiscount(fixed("KRW", 5000)))
+	if err != nil {
+		t.Fatalf("error occurred while creating campaign: %v", err)
+	}
+	defer store.delete(camp.Id)
+
+	// The budget covers two coupons of 5000
+	for i := 0; i < 2; i++ {
+		camp.Coupons.SpendBudget(fmt.Sprintf("code-%d", i), fmt.Sprintf("redemption-%d", i), 5000, true)
+	}
+	if got := camp.State(now); got != couponv1.CampaignState_CAMPAIGN_STATE_SOLD_OUT {
+		t.Errorf("expected a campaign out of budget to be sold out, got %v", got)
+	}
+
+	if _, err := NewCampaign(10, "name", "desc", now, now.Add(time.Hour), WithBudget(budget), WithDiscount(fixed("USD", 5000))); err == nil {
+		t.Errorf("expected error when creating a campaign with a discount in another currency than its budget")
+	}
+
+	// A coupon may cost as much as the largest fixed amount it can be issued with
+	variants := []*couponv1.Variant{{Name: "control", Weight: 1, Discount: fixed("KRW", 3000)}, {Name: "treatment", Weight: 1, Discount: fixed("KRW", 6000)}}
+	varied, err := NewCampaign(10, "name", "desc", now, now.Add(time.Hour), WithBudget(budget), WithVariants(variants))
+	if err != nil {
+		t.Fatalf("error occurred while creating campaign: %v", err)
+	}
+	defer store.delete(varied.Id)
+	if got := varied.couponCost(); got != 6000 {
+		t.Errorf("expected a coupon to cost up to 6000, got %d", got)
+	}
+}
+
+func TestNewCampaign_WithAllocation(t *testing.T) {
+	now := time.Now()
+	allocation := &couponv1.AllocationPolicy{
+		Allocations: []*couponv1.Allocation{{Channel: "app", Limit: 6}, {Channel: "web", Limit: 4}},
+	}
+
+	camp, err := NewCampaign(10, "name", "desc", now, now.Add(time.Hour), WithAllocation(allocation))
+	if err != nil {
+		t.Fatalf("error occurred while creating campaign: %v", err)
+	}
+	defer store.delete(camp.Id)
+
+	if len(camp.Coupons.AllocationStats(now)) != 2 {
+		t.Errorf("allocations were not set")
+	}
+
+	late := &couponv1.AllocationPolicy{Allocations: allocation.Allocations, SpilloverAt: timestamppb.New(now.Add(2 * time.Hour))}
+	if _, err := NewCampaign(10, "name", "desc", now, now.Add(time.Hour), WithAllocation(late)); err == nil {
+		t.Errorf("expected error when creating a campaign spilling over after its end")
+	}
+}
@@ -0,0 +1,87 @@
+package coupon
+
+import (
+	"errors"
+	"time"
+
+	couponv1 "github.com/jackgihokim/coupon-issuance-system/protos/coupon/v1"
+)
+
+// ValidateExpiryPolicy checks that every part of the expiry policy can be evaluated at issue time.
+// Returns an error describing the first invalid part.
+func ValidateExpiryPolicy(policy *couponv1.ExpiryPolicy) error {
+	switch p := policy.GetPolicy().(type) {
+	case *couponv1.ExpiryPolicy_FixedAt:
+		if err := p.FixedAt.CheckValid(); err != nil {
+			return err
+		}
+	case *couponv1.ExpiryPolicy_Ttl:
+		if err := p.Ttl.CheckValid(); err != nil {
+			return err
+		}
+		if p.Ttl.AsDuration() <= 0 {
+			return errors.New("expiry ttl must be positive")
+		}
+	case *couponv1.ExpiryPolicy_EndOfDay_:
+		if _, err := time.LoadLocation(p.EndOfDay.TimeZone); err != nil {
+			return err
+		}
+	case *couponv1.ExpiryPolicy_Earliest_:
+		if len(p.Earliest.Policies) == 0 {
+			return errors.New("earliest expiry policy needs at least one policy")
+		}
+		for _, policy := range p.Earliest.Policies {
+			if err := ValidateExpiryPolicy(policy); err != nil {
+				return err
+			}
+		}
+	default:
+		return errors.New("expiry policy is empty")
+	}
+	return nil
+}
+
+// Expiration evaluates the expiry policy for a coupon issued at issuedAt.
+// Returns fallback if no policy is set, or an error if the policy cannot be evaluated.
+func Expiration(policy *couponv1.ExpiryPolicy, issuedAt, fallback time.Time) (time.Time, error) {
+	if policy == nil {
+		return fallback, nil
+	}
+
+	switch p := policy.GetPolicy().(type) {
+	case *couponv1.ExpiryPolicy_FixedAt:
+		return p.FixedAt.AsTime(), nil
+	case *couponv1.ExpiryPolicy_Ttl:
+		return issuedAt.Add(p.Ttl.AsDuration()), nil
+	case *couponv1.ExpiryPolicy_EndOfDay_:
+		return endOfDay(issuedAt, p.EndOfDay.Days, p.EndOfDay.TimeZone)
+	case *couponv1.ExpiryPolicy_Earliest_:
+		var earliest time.Time
+		for i, policy := range p.Earliest.Policies {
+			expiration, err := Expiration(policy, issuedAt, fallback)
+			if err != nil {
+				return time.Time{}, err
+			}
+			if i == 0 || expiration.Before(earliest) {
+				earliest = expiration
+			}
+		}
+		if earliest.IsZero() {
+			return time.Time{}, errors.New("earliest expiry policy needs at least one policy")
+		}
+		return earliest, nil
+	}
+	return time.Time{}, errors.New("expiry policy is empty")
+}
+
+// endOfDay returns the last second of the day that is `days` days after issuedAt in the time zone.
+// The result is in UTC for being the same as timestamppb.
+func endOfDay(issuedAt time.Time, days uint32, timeZone string) (time.Time, error) {
+	loc, err := time.LoadLocation(timeZone)
+	if err != nil {
+		return time.Time{}, err
+	}
+	local := issuedAt.In(loc)
+	end := time.Date(local.Year(), local.Month(), local.Day()+int(days), 23, 59, 59, 0, loc)
+	return end.UTC(), nil
+}
@@ -0,0 +1,91 @@
+package coupon
+
+import (
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	couponv1 "github.com/jackgihokim/coupon-issuance-system/protos/coupon/v1"
+)
+
+func fixedAt(t time.Time) *couponv1.ExpiryPolicy {
+	return &couponv1.ExpiryPolicy{Policy: &couponv1.ExpiryPolicy_FixedAt{FixedAt: timestamppb.New(t)}}
+}
+
+func ttl(d time.Duration) *couponv1.ExpiryPolicy {
+	return &couponv1.ExpiryPolicy{Policy: &couponv1.ExpiryPolicy_Ttl{Ttl: durationpb.New(d)}}
+}
+
+func endOfDayIn(days uint32, timeZone string) *couponv1.ExpiryPolicy {
+	return &couponv1.ExpiryPolicy{Policy: &couponv1.ExpiryPolicy_EndOfDay_{
+		EndOfDay: &couponv1.ExpiryPolicy_EndOfDay{Days: days, TimeZone: timeZone},
+	}}
+}
+
+func earliest(policies ...*couponv1.ExpiryPolicy) *couponv1.ExpiryPolicy {
+	return &couponv1.ExpiryPolicy{Policy: &couponv1.ExpiryPolicy_Earliest_{
+		Earliest: &couponv1.ExpiryPolicy_Earliest{Policies: policies},
+	}}
+}
+
+func TestValidateExpiryPolicy(t *testing.T) {
+	testCases := []struct {
+		name    string
+		policy  *couponv1.ExpiryPolicy
+		wantErr bool
+	}{
+		{"fixed date", fixedAt(time.Now()), false},
+		{"ttl", ttl(72 * time.Hour), false},
+		{"non-positive ttl", ttl(0), true},
+		{"end of day", endOfDayIn(3, "Asia/Seoul"), false},
+		{"unknown time zone", endOfDayIn(3, "Mars/Olympus"), true},
+		{"earliest", earliest(ttl(time.Hour), endOfDayIn(0, "UTC")), false},
+		{"empty earliest", earliest(), true},
+		{"invalid nested policy", earliest(ttl(time.Hour), ttl(-time.Hour)), true},
+		{"empty policy", &couponv1.ExpiryPolicy{}, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateExpiryPolicy(tc.policy)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("ValidateExpiryPolicy() error = %v, wantErr %v", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestExpiration(t *testing.T) {
+	// 2025-03-26 23:30 in Seoul
+	issuedAt := time.Date(2025, 3, 26, 14, 30, 0, 0, time.UTC)
+	fallback := time.Date(2025, 3, 31, 0, 0, 0, 0, time.UTC)
+	fixed := time.Date(2025, 3, 28, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name   string
+		policy *couponv1.ExpiryPolicy
+		want   time.Time
+	}{
+		{"no policy falls back to campaign end", nil, fallback},
+		{"fixed date", fixedAt(fixed), fixed},
+		{"ttl from issuance", ttl(72 * time.Hour), issuedAt.Add(72 * time.Hour)},
+		{"end of the same day in time zone", endOfDayIn(0, "Asia/Seoul"), time.Date(2025, 3, 26, 14, 59, 59, 0, time.UTC)},
+		{"end of day after days in time zone", endOfDayIn(2, "Asia/Seoul"), time.Date(2025, 3, 28, 14, 59, 59, 0, time.UTC)},
+		{"end of day in UTC", endOfDayIn(1, "UTC"), time.Date(2025, 3, 27, 23, 59, 59, 0, time.UTC)},
+		{"earliest of several", earliest(ttl(72*time.Hour), fixedAt(fixed), endOfDayIn(5, "UTC")), fixed},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := Expiration(tc.policy, issuedAt, fallback)
+			if err != nil {
+				t.Fatalf("Expiration() error = %v", err)
+			}
+			if !got.Equal(tc.want) {
+				t.Errorf("Expiration() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
@@ -2,6 +2,10 @@ package coupon
 
 import (
 	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/timestamppb"
 	"strconv"
 	"time"
@@ -11,22 +15,76 @@ import (
 
 const (
 	maxCodeLength = 10
+	maxCodeTries  = 100
 	koText        = "테스트"
 )
 
-// NewCoupon generates a new Coupon with a unique code, expiration date, and issue timestamp.
-// Returns an error if the code generation fails.
-func NewCoupon(expiration, now time.Time) (*couponv1.Coupon, error) {
-	code, err := createCode(koText, now.UnixNano())
-	if err != nil {
-		return nil, err
+// Option configures optional fields of a coupon before it is registered in the code index.
+type Option func(*couponv1.Coupon)
+
+// WithDiscount stores a snapshot of the discount on the coupon, so later changes of the discount don't affect it.
+func WithDiscount(discount *couponv1.Discount) Option {
+	return func(c *couponv1.Coupon) {
+		c.Discount = proto.Clone(discount).(*couponv1.Discount)
+	}
+}
+
+// WithUserId sets the user the coupon is issued to.
+func WithUserId(userId string) Option {
+	return func(c *couponv1.Coupon) {
+		c.UserId = userId
+	}
+}
+
+// WithReferralCode sets the referral code the coupon is issued for.
+func WithReferralCode(code string) Option {
+	return func(c *couponv1.Coupon) {
+		c.ReferralCode = code
+	}
+}
+
+// WithChannel sets the channel or partner the coupon is issued through.
+func WithChannel(channel string) Option {
+	return func(c *couponv1.Coupon) {
+		c.Channel = channel
 	}
+}
 
-	return &couponv1.Coupon{
-		Code:     code,
-		ExpireAt: timestamppb.New(expiration),
-		IssuedAt: timestamppb.New(now),
-	}, nil
+// WithBalance makes the coupon a stored-value coupon starting with the balance.
+func WithBalance(balance *couponv1.Money) Option {
+	return func(c *couponv1.Coupon) {
+		c.Balance = proto.Clone(balance).(*couponv1.Money)
+	}
+}
+
+// NewCoupon generates a new active Coupon of the campaign with a unique code, expiration date, and issue timestamp.
+// A copy of the coupon is registered in the code index, which owns it from then on, so the coupon returned stays the
+// caller's. It must be discarded by Discard if it is not issued after all.
+// Returns an error if the code generation fails.
+func NewCoupon(campaignId uint32, expiration, now time.Time, opts ...Option) (*couponv1.Coupon, error) {
+	nano := now.UnixNano()
+	for i := 0; i < maxCodeTries; i++ {
+		code, err := createCode(koText, nano)
+		if err != nil {
+			return nil, err
+		}
+
+		coupon := &couponv1.Coupon{
+			Code:       code,
+			ExpireAt:   timestamppb.New(expiration),
+			IssuedAt:   timestamppb.New(now),
+			CampaignId: campaignId,
+			Status:     couponv1.CouponStatus_COUPON_STATUS_ACTIVE,
+		}
+		for _, opt := range opts {
+			opt(coupon)
+		}
+		if index.add(proto.Clone(coupon).(*couponv1.Coupon)) {
+			return coupon, nil
+		}
+		nano += int64(time.Microsecond) // the code is taken, so try the next microsecond.
+	}
+	return nil, errors.New("failed to generate a unique coupon code")
 }
 
 // createCode generates a string by appending a substring of the nanoseconds to the input text to meet the required length.
@@ -54,3 +112,12 @@ func createStringNumbers(nanoSec int64, cnt int) string {
 	from := len(strMicroSec) - cnt
 	return strMicroSec[from:]
 }
+
+// newRandomId returns a random ID which cannot be guessed.
+func newRandomId() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
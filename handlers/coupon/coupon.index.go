@@ -0,0 +1,192 @@
+package coupon
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	couponv1 "github.com/jackgihokim/coupon-issuance-system/protos/coupon/v1"
+)
+
+// Index maps coupon codes to the issued coupons across all campaigns.
+type Index struct {
+	mu sync.RWMutex
+	m  map[string]*couponv1.Coupon
+	// reserved has the codes of the coupons whose status is reserved, for the sweeper to find them.
+	reserved map[string]struct{}
+	// ledgers has the balance changes of the stored-value coupons by code, in order.
+	ledgers map[string][]*couponv1.LedgerEntry
+	// redemptions has the redemptions by ID, and orders the IDs of the redemptions of each order, for reversals.
+	redemptions map[string]*redemption
+	orders      map[string][]string
+	// offers has the one-time tokens of the claim links of the coupons offered for transfer, by code.
+	offers map[string]string
+}
+
+var index = newIndex()
+
+// newIndex initializes and returns a new instance of Index with an empty code map.
+func newIndex() *Index {
+	return &Index{
+		m:           make(map[string]*couponv1.Coupon),
+		reserved:    make(map[string]struct{}),
+		ledgers:     make(map[string][]*couponv1.LedgerEntry),
+		redemptions: make(map[string]*redemption),
+		orders:      make(map[string][]string),
+		offers:      make(map[string]string),
+	}
+}
+
+// add registers the coupon under its code. Returns false if the code is already taken.
+func (i *Index) add(coupon *couponv1.Coupon) bool {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	if _, ok := i.m[coupon.Code]; ok {
+		return false
+	}
+	i.m[coupon.Code] = coupon
+	return true
+}
+
+// issue replaces the copy of the coupon registered when it was created with a copy of the coupon as issued, with
+// whatever the coupons assigned it. Coupons the index does not know are ignored.
+func (i *Index) issue(coupon *couponv1.Coupon) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	if _, ok := i.m[coupon.Code]; ok {
+		i.m[coupon.Code] = proto.Clone(coupon).(*couponv1.Coupon)
+	}
+}
+
+// delete removes the coupon with the specified code from the index.
+func (i *Index) delete(code string) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	delete(i.m, code)
+}
+
+// get returns a snapshot of the coupon with the specified code, so callers can read it without holding the lock.
+// Returns an error if the code is unknown.
+func (i *Index) get(code string) (*couponv1.Coupon, error) {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	coupon, ok := i.m[code]
+	if !ok {
+		return nil, errors.New("coupon not found")
+	}
+	return proto.Clone(coupon).(*couponv1.Coupon), nil
+}
+
+// redeem marks an active and unexpired coupon as redeemed for the order at now.
+// Returns a snapshot of the redeemed coupon or an error if the coupon cannot be redeemed.
+func (i *Index) redeem(code, orderId string, now time.Time) (*couponv1.Coupon, error) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	coupon, ok := i.m[code]
+	if !ok {
+		return nil, errors.New("coupon not found")
+	}
+	if coupon.Balance != nil {
+		return nil, errors.New("stored-value coupon is redeemed by amount")
+	}
+	if err := ReasonError(Reason(coupon, now)); err != nil {
+		return nil, err
+	}
+	if err := i.markRedeemed(coupon, orderId, now); err != nil {
+		return nil, err
+	}
+	return proto.Clone(coupon).(*couponv1.Coupon), nil
+}
+
+// revoke marks an active coupon as revoked at now for the given reason.
+// Returns a snapshot of the revoked coupon or an error if the coupon is unknown or no longer active.
+func (i *Index) revoke(code, reason string, now time.Time) (*couponv1.Coupon, error) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	coupon, ok := i.m[code]
+	if !ok {
+		return nil, errors.New("coupon not found")
+	}
+	switch coupon.Status {
+	case couponv1.CouponStatus_COUPON_STATUS_REVOKED:
+		return nil, errors.New("coupon is already revoked")
+	case couponv1.CouponStatus_COUPON_STATUS_REDEEMED:
+		return nil, errors.New("coupon is already redeemed")
+	}
+	coupon.Status = couponv1.CouponStatus_COUPON_STATUS_REVOKED
+	coupon.RevokedAt = timestamppb.New(now)
+	coupon.RevokeReason = reason
+	delete(i.reserved, code)
+	return proto.Clone(coupon).(*couponv1.Coupon), nil
+}
+
+// Lookup returns a snapshot of the issued coupon with the specified code regardless of its campaign.
+// Returns an error if no coupon was issued with the code.
+func Lookup(code string) (*couponv1.Coupon, error) {
+	return index.get(code)
+}
+
+// Redeem marks the coupon with the specified code as redeemed for the order at now. The order ID may be empty.
+// Returns the redeemed coupon or an error if the coupon is unknown, expired, no longer active or has a stored value.
+func Redeem(code, orderId string, now time.Time) (*couponv1.Coupon, error) {
+	return index.redeem(code, orderId, now)
+}
+
+// Revoke cancels the coupon with the specified code at now for the given reason, so it can no longer be used.
+// Returns the revoked coupon or an error if the coupon is unknown, redeemed or already revoked.
+func Revoke(code, reason string, now time.Time) (*couponv1.Coupon, error) {
+	return index.revoke(code, reason, now)
+}
+
+// Discard removes a coupon from the index. It is used when a generated coupon could not be issued.
+func Discard(code string) {
+	index.delete(code)
+}
+
+// Reason returns the reason why the coupon cannot be used at now, based on its own status and expiration.
+// A coupon whose reservation expired is usable again even before the sweeper releases it.
+// Returns VALIDATION_REASON_UNSPECIFIED if the coupon is usable.
+func Reason(coupon *couponv1.Coupon, now time.Time) couponv1.ValidationReason {
+	switch coupon.Status {
+	case couponv1.CouponStatus_COUPON_STATUS_REVOKED:
+		return couponv1.ValidationReason_VALIDATION_REASON_REVOKED
+	case couponv1.CouponStatus_COUPON_STATUS_REDEEMED:
+		return couponv1.ValidationReason_VALIDATION_REASON_REDEEMED
+	case couponv1.CouponStatus_COUPON_STATUS_RESERVED:
+		if held(coupon, now) {
+			return couponv1.ValidationReason_VALIDATION_REASON_RESERVED
+		}
+	}
+	if coupon.ExpireAt.AsTime().Before(now) {
+		return couponv1.ValidationReason_VALIDATION_REASON_EXPIRED
+	}
+	return couponv1.ValidationReason_VALIDATION_REASON_UNSPECIFIED
+}
+
+// ReasonError converts a validation reason into an error. Returns nil if the reason means the coupon is usable.
+func ReasonError(reason couponv1.ValidationReason) error {
+	switch reason {
+	case couponv1.ValidationReason_VALIDATION_REASON_UNSPECIFIED:
+		return nil
+	case couponv1.ValidationReason_VALIDATION_REASON_UNKNOWN_CODE:
+		return errors.New("coupon not found")
+	case couponv1.ValidationReason_VALIDATION_REASON_EXPIRED:
+		return errors.New("coupon is expired")
+	case couponv1.ValidationReason_VALIDATION_REASON_REVOKED:
+		return errors.New("coupon is revoked")
+	case couponv1.ValidationReason_VALIDATION_REASON_REDEEMED:
+		return errors.New("coupon is already redeemed")
+	case couponv1.ValidationReason_VALIDATION_REASON_CAMPAIGN_ENDED:
+		return errors.New("campaign is over")
+	case couponv1.ValidationReason_VALIDATION_REASON_CHANNEL_NOT_ALLOWED:
+		return errors.New("channel is not allowed")
+	case couponv1.ValidationReason_VALIDATION_REASON_PAYMENT_METHOD_NOT_ALLOWED:
+		return errors.New("payment method is not allowed")
+	case couponv1.ValidationReason_VALIDATION_REASON_RESERVED:
+		return errors.New("coupon is reserved")
+	}
+	return errors.New("coupon is not valid")
+}
@@ -0,0 +1,141 @@
+package coupon
+
+import (
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	couponv1 "github.com/jackgihokim/coupon-issuance-system/protos/coupon/v1"
+)
+
+func newTestCoupon(code string, expiration time.Time) *couponv1.Coupon {
+	return &couponv1.Coupon{
+		Code:       code,
+		ExpireAt:   timestamppb.New(expiration),
+		CampaignId: 1,
+		Status:     couponv1.CouponStatus_COUPON_STATUS_ACTIVE,
+	}
+}
+
+func TestIndex_Add(t *testing.T) {
+	idx := newIndex()
+	coupon := newTestCoupon("A", time.Now().Add(time.Hour))
+
+	if !idx.add(coupon) {
+		t.Errorf("Expected first add to succeed")
+	}
+	if idx.add(newTestCoupon("A", time.Now().Add(time.Hour))) {
+		t.Errorf("Expected add of a duplicate code to fail")
+	}
+	if len(idx.m) != 1 {
+		t.Errorf("Expected index size to be 1, got %d", len(idx.m))
+	}
+}
+
+func TestIndex_Get(t *testing.T) {
+	idx := newIndex()
+	coupon := newTestCoupon("A", time.Now().Add(time.Hour))
+	idx.add(coupon)
+
+	got, err := idx.get("A")
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if got == coupon {
+		t.Errorf("Expected a snapshot, got the indexed coupon itself")
+	}
+	if got.Code != "A" || got.CampaignId != 1 {
+		t.Errorf("Snapshot doesn't match the indexed coupon: %v", got)
+	}
+
+	if _, err := idx.get("B"); err == nil {
+		t.Errorf("Expected error for an unknown code, got nil")
+	}
+}
+
+func TestIndex_Redeem(t *testing.T) {
+	now := time.Now()
+	idx := newIndex()
+	idx.add(newTestCoupon("A", now.Add(time.Hour)))
+	idx.add(newTestCoupon("EXPIRED", now.Add(-time.Hour)))
+
+	got, err := idx.redeem("A", "", now)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if got.Status != couponv1.CouponStatus_COUPON_STATUS_REDEEMED {
+		t.Errorf("Expected status to be redeemed, got %v", got.Status)
+	}
+	if !got.RedeemedAt.AsTime().Equal(now) {
+		t.Errorf("Expected RedeemedAt to be %v, got %v", now, got.RedeemedAt.AsTime())
+	}
+
+	// A coupon can be redeemed only once
+	if _, err := idx.redeem("A", "", now); err == nil {
+		t.Errorf("Expected error when redeeming twice, got nil")
+	}
+	if _, err := idx.redeem("EXPIRED", "", now); err == nil {
+		t.Errorf("Expected error when redeeming an expired coupon, got nil")
+	}
+	if _, err := idx.redeem("B", "", now); err == nil {
+		t.Errorf("Expected error when redeeming an unknown code, got nil")
+	}
+}
+
+func TestIndex_Revoke(t *testing.T) {
+	now := time.Now()
+	idx := newIndex()
+	idx.add(newTestCoupon("A", now.Add(time.Hour)))
+	idx.add(newTestCoupon("B", now.Add(time.Hour)))
+
+	got, err := idx.revoke("A", "issued by mistake", now)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if got.Status != couponv1.CouponStatus_COUPON_STATUS_REVOKED {
+		t.Errorf("Expected status to be revoked, got %v", got.Status)
+	}
+	if got.RevokeReason != "issued by mistake" {
+		t.Errorf("Expected revoke reason to be recorded, got %q", got.RevokeReason)
+	}
+
+	// Revoked coupons can be neither revoked again nor redeemed
+	if _, err := idx.revoke("A", "again", now); err == nil {
+		t.Errorf("Expected error when revoking twice, got nil")
+	}
+	if _, err := idx.redeem("A", "", now); err == nil {
+		t.Errorf("Expected error when redeeming a revoked coupon, got nil")
+	}
+
+	// Redeemed coupons cannot be revoked
+	idx.redeem("B", "", now)
+	if _, err := idx.revoke("B", "too late", now); err == nil {
+		t.Errorf("Expected error when revoking a redeemed coupon, got nil")
+	}
+}
+
+func TestReason(t *testing.T) {
+	now := time.Now()
+	testCases := []struct {
+		name     string
+		status   couponv1.CouponStatus
+		expireAt time.Time
+		want     couponv1.ValidationReason
+	}{
+		{"active", couponv1.CouponStatus_COUPON_STATUS_ACTIVE, now.Add(time.Hour), couponv1.ValidationReason_VALIDATION_REASON_UNSPECIFIED},
+		{"expired", couponv1.CouponStatus_COUPON_STATUS_ACTIVE, now.Add(-time.Hour), couponv1.ValidationReason_VALIDATION_REASON_EXPIRED},
+		{"revoked", couponv1.CouponStatus_COUPON_STATUS_REVOKED, now.Add(time.Hour), couponv1.ValidationReason_VALIDATION_REASON_REVOKED},
+		{"redeemed", couponv1.CouponStatus_COUPON_STATUS_REDEEMED, now.Add(-time.Hour), couponv1.ValidationReason_VALIDATION_REASON_REDEEMED},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			coupon := newTestCoupon("A", tc.expireAt)
+			coupon.Status = tc.status
+			if got := Reason(coupon, now); got != tc.want {
+				t.Errorf("Reason() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
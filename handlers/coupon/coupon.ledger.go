@@ -0,0 +1,93 @@
+package coupon
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	couponv1 "github.com/jackgihokim/coupon-issuance-system/protos/coupon/v1"
+)
+
+// redeemAmount takes the amount off the balance of a usable stored-value coupon for the order at now, and records
+// it in the ledger. The coupon is redeemed once its balance runs out.
+// Returns snapshots of the coupon and the ledger entry, or an error if the coupon cannot be used or the amount is
+// not positive, in another currency or more than the balance.
+func (i *Index) redeemAmount(
+	code string, amount *couponv1.Money, orderId string, now time.Time,
+) (*couponv1.Coupon, *couponv1.LedgerEntry, error) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	coupon, ok := i.m[code]
+	if !ok {
+		return nil, nil, errors.New("coupon not found")
+	}
+	if coupon.Balance == nil {
+		return nil, nil, errors.New("coupon has no stored value")
+	}
+	if err := ReasonError(Reason(coupon, now)); err != nil {
+		return nil, nil, err
+	}
+	switch {
+	case amount.GetAmount() <= 0:
+		return nil, nil, errors.New("amount must be positive")
+	case amount.Currency != coupon.Balance.Currency:
+		return nil, nil, fmt.Errorf("amount must be in %s", coupon.Balance.Currency)
+	case amount.Amount > coupon.Balance.Amount:
+		return nil, nil, errors.New("amount exceeds the balance")
+	}
+
+	id, err := i.recordRedemption(code, orderId, amount, now)
+	if err != nil {
+		return nil, nil, err
+	}
+	coupon.Balance.Amount -= amount.Amount
+	if coupon.Balance.Amount == 0 {
+		coupon.Status = couponv1.CouponStatus_COUPON_STATUS_REDEEMED
+		coupon.RedeemedAt = timestamppb.New(now)
+	}
+	entry := &couponv1.LedgerEntry{
+		Id:         id,
+		Type:       couponv1.LedgerEntryType_LEDGER_ENTRY_TYPE_REDEMPTION,
+		Amount:     proto.Clone(amount).(*couponv1.Money),
+		Balance:    proto.Clone(coupon.Balance).(*couponv1.Money),
+		OrderId:    orderId,
+		OccurredAt: timestamppb.New(now),
+	}
+	i.ledgers[code] = append(i.ledgers[code], entry)
+	return proto.Clone(coupon).(*couponv1.Coupon), proto.Clone(entry).(*couponv1.LedgerEntry), nil
+}
+
+// ledger returns snapshots of the ledger entries of the coupon in order.
+// Returns an error if the coupon is unknown or has no stored value.
+func (i *Index) ledger(code string) ([]*couponv1.LedgerEntry, error) {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	coupon, ok := i.m[code]
+	if !ok {
+		return nil, errors.New("coupon not found")
+	}
+	if coupon.Balance == nil {
+		return nil, errors.New("coupon has no stored value")
+	}
+	entries := make([]*couponv1.LedgerEntry, len(i.ledgers[code]))
+	for j, entry := range i.ledgers[code] {
+		entries[j] = proto.Clone(entry).(*couponv1.LedgerEntry)
+	}
+	return entries, nil
+}
+
+// RedeemAmount takes the amount off the balance of the stored-value coupon with the specified code for the order.
+// Returns the coupon with its balance and the ledger entry, or an error if the coupon cannot be used
+// or the amount cannot be taken off its balance.
+func RedeemAmount(code string, amount *couponv1.Money, orderId string, now time.Time) (*couponv1.Coupon, *couponv1.LedgerEntry, error) {
+	return index.redeemAmount(code, amount, orderId, now)
+}
+
+// Ledger returns the balance changes of the stored-value coupon with the specified code in order.
+// Returns an error if the coupon is unknown or has no stored value.
+func Ledger(code string) ([]*couponv1.LedgerEntry, error) {
+	return index.ledger(code)
+}
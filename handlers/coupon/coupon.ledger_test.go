@@ -0,0 +1,107 @@
+package coupon
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	couponv1 "github.com/jackgihokim/coupon-issuance-system/protos/coupon/v1"
+)
+
+func newTestStoredValue(code string, balance int64) *couponv1.Coupon {
+	coupon := newTestCoupon(code, time.Now().Add(time.Hour))
+	coupon.Balance = &couponv1.Money{Currency: "KRW", Amount: balance}
+	return coupon
+}
+
+func TestIndex_RedeemAmount(t *testing.T) {
+	now := time.Now()
+	idx := newIndex()
+	idx.add(newTestStoredValue("A", 10000))
+	idx.add(newTestCoupon("B", now.Add(time.Hour)))
+
+	testCases := []struct {
+		name        string
+		code        string
+		amount      *couponv1.Money
+		wantBalance int64
+		wantErr     string
+	}{
+		{"partial redemption", "A", &couponv1.Money{Currency: "KRW", Amount: 3000}, 7000, ""},
+		{"overdraw", "A", &couponv1.Money{Currency: "KRW", Amount: 7001}, 0, "amount exceeds the balance"},
+		{"another currency", "A", &couponv1.Money{Currency: "USD", Amount: 10}, 0, "amount must be in KRW"},
+		{"zero amount", "A", &couponv1.Money{Currency: "KRW"}, 0, "amount must be positive"},
+		{"the rest", "A", &couponv1.Money{Currency: "KRW", Amount: 7000}, 0, ""},
+		{"used up", "A", &couponv1.Money{Currency: "KRW", Amount: 1}, 0, "coupon is already redeemed"},
+		{"no stored value", "B", &couponv1.Money{Currency: "KRW", Amount: 1}, 0, "coupon has no stored value"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			coupon, entry, err := idx.redeemAmount(tc.code, tc.amount, "order-1", now)
+			if tc.wantErr != "" {
+				if err == nil || err.Error() != tc.wantErr {
+					t.Fatalf("Expected %q error, got: %v", tc.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+			if coupon.Balance.Amount != tc.wantBalance || entry.Balance.Amount != tc.wantBalance {
+				t.Errorf("Expected balance %d, got %d and %d in the entry", tc.wantBalance, coupon.Balance.Amount, entry.Balance.Amount)
+			}
+		})
+	}
+
+	if status := idx.m["A"].Status; status != couponv1.CouponStatus_COUPON_STATUS_REDEEMED {
+		t.Errorf("Expected the used up coupon to be redeemed, got %v", status)
+	}
+	entries, err := idx.ledger("A")
+	if err != nil || len(entries) != 2 {
+		t.Fatalf("Expected 2 ledger entries, got %d, %v", len(entries), err)
+	}
+	if entries[0].Amount.Amount != 3000 || entries[1].Amount.Amount != 7000 || entries[0].OrderId != "order-1" {
+		t.Errorf("Unexpected ledger entries: %v", entries)
+	}
+}
+
+func TestIndex_RedeemAmount_Concurrent(t *testing.T) {
+	now := time.Now()
+	idx := newIndex()
+	idx.add(newTestStoredValue("A", 100))
+
+	// The balance never goes below zero however many orders redeem it at once
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	redeemed := 0
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, _, err := idx.redeemAmount("A", &couponv1.Money{Currency: "KRW", Amount: 3}, "", now); err == nil {
+				mu.Lock()
+				redeemed++
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if redeemed != 33 || idx.m["A"].Balance.Amount != 1 {
+		t.Errorf("Expected 33 redemptions leaving 1, got %d leaving %d", redeemed, idx.m["A"].Balance.Amount)
+	}
+}
+
+func TestIndex_StoredValueRedeem(t *testing.T) {
+	now := time.Now()
+	idx := newIndex()
+	idx.add(newTestStoredValue("A", 100))
+
+	if _, err := idx.redeem("A", "", now); err == nil || err.Error() != "stored-value coupon is redeemed by amount" {
+		t.Errorf("Expected 'stored-value coupon is redeemed by amount' error, got: %v", err)
+	}
+	if _, err := idx.reserve("A", time.Minute, now); err == nil || err.Error() != "stored-value coupon cannot be reserved" {
+		t.Errorf("Expected 'stored-value coupon cannot be reserved' error, got: %v", err)
+	}
+}
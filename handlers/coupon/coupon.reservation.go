@@ -0,0 +1,153 @@
+package coupon
+
+import (
+	"errors"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	couponv1 "github.com/jackgihokim/coupon-issuance-system/protos/coupon/v1"
+)
+
+// MaxReservationTTL is how long a coupon can be held for a checkout, and how long it is held by default.
+const MaxReservationTTL = 15 * time.Minute
+
+// ReservationTTL returns how long a reservation holds a coupon, MaxReservationTTL if ttl is nil.
+// Returns an error if ttl is not positive or longer than MaxReservationTTL.
+func ReservationTTL(ttl *durationpb.Duration) (time.Duration, error) {
+	if ttl == nil {
+		return MaxReservationTTL, nil
+	}
+	if err := ttl.CheckValid(); err != nil {
+		return 0, err
+	}
+	d := ttl.AsDuration()
+	if d <= 0 || d > MaxReservationTTL {
+		return 0, errors.New("reservation TTL must be positive and at most 15 minutes")
+	}
+	return d, nil
+}
+
+// reserve holds a usable coupon from now for the TTL under a new reservation.
+// Returns a snapshot of the reserved coupon or an error if the coupon cannot be used or the ID generation fails.
+func (i *Index) reserve(code string, ttl time.Duration, now time.Time) (*couponv1.Coupon, error) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	coupon, ok := i.m[code]
+	if !ok {
+		return nil, errors.New("coupon not found")
+	}
+	if coupon.Balance != nil {
+		return nil, errors.New("stored-value coupon cannot be reserved")
+	}
+	if err := ReasonError(Reason(coupon, now)); err != nil {
+		return nil, err
+	}
+
+	id, err := newRandomId()
+	if err != nil {
+		return nil, err
+	}
+	coupon.Status = couponv1.CouponStatus_COUPON_STATUS_RESERVED
+	coupon.Reservation = &couponv1.Reservation{
+		Id:         id,
+		ReservedAt: timestamppb.New(now),
+		ExpireAt:   timestamppb.New(now.Add(ttl)),
+	}
+	i.reserved[code] = struct{}{}
+	return proto.Clone(coupon).(*couponv1.Coupon), nil
+}
+
+// commit redeems the coupon held by the reservation for the order at now. The reservation holds the coupon as it
+// was when reserved, so it is redeemed even if it expired meanwhile.
+// Returns a snapshot of the redeemed coupon or an error if the reservation is not holding the coupon.
+func (i *Index) commit(code, reservationId, orderId string, now time.Time) (*couponv1.Coupon, error) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	coupon, err := i.holding(code, reservationId, now)
+	if err != nil {
+		return nil, err
+	}
+	if err := i.markRedeemed(coupon, orderId, now); err != nil {
+		return nil, err
+	}
+	return proto.Clone(coupon).(*couponv1.Coupon), nil
+}
+
+// release makes the coupon held by the reservation active again.
+// Returns a snapshot of the released coupon or an error if the reservation is not holding the coupon.
+func (i *Index) release(code, reservationId string, now time.Time) (*couponv1.Coupon, error) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	coupon, err := i.holding(code, reservationId, now)
+	if err != nil {
+		return nil, err
+	}
+	coupon.Status = couponv1.CouponStatus_COUPON_STATUS_ACTIVE
+	delete(i.reserved, code)
+	return proto.Clone(coupon).(*couponv1.Coupon), nil
+}
+
+// holding returns the coupon if the reservation holds it at now. The caller must hold mu.
+// Returns an error if the coupon is unknown, not reserved, or reserved by another or an expired reservation.
+func (i *Index) holding(code, reservationId string, now time.Time) (*couponv1.Coupon, error) {
+	coupon, ok := i.m[code]
+	if !ok {
+		return nil, errors.New("coupon not found")
+	}
+	switch {
+	case coupon.Status != couponv1.CouponStatus_COUPON_STATUS_RESERVED || coupon.Reservation.Id != reservationId:
+		return nil, errors.New("reservation not found")
+	case !held(coupon, now):
+		return nil, errors.New("reservation is expired")
+	}
+	return coupon, nil
+}
+
+// sweep makes the coupons whose reservations expired by now active again. Returns the number of them.
+func (i *Index) sweep(now time.Time) int {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	n := 0
+	for code := range i.reserved {
+		coupon := i.m[code]
+		if held(coupon, now) {
+			continue
+		}
+		coupon.Status = couponv1.CouponStatus_COUPON_STATUS_ACTIVE
+		delete(i.reserved, code)
+		n++
+	}
+	return n
+}
+
+// held reports whether the coupon is reserved by a reservation which has not expired at now.
+func held(coupon *couponv1.Coupon, now time.Time) bool {
+	return coupon.Status == couponv1.CouponStatus_COUPON_STATUS_RESERVED && !coupon.Reservation.ExpireAt.AsTime().Before(now)
+}
+
+// Reserve holds the coupon with the specified code for a checkout from now for the TTL, so it cannot be redeemed
+// elsewhere. Returns the reserved coupon with its reservation or an error if the coupon cannot be used.
+func Reserve(code string, ttl time.Duration, now time.Time) (*couponv1.Coupon, error) {
+	return index.reserve(code, ttl, now)
+}
+
+// CommitReservation redeems the coupon with the specified code held by the reservation for the order.
+// The order ID may be empty. Returns the redeemed coupon or an error if the reservation is unknown or expired.
+func CommitReservation(code, reservationId, orderId string, now time.Time) (*couponv1.Coupon, error) {
+	return index.commit(code, reservationId, orderId, now)
+}
+
+// ReleaseReservation ends the reservation of the coupon with the specified code, so it can be used again.
+// Returns the released coupon or an error if the reservation is unknown or expired.
+func ReleaseReservation(code, reservationId string, now time.Time) (*couponv1.Coupon, error) {
+	return index.release(code, reservationId, now)
+}
+
+// SweepReservations makes the coupons whose reservations expired by now active again.
+// Returns the number of released coupons.
+func SweepReservations(now time.Time) int {
+	return index.sweep(now)
+}
@@ -0,0 +1,108 @@
+package coupon
+
+import (
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	couponv1 "github.com/jackgihokim/coupon-issuance-system/protos/coupon/v1"
+)
+
+func TestReservationTTL(t *testing.T) {
+	testCases := []struct {
+		name    string
+		ttl     *durationpb.Duration
+		want    time.Duration
+		wantErr bool
+	}{
+		{"default", nil, MaxReservationTTL, false},
+		{"five minutes", durationpb.New(5 * time.Minute), 5 * time.Minute, false},
+		{"zero", durationpb.New(0), 0, true},
+		{"longer than the maximum", durationpb.New(16 * time.Minute), 0, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ReservationTTL(tc.ttl)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("ReservationTTL() error = %v, wantErr %v", err, tc.wantErr)
+			}
+			if got != tc.want {
+				t.Errorf("ReservationTTL() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestIndex_Reserve(t *testing.T) {
+	now := time.Now()
+	idx := newIndex()
+	idx.add(newTestCoupon("A", now.Add(time.Hour)))
+
+	reserved, err := idx.reserve("A", time.Minute, now)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if reserved.Status != couponv1.CouponStatus_COUPON_STATUS_RESERVED || reserved.Reservation.Id == "" {
+		t.Errorf("Expected a reserved coupon with a reservation, got: %v", reserved)
+	}
+	id := reserved.Reservation.Id
+
+	// A held coupon can neither be reserved nor redeemed elsewhere
+	if _, err := idx.reserve("A", time.Minute, now); err == nil || err.Error() != "coupon is reserved" {
+		t.Errorf("Expected 'coupon is reserved' error, got: %v", err)
+	}
+	if _, err := idx.redeem("A", "", now); err == nil || err.Error() != "coupon is reserved" {
+		t.Errorf("Expected 'coupon is reserved' error, got: %v", err)
+	}
+	if _, err := idx.commit("A", "other", "", now); err == nil || err.Error() != "reservation not found" {
+		t.Errorf("Expected 'reservation not found' error, got: %v", err)
+	}
+
+	released, err := idx.release("A", id, now)
+	if err != nil || released.Status != couponv1.CouponStatus_COUPON_STATUS_ACTIVE {
+		t.Fatalf("Expected the coupon to be active again, got: %v, %v", released, err)
+	}
+	if _, err := idx.release("A", id, now); err == nil {
+		t.Errorf("Expected error when releasing a released reservation")
+	}
+
+	reserved, _ = idx.reserve("A", time.Minute, now)
+	committed, err := idx.commit("A", reserved.Reservation.Id, "", now.Add(30*time.Second))
+	if err != nil || committed.Status != couponv1.CouponStatus_COUPON_STATUS_REDEEMED {
+		t.Fatalf("Expected the coupon to be redeemed, got: %v, %v", committed, err)
+	}
+	if len(idx.reserved) != 0 {
+		t.Errorf("Expected no reserved coupons left, got %d", len(idx.reserved))
+	}
+}
+
+func TestIndex_ReservationExpiry(t *testing.T) {
+	now := time.Now()
+	idx := newIndex()
+	idx.add(newTestCoupon("A", now.Add(time.Hour)))
+	idx.add(newTestCoupon("B", now.Add(time.Hour)))
+
+	a, _ := idx.reserve("A", time.Minute, now)
+	_, _ = idx.reserve("B", 10*time.Minute, now)
+
+	// An expired hold cannot be committed, and the coupon is usable before the sweeper runs
+	later := now.Add(2 * time.Minute)
+	if _, err := idx.commit("A", a.Reservation.Id, "", later); err == nil || err.Error() != "reservation is expired" {
+		t.Errorf("Expected 'reservation is expired' error, got: %v", err)
+	}
+	if reason := Reason(idx.m["A"], later); reason != couponv1.ValidationReason_VALIDATION_REASON_UNSPECIFIED {
+		t.Errorf("Expected the coupon to be usable, got reason %v", reason)
+	}
+
+	if n := idx.sweep(later); n != 1 {
+		t.Errorf("Expected 1 swept reservation, got %d", n)
+	}
+	if idx.m["A"].Status != couponv1.CouponStatus_COUPON_STATUS_ACTIVE {
+		t.Errorf("Expected the swept coupon to be active, got %v", idx.m["A"].Status)
+	}
+	if idx.m["B"].Status != couponv1.CouponStatus_COUPON_STATUS_RESERVED {
+		t.Errorf("Expected the held coupon to stay reserved, got %v", idx.m["B"].Status)
+	}
+}
@@ -0,0 +1,180 @@
+package coupon
+
+import (
+	"errors"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	couponv1 "github.com/jackgihokim/coupon-issuance-system/protos/coupon/v1"
+)
+
+// redemption is a redemption of a coupon which can be reversed, e.g. when its order is refunded.
+type redemption struct {
+	code    string
+	orderId string
+	amount  *couponv1.Money // the amount taken off a stored-value coupon, or nil.
+	at      time.Time
+	// reversal is set once the redemption is reversed, so reversing it again returns the same outcome.
+	reversal *couponv1.Reversal
+}
+
+// recordRedemption records a redemption of the coupon for the order at now under a new ID. The caller must hold mu.
+// Returns the ID of the redemption or an error if the ID generation fails.
+func (i *Index) recordRedemption(code, orderId string, amount *couponv1.Money, now time.Time) (string, error) {
+	id, err := newRandomId()
+	if err != nil {
+		return "", err
+	}
+	r := &redemption{code: code, orderId: orderId, at: now}
+	if amount != nil {
+		r.amount = proto.Clone(amount).(*couponv1.Money)
+	}
+	i.redemptions[id] = r
+	if orderId != "" {
+		i.orders[orderId] = append(i.orders[orderId], id)
+	}
+	return id, nil
+}
+
+// markRedeemed records the redemption of the coupon for the order and marks it as redeemed at now.
+// The caller must hold mu. Returns an error if the ID generation fails.
+func (i *Index) markRedeemed(coupon *couponv1.Coupon, orderId string, now time.Time) error {
+	id, err := i.recordRedemption(coupon.Code, orderId, nil, now)
+	if err != nil {
+		return err
+	}
+	coupon.Status = couponv1.CouponStatus_COUPON_STATUS_REDEEMED
+	coupon.RedeemedAt = timestamppb.New(now)
+	coupon.RedemptionId = id
+	coupon.OrderId = orderId
+	delete(i.reserved, coupon.Code)
+	return nil
+}
+
+// redeemed returns a snapshot of the coupon the redemption was of. Returns an error if the redemption is unknown.
+func (i *Index) redeemed(redemptionId string) (*couponv1.Coupon, error) {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	r, ok := i.redemptions[redemptionId]
+	if !ok {
+		return nil, errors.New("redemption not found")
+	}
+	return proto.Clone(i.m[r.code]).(*couponv1.Coupon), nil
+}
+
+// orderRedemptions returns the IDs of the redemptions for the order in the order they occurred.
+// Returns an error if no coupon was redeemed for the order.
+func (i *Index) orderRedemptions(orderId string) ([]string, error) {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	ids, ok := i.orders[orderId]
+	if !ok {
+		return nil, errors.New("no redemption for the order")
+	}
+	return append([]string(nil), ids...), nil
+}
+
+// reverse reverses the redemption at now, restoring the coupon as the policy allows. A regular coupon becomes
+// active again, and the amount of a stored-value coupon is credited back. A revoked coupon is never restored.
+// The reversal is recorded in the coupon's history either way.
+// Returns a snapshot of the reversal and whether this call reversed it, or the earlier reversal if it is already
+// reversed, or an error if the redemption is unknown or the ID generation fails.
+func (i *Index) reverse(redemptionId string, policy couponv1.RestorePolicy, now time.Time) (*couponv1.Reversal, bool, error) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	r, ok := i.redemptions[redemptionId]
+	if !ok {
+		return nil, false, errors.New("redemption not found")
+	}
+	if r.reversal != nil {
+		return proto.Clone(r.reversal).(*couponv1.Reversal), false, nil
+	}
+
+	reversal := &couponv1.Reversal{
+		RedemptionId: redemptionId,
+		Code:         r.code,
+		OrderId:      r.orderId,
+		ReversedAt:   timestamppb.New(now),
+	}
+	coupon := i.m[r.code]
+	if restorable(coupon, redemptionId, r, policy, now) {
+		if coupon.ExpireAt.AsTime().Before(now) {
+			// Only ALWAYS gets here, giving back the validity the coupon had left when redeemed
+			coupon.ExpireAt = timestamppb.New(now.Add(coupon.ExpireAt.AsTime().Sub(r.at)))
+		}
+		if r.amount != nil {
+			id, err := newRandomId()
+			if err != nil {
+				return nil, false, err
+			}
+			coupon.Balance.Amount += r.amount.Amount
+			entry := &couponv1.LedgerEntry{
+				Id:         id,
+				Type:       couponv1.LedgerEntryType_LEDGER_ENTRY_TYPE_REFUND,
+				Amount:     proto.Clone(r.amount).(*couponv1.Money),
+				Balance:    proto.Clone(coupon.Balance).(*couponv1.Money),
+				OrderId:    r.orderId,
+				OccurredAt: timestamppb.New(now),
+			}
+			i.ledgers[r.code] = append(i.ledgers[r.code], entry)
+			reversal.Refund = proto.Clone(entry).(*couponv1.LedgerEntry)
+		} else {
+			coupon.RedemptionId = ""
+			coupon.OrderId = ""
+		}
+		coupon.Status = couponv1.CouponStatus_COUPON_STATUS_ACTIVE
+		coupon.RedeemedAt = nil
+		reversal.Restored = true
+	}
+	coupon.History = append(coupon.History, &couponv1.CouponEvent{
+		Type:         couponv1.CouponEventType_COUPON_EVENT_TYPE_REDEMPTION_REVERSED,
+		RedemptionId: redemptionId,
+		OrderId:      r.orderId,
+		Restored:     reversal.Restored,
+		OccurredAt:   timestamppb.New(now),
+	})
+	r.reversal = reversal
+	return proto.Clone(reversal).(*couponv1.Reversal), true, nil
+}
+
+// restorable reports whether reversing the redemption at now restores the coupon under the policy.
+// A regular coupon must still be redeemed by the redemption, as it may have been redeemed again since.
+func restorable(
+	coupon *couponv1.Coupon, redemptionId string, r *redemption, policy couponv1.RestorePolicy, now time.Time,
+) bool {
+	if coupon.Status == couponv1.CouponStatus_COUPON_STATUS_REVOKED {
+		return false
+	}
+	if r.amount == nil &&
+		(coupon.Status != couponv1.CouponStatus_COUPON_STATUS_REDEEMED || coupon.RedemptionId != redemptionId) {
+		return false
+	}
+	switch policy {
+	case couponv1.RestorePolicy_RESTORE_POLICY_NEVER:
+		return false
+	case couponv1.RestorePolicy_RESTORE_POLICY_ALWAYS:
+		return true
+	}
+	return !coupon.ExpireAt.AsTime().Before(now)
+}
+
+// Reverse reverses the redemption with the specified ID at now, restoring its coupon as the policy allows.
+// Reversing a redemption again returns the same reversal. Returns the reversal and whether this call reversed it,
+// or an error if the redemption is unknown.
+func Reverse(redemptionId string, policy couponv1.RestorePolicy, now time.Time) (*couponv1.Reversal, bool, error) {
+	return index.reverse(redemptionId, policy, now)
+}
+
+// Redeemed returns the coupon the redemption with the specified ID was of.
+// Returns an error if the redemption is unknown.
+func Redeemed(redemptionId string) (*couponv1.Coupon, error) {
+	return index.redeemed(redemptionId)
+}
+
+// OrderRedemptions returns the IDs of the redemptions for the order in the order they occurred.
+// Returns an error if no coupon was redeemed for the order.
+func OrderRedemptions(orderId string) ([]string, error) {
+	return index.orderRedemptions(orderId)
+}
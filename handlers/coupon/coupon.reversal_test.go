@@ -0,0 +1,130 @@
+package coupon
+
+import (
+	"testing"
+	"time"
+
+	couponv1 "github.com/jackgihokim/coupon-issuance-system/protos/coupon/v1"
+)
+
+func TestIndex_Reverse(t *testing.T) {
+	now := time.Date(2025, 5, 1, 10, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name         string
+		policy       couponv1.RestorePolicy
+		expireIn     time.Duration // from the redemption.
+		reverseAfter time.Duration
+		wantRestored bool
+		wantExpireAt time.Time
+	}{
+		{"unspecified before expiry", couponv1.RestorePolicy_RESTORE_POLICY_UNSPECIFIED, time.Hour, time.Minute, true, now.Add(time.Hour)},
+		{"unspecified after expiry", couponv1.RestorePolicy_RESTORE_POLICY_UNSPECIFIED, time.Hour, 2 * time.Hour, false, now.Add(time.Hour)},
+		{"if not expired after expiry", couponv1.RestorePolicy_RESTORE_POLICY_IF_NOT_EXPIRED, time.Hour, 2 * time.Hour, false, now.Add(time.Hour)},
+		{"always after expiry", couponv1.RestorePolicy_RESTORE_POLICY_ALWAYS, time.Hour, 2 * time.Hour, true, now.Add(3 * time.Hour)},
+		{"never", couponv1.RestorePolicy_RESTORE_POLICY_NEVER, time.Hour, time.Minute, false, now.Add(time.Hour)},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			idx := newIndex()
+			idx.add(newTestCoupon("A", now.Add(tc.expireIn)))
+			redeemed, err := idx.redeem("A", "order-1", now)
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+
+			reversal, reversed, err := idx.reverse(redeemed.RedemptionId, tc.policy, now.Add(tc.reverseAfter))
+			if err != nil || !reversed {
+				t.Fatalf("Expected the redemption to be reversed, got %v, %v", reversed, err)
+			}
+			if reversal.Restored != tc.wantRestored || reversal.OrderId != "order-1" {
+				t.Errorf("Unexpected reversal: %v", reversal)
+			}
+			coupon := idx.m["A"]
+			wantStatus := couponv1.CouponStatus_COUPON_STATUS_REDEEMED
+			if tc.wantRestored {
+				wantStatus = couponv1.CouponStatus_COUPON_STATUS_ACTIVE
+			}
+			if coupon.Status != wantStatus || !coupon.ExpireAt.AsTime().Equal(tc.wantExpireAt) {
+				t.Errorf("Expected %v until %v, got %v until %v", wantStatus, tc.wantExpireAt, coupon.Status, coupon.ExpireAt.AsTime())
+			}
+			if len(coupon.History) != 1 || coupon.History[0].RedemptionId != redeemed.RedemptionId ||
+				coupon.History[0].Restored != tc.wantRestored {
+				t.Errorf("Expected the reversal in the coupon's history, got %v", coupon.History)
+			}
+		})
+	}
+}
+
+func TestIndex_Reverse_Idempotent(t *testing.T) {
+	now := time.Now()
+	idx := newIndex()
+	idx.add(newTestCoupon("A", now.Add(time.Hour)))
+	first, _ := idx.redeem("A", "order-1", now)
+	if _, _, err := idx.reverse(first.RedemptionId, couponv1.RestorePolicy_RESTORE_POLICY_ALWAYS, now); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	// Reversing again changes nothing, even once the coupon is redeemed by another order
+	second, err := idx.redeem("A", "order-2", now)
+	if err != nil {
+		t.Fatalf("Expected the restored coupon to be redeemed again, got: %v", err)
+	}
+	reversal, reversed, err := idx.reverse(first.RedemptionId, couponv1.RestorePolicy_RESTORE_POLICY_ALWAYS, now)
+	if err != nil || reversed || !reversal.Restored {
+		t.Errorf("Expected the earlier reversal, got %v, %v, %v", reversal, reversed, err)
+	}
+	if coupon := idx.m["A"]; coupon.Status != couponv1.CouponStatus_COUPON_STATUS_REDEEMED || coupon.RedemptionId != second.RedemptionId {
+		t.Errorf("Expected the coupon to stay redeemed by order-2, got %v", coupon)
+	}
+	if coupon := idx.m["A"]; len(coupon.History) != 1 {
+		t.Errorf("Expected the reversal to be recorded in the coupon's history once, got %v", coupon.History)
+	}
+	if _, _, err := idx.reverse("unknown", couponv1.RestorePolicy_RESTORE_POLICY_ALWAYS, now); err == nil {
+		t.Errorf("Expected an error for an unknown redemption")
+	}
+}
+
+func TestIndex_Reverse_StoredValue(t *testing.T) {
+	now := time.Now()
+	idx := newIndex()
+	idx.add(newTestStoredValue("A", 10000))
+	idx.redeemAmount("A", &couponv1.Money{Currency: "KRW", Amount: 4000}, "order-1", now)
+	_, entry, _ := idx.redeemAmount("A", &couponv1.Money{Currency: "KRW", Amount: 6000}, "order-2", now)
+
+	ids, err := idx.orderRedemptions("order-2")
+	if err != nil || len(ids) != 1 || ids[0] != entry.Id {
+		t.Fatalf("Expected the ledger entry to be the redemption of order-2, got %v, %v", ids, err)
+	}
+	reversal, _, err := idx.reverse(entry.Id, couponv1.RestorePolicy_RESTORE_POLICY_UNSPECIFIED, now)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if !reversal.Restored || reversal.Refund.Type != couponv1.LedgerEntryType_LEDGER_ENTRY_TYPE_REFUND || reversal.Refund.Balance.Amount != 6000 {
+		t.Errorf("Expected 6000 to be refunded, got %v", reversal)
+	}
+	coupon := idx.m["A"]
+	if coupon.Status != couponv1.CouponStatus_COUPON_STATUS_ACTIVE || coupon.RedeemedAt != nil {
+		t.Errorf("Expected the used up coupon to be active again, got %v", coupon)
+	}
+	if entries, _ := idx.ledger("A"); len(entries) != 3 {
+		t.Errorf("Expected 3 ledger entries, got %d", len(entries))
+	}
+}
+
+func TestIndex_Reverse_Revoked(t *testing.T) {
+	now := time.Now()
+	idx := newIndex()
+	idx.add(newTestStoredValue("A", 10000))
+	_, entry, _ := idx.redeemAmount("A", &couponv1.Money{Currency: "KRW", Amount: 4000}, "", now)
+	idx.revoke("A", "fraud", now)
+
+	reversal, _, err := idx.reverse(entry.Id, couponv1.RestorePolicy_RESTORE_POLICY_ALWAYS, now)
+	if err != nil || reversal.Restored {
+		t.Errorf("Expected a revoked coupon not to be restored, got %v, %v", reversal, err)
+	}
+	if idx.m["A"].Balance.Amount != 6000 {
+		t.Errorf("Expected the balance to stay 6000, got %d", idx.m["A"].Balance.Amount)
+	}
+}
@@ -0,0 +1,168 @@
+package coupon
+
+import (
+	"crypto/subtle"
+	"errors"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	couponv1 "github.com/jackgihokim/coupon-issuance-system/protos/coupon/v1"
+)
+
+// DefaultClaimTTL is how long a claim link stays valid unless the transfer policy sets it.
+const DefaultClaimTTL = 7 * 24 * time.Hour
+
+// ValidateTransferPolicy checks that the claim TTL of the transfer policy is positive if set.
+func ValidateTransferPolicy(p *couponv1.TransferPolicy) error {
+	if p.ClaimTtl != nil {
+		if err := p.ClaimTtl.CheckValid(); err != nil {
+			return err
+		}
+		if p.ClaimTtl.AsDuration() <= 0 {
+			return errors.New("claim TTL must be positive")
+		}
+	}
+	return nil
+}
+
+// ClaimTTL returns how long the claim links of the transfer policy stay valid.
+func ClaimTTL(p *couponv1.TransferPolicy) time.Duration {
+	if p.GetClaimTtl() == nil {
+		return DefaultClaimTTL
+	}
+	return p.ClaimTtl.AsDuration()
+}
+
+// transferable checks that the user owns the coupon, which is usable at now and has changed hands fewer than
+// maxTransfers times, or any number of times if it is 0. The caller must hold mu.
+func transferable(coupon *couponv1.Coupon, fromUserId string, maxTransfers uint32, now time.Time) error {
+	if coupon.UserId == "" || coupon.UserId != fromUserId {
+		return errors.New("only the owner can transfer the coupon")
+	}
+	if err := ReasonError(Reason(coupon, now)); err != nil {
+		return err
+	}
+	if maxTransfers > 0 && len(coupon.Transfers) >= int(maxTransfers) {
+		return errors.New("coupon reached the maximum number of transfers")
+	}
+	return nil
+}
+
+// moveOwnership makes the user the owner of the coupon at now and appends the transfer to its ownership chain.
+// Any claim link of the coupon is withdrawn. The caller must hold mu.
+func (i *Index) moveOwnership(coupon *couponv1.Coupon, toUserId string, claimed bool, now time.Time) {
+	coupon.Transfers = append(coupon.Transfers, &couponv1.Transfer{
+		FromUserId:    coupon.UserId,
+		ToUserId:      toUserId,
+		TransferredAt: timestamppb.New(now),
+		Claimed:       claimed,
+	})
+	coupon.UserId = toUserId
+	coupon.TransferOffer = nil
+	delete(i.offers, coupon.Code)
+}
+
+// transfer moves the coupon from its owner to another user at now.
+// Returns a snapshot of the transferred coupon or an error if the coupon cannot be transferred to the user.
+func (i *Index) transfer(code, fromUserId, toUserId string, maxTransfers uint32, now time.Time) (*couponv1.Coupon, error) {
+	if toUserId == "" {
+		return nil, errors.New("recipient is required")
+	}
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	coupon, ok := i.m[code]
+	if !ok {
+		return nil, errors.New("coupon not found")
+	}
+	if err := transferable(coupon, fromUserId, maxTransfers, now); err != nil {
+		return nil, err
+	}
+	if toUserId == fromUserId {
+		return nil, errors.New("cannot transfer the coupon to its owner")
+	}
+	i.moveOwnership(coupon, toUserId, false, now)
+	return proto.Clone(coupon).(*couponv1.Coupon), nil
+}
+
+// offer offers the coupon of its owner with a claim link valid from now for the TTL, replacing any earlier link.
+// Returns a snapshot of the coupon and the one-time token of the link, or an error if the coupon cannot be
+// transferred or the token generation fails.
+func (i *Index) offer(code, fromUserId string, maxTransfers uint32, ttl time.Duration, now time.Time) (*couponv1.Coupon, string, error) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	coupon, ok := i.m[code]
+	if !ok {
+		return nil, "", errors.New("coupon not found")
+	}
+	if err := transferable(coupon, fromUserId, maxTransfers, now); err != nil {
+		return nil, "", err
+	}
+
+	token, err := newRandomId()
+	if err != nil {
+		return nil, "", err
+	}
+	coupon.TransferOffer = &couponv1.TransferOffer{
+		FromUserId: fromUserId,
+		OfferedAt:  timestamppb.New(now),
+		ExpireAt:   timestamppb.New(now.Add(ttl)),
+	}
+	i.offers[code] = token
+	return proto.Clone(coupon).(*couponv1.Coupon), token, nil
+}
+
+// claim transfers the coupon offered with the claim link to the user at now, using up its token.
+// Returns a snapshot of the transferred coupon or an error if the token does not match an unexpired link
+// or the coupon is no longer usable.
+func (i *Index) claim(code, token, toUserId string, now time.Time) (*couponv1.Coupon, error) {
+	if toUserId == "" {
+		return nil, errors.New("user ID is required")
+	}
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	coupon, ok := i.m[code]
+	if !ok {
+		return nil, errors.New("coupon not found")
+	}
+	want, ok := i.offers[code]
+	if !ok {
+		return nil, errors.New("coupon has no claim link")
+	}
+	if subtle.ConstantTimeCompare([]byte(token), []byte(want)) != 1 {
+		return nil, errors.New("claim token is not valid")
+	}
+	if coupon.TransferOffer.ExpireAt.AsTime().Before(now) {
+		return nil, errors.New("claim link is expired")
+	}
+	if err := ReasonError(Reason(coupon, now)); err != nil {
+		return nil, err
+	}
+	if toUserId == coupon.UserId {
+		return nil, errors.New("cannot transfer the coupon to its owner")
+	}
+	i.moveOwnership(coupon, toUserId, true, now)
+	return proto.Clone(coupon).(*couponv1.Coupon), nil
+}
+
+// Transfer moves the coupon with the specified code from its owner to another user at now. maxTransfers limits
+// how many times the coupon can change hands, unless it is 0.
+// Returns the transferred coupon or an error if the user is not the owner or the coupon cannot be transferred.
+func Transfer(code, fromUserId, toUserId string, maxTransfers uint32, now time.Time) (*couponv1.Coupon, error) {
+	return index.transfer(code, fromUserId, toUserId, maxTransfers, now)
+}
+
+// Offer offers the coupon with the specified code with a claim link valid for the TTL, replacing any earlier link.
+// Returns the coupon and the one-time token of the link, or an error if the coupon cannot be transferred.
+func Offer(code, fromUserId string, maxTransfers uint32, ttl time.Duration, now time.Time) (*couponv1.Coupon, string, error) {
+	return index.offer(code, fromUserId, maxTransfers, ttl, now)
+}
+
+// Claim transfers the coupon with the specified code offered with the claim link to the user at now.
+// Returns the transferred coupon or an error if the token is not valid or the coupon is no longer usable.
+func Claim(code, token, toUserId string, now time.Time) (*couponv1.Coupon, error) {
+	return index.claim(code, token, toUserId, now)
+}
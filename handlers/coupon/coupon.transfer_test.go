@@ -0,0 +1,111 @@
+package coupon
+
+import (
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	couponv1 "github.com/jackgihokim/coupon-issuance-system/protos/coupon/v1"
+)
+
+func TestValidateTransferPolicy(t *testing.T) {
+	testCases := []struct {
+		name    string
+		policy  *couponv1.TransferPolicy
+		wantErr bool
+	}{
+		{"default claim TTL", &couponv1.TransferPolicy{MaxTransfers: 1}, false},
+		{"claim TTL", &couponv1.TransferPolicy{ClaimTtl: durationpb.New(time.Hour)}, false},
+		{"zero claim TTL", &couponv1.TransferPolicy{ClaimTtl: durationpb.New(0)}, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := ValidateTransferPolicy(tc.policy); (err != nil) != tc.wantErr {
+				t.Errorf("ValidateTransferPolicy() error = %v, wantErr %v", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestIndex_Transfer(t *testing.T) {
+	now := time.Now()
+	idx := newIndex()
+	coupon := newTestCoupon("A", now.Add(time.Hour))
+	coupon.UserId = "alice"
+	idx.add(coupon)
+
+	testCases := []struct {
+		name    string
+		from    string
+		to      string
+		wantErr string
+	}{
+		{"not the owner", "bob", "carol", "only the owner can transfer the coupon"},
+		{"to the owner", "alice", "alice", "cannot transfer the coupon to its owner"},
+		{"no recipient", "alice", "", "recipient is required"},
+		{"first transfer", "alice", "bob", ""},
+		{"second transfer", "bob", "carol", ""},
+		{"over the maximum", "carol", "dave", "coupon reached the maximum number of transfers"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := idx.transfer("A", tc.from, tc.to, 2, now)
+			if tc.wantErr != "" {
+				if err == nil || err.Error() != tc.wantErr {
+					t.Fatalf("Expected %q error, got: %v", tc.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+		})
+	}
+
+	chain := idx.m["A"].Transfers
+	if idx.m["A"].UserId != "carol" || len(chain) != 2 || chain[0].FromUserId != "alice" || chain[1].ToUserId != "carol" {
+		t.Errorf("Unexpected ownership chain: %v", chain)
+	}
+
+	idx.redeem("A", "", now)
+	if _, err := idx.transfer("A", "carol", "dave", 0, now); err == nil || err.Error() != "coupon is already redeemed" {
+		t.Errorf("Expected 'coupon is already redeemed' error, got: %v", err)
+	}
+}
+
+func TestIndex_Claim(t *testing.T) {
+	now := time.Now()
+	idx := newIndex()
+	coupon := newTestCoupon("A", now.Add(time.Hour))
+	coupon.UserId = "alice"
+	idx.add(coupon)
+
+	// A new claim link replaces the earlier one
+	_, stale, _ := idx.offer("A", "alice", 0, time.Minute, now)
+	_, token, err := idx.offer("A", "alice", 0, time.Minute, now)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if _, err := idx.claim("A", stale, "bob", now); err == nil || err.Error() != "claim token is not valid" {
+		t.Errorf("Expected 'claim token is not valid' error, got: %v", err)
+	}
+	if _, err := idx.claim("A", token, "bob", now.Add(2*time.Minute)); err == nil || err.Error() != "claim link is expired" {
+		t.Errorf("Expected 'claim link is expired' error, got: %v", err)
+	}
+
+	claimed, err := idx.claim("A", token, "bob", now)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if claimed.UserId != "bob" || claimed.TransferOffer != nil || !claimed.Transfers[0].Claimed {
+		t.Errorf("Unexpected claimed coupon: %v", claimed)
+	}
+
+	// The token is used up
+	if _, err := idx.claim("A", token, "carol", now); err == nil || err.Error() != "coupon has no claim link" {
+		t.Errorf("Expected 'coupon has no claim link' error, got: %v", err)
+	}
+}
@@ -4,15 +4,18 @@ import (
 	"strings"
 	"testing"
 	"time"
+
+	couponv1 "github.com/jackgihokim/coupon-issuance-system/protos/coupon/v1"
 )
 
 func TestNewCoupon(t *testing.T) {
 	now := time.Now()
 	expiration := now.Add(time.Hour * 24)
-	coupon, err := NewCoupon(expiration, now)
+	coupon, err := NewCoupon(1, expiration, now)
 	if err != nil {
 		t.Fatalf("Error occurred while creating NewCoupon(): %v", err)
 	}
+	defer Discard(coupon.Code)
 
 	// Verify code has correct format
 	if !strings.HasPrefix(coupon.Code, koText) {
@@ -29,6 +32,14 @@ func TestNewCoupon(t *testing.T) {
 		t.Errorf("Expiration date not set correctly. expected: %v, got: %v", expiration, coupon.ExpireAt.AsTime())
 	}
 
+	// Verify the coupon belongs to the campaign and is active
+	if coupon.CampaignId != 1 {
+		t.Errorf("CampaignId not set correctly. expected: 1, got: %d", coupon.CampaignId)
+	}
+	if coupon.Status != couponv1.CouponStatus_COUPON_STATUS_ACTIVE {
+		t.Errorf("Status should be active. got: %v", coupon.Status)
+	}
+
 	// Check IssuedAt is close to current time
 	timeDiff := now.Sub(coupon.IssuedAt.AsTime())
 	if timeDiff > time.Second {
@@ -36,6 +47,47 @@ func TestNewCoupon(t *testing.T) {
 	}
 }
 
+func TestNewCoupon_UniqueCode(t *testing.T) {
+	now := time.Now()
+	expiration := now.Add(time.Hour * 24)
+
+	// The same timestamp must not produce the same code twice
+	first, err := NewCoupon(1, expiration, now)
+	if err != nil {
+		t.Fatalf("Error occurred while creating NewCoupon(): %v", err)
+	}
+	defer Discard(first.Code)
+
+	second, err := NewCoupon(2, expiration, now)
+	if err != nil {
+		t.Fatalf("Error occurred while creating NewCoupon(): %v", err)
+	}
+	defer Discard(second.Code)
+
+	if first.Code == second.Code {
+		t.Errorf("Codes should be unique. got: %s twice", first.Code)
+	}
+}
+
+func TestNewCoupon_WithDiscount(t *testing.T) {
+	now := time.Now()
+	discount := &couponv1.Discount{Kind: &couponv1.Discount_FixedAmount_{
+		FixedAmount: &couponv1.Discount_FixedAmount{Amount: &couponv1.Money{Currency: "KRW", Amount: 5000}},
+	}}
+
+	coupon, err := NewCoupon(1, now.Add(time.Hour), now, WithDiscount(discount))
+	if err != nil {
+		t.Fatalf("Error occurred while creating NewCoupon(): %v", err)
+	}
+	defer Discard(coupon.Code)
+
+	// Changing the campaign's discount later must not change the snapshot
+	discount.GetFixedAmount().Amount.Amount = 10000
+	if got := coupon.Discount.GetFixedAmount().Amount.Amount; got != 5000 {
+		t.Errorf("Discount snapshot changed with the original. expected: 5000, got: %d", got)
+	}
+}
+
 func TestCreateCode(t *testing.T) {
 	testCases := []struct {
 		name    string
@@ -0,0 +1,145 @@
+package coupon
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	couponv1 "github.com/jackgihokim/coupon-issuance-system/protos/coupon/v1"
+)
+
+// allocation is the share of the coupon limit a channel issues.
+type allocation struct {
+	channel string
+	limit   uint32
+	issued  uint32 // in the latest occurrence if the campaign recurs.
+}
+
+// allocations splits the coupon limit across channels.
+type allocations struct {
+	byChannel map[string]*allocation
+	order     []*allocation
+	// spilloverAt is when the channels can start issuing what the others left of their allocations. Zero never.
+	spilloverAt time.Time
+}
+
+// AllocationStats is the number of coupons a channel issued and how many more it can issue.
+type AllocationStats struct {
+	Channel   string
+	Issued    uint32
+	Remaining uint32
+}
+
+// ValidateAllocation checks that the allocations have unique channels and limits, which add up to the coupon limit.
+// Returns an error describing the first invalid allocation.
+func ValidateAllocation(p *couponv1.AllocationPolicy, couponLimit uint32) error {
+	if len(p.Allocations) == 0 {
+		return errors.New("allocation policy needs at least one allocation")
+	}
+
+	channels := make(map[string]struct{}, len(p.Allocations))
+	var total uint64
+	for _, a := range p.Allocations {
+		if a.Channel == "" {
+			return errors.New("allocation channel is required")
+		}
+		if _, ok := channels[a.Channel]; ok {
+			return fmt.Errorf("channel %q is allocated twice", a.Channel)
+		}
+		channels[a.Channel] = struct{}{}
+		if a.Limit == 0 {
+			return fmt.Errorf("allocation of channel %q needs a limit", a.Channel)
+		}
+		total += uint64(a.Limit)
+	}
+	if total != uint64(couponLimit) {
+		return errors.New("allocation limits must add up to the coupon limit")
+	}
+	return nil
+}
+
+// SetAllocation makes the coupons issued through the channels of the allocations, each within its limit until the
+// spillover. The allocation policy must be valid for the limit of the coupons.
+func (c *Coupons) SetAllocation(p *couponv1.AllocationPolicy) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.allocations = &allocations{byChannel: make(map[string]*allocation, len(p.Allocations))}
+	for _, a := range p.Allocations {
+		alloc := &allocation{channel: a.Channel, limit: a.Limit}
+		c.allocations.byChannel[a.Channel] = alloc
+		c.allocations.order = append(c.allocations.order, alloc)
+	}
+	if p.SpilloverAt != nil {
+		c.allocations.spilloverAt = p.SpilloverAt.AsTime()
+	}
+}
+
+// spilled reports whether the channels can issue what the others left of their allocations at the time.
+func (a *allocations) spilled(at time.Time) bool {
+	return !a.spilloverAt.IsZero() && !at.Before(a.spilloverAt)
+}
+
+// AllocationStats returns the stats of the allocations in order as of now.
+func (c *Coupons) AllocationStats(now time.Time) []AllocationStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.allocations == nil {
+		return nil
+	}
+	spilled := c.allocations.spilled(now)
+	stats := make([]AllocationStats, len(c.allocations.order))
+	for i, a := range c.allocations.order {
+		remaining := c.count
+		if !spilled {
+			remaining = min(a.limit-min(a.issued, a.limit), c.count)
+		}
+		stats[i] = AllocationStats{Channel: a.channel, Issued: a.issued, Remaining: remaining}
+	}
+	return stats
+}
+
+// checkAllocation returns an error if the coupon is not issued through an allocated channel, or the channel issued
+// its limit and nothing spilled over yet. The caller must hold mu.
+func (c *Coupons) checkAllocation(coupon *couponv1.Coupon, newOccurrence bool) error {
+	if c.allocations == nil {
+		return nil
+	}
+	if coupon.Channel == "" {
+		return errors.New("channel is required for the campaign's allocations")
+	}
+	a, ok := c.allocations.byChannel[coupon.Channel]
+	if !ok {
+		return fmt.Errorf("channel %q has no allocation", coupon.Channel)
+	}
+	if a.issued >= a.limit && !newOccurrence && !c.allocations.spilled(coupon.IssuedAt.AsTime()) {
+		return fmt.Errorf("channel %q has no more coupon", coupon.Channel)
+	}
+	return nil
+}
+
+// allocate counts the coupon against the allocation of its channel. The caller must hold mu and check the allocation.
+func (c *Coupons) allocate(coupon *couponv1.Coupon) {
+	if c.allocations != nil {
+		c.allocations.byChannel[coupon.Channel].issued++
+	}
+}
+
+// releaseAllocation gives a slot back to the allocation of the channel. The caller must hold mu.
+func (c *Coupons) releaseAllocation(channel string) {
+	if c.allocations == nil {
+		return
+	}
+	if a, ok := c.allocations.byChannel[channel]; ok && a.issued > 0 {
+		a.issued--
+	}
+}
+
+// resetAllocations starts the allocations over for a new occurrence. The caller must hold mu.
+func (c *Coupons) resetAllocations() {
+	if c.allocations == nil {
+		return
+	}
+	for _, a := range c.allocations.order {
+		a.issued = 0
+	}
+}
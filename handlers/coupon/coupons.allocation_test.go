@@ -0,0 +1,91 @@
+package coupon
+
+import (
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	couponv1 "github.com/jackgihokim/coupon-issuance-system/protos/coupon/v1"
+)
+
+func TestValidateAllocation(t *testing.T) {
+	testCases := []struct {
+		name        string
+		allocations []*couponv1.Allocation
+		wantErr     bool
+	}{
+		{"limits add up", []*couponv1.Allocation{{Channel: "app", Limit: 6}, {Channel: "web", Limit: 4}}, false},
+		{"no allocations", nil, true},
+		{"limits short", []*couponv1.Allocation{{Channel: "app", Limit: 6}, {Channel: "web", Limit: 3}}, true},
+		{"same channel", []*couponv1.Allocation{{Channel: "app", Limit: 5}, {Channel: "app", Limit: 5}}, true},
+		{"no limit", []*couponv1.Allocation{{Channel: "app", Limit: 10}, {Channel: "web"}}, true},
+		{"no channel", []*couponv1.Allocation{{Limit: 10}}, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateAllocation(&couponv1.AllocationPolicy{Allocations: tc.allocations}, 10)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("ValidateAllocation() error = %v, wantErr %v", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestCoupons_Allocation(t *testing.T) {
+	now := time.Now()
+	spilloverAt := now.Add(time.Hour)
+	coupons := NewCoupons(3)
+	coupons.SetAllocation(&couponv1.AllocationPolicy{
+		Allocations: []*couponv1.Allocation{{Channel: "app", Limit: 1}, {Channel: "partner", Limit: 2}},
+		SpilloverAt: timestamppb.New(spilloverAt),
+	})
+	newChannelCoupon := func(channel string, at time.Time) *couponv1.Coupon {
+		return &couponv1.Coupon{Channel: channel, IssuedAt: timestamppb.New(at)}
+	}
+
+	if err := coupons.Add(newChannelCoupon("app", now)); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	testCases := []struct {
+		name    string
+		coupon  *couponv1.Coupon
+		wantErr string
+	}{
+		{"app used its allocation", newChannelCoupon("app", now), `channel "app" has no more coupon`},
+		{"unknown channel", newChannelCoupon("web", now), `channel "web" has no allocation`},
+		{"no channel", newChannelCoupon("", now), "channel is required for the campaign's allocations"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := coupons.Add(tc.coupon); err == nil || err.Error() != tc.wantErr {
+				t.Errorf("Expected '%s' error, got: %v", tc.wantErr, err)
+			}
+		})
+	}
+
+	stats := coupons.AllocationStats(now)
+	if stats[0] != (AllocationStats{Channel: "app", Issued: 1}) || stats[1] != (AllocationStats{Channel: "partner", Remaining: 2}) {
+		t.Errorf("Unexpected allocation stats: %v", stats)
+	}
+
+	// After the spillover the app can issue what the partner left
+	if err := coupons.Add(newChannelCoupon("app", spilloverAt)); err != nil {
+		t.Errorf("Expected no error after the spillover, got: %v", err)
+	}
+	if stats := coupons.AllocationStats(spilloverAt); stats[0].Remaining != 1 || stats[1].Remaining != 1 {
+		t.Errorf("Expected 1 coupon left for each channel, got: %v", stats)
+	}
+}
+
+func TestCoupons_ReleaseToAllocation(t *testing.T) {
+	coupons := NewCoupons(1)
+	coupons.SetAllocation(&couponv1.AllocationPolicy{Allocations: []*couponv1.Allocation{{Channel: "app", Limit: 1}}})
+	coupons.Add(&couponv1.Coupon{Channel: "app", IssuedAt: timestamppb.Now()})
+
+	coupons.Release(&couponv1.Coupon{Channel: "app"})
+	if err := coupons.Add(&couponv1.Coupon{Channel: "app", IssuedAt: timestamppb.Now()}); err != nil {
+		t.Errorf("Expected no error, got: %v", err)
+	}
+}
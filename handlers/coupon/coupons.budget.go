@@ -0,0 +1,156 @@
+package coupon
+
+import (
+	"cmp"
+	"slices"
+
+	couponv1 "github.com/jackgihokim/coupon-issuance-system/protos/coupon/v1"
+)
+
+// budget caps what the coupons cost in a currency. Each coupon issued reserves what it is expected to cost until it
+// is redeemed, revoked or expires unused, and redemptions spend what they discount.
+type budget struct {
+	currency   string
+	amount     int64
+	perCoupon  int64    // reserved for each coupon issued, 0 if nothing is.
+	cost       int64    // what another coupon is expected to cost, for whether the budget covers it.
+	thresholds []uint32 // in basis points of the amount spent, ascending.
+	alerted    int      // the number of thresholds the amount spent reached.
+	spent      int64
+	reserved   int64
+	// reservations has what is still reserved for each coupon by code, and redemptions what each redemption spent
+	// by ID, for refunds.
+	reservations map[string]int64
+	redemptions  map[string]int64
+}
+
+// BudgetStats is what the coupons spent and reserved of the budget, in its currency.
+type BudgetStats struct {
+	Currency  string
+	Spent     int64
+	Reserved  int64
+	Remaining int64
+}
+
+// SetBudget caps what the coupons cost by the budget, where cost is what a coupon can cost when redeemed in full, 0 if
+// it depends on the order. Each coupon issued reserves the projected cost of the budget if set, or else cost, and
+// coupons are issued only while the budget left after what is spent and reserved covers another one.
+// The budget must be valid.
+func (c *Coupons) SetBudget(b *couponv1.Budget, cost int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.budget = &budget{
+		currency:     b.Amount.Currency,
+		amount:       b.Amount.Amount,
+		perCoupon:    cmp.Or(b.ProjectedCost.GetAmount(), cost),
+		cost:         max(cost, b.ProjectedCost.GetAmount(), 1),
+		thresholds:   slices.Sorted(slices.Values(b.AlertThresholds)),
+		reservations: make(map[string]int64),
+		redemptions:  make(map[string]int64),
+	}
+}
+
+// covers reports whether the budget left covers another coupon.
+func (b *budget) covers() bool {
+	return b.amount-b.spent-b.reserved >= b.cost
+}
+
+// reserve reserves what a coupon is expected to cost for the coupon with the specified code.
+func (b *budget) reserve(code string) {
+	if b.perCoupon == 0 {
+		return
+	}
+	b.reservations[code] = b.perCoupon
+	b.reserved += b.perCoupon
+}
+
+// release releases up to amount of what is reserved for the coupon with the specified code, or all of it if amount
+// is negative.
+func (b *budget) release(code string, amount int64) {
+	left, ok := b.reservations[code]
+	if !ok {
+		return
+	}
+	if amount < 0 || amount >= left {
+		delete(b.reservations, code)
+		b.reserved -= left
+		return
+	}
+	b.reservations[code] = left - amount
+	b.reserved -= amount
+}
+
+// BudgetCovers reports whether the budget left covers another coupon. It is true if the coupons have no budget.
+func (c *Coupons) BudgetCovers() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.budget == nil || c.budget.covers()
+}
+
+// SpendBudget spends what the redemption of the coupon with the specified code discounted, releasing as much of
+// what is reserved for the coupon, or all of it once the coupon is redeemed for good.
+// Returns the alert thresholds the amount spent reached for the first time, in order.
+func (c *Coupons) SpendBudget(code, redemptionId string, amount int64, redeemed bool) []uint32 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	b := c.budget
+	if b == nil {
+		return nil
+	}
+	if redeemed {
+		b.release(code, -1)
+	} else {
+		b.release(code, amount)
+	}
+	b.redemptions[redemptionId] = amount
+	b.spent += amount
+
+	var reached []uint32
+	for ; b.alerted < len(b.thresholds); b.alerted++ {
+		threshold := b.thresholds[b.alerted]
+		if b.spent*10000 < b.amount*int64(threshold) {
+			break
+		}
+		reached = append(reached, threshold)
+	}
+	return reached
+}
+
+// RefundBudget gives back to the budget what the redemption spent, once. Alerts which fired do not fire again.
+func (c *Coupons) RefundBudget(redemptionId string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.budget == nil {
+		return
+	}
+	if amount, ok := c.budget.redemptions[redemptionId]; ok {
+		delete(c.budget.redemptions, redemptionId)
+		c.budget.spent -= amount
+	}
+}
+
+// ReleaseBudget releases what is reserved for the coupon with the specified code, e.g. when it is revoked or expires
+// unused.
+func (c *Coupons) ReleaseBudget(code string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.budget != nil {
+		c.budget.release(code, -1)
+	}
+}
+
+// BudgetStats returns what the coupons spent and reserved of the budget. Returns false if the coupons have no budget.
+func (c *Coupons) BudgetStats() (BudgetStats, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	b := c.budget
+	if b == nil {
+		return BudgetStats{}, false
+	}
+	return BudgetStats{
+		Currency:  b.currency,
+		Spent:     b.spent,
+		Reserved:  b.reserved,
+		Remaining: max(b.amount-b.spent-b.reserved, 0),
+	}, true
+}
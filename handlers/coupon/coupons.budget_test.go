@@ -0,0 +1,139 @@
+package coupon
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+
+	couponv1 "github.com/jackgihokim/coupon-issuance-system/protos/coupon/v1"
+)
+
+func newTestBudget(amount, projected int64, thresholds ...uint32) *couponv1.Budget {
+	b := &couponv1.Budget{Amount: &couponv1.Money{Currency: "KRW", Amount: amount}, AlertThresholds: thresholds}
+	if projected > 0 {
+		b.ProjectedCost = &couponv1.Money{Currency: "KRW", Amount: projected}
+	}
+	return b
+}
+
+func TestCoupons_BudgetReserved(t *testing.T) {
+	coupons := NewCoupons(10)
+	coupons.SetBudget(newTestBudget(10000, 4000), 0)
+
+	for _, code := range []string{"a", "b"} {
+		if err := coupons.Add(&couponv1.Coupon{Code: code}); err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+	}
+	// 8000 of 10000 is reserved, which cannot cover another 4000
+	err := coupons.Add(&couponv1.Coupon{Code: "c"})
+	if err == nil || err.Error() != "budget cannot cover another coupon" {
+		t.Errorf("Expected 'budget cannot cover another coupon' error, got: %v", err)
+	}
+
+	// Redeeming for less than projected releases the whole reservation
+	coupons.SpendBudget("a", "redemption-a", 1000, true)
+	stats, _ := coupons.BudgetStats()
+	if stats != (BudgetStats{Currency: "KRW", Spent: 1000, Reserved: 4000, Remaining: 5000}) {
+		t.Errorf("Unexpected budget stats: %v", stats)
+	}
+	if err := coupons.Add(&couponv1.Coupon{Code: "c"}); err != nil {
+		t.Errorf("Expected no error, got: %v", err)
+	}
+
+	coupons.ReleaseBudget("b")
+	coupons.RefundBudget("redemption-a")
+	coupons.RefundBudget("redemption-a")
+	stats, _ = coupons.BudgetStats()
+	if stats != (BudgetStats{Currency: "KRW", Spent: 0, Reserved: 4000, Remaining: 6000}) {
+		t.Errorf("Unexpected budget stats: %v", stats)
+	}
+}
+
+func TestCoupons_BudgetReservedCost(t *testing.T) {
+	coupons := NewCoupons(1000)
+	coupons.SetBudget(newTestBudget(1000, 0), 10)
+
+	// Without a projected cost each coupon reserves what it can cost, so the budget caps the coupons outstanding
+	for i := 0; i < 100; i++ {
+		if err := coupons.Add(&couponv1.Coupon{Code: fmt.Sprintf("cost-%d", i)}); err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+	}
+	err := coupons.Add(&couponv1.Coupon{Code: "cost-100"})
+	if err == nil || err.Error() != "budget cannot cover another coupon" {
+		t.Errorf("Expected 'budget cannot cover another coupon' error, got: %v", err)
+	}
+	stats, _ := coupons.BudgetStats()
+	if stats != (BudgetStats{Currency: "KRW", Reserved: 1000}) {
+		t.Errorf("Unexpected budget stats: %v", stats)
+	}
+
+	// A coupon expiring unused gives back what it reserved
+	coupons.ReleaseBudget("cost-0")
+	if err := coupons.Add(&couponv1.Coupon{Code: "cost-100"}); err != nil {
+		t.Errorf("Expected no error, got: %v", err)
+	}
+}
+
+func TestCoupons_BudgetPartialSpend(t *testing.T) {
+	coupons := NewCoupons(10)
+	coupons.SetBudget(newTestBudget(10000, 5000), 5000)
+	coupons.Add(&couponv1.Coupon{Code: "a"})
+
+	// A stored-value coupon releases its reservation as it is spent
+	coupons.SpendBudget("a", "redemption-1", 2000, false)
+	stats, _ := coupons.BudgetStats()
+	if stats.Spent != 2000 || stats.Reserved != 3000 {
+		t.Errorf("Expected 2000 spent and 3000 reserved, got %d and %d", stats.Spent, stats.Reserved)
+	}
+	coupons.SpendBudget("a", "redemption-2", 3000, true)
+	stats, _ = coupons.BudgetStats()
+	if stats.Spent != 5000 || stats.Reserved != 0 {
+		t.Errorf("Expected 5000 spent and none reserved, got %d and %d", stats.Spent, stats.Reserved)
+	}
+}
+
+func TestCoupons_BudgetAlerts(t *testing.T) {
+	coupons := NewCoupons(10)
+	coupons.SetBudget(newTestBudget(10000, 0, 8000, 5000, 10000), 0)
+
+	testCases := []struct {
+		amount int64
+		want   []uint32
+	}{
+		{4000, nil},
+		{4500, []uint32{5000, 8000}},
+		{500, nil},
+		{1000, []uint32{10000}},
+	}
+	for i, tc := range testCases {
+		if got := coupons.SpendBudget("a", fmt.Sprintf("redemption-%d", i), tc.amount, false); !slices.Equal(got, tc.want) {
+			t.Errorf("Spending %d: expected alerts %v, got %v", tc.amount, tc.want, got)
+		}
+	}
+
+	// The budget is spent, so no more coupons are issued
+	if coupons.BudgetCovers() {
+		t.Errorf("Expected the spent budget not to cover another coupon")
+	}
+}
+
+func TestCoupons_BudgetReward(t *testing.T) {
+	coupons := NewCoupons(1)
+	coupons.SetBudget(newTestBudget(10000, 6000), 0)
+	_ = coupons.Add(&couponv1.Coupon{Code: "a"})
+
+	// A reward reserves its projected cost too, and is not issued once the budget cannot cover it
+	err := coupons.AddReward(&couponv1.Coupon{Code: "reward"})
+	if err == nil || err.Error() != "budget cannot cover another coupon" {
+		t.Errorf("Expected 'budget cannot cover another coupon' error, got: %v", err)
+	}
+	coupons.ReleaseBudget("a")
+	if err := coupons.AddReward(&couponv1.Coupon{Code: "reward"}); err != nil {
+		t.Errorf("Expected no error, got: %v", err)
+	}
+	if stats, _ := coupons.BudgetStats(); stats.Reserved != 6000 {
+		t.Errorf("Expected the reward to reserve 6000, got: %v", stats)
+	}
+}
@@ -0,0 +1,40 @@
+package coupon
+
+import (
+	"fmt"
+	"sync"
+
+	couponv1 "github.com/jackgihokim/coupon-issuance-system/protos/coupon/v1"
+)
+
+// Slot is a coupon to be inserted into the coupons of its campaign, in the occurrence if the campaign recurs.
+type Slot struct {
+	Coupons    *Coupons
+	Coupon     *couponv1.Coupon
+	Occurrence *Occurrence // nil unless the campaign recurs.
+}
+
+// bundleMu lets a single AddAll hold the locks of several Coupons at once, so they cannot deadlock each other.
+var bundleMu sync.Mutex
+
+// AddAll inserts the coupons of the slots all or nothing: either every coupon takes a slot of its campaign, or none
+// does and no count changes. The slots must be of different Coupons.
+// Returns an error naming the campaign of the first coupon which cannot be added, wrapping its reason.
+func AddAll(slots []Slot) error {
+	bundleMu.Lock()
+	defer bundleMu.Unlock()
+	for _, slot := range slots {
+		slot.Coupons.mu.Lock()
+		defer slot.Coupons.mu.Unlock()
+	}
+
+	for _, slot := range slots {
+		if err := slot.Coupons.check(slot.Coupon, slot.Occurrence); err != nil {
+			return fmt.Errorf("campaign %d: %w", slot.Coupon.CampaignId, err)
+		}
+	}
+	for _, slot := range slots {
+		slot.Coupons.add(slot.Coupon, slot.Occurrence)
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+package coupon
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	couponv1 "github.com/jackgihokim/coupon-issuance-system/protos/coupon/v1"
+)
+
+func TestAddAll(t *testing.T) {
+	now := time.Now()
+	a, b := NewCoupons(2), NewCoupons(1)
+	newSlots := func() []Slot {
+		return []Slot{
+			{Coupons: a, Coupon: &couponv1.Coupon{CampaignId: 1, IssuedAt: timestamppb.New(now)}},
+			{Coupons: b, Coupon: &couponv1.Coupon{CampaignId: 2, IssuedAt: timestamppb.New(now)}},
+		}
+	}
+
+	if err := AddAll(newSlots()); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	// The second campaign is sold out, so the first one keeps its slot
+	err := AddAll(newSlots())
+	if err == nil || err.Error() != "campaign 2: no more coupon" {
+		t.Errorf("Expected 'campaign 2: no more coupon' error, got: %v", err)
+	}
+	if a.Remaining() != 1 || len(a.List()) != 1 {
+		t.Errorf("Expected the first campaign to have 1 slot left and 1 coupon, got %d and %d", a.Remaining(), len(a.List()))
+	}
+}
+
+func TestAddAll_Throttled(t *testing.T) {
+	now := time.Now()
+	a, b := NewCoupons(10), NewCoupons(10)
+	b.SetThrottle(now, time.Hour, 1, false)
+	b.Add(&couponv1.Coupon{IssuedAt: timestamppb.New(now)})
+
+	err := AddAll([]Slot{
+		{Coupons: a, Coupon: &couponv1.Coupon{CampaignId: 1, IssuedAt: timestamppb.New(now)}},
+		{Coupons: b, Coupon: &couponv1.Coupon{CampaignId: 2, IssuedAt: timestamppb.New(now)}},
+	})
+	var quotaErr *SliceQuotaError
+	if !errors.As(err, &quotaErr) {
+		t.Errorf("Expected a SliceQuotaError, got: %v", err)
+	}
+	if a.Remaining() != 10 {
+		t.Errorf("Expected the first campaign to keep its slots, got %d", a.Remaining())
+	}
+}
+
+func TestAddAll_Concurrent(t *testing.T) {
+	now := time.Now()
+	a, b := NewCoupons(5), NewCoupons(3)
+
+	// Bundles and single issuances race, and the bundles never take a slot of one campaign only
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	bundled := 0
+	for i := 0; i < 10; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			err := AddAll([]Slot{
+				{Coupons: a, Coupon: &couponv1.Coupon{CampaignId: 1, IssuedAt: timestamppb.New(now)}},
+				{Coupons: b, Coupon: &couponv1.Coupon{CampaignId: 2, IssuedAt: timestamppb.New(now)}},
+			})
+			if err == nil {
+				mu.Lock()
+				bundled++
+				mu.Unlock()
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			b.Add(&couponv1.Coupon{IssuedAt: timestamppb.New(now)})
+		}()
+	}
+	wg.Wait()
+
+	if b.Remaining() != 0 || a.Remaining() != uint32(5-bundled) {
+		t.Errorf("Expected %d slots left in the first campaign and none in the second, got %d and %d", 5-bundled, a.Remaining(), b.Remaining())
+	}
+}
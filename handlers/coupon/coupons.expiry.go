@@ -0,0 +1,88 @@
+package coupon
+
+import (
+	"container/heap"
+	"time"
+
+	couponv1 "github.com/jackgihokim/coupon-issuance-system/protos/coupon/v1"
+)
+
+// expiry is when the coupon with the code expires.
+type expiry struct {
+	code string
+	at   time.Time
+}
+
+// expiryHeap orders the coupons by expiration, the earliest first.
+type expiryHeap []expiry
+
+func (h expiryHeap) Len() int           { return len(h) }
+func (h expiryHeap) Less(i, j int) bool { return h[i].at.Before(h[j].at) }
+func (h expiryHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+func (h *expiryHeap) Push(x any)        { *h = append(*h, x.(expiry)) }
+func (h *expiryHeap) Pop() any {
+	old := *h
+	e := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return e
+}
+
+// WatchExpiry makes the coupons keep track of when the coupons issued from now on expire, so Expired can find
+// those which expire unused.
+func (c *Coupons) WatchExpiry() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.expiries == nil {
+		c.expiries = &expiryHeap{}
+	}
+}
+
+// Watch keeps track of when the coupon expires again if the expirations are watched, e.g. once it is restored.
+func (c *Coupons) Watch(coupon *couponv1.Coupon) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.watch(coupon)
+}
+
+// watch keeps track of when the coupon expires if the expirations are watched. The caller must hold mu.
+func (c *Coupons) watch(coupon *couponv1.Coupon) {
+	if c.expiries != nil {
+		heap.Push(c.expiries, expiry{code: coupon.Code, at: coupon.ExpireAt.AsTime()})
+	}
+}
+
+// Expired returns snapshots of the coupons which expired unused by now and whose slots were not given back, each
+// once. A coupon whose reservation still holds it is found once the reservation lapses, and a coupon whose expiration
+// was extended once it expires. It returns nothing unless the expirations are watched.
+func (c *Coupons) Expired(now time.Time) []*couponv1.Coupon {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.expiries == nil {
+		return nil
+	}
+
+	var expired []*couponv1.Coupon
+	var later []expiry
+	for c.expiries.Len() > 0 && (*c.expiries)[0].at.Before(now) {
+		e := heap.Pop(c.expiries).(expiry)
+		if _, ok := c.returned[e.code]; ok {
+			continue
+		}
+		coupon, err := index.get(e.code)
+		if err != nil {
+			continue
+		}
+		switch Reason(coupon, now) {
+		case couponv1.ValidationReason_VALIDATION_REASON_EXPIRED:
+			expired = append(expired, coupon)
+		case couponv1.ValidationReason_VALIDATION_REASON_RESERVED:
+			later = append(later, e)
+		case couponv1.ValidationReason_VALIDATION_REASON_UNSPECIFIED:
+			later = append(later, expiry{code: e.code, at: coupon.ExpireAt.AsTime()})
+		}
+	}
+	for _, e := range later {
+		heap.Push(c.expiries, e)
+	}
+	return expired
+}
@@ -0,0 +1,60 @@
+package coupon
+
+import (
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	couponv1 "github.com/jackgihokim/coupon-issuance-system/protos/coupon/v1"
+)
+
+func TestCoupons_Expired(t *testing.T) {
+	now := time.Now().UTC()
+	coupons := NewCoupons(3)
+	coupons.WatchExpiry()
+
+	var issued []*couponv1.Coupon
+	for _, ttl := range []time.Duration{time.Hour, 2 * time.Hour, 3 * time.Hour} {
+		coup, err := NewCoupon(1, now.Add(ttl), now)
+		if err != nil {
+			t.Fatalf("NewCoupon() error = %v", err)
+		}
+		if err := coupons.Add(coup); err != nil {
+			t.Fatalf("Add() error = %v", err)
+		}
+		issued = append(issued, coup)
+	}
+	if _, err := Redeem(issued[1].Code, "", now); err != nil {
+		t.Fatalf("Redeem() error = %v", err)
+	}
+
+	if expired := coupons.Expired(now); len(expired) != 0 {
+		t.Errorf("Expected no coupon to have expired yet, got %d", len(expired))
+	}
+
+	// Only the unused coupons which expired are found, each once
+	later := now.Add(150 * time.Minute)
+	expired := coupons.Expired(later)
+	if len(expired) != 1 || expired[0].Code != issued[0].Code {
+		t.Fatalf("Expected the first coupon to expire unused, got %v", expired)
+	}
+	if coupons.Release(expired[0]); len(coupons.Expired(later)) != 0 {
+		t.Error("Expected an expired coupon to be found once")
+	}
+
+	// A coupon whose slot was given back is not found when it expires
+	coupons.Release(issued[2])
+	if expired := coupons.Expired(now.Add(4 * time.Hour)); len(expired) != 0 {
+		t.Errorf("Expected a coupon given back not to be found, got %v", expired)
+	}
+}
+
+func TestCoupons_ExpiredUnwatched(t *testing.T) {
+	now := time.Now().UTC()
+	coupons := NewCoupons(1)
+	_ = coupons.Add(&couponv1.Coupon{Code: "unwatched", ExpireAt: timestamppb.New(now)})
+	if expired := coupons.Expired(now.Add(time.Hour)); expired != nil {
+		t.Errorf("Expected nothing without watching expirations, got %v", expired)
+	}
+}
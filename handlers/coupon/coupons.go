@@ -3,21 +3,56 @@ package coupon
 import (
 	"errors"
 	"sync"
+	"time"
+
+	"google.golang.org/protobuf/proto"
 
 	couponv1 "github.com/jackgihokim/coupon-issuance-system/protos/coupon/v1"
 )
 
 type Coupons struct {
 	count uint32
+	limit uint32
 	mu    sync.Mutex
-	list  []*couponv1.Coupon
+	// list has copies of the coupons as issued, which are never changed. The code index owns the coupons which
+	// change as they are used.
+	list []*couponv1.Coupon
+	// occurrences are the issuance windows of a recurring campaign in which coupons were issued, the last being
+	// the one count applies to.
+	occurrences []Occurrence
+	// throttle limits how many coupons each time slice can issue. Nil issues without slices.
+	throttle *throttle
+	// tiers give the coupons their discounts by the issuance sequence. Nil issues them without tiers.
+	tiers []*tier
+	// variants give the coupons their discounts by the users they are issued to. Nil issues them without variants.
+	variants []*variant
+	// redeemed has the codes of the coupons of the variants counted as redeemed in the latest occurrence.
+	redeemed map[string]struct{}
+	// budget caps what the coupons cost. Nil issues them whatever they cost.
+	budget *budget
+	// allocations split the limit across the channels issuing the coupons. Nil lets any caller issue them.
+	allocations *allocations
+	// returned has the codes of the coupons whose slots were given back, so each slot is given back once, and of the
+	// rewards, which took no slot.
+	returned map[string]struct{}
+	// expiries has the coupons in order of expiration, for the slots of those expiring unused. Nil if not watched.
+	expiries *expiryHeap
+}
+
+// Occurrence is an issuance window of a recurring campaign with the number of coupons issued in it.
+type Occurrence struct {
+	StartAt time.Time
+	EndAt   time.Time
+	Issued  uint32
 }
 
 // NewCoupons initializes a new Coupons instance with the specified count and pre-allocated list capacity.
 func NewCoupons(cnt uint32) *Coupons {
 	return &Coupons{
-		count: cnt,
-		list:  make([]*couponv1.Coupon, 0, cnt),
+		count:    cnt,
+		limit:    cnt,
+		list:     make([]*couponv1.Coupon, 0, cnt),
+		returned: make(map[string]struct{}),
 	}
 }
 
@@ -25,18 +60,211 @@ func NewCoupons(cnt uint32) *Coupons {
 func (c *Coupons) Add(coupon *couponv1.Coupon) error {
 	c.mu.Lock()
 	defer c.mu.Unlock()
+	if err := c.check(coupon, nil); err != nil {
+		return err
+	}
+	c.add(coupon, nil)
+	return nil
+}
 
-	if c.count == 0 {
+// AddInOccurrence inserts a coupon issued in the occurrence which opens at start and closes at end.
+// A later occurrence than the current one resets the available coupons count to the limit first,
+// so the limit applies per occurrence. Returns an error if the occurrence is over or no coupons are available.
+func (c *Coupons) AddInOccurrence(start, end time.Time, coupon *couponv1.Coupon) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	occurrence := &Occurrence{StartAt: start, EndAt: end}
+	if err := c.check(coupon, occurrence); err != nil {
+		return err
+	}
+	c.add(coupon, occurrence)
+	return nil
+}
+
+// check returns an error if the coupon cannot be added, within the quota of its time slice if throttled and in the
+// occurrence if it is not nil. The caller must hold mu.
+func (c *Coupons) check(coupon *couponv1.Coupon, occurrence *Occurrence) error {
+	count := c.count
+	newOccurrence := false
+	var origin time.Time
+	if c.throttle != nil {
+		origin = c.throttle.start
+	}
+	if last := len(c.occurrences) - 1; last >= 0 {
+		origin = c.occurrences[last].StartAt
+	}
+	if occurrence != nil {
+		last := len(c.occurrences) - 1
+		switch {
+		case last < 0 || c.occurrences[last].StartAt.Before(occurrence.StartAt):
+			count = c.limit
+			newOccurrence = true
+			origin = occurrence.StartAt
+		case c.occurrences[last].StartAt.After(occurrence.StartAt):
+			return errors.New("occurrence is over")
+		}
+	}
+
+	if count == 0 {
 		return errors.New("no more coupon")
 	}
-	c.list = append(c.list, coupon)
+	if c.budget != nil && !c.budget.covers() {
+		return errors.New("budget cannot cover another coupon")
+	}
+	if err := c.checkAllocation(coupon, newOccurrence); err != nil {
+		return err
+	}
+	if err := c.checkVariant(coupon, newOccurrence); err != nil {
+		return err
+	}
+	if c.throttle != nil {
+		return c.throttle.check(origin, coupon.IssuedAt.AsTime())
+	}
+	return nil
+}
+
+// add inserts a coupon which passed check into its tier or variant and decrements the available coupons count, starting
+// the occurrence if it is later than the current one. The caller must hold mu.
+func (c *Coupons) add(coupon *couponv1.Coupon, occurrence *Occurrence) {
+	if occurrence != nil {
+		last := len(c.occurrences) - 1
+		if last < 0 || c.occurrences[last].StartAt.Before(occurrence.StartAt) {
+			c.occurrences = append(c.occurrences, Occurrence{StartAt: occurrence.StartAt, EndAt: occurrence.EndAt})
+			c.count = c.limit
+			c.resetTiers()
+			c.resetVariants()
+			c.resetAllocations()
+			last++
+		}
+		c.occurrences[last].Issued++
+	}
+	if c.throttle != nil {
+		c.throttle.record()
+	}
+	c.assignTier(coupon)
+	c.assignVariant(coupon)
+	c.allocate(coupon)
+	if c.budget != nil {
+		c.budget.reserve(coupon.Code)
+	}
+	c.insert(coupon)
 	c.count--
+}
+
+// insert keeps a copy of the issued coupon in the list and updates the code index with what the coupons assigned
+// it. The caller must hold mu.
+func (c *Coupons) insert(coupon *couponv1.Coupon) {
+	c.list = append(c.list, proto.Clone(coupon).(*couponv1.Coupon))
+	index.issue(coupon)
+	c.watch(coupon)
+}
+
+// RemainingInOccurrence returns the number of coupons which can still be issued in the occurrence which opens at start.
+func (c *Coupons) RemainingInOccurrence(start time.Time) uint32 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	last := len(c.occurrences) - 1
+	switch {
+	case last < 0 || c.occurrences[last].StartAt.Before(start):
+		return c.limit
+	case c.occurrences[last].StartAt.After(start):
+		return 0
+	}
+	return c.count
+}
+
+// Occurrences returns the issuance stats of the occurrences in which coupons were issued, in order.
+func (c *Coupons) Occurrences() []Occurrence {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]Occurrence(nil), c.occurrences...)
+}
+
+// Release gives the slot of the coupon back to the available coupons count, its tier, its variant and the allocation
+// of its channel, so another coupon can be issued in its place. The slot of a coupon is given back once, and not at
+// all if the coupon was issued in an occurrence which is over, as the count is of the current occurrence.
+// Returns whether the slot was given back.
+func (c *Coupons) Release(coupon *couponv1.Coupon) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.release(coupon)
+}
+
+// Reissue gives the slot of the released coupon back and issues the coupon in it at once, so nobody else can take
+// it first. The coupon is checked and counted like any other, and if it cannot be issued the slot stays given back.
+// Returns whether the slot was given back, and an error if the coupon was not issued.
+func (c *Coupons) Reissue(released, coupon *couponv1.Coupon) (bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.release(released) {
+		return false, errors.New("slot cannot be given back")
+	}
+	if err := c.check(coupon, nil); err != nil {
+		return true, err
+	}
+	c.add(coupon, nil)
+	return true, nil
+}
+
+// release gives the slot of the coupon back, once. The caller must hold mu.
+func (c *Coupons) release(coupon *couponv1.Coupon) bool {
+	if _, ok := c.returned[coupon.Code]; ok {
+		return false
+	}
+	if last := len(c.occurrences) - 1; last >= 0 && coupon.IssuedAt.AsTime().Before(c.occurrences[last].StartAt) {
+		return false
+	}
+	if c.count >= c.limit {
+		return false
+	}
+	c.returned[coupon.Code] = struct{}{}
+	c.releaseTier(coupon.Tier)
+	c.releaseVariant(coupon.Variant)
+	c.releaseAllocation(coupon.Channel)
+	c.count++
+	return true
+}
+
+// AddReward inserts a reward coupon, e.g. for a referral, without counting it against the coupon limit, so rewards
+// owed are issued even once the campaign is sold out. It is not throttled either, and takes no tier, variant or
+// allocation, and has no slot to give back if revoked. What the coupon is expected to cost is reserved from the
+// budget all the same. Returns an error if the budget cannot cover the coupon.
+func (c *Coupons) AddReward(coupon *couponv1.Coupon) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.budget != nil {
+		if !c.budget.covers() {
+			return errors.New("budget cannot cover another coupon")
+		}
+		c.budget.reserve(coupon.Code)
+	}
+	c.insert(coupon)
+	c.returned[coupon.Code] = struct{}{}
 	return nil
 }
 
-// List returns the current list of coupons. This method ensures thread safety by locking during access.
-func (c *Coupons) List() []*couponv1.Coupon {
+// Remaining returns the number of coupons which can still be issued.
+func (c *Coupons) Remaining() uint32 {
 	c.mu.Lock()
 	defer c.mu.Unlock()
-	return c.list
+	return c.count
+}
+
+// List returns snapshots of the issued coupons in order, as the code index has them now, or as issued if the index
+// does not know them. The snapshots are the caller's to read or change.
+func (c *Coupons) List() []*couponv1.Coupon {
+	c.mu.Lock()
+	issued := c.list[:len(c.list):len(c.list)]
+	c.mu.Unlock()
+
+	list := make([]*couponv1.Coupon, len(issued))
+	for i, coupon := range issued {
+		snapshot, err := index.get(coupon.Code)
+		if err != nil {
+			snapshot = proto.Clone(coupon).(*couponv1.Coupon)
+		}
+		list[i] = snapshot
+	}
+	return list
 }
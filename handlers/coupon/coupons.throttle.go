@@ -0,0 +1,86 @@
+package coupon
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	couponv1 "github.com/jackgihokim/coupon-issuance-system/protos/coupon/v1"
+)
+
+// SliceQuotaError is returned when the time slice a coupon is issued in has used up its quota.
+type SliceQuotaError struct {
+	NextSliceAt time.Time
+}
+
+func (e *SliceQuotaError) Error() string {
+	return fmt.Sprintf("slice quota is used up, the next slice opens at %s", e.NextSliceAt.Format(time.RFC3339))
+}
+
+// ValidateThrottle checks that the throttle has a positive slice length and quota.
+func ValidateThrottle(t *couponv1.Throttle) error {
+	if t.Slice == nil {
+		return errors.New("throttle slice is required")
+	}
+	if err := t.Slice.CheckValid(); err != nil {
+		return err
+	}
+	if t.Slice.AsDuration() <= 0 {
+		return errors.New("throttle slice must be positive")
+	}
+	if t.Quota == 0 {
+		return errors.New("throttle quota must be positive")
+	}
+	return nil
+}
+
+// throttle spreads issuance over time slices of a fixed length, each of which can issue up to its quota.
+type throttle struct {
+	slice    time.Duration
+	quota    uint32
+	rollover bool
+	start    time.Time // where the slices count from, unless the coupons are issued in occurrences.
+
+	origin   time.Time // where the slices of the counts below count from.
+	current  int64     // the index of the latest slice which issued coupons.
+	inSlice  uint32    // the coupons issued in the current slice.
+	inOrigin uint64    // the coupons issued since origin.
+}
+
+// SetThrottle spreads issuance over slices of the given length counted from start, or from the start of each
+// occurrence for a recurring campaign. Each slice can issue up to the quota, and unused quota rolls into
+// the next slices if rollover is set. Coupons are placed into slices by their issue time.
+func (c *Coupons) SetThrottle(start time.Time, slice time.Duration, quota uint32, rollover bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.throttle = &throttle{slice: slice, quota: quota, rollover: rollover, start: start}
+}
+
+// check returns a SliceQuotaError if the slice which at falls into has no quota left, counting the slices
+// from origin. Coupons issued at a time of an earlier slice count against the latest one.
+func (t *throttle) check(origin, at time.Time) error {
+	if !origin.Equal(t.origin) {
+		*t = throttle{slice: t.slice, quota: t.quota, rollover: t.rollover, start: t.start, origin: origin}
+	}
+	if at.After(origin) {
+		if i := int64(at.Sub(origin) / t.slice); i > t.current {
+			t.current = i
+			t.inSlice = 0
+		}
+	}
+
+	left := t.inSlice < t.quota
+	if t.rollover {
+		left = t.inOrigin < uint64(t.current+1)*uint64(t.quota)
+	}
+	if !left {
+		return &SliceQuotaError{NextSliceAt: origin.Add(time.Duration(t.current+1) * t.slice)}
+	}
+	return nil
+}
+
+// record counts a coupon issued in the current slice.
+func (t *throttle) record() {
+	t.inSlice++
+	t.inOrigin++
+}
@@ -0,0 +1,107 @@
+package coupon
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	couponv1 "github.com/jackgihokim/coupon-issuance-system/protos/coupon/v1"
+)
+
+func issuedAt(t time.Time) *couponv1.Coupon {
+	return &couponv1.Coupon{IssuedAt: timestamppb.New(t)}
+}
+
+func TestValidateThrottle(t *testing.T) {
+	testCases := []struct {
+		name     string
+		throttle *couponv1.Throttle
+		wantErr  bool
+	}{
+		{"valid throttle", &couponv1.Throttle{Slice: durationpb.New(10 * time.Minute), Quota: 500}, false},
+		{"no slice", &couponv1.Throttle{Quota: 500}, true},
+		{"negative slice", &couponv1.Throttle{Slice: durationpb.New(-time.Minute), Quota: 500}, true},
+		{"no quota", &couponv1.Throttle{Slice: durationpb.New(10 * time.Minute)}, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateThrottle(tc.throttle)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("ValidateThrottle() error = %v, wantErr %v", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestCoupons_Throttle(t *testing.T) {
+	start := time.Date(2025, 5, 1, 10, 0, 0, 0, time.UTC)
+	slice := 10 * time.Minute
+
+	testCases := []struct {
+		name     string
+		rollover bool
+		// adds are the minutes after the start coupons are issued at
+		adds    []int
+		wantErr []bool
+	}{
+		{"quota per slice", false, []int{0, 1, 2, 10, 11, 25}, []bool{false, false, true, false, false, false}},
+		{"unused quota is lost", false, []int{25, 25, 25}, []bool{false, false, true}},
+		{"unused quota rolls over", true, []int{25, 25, 25, 25, 25, 25, 25}, []bool{false, false, false, false, false, false, true}},
+		{"earlier times count against the latest slice", false, []int{10, 10, 5}, []bool{false, false, true}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			coupons := NewCoupons(100)
+			coupons.SetThrottle(start, slice, 2, tc.rollover)
+
+			for i, minute := range tc.adds {
+				err := coupons.Add(issuedAt(start.Add(time.Duration(minute) * time.Minute)))
+				if (err != nil) != tc.wantErr[i] {
+					t.Fatalf("Add() #%d error = %v, wantErr %v", i, err, tc.wantErr[i])
+				}
+			}
+		})
+	}
+}
+
+func TestCoupons_ThrottleNextSlice(t *testing.T) {
+	start := time.Date(2025, 5, 1, 10, 0, 0, 0, time.UTC)
+	coupons := NewCoupons(100)
+	coupons.SetThrottle(start, 10*time.Minute, 1, false)
+
+	_ = coupons.Add(issuedAt(start.Add(12 * time.Minute)))
+	err := coupons.Add(issuedAt(start.Add(13 * time.Minute)))
+
+	var quotaErr *SliceQuotaError
+	if !errors.As(err, &quotaErr) {
+		t.Fatalf("Expected a SliceQuotaError, got: %v", err)
+	}
+	if want := start.Add(20 * time.Minute); !quotaErr.NextSliceAt.Equal(want) {
+		t.Errorf("Expected the next slice at %v, got %v", want, quotaErr.NextSliceAt)
+	}
+	if coupons.Remaining() != 99 {
+		t.Errorf("Expected a throttled coupon not to take a slot, got remaining %d", coupons.Remaining())
+	}
+}
+
+func TestCoupons_ThrottleInOccurrence(t *testing.T) {
+	first := time.Date(2025, 5, 1, 10, 0, 0, 0, time.UTC)
+	second := first.Add(24 * time.Hour)
+	coupons := NewCoupons(100)
+	coupons.SetThrottle(first, 10*time.Minute, 1, false)
+
+	_ = coupons.AddInOccurrence(first, second, issuedAt(first))
+	if err := coupons.AddInOccurrence(first, second, issuedAt(first)); err == nil {
+		t.Error("Expected error when the slice quota is used up, got nil")
+	}
+
+	// The slices count from the start of each occurrence
+	if err := coupons.AddInOccurrence(second, second.Add(time.Hour), issuedAt(second.Add(time.Minute))); err != nil {
+		t.Errorf("Expected no error in the first slice of the next occurrence, got: %v", err)
+	}
+}
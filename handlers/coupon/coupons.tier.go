@@ -0,0 +1,137 @@
+package coupon
+
+import (
+	"errors"
+	"fmt"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/jackgihokim/coupon-issuance-system/handlers/discount"
+	couponv1 "github.com/jackgihokim/coupon-issuance-system/protos/coupon/v1"
+)
+
+// tier is a range of the issuance sequence whose coupons get its discount. Its limit caps the coupons it holds, so
+// the slots given back to the coupons go back to it, and it counts every coupon it issued apart.
+type tier struct {
+	name     string
+	limit    uint32 // 0 takes the rest of the coupon limit.
+	discount *couponv1.Discount
+	issued   uint32
+	held     uint32 // the coupons issued whose slots were not given back.
+}
+
+// TierStats is the number of coupons issued in a tier, including those whose slots were given back, and how many
+// more it can issue.
+type TierStats struct {
+	Name      string
+	Issued    uint32
+	Remaining uint32
+}
+
+// ValidateTiers checks that the tiers have unique names and valid discounts, and that their limits add up to the
+// coupon limit, or to at most the coupon limit if the last tier is unlimited. Only the last tier can be unlimited.
+// Returns an error describing the first invalid tier.
+func ValidateTiers(tiers []*couponv1.Tier, couponLimit uint32) error {
+	if len(tiers) == 0 {
+		return errors.New("tiered campaign needs at least one tier")
+	}
+
+	names := make(map[string]struct{}, len(tiers))
+	var total uint64
+	for i, t := range tiers {
+		if t.Name == "" {
+			return errors.New("tier name is required")
+		}
+		if _, ok := names[t.Name]; ok {
+			return fmt.Errorf("tier %q is defined twice", t.Name)
+		}
+		names[t.Name] = struct{}{}
+		if t.Discount == nil {
+			return fmt.Errorf("tier %q needs a discount", t.Name)
+		}
+		if err := discount.Validate(t.Discount); err != nil {
+			return fmt.Errorf("tier %q: %w", t.Name, err)
+		}
+		if t.Limit == 0 && i != len(tiers)-1 {
+			return fmt.Errorf("tier %q must have a limit, as only the last tier can be unlimited", t.Name)
+		}
+		total += uint64(t.Limit)
+	}
+
+	unlimited := tiers[len(tiers)-1].Limit == 0
+	switch {
+	case total > uint64(couponLimit):
+		return errors.New("tier limits exceed the coupon limit")
+	case !unlimited && total < uint64(couponLimit):
+		return errors.New("tier limits must add up to the coupon limit")
+	}
+	return nil
+}
+
+// SetTiers makes the coupons issued in the order of the tiers, each taking its limit of the issuance sequence.
+// The tiers must be valid for the limit of the coupons.
+func (c *Coupons) SetTiers(tiers []*couponv1.Tier) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.tiers = make([]*tier, len(tiers))
+	for i, t := range tiers {
+		c.tiers[i] = &tier{name: t.Name, limit: t.Limit, discount: t.Discount}
+	}
+}
+
+// TierStats returns the stats of the tiers in order, of the latest occurrence which issued coupons if they recur.
+func (c *Coupons) TierStats() []TierStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	stats := make([]TierStats, len(c.tiers))
+	available := c.count
+	for i, t := range c.tiers {
+		remaining := available
+		if t.limit > 0 && i < len(c.tiers)-1 {
+			remaining = min(t.limit-min(t.held, t.limit), available)
+		}
+		available -= remaining
+		stats[i] = TierStats{Name: t.name, Issued: t.issued, Remaining: remaining}
+	}
+	return stats
+}
+
+// assignTier puts the coupon in the first tier which holds fewer coupons than its limit, giving it the discount of
+// the tier. Slots given back to the coupons go back to their tiers, so the tiers cap the coupons outstanding, e.g.
+// the first 100 are the first 100 which were not given back, and a tier with room remains as long as a coupon can
+// be added. The caller must hold mu and check that a coupon can be added.
+func (c *Coupons) assignTier(coupon *couponv1.Coupon) {
+	if len(c.tiers) == 0 {
+		return
+	}
+	t := c.tiers[len(c.tiers)-1]
+	for _, candidate := range c.tiers {
+		if candidate.limit == 0 || candidate.held < candidate.limit {
+			t = candidate
+			break
+		}
+	}
+	t.issued++
+	t.held++
+	coupon.Tier = t.name
+	coupon.Discount = proto.Clone(t.discount).(*couponv1.Discount)
+}
+
+// releaseTier gives a slot back to the tier with the name, so the next coupon is issued in it unless an earlier
+// tier has room. The tier still counts the coupon as issued. The caller must hold mu.
+func (c *Coupons) releaseTier(name string) {
+	for _, t := range c.tiers {
+		if t.name == name && t.held > 0 {
+			t.held--
+			return
+		}
+	}
+}
+
+// resetTiers starts the tiers over for a new occurrence. The caller must hold mu.
+func (c *Coupons) resetTiers() {
+	for _, t := range c.tiers {
+		t.issued = 0
+		t.held = 0
+	}
+}
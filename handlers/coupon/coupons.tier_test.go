@@ -0,0 +1,109 @@
+package coupon
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+
+	couponv1 "github.com/jackgihokim/coupon-issuance-system/protos/coupon/v1"
+)
+
+func newTestTier(name string, limit uint32) *couponv1.Tier {
+	return &couponv1.Tier{
+		Name:     name,
+		Limit:    limit,
+		Discount: &couponv1.Discount{Kind: &couponv1.Discount_FreeShipping_{FreeShipping: &couponv1.Discount_FreeShipping{}}},
+	}
+}
+
+func TestValidateTiers(t *testing.T) {
+	testCases := []struct {
+		name    string
+		tiers   []*couponv1.Tier
+		wantErr bool
+	}{
+		{"limits add up", []*couponv1.Tier{newTestTier("a", 2), newTestTier("b", 8)}, false},
+		{"unlimited last tier", []*couponv1.Tier{newTestTier("a", 2), newTestTier("b", 0)}, false},
+		{"no tiers", nil, true},
+		{"limits short", []*couponv1.Tier{newTestTier("a", 2), newTestTier("b", 7)}, true},
+		{"limits over", []*couponv1.Tier{newTestTier("a", 2), newTestTier("b", 9)}, true},
+		{"unlimited tier before the last", []*couponv1.Tier{newTestTier("a", 0), newTestTier("b", 2)}, true},
+		{"same name", []*couponv1.Tier{newTestTier("a", 2), newTestTier("a", 8)}, true},
+		{"no discount", []*couponv1.Tier{{Name: "a", Limit: 10}}, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := ValidateTiers(tc.tiers, 10); (err != nil) != tc.wantErr {
+				t.Errorf("ValidateTiers() error = %v, wantErr %v", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestCoupons_Tiers(t *testing.T) {
+	coupons := NewCoupons(4)
+	coupons.SetTiers([]*couponv1.Tier{newTestTier("early", 1), newTestTier("rest", 0)})
+
+	for _, want := range []string{"early", "rest", "rest"} {
+		coupon := &couponv1.Coupon{}
+		if err := coupons.Add(coupon); err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if coupon.Tier != want || coupon.Discount == nil {
+			t.Errorf("Expected tier %q with its discount, got %q", want, coupon.Tier)
+		}
+	}
+
+	stats := coupons.TierStats()
+	if stats[0] != (TierStats{Name: "early", Issued: 1}) || stats[1] != (TierStats{Name: "rest", Issued: 2, Remaining: 1}) {
+		t.Errorf("Unexpected tier stats: %v", stats)
+	}
+}
+
+func TestCoupons_TiersInOccurrence(t *testing.T) {
+	start := time.Date(2025, 5, 1, 10, 0, 0, 0, time.UTC)
+	coupons := NewCoupons(2)
+	coupons.SetTiers([]*couponv1.Tier{newTestTier("early", 1), newTestTier("late", 1)})
+
+	// Each occurrence starts the tiers over
+	for _, occurrence := range []time.Time{start, start.Add(24 * time.Hour)} {
+		for _, want := range []string{"early", "late"} {
+			coupon := &couponv1.Coupon{}
+			if err := coupons.AddInOccurrence(occurrence, occurrence.Add(time.Hour), coupon); err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+			if coupon.Tier != want {
+				t.Errorf("Expected tier %q, got %q", want, coupon.Tier)
+			}
+		}
+	}
+}
+
+func TestCoupons_ReleaseToTier(t *testing.T) {
+	coupons := NewCoupons(3)
+	coupons.SetTiers([]*couponv1.Tier{newTestTier("early", 1), newTestTier("mid", 1), newTestTier("late", 1)})
+
+	var issued []*couponv1.Coupon
+	for i := 0; i < 3; i++ {
+		coupon := &couponv1.Coupon{Code: fmt.Sprintf("tier-%d", i)}
+		_ = coupons.Add(coupon)
+		issued = append(issued, coupon)
+	}
+
+	// The slot of the revoked coupon goes back to its own tier, not the last one, so the tiers cap the coupons
+	// outstanding, while the stats count every coupon issued
+	coupons.Release(issued[1])
+	coupon := &couponv1.Coupon{Code: "tier-again"}
+	if err := coupons.Add(coupon); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if coupon.Tier != "mid" {
+		t.Errorf("Expected tier \"mid\", got %q", coupon.Tier)
+	}
+	want := []TierStats{{Name: "early", Issued: 1}, {Name: "mid", Issued: 2}, {Name: "late", Issued: 1}}
+	if stats := coupons.TierStats(); !slices.Equal(stats, want) {
+		t.Errorf("Expected tier stats %v, got %v", want, stats)
+	}
+}
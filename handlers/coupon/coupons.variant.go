@@ -0,0 +1,198 @@
+package coupon
+
+import (
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"strconv"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/jackgihokim/coupon-issuance-system/handlers/discount"
+	couponv1 "github.com/jackgihokim/coupon-issuance-system/protos/coupon/v1"
+)
+
+// variant is an arm of an A/B test whose coupons get its discount.
+type variant struct {
+	name     string
+	weight   uint32
+	limit    uint32 // 0 is limited by the coupon limit only.
+	discount *couponv1.Discount
+	// issued and redeemed count the coupons of the latest occurrence if the campaign recurs, and held those issued
+	// whose slots were not given back, which the limit caps.
+	issued   uint32
+	redeemed uint32
+	held     uint32
+}
+
+// VariantStats is the number of coupons issued and redeemed in a variant, including those whose slots were given
+// back, and how many more it can issue.
+type VariantStats struct {
+	Name      string
+	Issued    uint32
+	Redeemed  uint32
+	Remaining uint32
+}
+
+// ValidateVariants checks that the variants have unique names, weights and valid discounts, and that none of them
+// has a limit above the coupon limit. Returns an error describing the first invalid variant.
+func ValidateVariants(variants []*couponv1.Variant, couponLimit uint32) error {
+	if len(variants) == 0 {
+		return errors.New("campaign with variants needs at least one variant")
+	}
+
+	names := make(map[string]struct{}, len(variants))
+	for _, v := range variants {
+		if v.Name == "" {
+			return errors.New("variant name is required")
+		}
+		if _, ok := names[v.Name]; ok {
+			return fmt.Errorf("variant %q is defined twice", v.Name)
+		}
+		names[v.Name] = struct{}{}
+		if v.Weight == 0 {
+			return fmt.Errorf("variant %q needs a weight", v.Name)
+		}
+		if v.Discount == nil {
+			return fmt.Errorf("variant %q needs a discount", v.Name)
+		}
+		if err := discount.Validate(v.Discount); err != nil {
+			return fmt.Errorf("variant %q: %w", v.Name, err)
+		}
+		if v.Limit > couponLimit {
+			return fmt.Errorf("variant %q has a limit above the coupon limit", v.Name)
+		}
+	}
+	return nil
+}
+
+// SetVariants makes the coupons issued in the variants the users are assigned. The variants must be valid for the
+// limit of the coupons.
+func (c *Coupons) SetVariants(variants []*couponv1.Variant) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.variants = make([]*variant, len(variants))
+	c.redeemed = make(map[string]struct{})
+	for i, v := range variants {
+		c.variants[i] = &variant{name: v.Name, weight: v.Weight, limit: v.Limit, discount: v.Discount}
+	}
+}
+
+// VariantStats returns the stats of the variants in order, of the latest occurrence which issued coupons if they
+// recur.
+func (c *Coupons) VariantStats() []VariantStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	stats := make([]VariantStats, len(c.variants))
+	for i, v := range c.variants {
+		remaining := c.count
+		if v.limit > 0 {
+			remaining = min(v.limit-min(v.held, v.limit), c.count)
+		}
+		stats[i] = VariantStats{Name: v.name, Issued: v.issued, Redeemed: v.redeemed, Remaining: remaining}
+	}
+	return stats
+}
+
+// RecordRedemption counts the redemption of the coupon for its variant, once per coupon however often it is
+// restored and redeemed again. Coupons of an occurrence which is over are not counted, as the stats are of the
+// latest occurrence.
+func (c *Coupons) RecordRedemption(coupon *couponv1.Coupon) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.variants) == 0 || coupon.Variant == "" {
+		return
+	}
+	if last := len(c.occurrences) - 1; last >= 0 && coupon.IssuedAt.AsTime().Before(c.occurrences[last].StartAt) {
+		return
+	}
+	if _, ok := c.redeemed[coupon.Code]; ok {
+		return
+	}
+	for _, v := range c.variants {
+		if v.name == coupon.Variant {
+			c.redeemed[coupon.Code] = struct{}{}
+			v.redeemed++
+			return
+		}
+	}
+}
+
+// variantOf returns the variant the user of the coupon is assigned, picking a point of the total weight by a hash
+// of the campaign and user IDs, so the assignment is the same however often the user asks.
+// Returns nil if the coupons have no variants. The caller must hold mu.
+func (c *Coupons) variantOf(coupon *couponv1.Coupon) *variant {
+	if len(c.variants) == 0 {
+		return nil
+	}
+	var total uint64
+	for _, v := range c.variants {
+		total += uint64(v.weight)
+	}
+
+	h := fnv.New64a()
+	h.Write([]byte(strconv.FormatUint(uint64(coupon.CampaignId), 10)))
+	h.Write([]byte{0})
+	h.Write([]byte(coupon.UserId))
+	point := h.Sum64() % total
+	for _, v := range c.variants {
+		if point < uint64(v.weight) {
+			return v
+		}
+		point -= uint64(v.weight)
+	}
+	return c.variants[len(c.variants)-1]
+}
+
+// checkVariant returns an error if the coupon has no user to assign a variant or the variant holds its limit of
+// coupons. The caller must hold mu.
+func (c *Coupons) checkVariant(coupon *couponv1.Coupon, newOccurrence bool) error {
+	if len(c.variants) == 0 {
+		return nil
+	}
+	if coupon.UserId == "" {
+		return errors.New("user ID is required for assigning a variant")
+	}
+	v := c.variantOf(coupon)
+	if v.limit > 0 && v.held >= v.limit && !newOccurrence {
+		return fmt.Errorf("variant %q has no more coupon", v.name)
+	}
+	return nil
+}
+
+// assignVariant puts the coupon in the variant of its user, giving it the discount of the variant.
+// The caller must hold mu and check the variant.
+func (c *Coupons) assignVariant(coupon *couponv1.Coupon) {
+	v := c.variantOf(coupon)
+	if v == nil {
+		return
+	}
+	v.issued++
+	v.held++
+	coupon.Variant = v.name
+	coupon.Discount = proto.Clone(v.discount).(*couponv1.Discount)
+}
+
+// releaseVariant gives a slot back to the variant with the name, so its users can be issued another coupon in it.
+// The variant still counts the coupon as issued. The caller must hold mu.
+func (c *Coupons) releaseVariant(name string) {
+	for _, v := range c.variants {
+		if v.name == name && v.held > 0 {
+			v.held--
+			return
+		}
+	}
+}
+
+// resetVariants starts the limits and stats of the variants over for a new occurrence. The caller must hold mu.
+func (c *Coupons) resetVariants() {
+	if len(c.variants) == 0 {
+		return
+	}
+	for _, v := range c.variants {
+		v.issued = 0
+		v.redeemed = 0
+		v.held = 0
+	}
+	clear(c.redeemed)
+}
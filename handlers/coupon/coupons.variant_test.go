@@ -0,0 +1,155 @@
+package coupon
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	couponv1 "github.com/jackgihokim/coupon-issuance-system/protos/coupon/v1"
+)
+
+func newTestVariant(name string, weight, limit uint32) *couponv1.Variant {
+	return &couponv1.Variant{
+		Name:     name,
+		Weight:   weight,
+		Limit:    limit,
+		Discount: &couponv1.Discount{Kind: &couponv1.Discount_FreeShipping_{FreeShipping: &couponv1.Discount_FreeShipping{}}},
+	}
+}
+
+func TestValidateVariants(t *testing.T) {
+	testCases := []struct {
+		name     string
+		variants []*couponv1.Variant
+		wantErr  bool
+	}{
+		{"weighted", []*couponv1.Variant{newTestVariant("a", 1, 0), newTestVariant("b", 3, 5)}, false},
+		{"no variants", nil, true},
+		{"no weight", []*couponv1.Variant{newTestVariant("a", 0, 0)}, true},
+		{"same name", []*couponv1.Variant{newTestVariant("a", 1, 0), newTestVariant("a", 1, 0)}, true},
+		{"limit above the coupon limit", []*couponv1.Variant{newTestVariant("a", 1, 11)}, true},
+		{"no discount", []*couponv1.Variant{{Name: "a", Weight: 1}}, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := ValidateVariants(tc.variants, 10); (err != nil) != tc.wantErr {
+				t.Errorf("ValidateVariants() error = %v, wantErr %v", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestCoupons_Variants(t *testing.T) {
+	coupons := NewCoupons(1000)
+	coupons.SetVariants([]*couponv1.Variant{newTestVariant("control", 1, 0), newTestVariant("treatment", 3, 0)})
+
+	assigned := make(map[string]int)
+	for i := 0; i < 400; i++ {
+		coupon := &couponv1.Coupon{CampaignId: 1, UserId: fmt.Sprintf("user-%d", i)}
+		if err := coupons.Add(coupon); err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		assigned[coupon.Variant]++
+
+		// The same user is always assigned the same variant
+		again := &couponv1.Coupon{CampaignId: 1, UserId: coupon.UserId}
+		if v := coupons.variantOf(again); v.name != coupon.Variant {
+			t.Errorf("Expected %s to stay in %q, got %q", coupon.UserId, coupon.Variant, v.name)
+		}
+	}
+	if assigned["control"] < 70 || assigned["control"] > 130 {
+		t.Errorf("Expected about a quarter of the users in control, got %d of 400", assigned["control"])
+	}
+
+	if err := coupons.Add(&couponv1.Coupon{CampaignId: 1}); err == nil {
+		t.Errorf("Expected an error for a coupon without a user")
+	}
+}
+
+func TestCoupons_VariantLimit(t *testing.T) {
+	coupons := NewCoupons(10)
+	coupons.SetVariants([]*couponv1.Variant{newTestVariant("only", 1, 1)})
+
+	first := &couponv1.Coupon{Code: "variant-limit-1", UserId: "user-1"}
+	if err := coupons.Add(first); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	err := coupons.Add(&couponv1.Coupon{Code: "variant-limit-2", UserId: "user-2"})
+	if err == nil || err.Error() != `variant "only" has no more coupon` {
+		t.Errorf("Expected 'variant \"only\" has no more coupon' error, got: %v", err)
+	}
+
+	// A slot given back goes back to its variant, which still counts the coupon as issued
+	coupons.Release(first)
+	if err := coupons.Add(&couponv1.Coupon{Code: "variant-limit-2", UserId: "user-2"}); err != nil {
+		t.Fatalf("Expected no error once the slot was given back, got: %v", err)
+	}
+	if stats := coupons.VariantStats(); stats[0] != (VariantStats{Name: "only", Issued: 2, Remaining: 0}) {
+		t.Errorf("Unexpected variant stats: %v", stats)
+	}
+
+	// Each occurrence starts the limits over
+	start := time.Date(2025, 5, 1, 10, 0, 0, 0, time.UTC)
+	if err := coupons.AddInOccurrence(start, start.Add(time.Hour), &couponv1.Coupon{UserId: "user-2"}); err != nil {
+		t.Errorf("Expected no error in a new occurrence, got: %v", err)
+	}
+}
+
+func TestCoupons_VariantStats(t *testing.T) {
+	now := time.Now()
+	coupons := NewCoupons(10)
+	coupons.SetVariants([]*couponv1.Variant{newTestVariant("only", 1, 4)})
+
+	var codes []string
+	for _, userId := range []string{"user-1", "user-2"} {
+		coupon, err := NewCoupon(1, now.Add(time.Hour), now, WithUserId(userId))
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if err := coupons.Add(coupon); err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		codes = append(codes, coupon.Code)
+	}
+	defer func() {
+		for _, code := range codes {
+			Discard(code)
+		}
+	}()
+	redeemed, err := Redeem(codes[0], "", now)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	// A coupon counts as redeemed once, even if it is restored and redeemed again
+	coupons.RecordRedemption(redeemed)
+	coupons.RecordRedemption(redeemed)
+
+	stats := coupons.VariantStats()
+	want := VariantStats{Name: "only", Issued: 2, Redeemed: 1, Remaining: 2}
+	if len(stats) != 1 || stats[0] != want {
+		t.Errorf("Expected stats %v, got %v", want, stats)
+	}
+}
+
+func TestCoupons_VariantStatsInOccurrence(t *testing.T) {
+	first := time.Date(2025, 5, 1, 10, 0, 0, 0, time.UTC)
+	second := first.Add(24 * time.Hour)
+	coupons := NewCoupons(2)
+	coupons.SetVariants([]*couponv1.Variant{newTestVariant("only", 1, 0)})
+
+	earlier := &couponv1.Coupon{Code: "variant-earlier", UserId: "user-1", IssuedAt: timestamppb.New(first)}
+	_ = coupons.AddInOccurrence(first, second, earlier)
+	_ = coupons.AddInOccurrence(second, second.Add(time.Hour), &couponv1.Coupon{
+		Code: "variant-current", UserId: "user-2", IssuedAt: timestamppb.New(second),
+	})
+
+	// The stats are of the current occurrence, like what remains of it
+	coupons.RecordRedemption(earlier)
+	want := VariantStats{Name: "only", Issued: 1, Remaining: 1}
+	if stats := coupons.VariantStats(); stats[0] != want {
+		t.Errorf("Expected stats %v, got %v", want, stats[0])
+	}
+}
@@ -2,6 +2,10 @@ package coupon
 
 import (
 	"testing"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	couponv1 "github.com/jackgihokim/coupon-issuance-system/protos/coupon/v1"
 )
@@ -57,6 +61,79 @@ func TestCoupons_Add(t *testing.T) {
 	}
 }
 
+func TestCoupons_Release(t *testing.T) {
+	coupons := NewCoupons(1)
+	_ = coupons.Add(&couponv1.Coupon{})
+
+	// The campaign is sold out until a slot is released
+	if err := coupons.Add(&couponv1.Coupon{}); err == nil {
+		t.Error("Expected error when adding beyond capacity, got nil")
+	}
+
+	coupons.Release(&couponv1.Coupon{})
+	if coupons.count != 1 {
+		t.Errorf("Expected count to be 1 after release, got %d", coupons.count)
+	}
+
+	if err := coupons.Add(&couponv1.Coupon{}); err != nil {
+		t.Errorf("Expected no error when adding into a released slot, got: %v", err)
+	}
+	if len(coupons.List()) != 2 {
+		t.Errorf("Expected list length to be 2, got %d", len(coupons.List()))
+	}
+}
+
+func TestCoupons_Reissue(t *testing.T) {
+	coupons := NewCoupons(1)
+	revoked := &couponv1.Coupon{Code: "revoked"}
+	_ = coupons.Add(revoked)
+
+	// The slot goes to the reissued coupon at once, and is given back once
+	if released, err := coupons.Reissue(revoked, &couponv1.Coupon{Code: "next"}); !released || err != nil {
+		t.Errorf("Expected the slot to be reissued, got %v, %v", released, err)
+	}
+	if coupons.Remaining() != 0 || len(coupons.List()) != 2 {
+		t.Errorf("Expected no remaining coupons and 2 issued, got %d and %d", coupons.Remaining(), len(coupons.List()))
+	}
+	if released, err := coupons.Reissue(revoked, &couponv1.Coupon{Code: "again"}); released || err == nil {
+		t.Errorf("Expected the slot not to be given back twice, got %v, %v", released, err)
+	}
+	if coupons.Release(revoked) {
+		t.Error("Expected the slot not to be released after it was reissued")
+	}
+}
+
+func TestCoupons_AddReward(t *testing.T) {
+	coupons := NewCoupons(1)
+	_ = coupons.Add(&couponv1.Coupon{})
+
+	// A reward is issued beyond the limit without taking a slot
+	if err := coupons.AddReward(&couponv1.Coupon{}); err != nil {
+		t.Errorf("Expected no error when adding a reward to sold out coupons, got: %v", err)
+	}
+	if coupons.Remaining() != 0 {
+		t.Errorf("Expected no remaining coupons after adding a reward, got %d", coupons.Remaining())
+	}
+	if len(coupons.List()) != 2 {
+		t.Errorf("Expected list length to be 2, got %d", len(coupons.List()))
+	}
+}
+
+func TestCoupons_ReleaseReward(t *testing.T) {
+	coupons := NewCoupons(2)
+	reward := &couponv1.Coupon{Code: "reward"}
+	_ = coupons.Add(&couponv1.Coupon{Code: "a"})
+	_ = coupons.AddReward(reward)
+
+	// A reward took no slot, so it gives none back
+	if coupons.Release(reward) {
+		t.Error("Expected no slot to be released for a reward")
+	}
+	if coupons.Remaining() != 1 {
+		t.Errorf("Expected 1 remaining coupon, got %d", coupons.Remaining())
+	}
+}
+
 func TestCoupons_List(t *testing.T) {
 	// Test case for listing coupons
 	coupons := NewCoupons(3)
@@ -74,12 +151,12 @@ func TestCoupons_List(t *testing.T) {
 		t.Errorf("Expected list length to be 2, got %d", len(list))
 	}
 
-	if list[0] != coupon1 {
-		t.Errorf("Expected first coupon to be %v, got %v", coupon1, list[0])
+	if !proto.Equal(list[0], coupon1) || list[0] == coupon1 {
+		t.Errorf("Expected first coupon to be a copy of %v, got %v", coupon1, list[0])
 	}
 
-	if list[1] != coupon2 {
-		t.Errorf("Expected second coupon to be %v, got %v", coupon2, list[1])
+	if !proto.Equal(list[1], coupon2) || list[1] == coupon2 {
+		t.Errorf("Expected second coupon to be a copy of %v, got %v", coupon2, list[1])
 	}
 }
 
@@ -113,3 +190,71 @@ func TestConcurrentAccess(t *testing.T) {
 		t.Errorf("Expected count to be 0, got %d", coupons.count)
 	}
 }
+
+func TestCoupons_Remaining(t *testing.T) {
+	coupons := NewCoupons(2)
+	_ = coupons.Add(&couponv1.Coupon{})
+
+	if coupons.Remaining() != 1 {
+		t.Errorf("Expected remaining to be 1, got %d", coupons.Remaining())
+	}
+}
+
+func TestCoupons_AddInOccurrence(t *testing.T) {
+	coupons := NewCoupons(1)
+	first := time.Date(2025, 5, 1, 10, 0, 0, 0, time.UTC)
+	second := first.Add(24 * time.Hour)
+
+	if err := coupons.AddInOccurrence(first, second, &couponv1.Coupon{}); err != nil {
+		t.Fatalf("Expected no error in the first occurrence, got: %v", err)
+	}
+	if err := coupons.AddInOccurrence(first, second, &couponv1.Coupon{}); err == nil {
+		t.Error("Expected error when adding beyond the occurrence limit, got nil")
+	}
+	if coupons.RemainingInOccurrence(second) != 1 {
+		t.Errorf("Expected the next occurrence to have the whole limit, got %d", coupons.RemainingInOccurrence(second))
+	}
+
+	// The count resets to the limit when the next occurrence opens
+	if err := coupons.AddInOccurrence(second, second.Add(24*time.Hour), &couponv1.Coupon{}); err != nil {
+		t.Fatalf("Expected no error in the second occurrence, got: %v", err)
+	}
+	if err := coupons.AddInOccurrence(first, second, &couponv1.Coupon{}); err == nil || err.Error() != "occurrence is over" {
+		t.Errorf("Expected 'occurrence is over' error, got: %v", err)
+	}
+	if coupons.RemainingInOccurrence(first) != 0 {
+		t.Errorf("Expected a past occurrence to have nothing remaining, got %d", coupons.RemainingInOccurrence(first))
+	}
+
+	occurrences := coupons.Occurrences()
+	if len(occurrences) != 2 || occurrences[0].Issued != 1 || occurrences[1].Issued != 1 {
+		t.Errorf("Unexpected occurrences: %v", occurrences)
+	}
+	if len(coupons.List()) != 2 {
+		t.Errorf("Expected list length to be 2, got %d", len(coupons.List()))
+	}
+}
+
+func TestCoupons_ReleaseInOccurrence(t *testing.T) {
+	coupons := NewCoupons(1)
+	first := time.Date(2025, 5, 1, 10, 0, 0, 0, time.UTC)
+	second := first.Add(24 * time.Hour)
+	earlier := &couponv1.Coupon{IssuedAt: timestamppb.New(first)}
+	_ = coupons.AddInOccurrence(first, second, earlier)
+	_ = coupons.AddInOccurrence(second, second.Add(24*time.Hour), &couponv1.Coupon{IssuedAt: timestamppb.New(second)})
+
+	// The slot of a coupon of an occurrence which is over does not go to the current one
+	if coupons.Release(earlier) {
+		t.Error("Expected the slot of an earlier occurrence not to be released")
+	}
+	if coupons.RemainingInOccurrence(second) != 0 {
+		t.Errorf("Expected the current occurrence to stay sold out, got %d", coupons.RemainingInOccurrence(second))
+	}
+
+	if !coupons.Release(&couponv1.Coupon{IssuedAt: timestamppb.New(second)}) {
+		t.Error("Expected the slot of the current occurrence to be released")
+	}
+	if coupons.Release(&couponv1.Coupon{IssuedAt: timestamppb.New(second)}) {
+		t.Error("Expected no slot to be released beyond the limit")
+	}
+}
@@ -0,0 +1,83 @@
+package discount
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	couponv1 "github.com/jackgihokim/coupon-issuance-system/protos/coupon/v1"
+)
+
+// ValidateApplicability checks that the rules don't contradict themselves.
+// Returns an error describing the first contradiction.
+func ValidateApplicability(a *couponv1.Applicability) error {
+	for _, channel := range a.Channels {
+		if channel == couponv1.Channel_CHANNEL_UNSPECIFIED {
+			return errors.New("allowed channels must be specified")
+		}
+	}
+	for _, rule := range []struct {
+		name             string
+		include, exclude []string
+	}{
+		{"sku", a.IncludeSkus, a.ExcludeSkus},
+		{"category", a.IncludeCategories, a.ExcludeCategories},
+		{"brand", a.IncludeBrands, a.ExcludeBrands},
+	} {
+		for _, v := range rule.include {
+			if slices.Contains(rule.exclude, v) {
+				return fmt.Errorf("%s %s is both included and excluded", rule.name, v)
+			}
+		}
+	}
+	return nil
+}
+
+// CheckChannel checks the sales channel against the rules. An unspecified channel is not checked.
+// Returns a message describing the failed rule, or an empty string if the channel is allowed.
+func CheckChannel(a *couponv1.Applicability, channel couponv1.Channel) string {
+	if channel == couponv1.Channel_CHANNEL_UNSPECIFIED || len(a.GetChannels()) == 0 {
+		return ""
+	}
+	if !slices.Contains(a.Channels, channel) {
+		return fmt.Sprintf("channel %s is not allowed", channel)
+	}
+	return ""
+}
+
+// CheckPaymentMethod checks the payment method against the rules. An empty payment method is not checked.
+// Returns a message describing the failed rule, or an empty string if the payment method is allowed.
+func CheckPaymentMethod(a *couponv1.Applicability, method string) string {
+	if method == "" || len(a.GetPaymentMethods()) == 0 {
+		return ""
+	}
+	if !slices.Contains(a.PaymentMethods, method) {
+		return fmt.Sprintf("payment method %s is not allowed", method)
+	}
+	return ""
+}
+
+// checkItem checks the line item against the product rules.
+// Returns a message describing the failed rule, or an empty string if the item is eligible.
+func checkItem(a *couponv1.Applicability, item *couponv1.LineItem) string {
+	if a == nil {
+		return ""
+	}
+	for _, rule := range []struct {
+		name             string
+		value            string
+		include, exclude []string
+	}{
+		{"sku", item.Sku, a.IncludeSkus, a.ExcludeSkus},
+		{"category", item.Category, a.IncludeCategories, a.ExcludeCategories},
+		{"brand", item.Brand, a.IncludeBrands, a.ExcludeBrands},
+	} {
+		if len(rule.include) > 0 && !slices.Contains(rule.include, rule.value) {
+			return fmt.Sprintf("%s %q is not included", rule.name, rule.value)
+		}
+		if slices.Contains(rule.exclude, rule.value) {
+			return fmt.Sprintf("%s %q is excluded", rule.name, rule.value)
+		}
+	}
+	return ""
+}
@@ -0,0 +1,85 @@
+package discount
+
+import (
+	"testing"
+
+	couponv1 "github.com/jackgihokim/coupon-issuance-system/protos/coupon/v1"
+)
+
+func TestValidateApplicability(t *testing.T) {
+	testCases := []struct {
+		name          string
+		applicability *couponv1.Applicability
+		wantErr       bool
+	}{
+		{"no rules", &couponv1.Applicability{}, false},
+		{"channels", &couponv1.Applicability{Channels: []couponv1.Channel{couponv1.Channel_CHANNEL_APP}}, false},
+		{"unspecified channel", &couponv1.Applicability{Channels: []couponv1.Channel{couponv1.Channel_CHANNEL_UNSPECIFIED}}, true},
+		{"sku both included and excluded", &couponv1.Applicability{IncludeSkus: []string{"A"}, ExcludeSkus: []string{"A"}}, true},
+		{"category both included and excluded", &couponv1.Applicability{IncludeCategories: []string{"shoes"}, ExcludeCategories: []string{"shoes"}}, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateApplicability(tc.applicability)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("ValidateApplicability() error = %v, wantErr %v", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestCheckChannel(t *testing.T) {
+	a := &couponv1.Applicability{Channels: []couponv1.Channel{couponv1.Channel_CHANNEL_APP}}
+
+	if msg := CheckChannel(a, couponv1.Channel_CHANNEL_APP); msg != "" {
+		t.Errorf("expected allowed channel to pass, got: %s", msg)
+	}
+	if msg := CheckChannel(a, couponv1.Channel_CHANNEL_WEB); msg != "channel CHANNEL_WEB is not allowed" {
+		t.Errorf("unexpected message: %q", msg)
+	}
+	if msg := CheckChannel(a, couponv1.Channel_CHANNEL_UNSPECIFIED); msg != "" {
+		t.Errorf("expected unspecified channel not to be checked, got: %s", msg)
+	}
+	if msg := CheckChannel(nil, couponv1.Channel_CHANNEL_WEB); msg != "" {
+		t.Errorf("expected any channel to pass without rules, got: %s", msg)
+	}
+}
+
+func TestCheckPaymentMethod(t *testing.T) {
+	a := &couponv1.Applicability{PaymentMethods: []string{"card"}}
+
+	if msg := CheckPaymentMethod(a, "card"); msg != "" {
+		t.Errorf("expected allowed payment method to pass, got: %s", msg)
+	}
+	if msg := CheckPaymentMethod(a, "cash"); msg != "payment method cash is not allowed" {
+		t.Errorf("unexpected message: %q", msg)
+	}
+	if msg := CheckPaymentMethod(a, ""); msg != "" {
+		t.Errorf("expected empty payment method not to be checked, got: %s", msg)
+	}
+}
+
+func TestCheckItem(t *testing.T) {
+	item := &couponv1.LineItem{Sku: "A", Category: "shoes", Brand: "acme"}
+	testCases := []struct {
+		name          string
+		applicability *couponv1.Applicability
+		want          string
+	}{
+		{"no rules", nil, ""},
+		{"included sku", &couponv1.Applicability{IncludeSkus: []string{"A"}}, ""},
+		{"sku not included", &couponv1.Applicability{IncludeSkus: []string{"B"}}, `sku "A" is not included`},
+		{"excluded category", &couponv1.Applicability{ExcludeCategories: []string{"shoes"}}, `category "shoes" is excluded`},
+		{"excluded brand", &couponv1.Applicability{ExcludeBrands: []string{"acme"}}, `brand "acme" is excluded`},
+		{"all included", &couponv1.Applicability{IncludeCategories: []string{"shoes"}, IncludeBrands: []string{"acme"}}, ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := checkItem(tc.applicability, item); got != tc.want {
+				t.Errorf("checkItem() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,257 @@
+package discount
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	couponv1 "github.com/jackgihokim/coupon-issuance-system/protos/coupon/v1"
+)
+
+// Candidate is a valid coupon presented for a cart.
+type Candidate struct {
+	Code          string
+	CampaignId    uint32
+	Discount      *couponv1.Discount
+	Applicability *couponv1.Applicability // limits the line items the discount applies to, if set.
+	Stacking      *couponv1.StackingPolicy
+}
+
+// cart keeps the amounts of an order in a single currency, and what is left of them as discounts are applied.
+type cart struct {
+	currency          string
+	items             []*couponv1.LineItem
+	subtotals         []int64
+	remaining         []int64
+	shipping          int64
+	remainingShipping int64
+}
+
+// application is the discount a coupon gives on each line and on shipping.
+type application struct {
+	lines    []int64
+	shipping int64
+}
+
+// total returns the discount of the application on line items.
+func (a *application) total() int64 {
+	return sumOf(a.lines)
+}
+
+// newCart computes the subtotals of the line items.
+// Returns an error if the items are empty, have different currencies or negative prices, or the amounts overflow.
+func newCart(items []*couponv1.LineItem, shipping *couponv1.Money) (*cart, error) {
+	if len(items) == 0 {
+		return nil, errors.New("cart is empty")
+	}
+
+	c := &cart{
+		currency:  items[0].GetUnitPrice().GetCurrency(),
+		items:     items,
+		subtotals: make([]int64, len(items)),
+		remaining: make([]int64, len(items)),
+	}
+	var total int64
+	for i, item := range items {
+		if err := validateMoney(item.UnitPrice); err != nil {
+			return nil, err
+		}
+		if item.UnitPrice.Currency != c.currency {
+			return nil, errors.New("line items must be in the same currency")
+		}
+		if item.UnitPrice.Amount < 0 {
+			return nil, errors.New("unit price must not be negative")
+		}
+		subtotal, err := multiply(item.UnitPrice.Amount, item.Quantity)
+		if err != nil {
+			return nil, err
+		}
+		if total, err = add(total, subtotal); err != nil {
+			return nil, err
+		}
+		c.subtotals[i] = subtotal
+		c.remaining[i] = subtotal
+	}
+
+	if shipping != nil {
+		if shipping.Currency != c.currency {
+			return nil, errors.New("shipping must be in the currency of the line items")
+		}
+		if shipping.Amount < 0 {
+			return nil, errors.New("shipping must not be negative")
+		}
+		if _, err := add(total, shipping.Amount); err != nil {
+			return nil, err
+		}
+		c.shipping = shipping.Amount
+		c.remainingShipping = shipping.Amount
+	}
+	return c, nil
+}
+
+// apply applies the candidate's discount to what is left of its eligible line items and deducts it.
+// Returns the application, or the reason with a message if the discount does not apply to the cart.
+func (c *cart) apply(candidate Candidate) (*application, couponv1.RejectionReason, string) {
+	d := candidate.Discount
+	if d == nil {
+		return nil, couponv1.RejectionReason_REJECTION_REASON_NO_DISCOUNT, "coupon has no discount"
+	}
+	if currency := Currency(d); currency != "" && currency != c.currency {
+		return nil, couponv1.RejectionReason_REJECTION_REASON_CURRENCY_MISMATCH,
+			fmt.Sprintf("discount is in %s, but the cart is in %s", currency, c.currency)
+	}
+
+	// Only the eligible lines count for the minimum order and get discounted
+	var (
+		subtotal  int64
+		remaining = make([]int64, len(c.items))
+		failures  []string
+	)
+	for i, item := range c.items {
+		if message := checkItem(candidate.Applicability, item); message != "" {
+			failures = append(failures, fmt.Sprintf("%s: %s", item.Sku, message))
+			continue
+		}
+		subtotal += c.subtotals[i]
+		remaining[i] = c.remaining[i]
+	}
+	if len(failures) == len(c.items) {
+		return nil, couponv1.RejectionReason_REJECTION_REASON_NOT_APPLICABLE,
+			"no item is eligible: " + strings.Join(failures, "; ")
+	}
+
+	if minOrder := d.MinOrderAmount; minOrder != nil {
+		if minOrder.Currency != c.currency {
+			return nil, couponv1.RejectionReason_REJECTION_REASON_CURRENCY_MISMATCH,
+				fmt.Sprintf("minimum order amount is in %s, but the cart is in %s", minOrder.Currency, c.currency)
+		}
+		if subtotal < minOrder.Amount {
+			return nil, couponv1.RejectionReason_REJECTION_REASON_MIN_ORDER_NOT_MET,
+				fmt.Sprintf("eligible order amount %d is less than the minimum %d", subtotal, minOrder.Amount)
+		}
+	}
+
+	app := &application{lines: make([]int64, len(c.items))}
+	switch k := d.GetKind().(type) {
+	case *couponv1.Discount_FixedAmount_:
+		app.lines = allocate(min(k.FixedAmount.Amount.Amount, sumOf(remaining)), remaining)
+	case *couponv1.Discount_Percentage_:
+		for i, r := range remaining {
+			app.lines[i] = basisPointsOf(r, k.Percentage.BasisPoints)
+		}
+		if limit := k.Percentage.Cap; limit != nil && app.total() > limit.Amount {
+			app.lines = allocate(limit.Amount, app.lines)
+		}
+	case *couponv1.Discount_FreeShipping_:
+		app.shipping = c.remainingShipping
+	case *couponv1.Discount_BuyXGetY_:
+		set := k.BuyXGetY.BuyQuantity + k.BuyXGetY.GetQuantity
+		for i, item := range c.items {
+			free := item.Quantity / set * k.BuyXGetY.GetQuantity
+			discount, _ := multiply(item.UnitPrice.Amount, free) // cannot overflow as it is within the subtotal.
+			app.lines[i] = min(discount, remaining[i])
+		}
+	default:
+		return nil, couponv1.RejectionReason_REJECTION_REASON_NO_DISCOUNT, "coupon has no discount"
+	}
+
+	if app.total() == 0 && app.shipping == 0 {
+		return nil, couponv1.RejectionReason_REJECTION_REASON_NOT_APPLICABLE, "nothing in the cart can be discounted"
+	}
+	for i, d := range app.lines {
+		c.remaining[i] -= d
+	}
+	c.remainingShipping -= app.shipping
+	return app, couponv1.RejectionReason_REJECTION_REASON_UNSPECIFIED, ""
+}
+
+// Evaluate picks the best combination of the candidates for the cart, and returns the discount per line and in total.
+// Candidates whose discount does not apply to the cart on their own are rejected, and the ones left out of the best
+// combination are reported as conflicts with a picked coupon. Returns an error if the cart itself is invalid.
+func Evaluate(items []*couponv1.LineItem, shipping *couponv1.Money, candidates []Candidate) (*couponv1.EvaluateCartResponse, error) {
+	if len(candidates) > maxCandidates {
+		return nil, fmt.Errorf("at most %d codes can be applied to a cart", maxCandidates)
+	}
+	c, err := newCart(items, shipping)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &couponv1.EvaluateCartResponse{}
+	var applicable []Candidate
+	for _, candidate := range candidates {
+		if _, reason, message := c.clone().apply(candidate); reason != couponv1.RejectionReason_REJECTION_REASON_UNSPECIFIED {
+			resp.Rejected = append(resp.Rejected, &couponv1.RejectedCoupon{
+				Code:    candidate.Code,
+				Reason:  reason,
+				Message: message,
+			})
+			continue
+		}
+		applicable = append(applicable, candidate)
+	}
+
+	picked := resolve(c, applicable)
+	for _, candidate := range picked {
+		app, _, _ := c.apply(candidate)
+		resp.Applied = append(resp.Applied, &couponv1.AppliedCoupon{
+			Code:             candidate.Code,
+			CampaignId:       candidate.CampaignId,
+			Discount:         newMoney(c.currency, app.total()),
+			ShippingDiscount: newMoney(c.currency, app.shipping),
+		})
+	}
+
+	for _, candidate := range applicable {
+		if slices.ContainsFunc(picked, func(p Candidate) bool { return p.Code == candidate.Code }) {
+			continue
+		}
+		if found, message := findConflict(picked, candidate); message != "" {
+			resp.Conflicts = append(resp.Conflicts, &couponv1.StackingConflict{
+				Code:          candidate.Code,
+				ConflictsWith: found.Code,
+				Message:       message,
+			})
+			continue
+		}
+		resp.Rejected = append(resp.Rejected, &couponv1.RejectedCoupon{
+			Code:    candidate.Code,
+			Reason:  couponv1.RejectionReason_REJECTION_REASON_NOT_APPLICABLE,
+			Message: "nothing is left to discount after the other coupons",
+		})
+	}
+
+	c.summarize(resp)
+	return resp, nil
+}
+
+// clone returns a copy of the cart which can be discounted without changing the original.
+func (c *cart) clone() *cart {
+	clone := *c
+	clone.remaining = slices.Clone(c.remaining)
+	return &clone
+}
+
+// summarize fills the lines and totals of the response from the cart's subtotals and what is left of them.
+func (c *cart) summarize(resp *couponv1.EvaluateCartResponse) {
+	var subtotal, discount int64
+	for i, item := range c.items {
+		lineDiscount := c.subtotals[i] - c.remaining[i]
+		resp.Lines = append(resp.Lines, &couponv1.LineResult{
+			Index:    uint32(i),
+			Sku:      item.Sku,
+			Subtotal: newMoney(c.currency, c.subtotals[i]),
+			Discount: newMoney(c.currency, lineDiscount),
+			Total:    newMoney(c.currency, c.remaining[i]),
+		})
+		subtotal += c.subtotals[i]
+		discount += lineDiscount
+	}
+	discount += c.shipping - c.remainingShipping
+
+	resp.Subtotal = newMoney(c.currency, subtotal)
+	resp.Shipping = newMoney(c.currency, c.shipping)
+	resp.DiscountTotal = newMoney(c.currency, discount)
+	resp.Total = newMoney(c.currency, subtotal+c.shipping-discount)
+}
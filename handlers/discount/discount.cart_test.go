@@ -0,0 +1,203 @@
+package discount
+
+import (
+	"testing"
+
+	couponv1 "github.com/jackgihokim/coupon-issuance-system/protos/coupon/v1"
+)
+
+func lineItem(sku, category string, unitPrice int64, quantity uint32) *couponv1.LineItem {
+	return &couponv1.LineItem{Sku: sku, Category: category, UnitPrice: krw(unitPrice), Quantity: quantity}
+}
+
+func TestNewCart(t *testing.T) {
+	testCases := []struct {
+		name     string
+		items    []*couponv1.LineItem
+		shipping *couponv1.Money
+		wantErr  bool
+	}{
+		{"valid cart", []*couponv1.LineItem{lineItem("A", "shoes", 10000, 2)}, krw(3000), false},
+		{"empty cart", nil, nil, true},
+		{"mixed currencies", []*couponv1.LineItem{
+			lineItem("A", "shoes", 10000, 2),
+			{Sku: "B", UnitPrice: &couponv1.Money{Currency: "USD", Amount: 10}, Quantity: 1},
+		}, nil, true},
+		{"negative price", []*couponv1.LineItem{lineItem("A", "shoes", -1, 1)}, nil, true},
+		{"shipping in another currency", []*couponv1.LineItem{lineItem("A", "shoes", 10000, 2)}, &couponv1.Money{Currency: "USD", Amount: 3}, true},
+		{"overflowing subtotal", []*couponv1.LineItem{lineItem("A", "shoes", 1<<62, 4)}, nil, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := newCart(tc.items, tc.shipping)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("newCart() error = %v, wantErr %v", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestCart_Apply(t *testing.T) {
+	items := []*couponv1.LineItem{
+		lineItem("A", "shoes", 30000, 1),
+		lineItem("B", "socks", 1000, 3),
+	}
+
+	testCases := []struct {
+		name         string
+		discount     *couponv1.Discount
+		wantLines    []int64
+		wantShipping int64
+		wantReason   couponv1.RejectionReason
+	}{
+		{"fixed amount split by line amount", fixedAmount(krw(3300)), []int64{3000, 300}, 0, couponv1.RejectionReason_REJECTION_REASON_UNSPECIFIED},
+		{"fixed amount over the order", fixedAmount(krw(100000)), []int64{30000, 3000}, 0, couponv1.RejectionReason_REJECTION_REASON_UNSPECIFIED},
+		{"percentage", percentage(1000, nil), []int64{3000, 300}, 0, couponv1.RejectionReason_REJECTION_REASON_UNSPECIFIED},
+		{"percentage over the cap", percentage(5000, krw(1100)), []int64{1000, 100}, 0, couponv1.RejectionReason_REJECTION_REASON_UNSPECIFIED},
+		{"free shipping", freeShipping(), []int64{0, 0}, 2500, couponv1.RejectionReason_REJECTION_REASON_UNSPECIFIED},
+		{"buy 2 get 1", buyXGetY(2, 1), []int64{0, 1000}, 0, couponv1.RejectionReason_REJECTION_REASON_UNSPECIFIED},
+		{"buy 3 get 1 does not apply", buyXGetY(3, 1), nil, 0, couponv1.RejectionReason_REJECTION_REASON_NOT_APPLICABLE},
+		{"minimum order met", withMinOrder(fixedAmount(krw(1000)), krw(33000)), []int64{909, 91}, 0, couponv1.RejectionReason_REJECTION_REASON_UNSPECIFIED},
+		{"minimum order not met", withMinOrder(fixedAmount(krw(1000)), krw(33001)), nil, 0, couponv1.RejectionReason_REJECTION_REASON_MIN_ORDER_NOT_MET},
+		{"currency mismatch", fixedAmount(&couponv1.Money{Currency: "USD", Amount: 10}), nil, 0, couponv1.RejectionReason_REJECTION_REASON_CURRENCY_MISMATCH},
+		{"no discount", nil, nil, 0, couponv1.RejectionReason_REJECTION_REASON_NO_DISCOUNT},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c, err := newCart(items, krw(2500))
+			if err != nil {
+				t.Fatalf("newCart() error = %v", err)
+			}
+
+			app, reason, _ := c.apply(Candidate{Discount: tc.discount})
+			if reason != tc.wantReason {
+				t.Fatalf("apply() reason = %v, want %v", reason, tc.wantReason)
+			}
+			if app == nil {
+				return
+			}
+			for i, want := range tc.wantLines {
+				if app.lines[i] != want {
+					t.Errorf("apply() line %d = %d, want %d", i, app.lines[i], want)
+				}
+				if c.remaining[i] != c.subtotals[i]-want {
+					t.Errorf("line %d remaining = %d, want %d", i, c.remaining[i], c.subtotals[i]-want)
+				}
+			}
+			if app.shipping != tc.wantShipping {
+				t.Errorf("apply() shipping = %d, want %d", app.shipping, tc.wantShipping)
+			}
+		})
+	}
+}
+
+func TestCart_ApplyEligibleItems(t *testing.T) {
+	items := []*couponv1.LineItem{
+		lineItem("A", "shoes", 30000, 1),
+		lineItem("B", "socks", 1000, 3),
+	}
+
+	t.Run("discount only eligible items", func(t *testing.T) {
+		c, _ := newCart(items, nil)
+		app, reason, _ := c.apply(Candidate{
+			Discount:      percentage(1000, nil),
+			Applicability: &couponv1.Applicability{IncludeCategories: []string{"socks"}},
+		})
+		if reason != couponv1.RejectionReason_REJECTION_REASON_UNSPECIFIED {
+			t.Fatalf("apply() reason = %v", reason)
+		}
+		if app.lines[0] != 0 || app.lines[1] != 300 {
+			t.Errorf("apply() lines = %v, want [0 300]", app.lines)
+		}
+	})
+
+	t.Run("minimum order counts only eligible items", func(t *testing.T) {
+		c, _ := newCart(items, nil)
+		_, reason, _ := c.apply(Candidate{
+			Discount:      withMinOrder(fixedAmount(krw(1000)), krw(10000)),
+			Applicability: &couponv1.Applicability{ExcludeSkus: []string{"A"}},
+		})
+		if reason != couponv1.RejectionReason_REJECTION_REASON_MIN_ORDER_NOT_MET {
+			t.Errorf("apply() reason = %v, want min order not met", reason)
+		}
+	})
+
+	t.Run("explain the failed rules if no item is eligible", func(t *testing.T) {
+		c, _ := newCart(items, nil)
+		_, reason, message := c.apply(Candidate{
+			Discount:      percentage(1000, nil),
+			Applicability: &couponv1.Applicability{IncludeBrands: []string{"acme"}, ExcludeCategories: []string{"socks"}},
+		})
+		if reason != couponv1.RejectionReason_REJECTION_REASON_NOT_APPLICABLE {
+			t.Fatalf("apply() reason = %v, want not applicable", reason)
+		}
+		want := `no item is eligible: A: brand "" is not included; B: category "socks" is excluded`
+		if message != want {
+			t.Errorf("apply() message = %q, want %q", message, want)
+		}
+	})
+}
+
+func TestEvaluate(t *testing.T) {
+	items := []*couponv1.LineItem{
+		lineItem("A", "shoes", 30000, 1),
+		lineItem("B", "socks", 1000, 3),
+	}
+	candidates := []Candidate{
+		{Code: "TEN", CampaignId: 1, Discount: percentage(1000, nil), Stacking: &couponv1.StackingPolicy{Priority: 1}},
+		{Code: "TEN-AGAIN", CampaignId: 1, Discount: percentage(1000, nil), Stacking: &couponv1.StackingPolicy{Priority: 1}},
+		{Code: "SHIP", CampaignId: 2, Discount: freeShipping()},
+		{Code: "SHIP-AGAIN", CampaignId: 3, Discount: freeShipping()},
+		{Code: "FIXED", CampaignId: 4, Discount: fixedAmount(krw(2970))},
+		{Code: "BIG-ORDER", CampaignId: 5, Discount: withMinOrder(fixedAmount(krw(1000)), krw(100000))},
+	}
+
+	resp, err := Evaluate(items, krw(2500), candidates)
+	if err != nil {
+		t.Fatalf("Evaluate() error = %v", err)
+	}
+
+	// TEN goes first by priority and takes 10% (3300), then FIXED takes 2970 out of what is left (29700)
+	if len(resp.Applied) != 3 {
+		t.Fatalf("expected applied count: 3, actual: %d", len(resp.Applied))
+	}
+	if resp.Applied[0].Code != "TEN" || resp.Applied[0].Discount.Amount != 3300 {
+		t.Errorf("unexpected first applied coupon: %v", resp.Applied[0])
+	}
+	if resp.Applied[1].Code != "FIXED" || resp.Applied[1].Discount.Amount != 2970 {
+		t.Errorf("unexpected second applied coupon: %v", resp.Applied[1])
+	}
+	if resp.Applied[2].Code != "SHIP" || resp.Applied[2].ShippingDiscount.Amount != 2500 {
+		t.Errorf("unexpected third applied coupon: %v", resp.Applied[2])
+	}
+
+	if len(resp.Conflicts) != 2 {
+		t.Fatalf("expected conflict count: 2, actual: %d", len(resp.Conflicts))
+	}
+	if resp.Conflicts[0].Code != "TEN-AGAIN" || resp.Conflicts[0].ConflictsWith != "TEN" {
+		t.Errorf("unexpected first conflict: %v", resp.Conflicts[0])
+	}
+	if resp.Conflicts[1].Code != "SHIP-AGAIN" || resp.Conflicts[1].ConflictsWith != "SHIP" {
+		t.Errorf("unexpected second conflict: %v", resp.Conflicts[1])
+	}
+
+	if len(resp.Rejected) != 1 || resp.Rejected[0].Reason != couponv1.RejectionReason_REJECTION_REASON_MIN_ORDER_NOT_MET {
+		t.Errorf("expected BIG-ORDER to be rejected for the minimum order, got: %v", resp.Rejected)
+	}
+
+	// Line A: 30000 - 3000 - 2700, line B: 3000 - 300 - 270
+	if resp.Lines[0].Discount.Amount != 5700 || resp.Lines[0].Total.Amount != 24300 {
+		t.Errorf("unexpected line A: %v", resp.Lines[0])
+	}
+	if resp.Lines[1].Discount.Amount != 570 || resp.Lines[1].Total.Amount != 2430 {
+		t.Errorf("unexpected line B: %v", resp.Lines[1])
+	}
+	if resp.Subtotal.Amount != 33000 || resp.Shipping.Amount != 2500 {
+		t.Errorf("unexpected subtotal and shipping: %v, %v", resp.Subtotal, resp.Shipping)
+	}
+	if resp.DiscountTotal.Amount != 8770 || resp.Total.Amount != 26730 {
+		t.Errorf("unexpected totals: discount %v, total %v", resp.DiscountTotal, resp.Total)
+	}
+}
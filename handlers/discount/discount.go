@@ -0,0 +1,128 @@
+package discount
+
+import (
+	"errors"
+	"fmt"
+
+	couponv1 "github.com/jackgihokim/coupon-issuance-system/protos/coupon/v1"
+)
+
+const maxBasisPoints = 10000 // 100%
+
+// Validate checks that the discount is complete and its amounts are consistent.
+// Returns an error describing the first invalid part.
+func Validate(d *couponv1.Discount) error {
+	switch k := d.GetKind().(type) {
+	case *couponv1.Discount_FixedAmount_:
+		if err := validateMoney(k.FixedAmount.GetAmount()); err != nil {
+			return err
+		}
+		if k.FixedAmount.Amount.Amount <= 0 {
+			return errors.New("fixed discount amount must be positive")
+		}
+	case *couponv1.Discount_Percentage_:
+		if k.Percentage.BasisPoints == 0 || k.Percentage.BasisPoints > maxBasisPoints {
+			return errors.New("discount percentage must be between 1 and 10000 basis points")
+		}
+		if k.Percentage.Cap != nil {
+			if err := validateMoney(k.Percentage.Cap); err != nil {
+				return err
+			}
+			if k.Percentage.Cap.Amount <= 0 {
+				return errors.New("discount cap must be positive")
+			}
+		}
+	case *couponv1.Discount_FreeShipping_:
+	case *couponv1.Discount_BuyXGetY_:
+		if k.BuyXGetY.BuyQuantity == 0 || k.BuyXGetY.GetQuantity == 0 {
+			return errors.New("buy and get quantities must be positive")
+		}
+	default:
+		return errors.New("discount kind is empty")
+	}
+
+	if d.MinOrderAmount != nil {
+		if err := validateMoney(d.MinOrderAmount); err != nil {
+			return err
+		}
+		if d.MinOrderAmount.Amount < 0 {
+			return errors.New("minimum order amount must not be negative")
+		}
+		if currency := Currency(d); currency != "" && currency != d.MinOrderAmount.Currency {
+			return errors.New("minimum order amount must be in the discount's currency")
+		}
+	}
+	return nil
+}
+
+// ValidateStoredValue checks that the starting balance of stored-value coupons is a positive amount of a currency.
+func ValidateStoredValue(balance *couponv1.Money) error {
+	if err := validateMoney(balance); err != nil {
+		return err
+	}
+	if balance.Amount <= 0 {
+		return errors.New("stored value must be positive")
+	}
+	return nil
+}
+
+// ValidateBudget checks that the budget is a positive amount of a currency, that its projected cost is positive
+// in the same currency and not above the budget, and that its alert thresholds are distinct basis points of at most
+// 100%.
+func ValidateBudget(b *couponv1.Budget) error {
+	if err := validateMoney(b.Amount); err != nil {
+		return err
+	}
+	if b.Amount.Amount <= 0 {
+		return errors.New("budget must be positive")
+	}
+	if b.ProjectedCost != nil {
+		switch {
+		case b.ProjectedCost.Currency != b.Amount.Currency:
+			return errors.New("projected cost must be in the budget's currency")
+		case b.ProjectedCost.Amount <= 0:
+			return errors.New("projected cost must be positive")
+		case b.ProjectedCost.Amount > b.Amount.Amount:
+			return errors.New("projected cost exceeds the budget")
+		}
+	}
+
+	seen := make(map[uint32]struct{}, len(b.AlertThresholds))
+	for _, threshold := range b.AlertThresholds {
+		if threshold == 0 || threshold > maxBasisPoints {
+			return errors.New("alert threshold must be between 1 and 10000 basis points")
+		}
+		if _, ok := seen[threshold]; ok {
+			return fmt.Errorf("alert threshold %d is set twice", threshold)
+		}
+		seen[threshold] = struct{}{}
+	}
+	return nil
+}
+
+// Currency returns the currency the discount is bound to, or an empty string if it applies to any currency.
+func Currency(d *couponv1.Discount) string {
+	switch k := d.GetKind().(type) {
+	case *couponv1.Discount_FixedAmount_:
+		return k.FixedAmount.GetAmount().GetCurrency()
+	case *couponv1.Discount_Percentage_:
+		return k.Percentage.GetCap().GetCurrency()
+	}
+	return ""
+}
+
+// validateMoney checks that the money has a set amount with an ISO 4217 currency code.
+func validateMoney(m *couponv1.Money) error {
+	if m == nil {
+		return errors.New("amount is required")
+	}
+	if len(m.Currency) != 3 {
+		return errors.New("currency must be an ISO 4217 code")
+	}
+	for _, r := range m.Currency {
+		if r < 'A' || r > 'Z' {
+			return errors.New("currency must be an ISO 4217 code")
+		}
+	}
+	return nil
+}
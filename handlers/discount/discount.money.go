@@ -0,0 +1,86 @@
+package discount
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"math/bits"
+
+	couponv1 "github.com/jackgihokim/coupon-issuance-system/protos/coupon/v1"
+)
+
+// newMoney returns the amount in the minor unit of the currency as Money.
+func newMoney(currency string, amount int64) *couponv1.Money {
+	return &couponv1.Money{Currency: currency, Amount: amount}
+}
+
+// multiply returns amount * n. Returns an error if the result overflows.
+func multiply(amount int64, n uint32) (int64, error) {
+	hi, lo := bits.Mul64(uint64(amount), uint64(n))
+	if amount < 0 || hi != 0 || lo > math.MaxInt64 {
+		return 0, errors.New("amount overflows")
+	}
+	return int64(lo), nil
+}
+
+// add returns a + b of non-negative amounts. Returns an error if the result overflows.
+func add(a, b int64) (int64, error) {
+	if a > math.MaxInt64-b {
+		return 0, errors.New("amount overflows")
+	}
+	return a + b, nil
+}
+
+// sumOf returns the sum of the amounts, which must not overflow.
+func sumOf(amounts []int64) int64 {
+	var sum int64
+	for _, a := range amounts {
+		sum += a
+	}
+	return sum
+}
+
+// basisPointsOf returns the basis points of the amount, rounded down.
+func basisPointsOf(amount int64, basisPoints uint32) int64 {
+	r := new(big.Int).Mul(big.NewInt(amount), big.NewInt(int64(basisPoints)))
+	r.Quo(r, big.NewInt(maxBasisPoints))
+	return r.Int64()
+}
+
+// allocate splits the total across the weights proportionally without losing a single minor unit.
+// Remainders go to the largest fractional parts first, and to the lower index on ties.
+// The total must not exceed the sum of the weights, so no share exceeds its weight.
+func allocate(total int64, weights []int64) []int64 {
+	shares := make([]int64, len(weights))
+	sum := new(big.Int)
+	for _, w := range weights {
+		sum.Add(sum, big.NewInt(w))
+	}
+	if sum.Sign() == 0 {
+		return shares
+	}
+
+	remainders := make([]*big.Int, len(weights))
+	allocated := int64(0)
+	for i, w := range weights {
+		q, r := new(big.Int).QuoRem(new(big.Int).Mul(big.NewInt(total), big.NewInt(w)), sum, new(big.Int))
+		shares[i] = q.Int64()
+		remainders[i] = r
+		allocated += shares[i]
+	}
+
+	for left := total - allocated; left > 0; left-- {
+		largest := -1
+		for i, r := range remainders {
+			if shares[i] >= weights[i] {
+				continue
+			}
+			if largest < 0 || r.Cmp(remainders[largest]) > 0 {
+				largest = i
+			}
+		}
+		shares[largest]++
+		remainders[largest] = new(big.Int)
+	}
+	return shares
+}
@@ -0,0 +1,80 @@
+package discount
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestMultiply(t *testing.T) {
+	got, err := multiply(12900, 3)
+	if err != nil || got != 38700 {
+		t.Errorf("multiply(12900, 3) = %d, %v, want 38700", got, err)
+	}
+	if _, err := multiply(math.MaxInt64/2+1, 2); err == nil {
+		t.Errorf("expected overflow error, got nil")
+	}
+	if _, err := multiply(-1, 2); err == nil {
+		t.Errorf("expected error for a negative amount, got nil")
+	}
+}
+
+func TestAdd(t *testing.T) {
+	got, err := add(1, 2)
+	if err != nil || got != 3 {
+		t.Errorf("add(1, 2) = %d, %v, want 3", got, err)
+	}
+	if _, err := add(math.MaxInt64, 1); err == nil {
+		t.Errorf("expected overflow error, got nil")
+	}
+}
+
+func TestBasisPointsOf(t *testing.T) {
+	testCases := []struct {
+		amount      int64
+		basisPoints uint32
+		want        int64
+	}{
+		{10000, 1000, 1000},
+		{999, 1000, 99}, // rounded down
+		{math.MaxInt64, 10000, math.MaxInt64},
+	}
+
+	for _, tc := range testCases {
+		if got := basisPointsOf(tc.amount, tc.basisPoints); got != tc.want {
+			t.Errorf("basisPointsOf(%d, %d) = %d, want %d", tc.amount, tc.basisPoints, got, tc.want)
+		}
+	}
+}
+
+func TestAllocate(t *testing.T) {
+	testCases := []struct {
+		name    string
+		total   int64
+		weights []int64
+		want    []int64
+	}{
+		{"even split", 100, []int64{50, 50}, []int64{50, 50}},
+		{"proportional split", 1000, []int64{3000, 1000}, []int64{750, 250}},
+		{"remainder to the largest fraction", 100, []int64{1, 1, 1}, []int64{1, 1, 1}},
+		{"remainder to the lower index on ties", 10, []int64{100, 100, 100}, []int64{4, 3, 3}},
+		{"zero weights", 10, []int64{0, 0}, []int64{0, 0}},
+		{"zero weight takes nothing", 10, []int64{0, 20}, []int64{0, 10}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			total := tc.total
+			if sum := sumOf(tc.weights); total > sum {
+				total = sum
+			}
+			got := allocate(total, tc.weights)
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("allocate(%d, %v) = %v, want %v", total, tc.weights, got, tc.want)
+			}
+			if sumOf(got) != total {
+				t.Errorf("allocated %d, want %d", sumOf(got), total)
+			}
+		})
+	}
+}
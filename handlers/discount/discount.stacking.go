@@ -0,0 +1,156 @@
+package discount
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	couponv1 "github.com/jackgihokim/coupon-issuance-system/protos/coupon/v1"
+)
+
+// maxCandidates bounds the combinations the resolver tries, which grow as 2^n.
+const maxCandidates = 10
+
+// ValidateStacking checks that a group is given exactly when the policy stacks within a group.
+func ValidateStacking(p *couponv1.StackingPolicy) error {
+	switch p.Mode {
+	case couponv1.StackingMode_STACKING_MODE_STACKABLE_WITH_GROUP:
+		if p.Group == "" {
+			return errors.New("stacking group is required")
+		}
+	default:
+		if p.Group != "" {
+			return errors.New("stacking group is allowed only for stackable-with-group")
+		}
+	}
+	return nil
+}
+
+// conflict checks whether two coupons can be combined in a cart.
+// Returns a message describing the conflict, or an empty string if they can be combined.
+func conflict(a, b Candidate) string {
+	if a.CampaignId == b.CampaignId {
+		return "coupons of the same campaign cannot be combined"
+	}
+	if a.Discount.GetFreeShipping() != nil && b.Discount.GetFreeShipping() != nil {
+		return "free shipping cannot be combined with another free shipping"
+	}
+	for _, c := range []Candidate{a, b} {
+		if c.Stacking.GetMode() == couponv1.StackingMode_STACKING_MODE_EXCLUSIVE {
+			return fmt.Sprintf("%s cannot be combined with any other coupon", c.Code)
+		}
+	}
+	for _, pair := range [][2]Candidate{{a, b}, {b, a}} {
+		c, other := pair[0], pair[1]
+		if c.Stacking.GetMode() == couponv1.StackingMode_STACKING_MODE_STACKABLE_WITH_GROUP &&
+			other.Stacking.GetGroup() != c.Stacking.Group {
+			return fmt.Sprintf("%s can be combined only with coupons of group %s", c.Code, c.Stacking.Group)
+		}
+	}
+	return ""
+}
+
+// findConflict returns the first of the coupons the candidate conflicts with, along with the conflict message.
+func findConflict(coupons []Candidate, candidate Candidate) (Candidate, string) {
+	for _, c := range coupons {
+		if message := conflict(c, candidate); message != "" {
+			return c, message
+		}
+	}
+	return Candidate{}, ""
+}
+
+// combination is a set of coupons which can be combined, in the order they are applied.
+type combination struct {
+	coupons  []Candidate
+	discount int64
+	priority int64
+}
+
+// better reports whether the combination beats the other one. It prefers the larger discount, then the higher
+// total priority, then fewer coupons, and finally the codes in lexical order, so the choice is deterministic.
+func (c *combination) better(other *combination) bool {
+	if other == nil {
+		return true
+	}
+	if c.discount != other.discount {
+		return c.discount > other.discount
+	}
+	if c.priority != other.priority {
+		return c.priority > other.priority
+	}
+	if len(c.coupons) != len(other.coupons) {
+		return len(c.coupons) < len(other.coupons)
+	}
+	return slices.Compare(sortedCodes(c.coupons), sortedCodes(other.coupons)) < 0
+}
+
+// sortedCodes returns the codes of the coupons in lexical order.
+func sortedCodes(coupons []Candidate) []string {
+	codes := make([]string, len(coupons))
+	for i, c := range coupons {
+		codes[i] = c.Code
+	}
+	slices.Sort(codes)
+	return codes
+}
+
+// applicationOrder sorts the candidates by higher priority first, and by code on ties.
+func applicationOrder(a, b Candidate) int {
+	if pa, pb := a.Stacking.GetPriority(), b.Stacking.GetPriority(); pa != pb {
+		return int(pb) - int(pa)
+	}
+	return strings.Compare(a.Code, b.Code)
+}
+
+// resolve picks the best combination of the candidates for the cart without changing it.
+// Every candidate must apply to the cart on its own. Returns the picked coupons in the order they are applied.
+func resolve(c *cart, candidates []Candidate) []Candidate {
+	sorted := slices.Clone(candidates)
+	slices.SortFunc(sorted, applicationOrder)
+
+	var best *combination
+	for mask := 1; mask < 1<<len(sorted); mask++ {
+		comb := &combination{}
+		for i, candidate := range sorted {
+			if mask&(1<<i) != 0 {
+				comb.coupons = append(comb.coupons, candidate)
+			}
+		}
+		if !combinable(comb.coupons) {
+			continue
+		}
+
+		// Every coupon of the combination must still discount something after the ones before it
+		trial := c.clone()
+		valid := true
+		for _, candidate := range comb.coupons {
+			app, reason, _ := trial.apply(candidate)
+			if reason != couponv1.RejectionReason_REJECTION_REASON_UNSPECIFIED {
+				valid = false
+				break
+			}
+			comb.discount += app.total() + app.shipping
+			comb.priority += int64(candidate.Stacking.GetPriority())
+		}
+		if valid && comb.better(best) {
+			best = comb
+		}
+	}
+
+	if best == nil {
+		return nil
+	}
+	return best.coupons
+}
+
+// combinable reports whether every pair of the coupons can be combined.
+func combinable(coupons []Candidate) bool {
+	for i := range coupons {
+		if _, message := findConflict(coupons[:i], coupons[i]); message != "" {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,198 @@
+package discount
+
+import (
+	"testing"
+
+	couponv1 "github.com/jackgihokim/coupon-issuance-system/protos/coupon/v1"
+)
+
+func stacking(mode couponv1.StackingMode, group string, priority int32) *couponv1.StackingPolicy {
+	return &couponv1.StackingPolicy{Mode: mode, Group: group, Priority: priority}
+}
+
+func TestValidateStacking(t *testing.T) {
+	testCases := []struct {
+		name    string
+		policy  *couponv1.StackingPolicy
+		wantErr bool
+	}{
+		{"exclusive", stacking(couponv1.StackingMode_STACKING_MODE_EXCLUSIVE, "", 0), false},
+		{"stackable with group", stacking(couponv1.StackingMode_STACKING_MODE_STACKABLE_WITH_GROUP, "members", 0), false},
+		{"stackable with group without group", stacking(couponv1.StackingMode_STACKING_MODE_STACKABLE_WITH_GROUP, "", 0), true},
+		{"stackable with all with group", stacking(couponv1.StackingMode_STACKING_MODE_STACKABLE_WITH_ALL, "members", 0), true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateStacking(tc.policy)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("ValidateStacking() error = %v, wantErr %v", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestConflict(t *testing.T) {
+	exclusive := stacking(couponv1.StackingMode_STACKING_MODE_EXCLUSIVE, "", 0)
+	members := stacking(couponv1.StackingMode_STACKING_MODE_STACKABLE_WITH_GROUP, "members", 0)
+	partners := stacking(couponv1.StackingMode_STACKING_MODE_STACKABLE_WITH_GROUP, "partners", 0)
+	all := stacking(couponv1.StackingMode_STACKING_MODE_STACKABLE_WITH_ALL, "", 0)
+
+	testCases := []struct {
+		name         string
+		a, b         Candidate
+		wantConflict bool
+	}{
+		{"same campaign", Candidate{Code: "A", CampaignId: 1}, Candidate{Code: "B", CampaignId: 1}, true},
+		{"two free shippings", Candidate{Code: "A", CampaignId: 1, Discount: freeShipping()}, Candidate{Code: "B", CampaignId: 2, Discount: freeShipping()}, true},
+		{"no policies", Candidate{Code: "A", CampaignId: 1}, Candidate{Code: "B", CampaignId: 2}, false},
+		{"exclusive with all", Candidate{Code: "A", CampaignId: 1, Stacking: exclusive}, Candidate{Code: "B", CampaignId: 2, Stacking: all}, true},
+		{"all with exclusive", Candidate{Code: "A", CampaignId: 1, Stacking: all}, Candidate{Code: "B", CampaignId: 2, Stacking: exclusive}, true},
+		{"same group", Candidate{Code: "A", CampaignId: 1, Stacking: members}, Candidate{Code: "B", CampaignId: 2, Stacking: members}, false},
+		{"different groups", Candidate{Code: "A", CampaignId: 1, Stacking: members}, Candidate{Code: "B", CampaignId: 2, Stacking: partners}, true},
+		{"group with all", Candidate{Code: "A", CampaignId: 1, Stacking: members}, Candidate{Code: "B", CampaignId: 2, Stacking: all}, true},
+		{"all with all", Candidate{Code: "A", CampaignId: 1, Stacking: all}, Candidate{Code: "B", CampaignId: 2, Stacking: all}, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := conflict(tc.a, tc.b) != ""; got != tc.wantConflict {
+				t.Errorf("conflict() = %v, want %v", got, tc.wantConflict)
+			}
+			if got := conflict(tc.b, tc.a) != ""; got != tc.wantConflict {
+				t.Errorf("conflict() is not symmetric")
+			}
+		})
+	}
+}
+
+func TestResolve(t *testing.T) {
+	items := []*couponv1.LineItem{lineItem("A", "shoes", 100000, 1)}
+	exclusive := stacking(couponv1.StackingMode_STACKING_MODE_EXCLUSIVE, "", 0)
+	members := stacking(couponv1.StackingMode_STACKING_MODE_STACKABLE_WITH_GROUP, "members", 0)
+	all := stacking(couponv1.StackingMode_STACKING_MODE_STACKABLE_WITH_ALL, "", 0)
+
+	testCases := []struct {
+		name       string
+		candidates []Candidate
+		want       []string
+	}{
+		{
+			name: "combination beats a smaller exclusive coupon",
+			candidates: []Candidate{
+				{Code: "EXCL", CampaignId: 1, Discount: fixedAmount(krw(15000)), Stacking: exclusive},
+				{Code: "M1", CampaignId: 2, Discount: fixedAmount(krw(10000)), Stacking: members},
+				{Code: "M2", CampaignId: 3, Discount: fixedAmount(krw(10000)), Stacking: members},
+			},
+			want: []string{"M1", "M2"},
+		},
+		{
+			name: "exclusive coupon beats a smaller combination",
+			candidates: []Candidate{
+				{Code: "EXCL", CampaignId: 1, Discount: fixedAmount(krw(25000)), Stacking: exclusive},
+				{Code: "M1", CampaignId: 2, Discount: fixedAmount(krw(10000)), Stacking: members},
+				{Code: "M2", CampaignId: 3, Discount: fixedAmount(krw(10000)), Stacking: members},
+			},
+			want: []string{"EXCL"},
+		},
+		{
+			name: "group coupons do not combine with stackable-with-all",
+			candidates: []Candidate{
+				{Code: "M1", CampaignId: 1, Discount: fixedAmount(krw(10000)), Stacking: members},
+				{Code: "ALL1", CampaignId: 2, Discount: fixedAmount(krw(6000)), Stacking: all},
+				{Code: "ALL2", CampaignId: 3, Discount: fixedAmount(krw(6000)), Stacking: all},
+			},
+			want: []string{"ALL1", "ALL2"},
+		},
+		{
+			name: "higher priority wins a tie",
+			candidates: []Candidate{
+				{Code: "LOW", CampaignId: 1, Discount: fixedAmount(krw(10000)), Stacking: stacking(couponv1.StackingMode_STACKING_MODE_EXCLUSIVE, "", 1)},
+				{Code: "HIGH", CampaignId: 2, Discount: fixedAmount(krw(10000)), Stacking: stacking(couponv1.StackingMode_STACKING_MODE_EXCLUSIVE, "", 2)},
+			},
+			want: []string{"HIGH"},
+		},
+		{
+			name: "lexical order of codes breaks a full tie",
+			candidates: []Candidate{
+				{Code: "B", CampaignId: 1, Discount: fixedAmount(krw(10000)), Stacking: exclusive},
+				{Code: "A", CampaignId: 2, Discount: fixedAmount(krw(10000)), Stacking: exclusive},
+			},
+			want: []string{"A"},
+		},
+		{
+			name: "higher priority is applied first",
+			candidates: []Candidate{
+				{Code: "FIXED", CampaignId: 1, Discount: fixedAmount(krw(10000)), Stacking: stacking(couponv1.StackingMode_STACKING_MODE_STACKABLE_WITH_ALL, "", 1)},
+				{Code: "PERCENT", CampaignId: 2, Discount: percentage(1000, nil), Stacking: stacking(couponv1.StackingMode_STACKING_MODE_STACKABLE_WITH_ALL, "", 2)},
+			},
+			want: []string{"PERCENT", "FIXED"},
+		},
+		{
+			name: "coupon with nothing left to discount is left out",
+			candidates: []Candidate{
+				{Code: "ALL", CampaignId: 1, Discount: percentage(10000, nil), Stacking: all},
+				{Code: "MORE", CampaignId: 2, Discount: fixedAmount(krw(1000)), Stacking: all},
+			},
+			want: []string{"ALL"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c, err := newCart(items, nil)
+			if err != nil {
+				t.Fatalf("newCart() error = %v", err)
+			}
+
+			// The same input must always resolve to the same combination
+			for i := 0; i < 3; i++ {
+				picked := resolve(c, tc.candidates)
+				got := make([]string, len(picked))
+				for i, p := range picked {
+					got[i] = p.Code
+				}
+				if len(got) != len(tc.want) {
+					t.Fatalf("resolve() = %v, want %v", got, tc.want)
+				}
+				for i := range got {
+					if got[i] != tc.want[i] {
+						t.Fatalf("resolve() = %v, want %v", got, tc.want)
+					}
+				}
+			}
+
+			if c.remaining[0] != c.subtotals[0] {
+				t.Errorf("resolve() must not change the cart")
+			}
+		})
+	}
+}
+
+func TestEvaluate_StackingConflicts(t *testing.T) {
+	items := []*couponv1.LineItem{lineItem("A", "shoes", 100000, 1)}
+	candidates := []Candidate{
+		{Code: "EXCL", CampaignId: 1, Discount: fixedAmount(krw(15000)), Stacking: stacking(couponv1.StackingMode_STACKING_MODE_EXCLUSIVE, "", 0)},
+		{Code: "M1", CampaignId: 2, Discount: fixedAmount(krw(10000)), Stacking: stacking(couponv1.StackingMode_STACKING_MODE_STACKABLE_WITH_GROUP, "members", 0)},
+		{Code: "M2", CampaignId: 3, Discount: fixedAmount(krw(10000)), Stacking: stacking(couponv1.StackingMode_STACKING_MODE_STACKABLE_WITH_GROUP, "members", 0)},
+	}
+
+	resp, err := Evaluate(items, nil, candidates)
+	if err != nil {
+		t.Fatalf("Evaluate() error = %v", err)
+	}
+	if len(resp.Applied) != 2 || resp.DiscountTotal.Amount != 20000 {
+		t.Errorf("expected M1 and M2 to be applied for 20000, got: %v", resp.Applied)
+	}
+	if len(resp.Conflicts) != 1 || resp.Conflicts[0].Code != "EXCL" || resp.Conflicts[0].ConflictsWith != "M1" {
+		t.Errorf("expected EXCL to conflict with M1, got: %v", resp.Conflicts)
+	}
+	if resp.Conflicts[0].Message != "EXCL cannot be combined with any other coupon" {
+		t.Errorf("unexpected conflict message: %q", resp.Conflicts[0].Message)
+	}
+
+	tooMany := make([]Candidate, maxCandidates+1)
+	if _, err := Evaluate(items, nil, tooMany); err == nil {
+		t.Errorf("expected error when evaluating more than %d codes", maxCandidates)
+	}
+}
@@ -0,0 +1,141 @@
+package discount
+
+import (
+	"testing"
+
+	couponv1 "github.com/jackgihokim/coupon-issuance-system/protos/coupon/v1"
+)
+
+func krw(amount int64) *couponv1.Money {
+	return &couponv1.Money{Currency: "KRW", Amount: amount}
+}
+
+func fixedAmount(amount *couponv1.Money) *couponv1.Discount {
+	return &couponv1.Discount{Kind: &couponv1.Discount_FixedAmount_{
+		FixedAmount: &couponv1.Discount_FixedAmount{Amount: amount},
+	}}
+}
+
+func percentage(basisPoints uint32, cap *couponv1.Money) *couponv1.Discount {
+	return &couponv1.Discount{Kind: &couponv1.Discount_Percentage_{
+		Percentage: &couponv1.Discount_Percentage{BasisPoints: basisPoints, Cap: cap},
+	}}
+}
+
+func freeShipping() *couponv1.Discount {
+	return &couponv1.Discount{Kind: &couponv1.Discount_FreeShipping_{
+		FreeShipping: &couponv1.Discount_FreeShipping{},
+	}}
+}
+
+func buyXGetY(buy, get uint32) *couponv1.Discount {
+	return &couponv1.Discount{Kind: &couponv1.Discount_BuyXGetY_{
+		BuyXGetY: &couponv1.Discount_BuyXGetY{BuyQuantity: buy, GetQuantity: get},
+	}}
+}
+
+func withMinOrder(d *couponv1.Discount, min *couponv1.Money) *couponv1.Discount {
+	d.MinOrderAmount = min
+	return d
+}
+
+func TestValidate(t *testing.T) {
+	testCases := []struct {
+		name     string
+		discount *couponv1.Discount
+		wantErr  bool
+	}{
+		{"fixed amount", fixedAmount(krw(5000)), false},
+		{"fixed amount without currency", fixedAmount(&couponv1.Money{Amount: 5000}), true},
+		{"fixed amount with lowercase currency", fixedAmount(&couponv1.Money{Currency: "krw", Amount: 5000}), true},
+		{"non-positive fixed amount", fixedAmount(krw(0)), true},
+		{"percentage", percentage(1000, nil), false},
+		{"percentage with cap", percentage(1000, krw(10000)), false},
+		{"zero percentage", percentage(0, nil), true},
+		{"percentage over 100%", percentage(10001, nil), true},
+		{"non-positive cap", percentage(1000, krw(0)), true},
+		{"free shipping", freeShipping(), false},
+		{"buy 2 get 1", buyXGetY(2, 1), false},
+		{"buy 0 get 1", buyXGetY(0, 1), true},
+		{"minimum order amount", withMinOrder(fixedAmount(krw(5000)), krw(30000)), false},
+		{"minimum order amount in another currency", withMinOrder(fixedAmount(krw(5000)), &couponv1.Money{Currency: "USD", Amount: 30}), true},
+		{"negative minimum order amount", withMinOrder(freeShipping(), krw(-1)), true},
+		{"empty discount", &couponv1.Discount{}, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := Validate(tc.discount)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestCurrency(t *testing.T) {
+	testCases := []struct {
+		name     string
+		discount *couponv1.Discount
+		want     string
+	}{
+		{"fixed amount", fixedAmount(krw(5000)), "KRW"},
+		{"percentage with cap", percentage(1000, krw(10000)), "KRW"},
+		{"percentage without cap", percentage(1000, nil), ""},
+		{"free shipping", freeShipping(), ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := Currency(tc.discount); got != tc.want {
+				t.Errorf("Currency() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestValidateStoredValue(t *testing.T) {
+	testCases := []struct {
+		name    string
+		balance *couponv1.Money
+		wantErr bool
+	}{
+		{"valid balance", krw(50000), false},
+		{"zero balance", krw(0), true},
+		{"invalid currency", &couponv1.Money{Currency: "won", Amount: 50000}, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateStoredValue(tc.balance)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("ValidateStoredValue() error = %v, wantErr %v", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateBudget(t *testing.T) {
+	testCases := []struct {
+		name    string
+		budget  *couponv1.Budget
+		wantErr bool
+	}{
+		{"valid budget", &couponv1.Budget{Amount: krw(1000000), ProjectedCost: krw(5000), AlertThresholds: []uint32{5000, 10000}}, false},
+		{"no amount", &couponv1.Budget{}, true},
+		{"zero amount", &couponv1.Budget{Amount: krw(0)}, true},
+		{"projected cost in another currency", &couponv1.Budget{Amount: krw(1000000), ProjectedCost: &couponv1.Money{Currency: "USD", Amount: 5}}, true},
+		{"projected cost above the budget", &couponv1.Budget{Amount: krw(1000), ProjectedCost: krw(5000)}, true},
+		{"threshold above 100%", &couponv1.Budget{Amount: krw(1000000), AlertThresholds: []uint32{10001}}, true},
+		{"threshold set twice", &couponv1.Budget{Amount: krw(1000000), AlertThresholds: []uint32{8000, 8000}}, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateBudget(tc.budget)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("ValidateBudget() error = %v, wantErr %v", err, tc.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,177 @@
+package eligibility
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	couponv1 "github.com/jackgihokim/coupon-issuance-system/protos/coupon/v1"
+)
+
+// AttributeProvider looks up the attributes of a user when the issue request does not carry them.
+type AttributeProvider interface {
+	Attributes(ctx context.Context, userID string) (*couponv1.UserAttributes, error)
+}
+
+// tierRanks orders the membership tiers from the lowest. A tier which is not listed ranks below all of them.
+var tierRanks = map[string]int64{
+	"bronze":   1,
+	"silver":   2,
+	"gold":     3,
+	"platinum": 4,
+	"diamond":  5,
+}
+
+// Rule is a parsed and type-checked eligibility expression over the user attributes, e.g.
+//
+//	new_user || (tier >= "gold" && country == "KR" && !("fraud" in segments))
+type Rule struct {
+	source string
+	root   node
+}
+
+// Parse parses the expression and type-checks it against the user attributes.
+// Returns an error describing the first syntax or type error.
+func Parse(src string) (*Rule, error) {
+	tokens, err := tokenize(src)
+	if err != nil {
+		return nil, fmt.Errorf("invalid eligibility rule: %w", err)
+	}
+	if len(tokens) == 1 {
+		return nil, errors.New("invalid eligibility rule: empty expression")
+	}
+
+	p := &parser{tokens: tokens}
+	root, err := p.parseOr()
+	if err != nil {
+		return nil, fmt.Errorf("invalid eligibility rule: %w", err)
+	}
+	if t := p.peek(); t.kind != tokenEOF {
+		return nil, fmt.Errorf("invalid eligibility rule: unexpected %q at %d", t.text, t.pos)
+	}
+	return &Rule{source: src, root: root}, nil
+}
+
+// String returns the expression the rule was parsed from.
+func (r *Rule) String() string {
+	return r.source
+}
+
+// Eval reports whether the user with the attributes is eligible. Missing attributes count as zero values.
+func (r *Rule) Eval(attrs *couponv1.UserAttributes) bool {
+	if attrs == nil {
+		attrs = &couponv1.UserAttributes{}
+	}
+	return r.root.eval(attrs).(bool)
+}
+
+// kind is the type of an expression.
+type kind int
+
+const (
+	kindBool kind = iota
+	kindInt
+	kindString
+	kindTier
+	kindList
+)
+
+func (k kind) String() string {
+	switch k {
+	case kindBool:
+		return "bool"
+	case kindInt:
+		return "int"
+	case kindString:
+		return "string"
+	case kindTier:
+		return "tier"
+	}
+	return "list"
+}
+
+// node is an expression of the syntax tree. Its value has the Go type of its kind:
+// bool, int64, string, int64 (the tier rank) or []string.
+type node interface {
+	kind() kind
+	eval(attrs *couponv1.UserAttributes) any
+}
+
+// attributes are the user attributes rules can refer to.
+var attributes = map[string]*attribute{
+	"new_user":    {kindBool, func(a *couponv1.UserAttributes) any { return a.NewUser }},
+	"tier":        {kindTier, func(a *couponv1.UserAttributes) any { return tierRanks[a.Tier] }},
+	"segments":    {kindList, func(a *couponv1.UserAttributes) any { return a.Segments }},
+	"order_count": {kindInt, func(a *couponv1.UserAttributes) any { return a.OrderCount }},
+	"country":     {kindString, func(a *couponv1.UserAttributes) any { return a.Country }},
+}
+
+type attribute struct {
+	k     kind
+	value func(*couponv1.UserAttributes) any
+}
+
+func (a *attribute) kind() kind                              { return a.k }
+func (a *attribute) eval(attrs *couponv1.UserAttributes) any { return a.value(attrs) }
+
+type literal struct {
+	k     kind
+	value any
+}
+
+func (l *literal) kind() kind                        { return l.k }
+func (l *literal) eval(*couponv1.UserAttributes) any { return l.value }
+
+type not struct {
+	operand node
+}
+
+func (n *not) kind() kind { return kindBool }
+func (n *not) eval(attrs *couponv1.UserAttributes) any {
+	return !n.operand.eval(attrs).(bool)
+}
+
+type logical struct {
+	op          string
+	left, right node
+}
+
+func (l *logical) kind() kind { return kindBool }
+func (l *logical) eval(attrs *couponv1.UserAttributes) any {
+	left := l.left.eval(attrs).(bool)
+	if l.op == "&&" {
+		return left && l.right.eval(attrs).(bool)
+	}
+	return left || l.right.eval(attrs).(bool)
+}
+
+type comparison struct {
+	op          string
+	left, right node
+}
+
+func (c *comparison) kind() kind { return kindBool }
+func (c *comparison) eval(attrs *couponv1.UserAttributes) any {
+	left, right := c.left.eval(attrs), c.right.eval(attrs)
+	switch c.op {
+	case "in":
+		return slices.Contains(right.([]string), left.(string))
+	case "==":
+		return left == right
+	case "!=":
+		return left != right
+	}
+
+	// Ordering is type-checked to ints and tier ranks, both int64
+	l, r := left.(int64), right.(int64)
+	switch c.op {
+	case "<":
+		return l < r
+	case "<=":
+		return l <= r
+	case ">":
+		return l > r
+	}
+	return l >= r
+}
@@ -0,0 +1,271 @@
+package eligibility
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenIdent
+	tokenString
+	tokenInt
+	tokenOperator
+	tokenLParen
+	tokenRParen
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	pos  int
+}
+
+// tokenize splits the expression into tokens. Returns an error at the first unexpected character.
+func tokenize(src string) ([]token, error) {
+	var tokens []token
+	for i := 0; i < len(src); {
+		c := src[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case c == '(':
+			tokens = append(tokens, token{tokenLParen, "(", i})
+			i++
+		case c == ')':
+			tokens = append(tokens, token{tokenRParen, ")", i})
+			i++
+		case c == '"':
+			end := strings.IndexByte(src[i+1:], '"')
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated string at %d", i)
+			}
+			tokens = append(tokens, token{tokenString, src[i+1 : i+1+end], i})
+			i += end + 2
+		case c >= '0' && c <= '9':
+			start := i
+			for i < len(src) && src[i] >= '0' && src[i] <= '9' {
+				i++
+			}
+			tokens = append(tokens, token{tokenInt, src[start:i], start})
+		case c == '_' || unicode.IsLetter(rune(c)):
+			start := i
+			for i < len(src) && (src[i] == '_' || unicode.IsLetter(rune(src[i])) || unicode.IsDigit(rune(src[i]))) {
+				i++
+			}
+			tokens = append(tokens, token{tokenIdent, src[start:i], start})
+		default:
+			op := ""
+			for _, candidate := range []string{"&&", "||", "==", "!=", "<=", ">=", "<", ">", "!"} {
+				if strings.HasPrefix(src[i:], candidate) {
+					op = candidate
+					break
+				}
+			}
+			if op == "" {
+				return nil, fmt.Errorf("unexpected character %q at %d", c, i)
+			}
+			tokens = append(tokens, token{tokenOperator, op, i})
+			i += len(op)
+		}
+	}
+	return append(tokens, token{tokenEOF, "", len(src)}), nil
+}
+
+// parser builds a type-checked syntax tree with recursive descent:
+//
+//	or         = and { "||" and }
+//	and        = unary { "&&" unary }
+//	unary      = "!" unary | "(" or ")" | comparison
+//	comparison = operand [ ( "==" | "!=" | "<" | "<=" | ">" | ">=" | "in" ) operand ]
+//	operand    = identifier | string | integer | "true" | "false"
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+// peek returns the current token without consuming it.
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+// next consumes and returns the current token.
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokenEOF {
+		p.pos++
+	}
+	return t
+}
+
+// accept consumes the current token if it is the operator or keyword.
+func (p *parser) accept(text string) bool {
+	t := p.peek()
+	if (t.kind == tokenOperator || t.kind == tokenIdent) && t.text == text {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *parser) parseOr() (node, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.accept("||") {
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &logical{op: "||", left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseAnd() (node, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.accept("&&") {
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = &logical{op: "&&", left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseUnary() (node, error) {
+	if p.accept("!") {
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &not{operand: operand}, nil
+	}
+	if p.peek().kind == tokenLParen {
+		p.next()
+		inner, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if t := p.next(); t.kind != tokenRParen {
+			return nil, fmt.Errorf("expected ) at %d", t.pos)
+		}
+		return inner, nil
+	}
+	return p.parseComparison()
+}
+
+// parseComparison returns a bool expression, so every expression the parser returns is a bool.
+func (p *parser) parseComparison() (node, error) {
+	left, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+
+	t := p.peek()
+	isOperator := t.kind == tokenOperator && t.text != "&&" && t.text != "||" && t.text != "!"
+	if !isOperator && !(t.kind == tokenIdent && t.text == "in") {
+		if left.kind() != kindBool {
+			return nil, fmt.Errorf("expected a bool expression at %d, got %s", t.pos, left.kind())
+		}
+		return left, nil
+	}
+	p.next()
+
+	right, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+	return newComparison(t.text, left, right)
+}
+
+func (p *parser) parseOperand() (node, error) {
+	t := p.next()
+	switch t.kind {
+	case tokenString:
+		return &literal{k: kindString, value: t.text}, nil
+	case tokenInt:
+		n, err := strconv.ParseInt(t.text, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid integer %s at %d", t.text, t.pos)
+		}
+		return &literal{k: kindInt, value: n}, nil
+	case tokenIdent:
+		switch t.text {
+		case "true":
+			return &literal{k: kindBool, value: true}, nil
+		case "false":
+			return &literal{k: kindBool, value: false}, nil
+		}
+		attr, ok := attributes[t.text]
+		if !ok {
+			return nil, fmt.Errorf("unknown attribute %s at %d", t.text, t.pos)
+		}
+		return attr, nil
+	case tokenEOF:
+		return nil, fmt.Errorf("unexpected end of expression")
+	}
+	return nil, fmt.Errorf("unexpected %q at %d", t.text, t.pos)
+}
+
+// newComparison type-checks the operands of the operator and returns the comparison.
+func newComparison(op string, left, right node) (node, error) {
+	if op == "in" {
+		if left.kind() != kindString || right.kind() != kindList {
+			return nil, fmt.Errorf("operator in needs a string and a list, got %s and %s", left.kind(), right.kind())
+		}
+		return &comparison{op: op, left: left, right: right}, nil
+	}
+
+	// Tiers are compared by rank, so string literals must name a tier
+	var err error
+	if left, right, err = rankTiers(left, right); err != nil {
+		return nil, err
+	}
+	if left.kind() != right.kind() {
+		return nil, fmt.Errorf("operator %s cannot compare %s with %s", op, left.kind(), right.kind())
+	}
+	switch op {
+	case "==", "!=":
+		if left.kind() == kindList {
+			return nil, fmt.Errorf("operator %s cannot compare lists", op)
+		}
+	default:
+		if left.kind() != kindInt && left.kind() != kindTier {
+			return nil, fmt.Errorf("operator %s needs ints or tiers, got %s", op, left.kind())
+		}
+	}
+	return &comparison{op: op, left: left, right: right}, nil
+}
+
+// rankTiers converts a string literal compared with a tier into the tier's rank.
+func rankTiers(left, right node) (node, node, error) {
+	convert := func(n node) (node, error) {
+		l, ok := n.(*literal)
+		if !ok || l.k != kindString {
+			return n, nil
+		}
+		rank, ok := tierRanks[l.value.(string)]
+		if !ok {
+			return nil, fmt.Errorf("unknown tier %q", l.value)
+		}
+		return &literal{k: kindTier, value: rank}, nil
+	}
+
+	var err error
+	if left.kind() == kindTier {
+		right, err = convert(right)
+	} else if right.kind() == kindTier {
+		left, err = convert(left)
+	}
+	return left, right, err
+}
@@ -0,0 +1,58 @@
+package eligibility
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParse_Errors(t *testing.T) {
+	testCases := []struct {
+		name    string
+		rule    string
+		wantErr string
+	}{
+		{"empty", "  ", "empty expression"},
+		{"unknown attribute", "age > 20", "unknown attribute age"},
+		{"unknown tier", `tier >= "gilded"`, `unknown tier "gilded"`},
+		{"unterminated string", `country == "KR`, "unterminated string"},
+		{"unexpected character", "order_count > 1 & new_user", "unexpected character"},
+		{"not a bool", "order_count", "expected a bool expression"},
+		{"mismatched types", `order_count == "ten"`, "cannot compare int with string"},
+		{"ordering strings", `country > "KR"`, "needs ints or tiers"},
+		{"in needs a list", `"KR" in country`, "needs a string and a list"},
+		{"comparing lists", "segments == segments", "cannot compare lists"},
+		{"negating an int", "!order_count", "expected a bool expression"},
+		{"unbalanced parentheses", "(new_user", "expected )"},
+		{"trailing tokens", "new_user new_user", "unexpected \"new_user\""},
+		{"missing operand", "order_count >", "unexpected end of expression"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := Parse(tc.rule)
+			if err == nil {
+				t.Fatalf("Parse() expected an error")
+			}
+			if !strings.Contains(err.Error(), tc.wantErr) {
+				t.Errorf("Parse() error = %v, want containing %q", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestTokenize(t *testing.T) {
+	tokens, err := tokenize(`!("vip" in segments)||order_count>=3`)
+	if err != nil {
+		t.Fatalf("tokenize() error = %v", err)
+	}
+
+	want := []string{"!", "(", "vip", "in", "segments", ")", "||", "order_count", ">=", "3", ""}
+	if len(tokens) != len(want) {
+		t.Fatalf("expected token count: %d, actual: %d", len(want), len(tokens))
+	}
+	for i, w := range want {
+		if tokens[i].text != w {
+			t.Errorf("token %d = %q, want %q", i, tokens[i].text, w)
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package eligibility
+
+import (
+	"testing"
+
+	couponv1 "github.com/jackgihokim/coupon-issuance-system/protos/coupon/v1"
+)
+
+func TestRule_Eval(t *testing.T) {
+	gold := &couponv1.UserAttributes{Tier: "gold", Segments: []string{"vip"}, OrderCount: 12, Country: "KR"}
+	newcomer := &couponv1.UserAttributes{NewUser: true, Country: "US"}
+
+	testCases := []struct {
+		name  string
+		rule  string
+		attrs *couponv1.UserAttributes
+		want  bool
+	}{
+		{"new user", "new_user", newcomer, true},
+		{"not a new user", "new_user", gold, false},
+		{"negation", "!new_user", gold, true},
+		{"tier at least", `tier >= "silver"`, gold, true},
+		{"tier below", `tier > "gold"`, gold, false},
+		{"tier equal", `tier == "gold"`, gold, true},
+		{"unknown user tier ranks lowest", `tier < "bronze"`, newcomer, true},
+		{"segment membership", `"vip" in segments`, gold, true},
+		{"missing segment", `"vip" in segments`, newcomer, false},
+		{"order count", "order_count >= 10", gold, true},
+		{"country", `country != "KR"`, newcomer, true},
+		{"and binds tighter than or", `new_user || tier >= "gold" && country == "US"`, newcomer, true},
+		{"parentheses", `(new_user || tier >= "gold") && country == "KR"`, newcomer, false},
+		{"literal", "true && !false", gold, true},
+		{"missing attributes", "order_count == 0 && !new_user", nil, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			rule, err := Parse(tc.rule)
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			if got := rule.Eval(tc.attrs); got != tc.want {
+				t.Errorf("Eval() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestRule_String(t *testing.T) {
+	src := `tier >= "gold"`
+	rule, err := Parse(src)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if rule.String() != src {
+		t.Errorf("String() = %q, want %q", rule.String(), src)
+	}
+}
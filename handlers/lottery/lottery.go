@@ -0,0 +1,178 @@
+package lottery
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"errors"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Lottery collects one entry per user and draws its winners once, from a seed fixed when the lottery is created.
+// Publishing the hash of the seed before the draw and the seed after it lets anyone verify the winners with Winners.
+type Lottery struct {
+	seed []byte
+
+	mu      sync.Mutex
+	entries []string
+	entered map[string]struct{}
+	// drawn tells whether the winners are drawn. winners maps each of them to the code of the coupon issued to
+	// them, which is empty until it is issued.
+	drawn   bool
+	drawnAt time.Time
+	ranked  []string
+	winners map[string]string
+}
+
+// Result is the outcome of the lottery for a user who entered it.
+type Result struct {
+	Drawn bool
+	Won   bool
+	// Code is the code of the coupon issued to the winner, or empty if it is not issued yet.
+	Code string
+}
+
+// New creates a lottery without entries and with a random seed. Returns an error if the seed could not be made.
+func New() (*Lottery, error) {
+	seed := make([]byte, 32)
+	if _, err := rand.Read(seed); err != nil {
+		return nil, err
+	}
+	return &Lottery{
+		seed:    seed,
+		entered: make(map[string]struct{}),
+		winners: make(map[string]string),
+	}, nil
+}
+
+// SeedHash returns the SHA-256 of the seed, which can be published before the draw.
+func (l *Lottery) SeedHash() []byte {
+	h := sha256.Sum256(l.seed)
+	return h[:]
+}
+
+// Seed returns the seed once the lottery is drawn, or nil before, so nobody can work out the winners in advance.
+func (l *Lottery) Seed() []byte {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if !l.drawn {
+		return nil
+	}
+	return l.seed
+}
+
+// Enter enters the user into the lottery. Returns the number of entries so far, or an error if the user ID is
+// empty, the user already entered or the lottery is drawn.
+func (l *Lottery) Enter(userId string) (uint64, error) {
+	if userId == "" {
+		return 0, errors.New("user ID is required")
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.drawn {
+		return 0, errors.New("lottery is already drawn")
+	}
+	if _, ok := l.entered[userId]; ok {
+		return 0, errors.New("user already entered the lottery")
+	}
+	l.entered[userId] = struct{}{}
+	l.entries = append(l.entries, userId)
+	return uint64(len(l.entries)), nil
+}
+
+// Entries returns the number of entries.
+func (l *Lottery) Entries() uint64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return uint64(len(l.entries))
+}
+
+// Draw draws n winners at now unless they are drawn already, and issues a coupon to each winner without one.
+// issue returns the code of the coupon issued to the user. A winner whose coupon fails to be issued is issued
+// one by the next call, as the winners never change once drawn.
+// Returns whether this call drew the winners, or the first error of issue.
+func (l *Lottery) Draw(n uint32, now time.Time, issue func(userId string) (string, error)) (bool, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	drew := false
+	if !l.drawn {
+		l.ranked = Winners(l.seed, l.entries, int(n))
+		for _, userId := range l.ranked {
+			l.winners[userId] = ""
+		}
+		l.drawn = true
+		l.drawnAt = now
+		drew = true
+	}
+
+	for _, userId := range l.ranked {
+		if l.winners[userId] != "" {
+			continue
+		}
+		code, err := issue(userId)
+		if err != nil {
+			return drew, err
+		}
+		l.winners[userId] = code
+	}
+	return drew, nil
+}
+
+// DrawnAt returns when the lottery was drawn, or the zero time if it is not.
+func (l *Lottery) DrawnAt() time.Time {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.drawnAt
+}
+
+// WinnerCount returns the number of winners, which is 0 until drawn.
+func (l *Lottery) WinnerCount() uint32 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return uint32(len(l.ranked))
+}
+
+// Result returns the outcome of the lottery for the user. Returns an error if the user did not enter it.
+func (l *Lottery) Result(userId string) (Result, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if _, ok := l.entered[userId]; !ok {
+		return Result{}, errors.New("user did not enter the lottery")
+	}
+	code, won := l.winners[userId]
+	return Result{Drawn: l.drawn, Won: won, Code: code}, nil
+}
+
+// Winners returns the n user IDs with the lowest HMAC-SHA256 keyed with the seed, from the lowest.
+// The result depends only on the seed and the set of user IDs, not on their order, so a draw can be verified.
+func Winners(seed []byte, userIds []string, n int) []string {
+	type entry struct {
+		userId string
+		score  []byte
+	}
+	entries := make([]entry, len(userIds))
+	for i, userId := range userIds {
+		mac := hmac.New(sha256.New, seed)
+		mac.Write([]byte(userId))
+		entries[i] = entry{userId: userId, score: mac.Sum(nil)}
+	}
+	slices.SortFunc(entries, func(a, b entry) int {
+		if c := bytes.Compare(a.score, b.score); c != 0 {
+			return c
+		}
+		return strings.Compare(a.userId, b.userId)
+	})
+
+	winners := make([]string, 0, min(n, len(entries)))
+	for _, e := range entries[:min(n, len(entries))] {
+		winners = append(winners, e.userId)
+	}
+	return winners
+}
@@ -0,0 +1,136 @@
+package lottery
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestLottery_Enter(t *testing.T) {
+	l, err := New()
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	testCases := []struct {
+		name    string
+		userId  string
+		want    uint64
+		wantErr bool
+	}{
+		{"first entry", "a", 1, false},
+		{"second entry", "b", 2, false},
+		{"same user again", "a", 0, true},
+		{"no user ID", "", 0, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := l.Enter(tc.userId)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("Enter() error = %v, wantErr %v", err, tc.wantErr)
+			}
+			if got != tc.want {
+				t.Errorf("Enter() = %d, want %d", got, tc.want)
+			}
+		})
+	}
+	if l.Entries() != 2 {
+		t.Errorf("Entries() = %d, want 2", l.Entries())
+	}
+}
+
+func TestLottery_Draw(t *testing.T) {
+	l, _ := New()
+	var userIds []string
+	for i := 0; i < 10; i++ {
+		userId := fmt.Sprintf("user-%d", i)
+		userIds = append(userIds, userId)
+		if _, err := l.Enter(userId); err != nil {
+			t.Fatalf("Enter() error = %v", err)
+		}
+	}
+	if l.Seed() != nil {
+		t.Errorf("Seed() is revealed before the draw")
+	}
+
+	// The first issuance fails, and the next draw issues it without drawing again
+	now := time.Date(2025, 5, 1, 10, 0, 0, 0, time.UTC)
+	var issued []string
+	fail := true
+	issue := func(userId string) (string, error) {
+		if fail {
+			fail = false
+			return "", errors.New("out of codes")
+		}
+		issued = append(issued, userId)
+		return "code-" + userId, nil
+	}
+	drew, err := l.Draw(3, now, issue)
+	if !drew || err == nil {
+		t.Fatalf("Draw() = %v, %v, want true and an error", drew, err)
+	}
+	drew, err = l.Draw(3, now.Add(time.Minute), issue)
+	if drew || err != nil {
+		t.Fatalf("Draw() again = %v, %v, want false and no error", drew, err)
+	}
+	if !l.DrawnAt().Equal(now) || l.WinnerCount() != 3 {
+		t.Errorf("DrawnAt() = %v, WinnerCount() = %d", l.DrawnAt(), l.WinnerCount())
+	}
+
+	// The revealed seed matches the published hash and reproduces the winners from the entries in any order
+	seed := l.Seed()
+	if hash := sha256.Sum256(seed); !bytes.Equal(hash[:], l.SeedHash()) {
+		t.Errorf("SeedHash() does not match the revealed seed")
+	}
+	reversed := slices.Clone(userIds)
+	slices.Reverse(reversed)
+	if winners := Winners(seed, reversed, 3); !slices.Equal(winners, issued) {
+		t.Errorf("Winners() = %v, issued to %v", winners, issued)
+	}
+
+	won := 0
+	for _, userId := range userIds {
+		r, err := l.Result(userId)
+		if err != nil {
+			t.Fatalf("Result() error = %v", err)
+		}
+		if !r.Drawn {
+			t.Errorf("Result(%s) is not drawn", userId)
+		}
+		if r.Won {
+			won++
+			if r.Code != "code-"+userId {
+				t.Errorf("Result(%s).Code = %q", userId, r.Code)
+			}
+		}
+	}
+	if won != 3 {
+		t.Errorf("%d users won, want 3", won)
+	}
+	if _, err := l.Result("stranger"); err == nil {
+		t.Errorf("Result() of a user who did not enter returned no error")
+	}
+	if _, err := l.Enter("late"); err == nil {
+		t.Errorf("Enter() after the draw returned no error")
+	}
+}
+
+func TestWinners(t *testing.T) {
+	seed := []byte("seed")
+	userIds := []string{"a", "b", "c"}
+
+	if got := Winners(seed, userIds, 5); len(got) != 3 {
+		t.Errorf("Winners() of more than the entries = %v, want all of them", got)
+	}
+	if got := Winners(seed, nil, 5); len(got) != 0 {
+		t.Errorf("Winners() without entries = %v, want none", got)
+	}
+	if a, b := Winners(seed, userIds, 2), Winners(seed, userIds, 2); !slices.Equal(a, b) {
+		t.Errorf("Winners() = %v and then %v from the same seed", a, b)
+	}
+}
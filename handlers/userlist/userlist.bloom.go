@@ -0,0 +1,78 @@
+package userlist
+
+import (
+	"errors"
+	"math"
+
+	couponv1 "github.com/jackgihokim/coupon-issuance-system/protos/coupon/v1"
+)
+
+// maxBloomBits bounds the memory of a Bloom filter to 1 GiB.
+const maxBloomBits = 1 << 33
+
+// bloomFilter is a Bloom filter sized for the expected number of users and the false positive rate.
+type bloomFilter struct {
+	settings *couponv1.BloomFilter
+	bits     []uint64
+	m        uint64 // the number of bits.
+	k        uint64 // the number of bits set per ID.
+}
+
+// newBloomFilter sizes a Bloom filter with the optimal number of bits and hash functions for the settings.
+// Returns an error if the settings are invalid or the filter would be too large.
+func newBloomFilter(settings *couponv1.BloomFilter) (*bloomFilter, error) {
+	n, p := float64(settings.ExpectedUsers), settings.FalsePositiveRate
+	if settings.ExpectedUsers == 0 {
+		return nil, errors.New("bloom filter expected users must be positive")
+	}
+	if settings.ExpectedUsers > MaxUsers {
+		return nil, errors.New("bloom filter cannot expect more users than a list can have")
+	}
+	if !(p > 0 && p < 1) {
+		return nil, errors.New("bloom filter false positive rate must be between 0 and 1")
+	}
+
+	m := math.Ceil(-n * math.Log(p) / (math.Ln2 * math.Ln2))
+	if m > maxBloomBits {
+		return nil, errors.New("bloom filter is too large")
+	}
+	k := max(1, math.Round(m/n*math.Ln2))
+
+	return &bloomFilter{
+		settings: settings,
+		bits:     make([]uint64, (uint64(m)+63)/64),
+		m:        uint64(m),
+		k:        uint64(k),
+	}, nil
+}
+
+// add sets the bits of the hash.
+func (f *bloomFilter) add(h uint64) {
+	h2 := mix(h)
+	for i := uint64(0); i < f.k; i++ {
+		bit := (h + i*h2) % f.m
+		f.bits[bit/64] |= 1 << (bit % 64)
+	}
+}
+
+// contains reports whether all the bits of the hash are set.
+func (f *bloomFilter) contains(h uint64) bool {
+	h2 := mix(h)
+	for i := uint64(0); i < f.k; i++ {
+		bit := (h + i*h2) % f.m
+		if f.bits[bit/64]&(1<<(bit%64)) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// mix derives a second, odd hash from the hash with the SplitMix64 finalizer, for double hashing.
+func mix(h uint64) uint64 {
+	h ^= h >> 30
+	h *= 0xbf58476d1ce4e5b9
+	h ^= h >> 27
+	h *= 0x94d049bb133111eb
+	h ^= h >> 31
+	return h | 1
+}
@@ -0,0 +1,73 @@
+package userlist
+
+import (
+	"fmt"
+	"testing"
+
+	couponv1 "github.com/jackgihokim/coupon-issuance-system/protos/coupon/v1"
+)
+
+func TestNewBloomFilter(t *testing.T) {
+	testCases := []struct {
+		name     string
+		settings *couponv1.BloomFilter
+		wantErr  bool
+	}{
+		{"valid filter", &couponv1.BloomFilter{ExpectedUsers: 1000, FalsePositiveRate: 0.01}, false},
+		{"no expected users", &couponv1.BloomFilter{FalsePositiveRate: 0.01}, true},
+		{"zero false positive rate", &couponv1.BloomFilter{ExpectedUsers: 1000}, true},
+		{"false positive rate of one", &couponv1.BloomFilter{ExpectedUsers: 1000, FalsePositiveRate: 1}, true},
+		{"more users than a list", &couponv1.BloomFilter{ExpectedUsers: MaxUsers + 1, FalsePositiveRate: 0.01}, true},
+		{"too large", &couponv1.BloomFilter{ExpectedUsers: MaxUsers, FalsePositiveRate: 1e-300}, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := newBloomFilter(tc.settings)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("newBloomFilter() error = %v, wantErr %v", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestList_BloomFilter(t *testing.T) {
+	const n = 50000
+	settings := &couponv1.BloomFilter{ExpectedUsers: n, FalsePositiveRate: 0.01}
+	l, err := New(settings)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	for i := 0; i < n; i++ {
+		_ = l.Add(fmt.Sprintf("user-%d", i))
+	}
+	_ = l.Add("user-0")
+	for i := 0; i < n; i++ {
+		if !l.Contains(fmt.Sprintf("user-%d", i)) {
+			t.Fatalf("Contains(user-%d) = false, want true", i)
+		}
+	}
+
+	// The Bloom filter only answers ahead of the list, so unlisted users never match
+	for i := n; i < 2*n; i++ {
+		if l.Contains(fmt.Sprintf("user-%d", i)) {
+			t.Fatalf("Contains(user-%d) = true, want false", i)
+		}
+	}
+
+	if l.Len() != n {
+		t.Errorf("Len() = %d, want %d", l.Len(), n)
+	}
+	// About 9.6 bits per user for 1% on top of the hashes
+	exact, _ := New(nil)
+	for i := 0; i < n; i++ {
+		_ = exact.Add(fmt.Sprintf("user-%d", i))
+	}
+	if extra := l.SizeBytes() - exact.SizeBytes(); extra == 0 || extra > n*10/8+8 {
+		t.Errorf("SizeBytes() = %d, want the %d bytes of the hashes and the Bloom filter", l.SizeBytes(), exact.SizeBytes())
+	}
+	if l.BloomFilter() != settings {
+		t.Errorf("BloomFilter() = %v, want %v", l.BloomFilter(), settings)
+	}
+}
@@ -0,0 +1,162 @@
+package userlist
+
+import (
+	"errors"
+	"fmt"
+	"hash/maphash"
+
+	couponv1 "github.com/jackgihokim/coupon-issuance-system/protos/coupon/v1"
+)
+
+// MaxUsers is the number of distinct user IDs a list can hold, which bounds the hashes of a list to 128 MiB.
+const MaxUsers = 10_000_000
+
+// List is a set of user IDs with constant time membership checks.
+// It keeps 64-bit hashes of the IDs instead of the IDs, at most MaxUsers of them. If a Bloom filter is configured,
+// it answers most checks of unlisted IDs before the hashes are probed, so the list never matches an unlisted user.
+// The filter speeds up the checks at the cost of its own memory on top of the hashes.
+// A list is built by a single uploader and must not be changed with Add once it is shared.
+type List struct {
+	seed  maphash.Seed
+	set   *hashSet
+	bloom *bloomFilter
+	// count is the number of distinct IDs, and limit the number it can reach.
+	count uint64
+	limit uint64
+}
+
+// New creates an empty list, with a Bloom filter of the given size in front of it if the filter is not nil.
+// Returns an error if the Bloom filter is invalid.
+func New(filter *couponv1.BloomFilter) (*List, error) {
+	l := &List{seed: maphash.MakeSeed(), set: &hashSet{}, limit: MaxUsers}
+	if filter == nil {
+		return l, nil
+	}
+
+	bloom, err := newBloomFilter(filter)
+	if err != nil {
+		return nil, err
+	}
+	l.bloom = bloom
+	return l, nil
+}
+
+// Add adds the user ID to the list. Returns an error if the ID is empty or the list is full.
+func (l *List) Add(userId string) error {
+	if userId == "" {
+		return errors.New("user ID is required")
+	}
+
+	h := maphash.String(l.seed, userId)
+	if l.set.contains(h) {
+		return nil
+	}
+	if l.count >= l.limit {
+		return fmt.Errorf("user list cannot have more than %d users", l.limit)
+	}
+	if l.bloom != nil {
+		l.bloom.add(h)
+	}
+	l.set.add(h)
+	l.count++
+	return nil
+}
+
+// Contains reports whether the user ID is in the list.
+func (l *List) Contains(userId string) bool {
+	h := maphash.String(l.seed, userId)
+	// A Bloom filter has no false negatives, so an ID it does not match is not listed
+	if l.bloom != nil && !l.bloom.contains(h) {
+		return false
+	}
+	return l.set.contains(h)
+}
+
+// Len returns the number of distinct user IDs.
+func (l *List) Len() uint64 {
+	return l.count
+}
+
+// SizeBytes returns the memory the list uses to store the user IDs, including its Bloom filter.
+func (l *List) SizeBytes() uint64 {
+	size := uint64(len(l.set.slots)) * 8
+	if l.bloom != nil {
+		size += uint64(len(l.bloom.bits)) * 8
+	}
+	return size
+}
+
+// BloomFilter returns the Bloom filter settings, or nil if the list has no Bloom filter.
+func (l *List) BloomFilter() *couponv1.BloomFilter {
+	if l.bloom == nil {
+		return nil
+	}
+	return l.bloom.settings
+}
+
+// minSlots is the initial capacity of a hash set.
+const minSlots = 1024
+
+// hashSet is an open addressing set of 64-bit hashes with linear probing. It takes about 11 to 22 bytes
+// per hash as the load factor stays between 3/8 and 3/4.
+// Zero marks an empty slot, so a zero hash is stored as one.
+type hashSet struct {
+	slots []uint64
+	count int
+}
+
+// add adds the hash to the set. Returns false if the set already has it.
+func (s *hashSet) add(h uint64) bool {
+	if h == 0 {
+		h = 1
+	}
+	// Keep the load factor at most 3/4, so probes stay short
+	if (s.count+1)*4 > len(s.slots)*3 {
+		s.grow()
+	}
+
+	mask := uint64(len(s.slots) - 1)
+	for i := h & mask; ; i = (i + 1) & mask {
+		switch s.slots[i] {
+		case 0:
+			s.slots[i] = h
+			s.count++
+			return true
+		case h:
+			return false
+		}
+	}
+}
+
+// contains reports whether the set has the hash.
+func (s *hashSet) contains(h uint64) bool {
+	if h == 0 {
+		h = 1
+	}
+	if len(s.slots) == 0 {
+		return false
+	}
+
+	mask := uint64(len(s.slots) - 1)
+	for i := h & mask; ; i = (i + 1) & mask {
+		switch s.slots[i] {
+		case 0:
+			return false
+		case h:
+			return true
+		}
+	}
+}
+
+// grow doubles the slots and rehashes the set.
+func (s *hashSet) grow() {
+	old := s.slots
+	size := max(len(old)*2, minSlots)
+	s.slots = make([]uint64, size)
+	s.count = 0
+	for _, h := range old {
+		if h != 0 {
+			s.add(h)
+		}
+	}
+}
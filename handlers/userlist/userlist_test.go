@@ -0,0 +1,76 @@
+package userlist
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestList(t *testing.T) {
+	l, err := New(nil)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	const n = 100000
+	for i := 0; i < n; i++ {
+		if err := l.Add(fmt.Sprintf("user-%d", i)); err != nil {
+			t.Fatalf("Add() error = %v", err)
+		}
+	}
+	_ = l.Add("user-0")
+
+	for i := 0; i < n; i++ {
+		if !l.Contains(fmt.Sprintf("user-%d", i)) {
+			t.Fatalf("Contains(user-%d) = false, want true", i)
+		}
+	}
+	for i := n; i < 2*n; i++ {
+		if l.Contains(fmt.Sprintf("user-%d", i)) {
+			t.Fatalf("Contains(user-%d) = true, want false", i)
+		}
+	}
+
+	if l.Len() != n {
+		t.Errorf("Len() = %d, want %d (duplicates count once)", l.Len(), n)
+	}
+	if l.SizeBytes() > 22*n {
+		t.Errorf("SizeBytes() = %d, want at most 22 bytes per user", l.SizeBytes())
+	}
+	if err := l.Add(""); err == nil {
+		t.Errorf("Add() with an empty ID expected an error")
+	}
+}
+
+func TestList_Full(t *testing.T) {
+	l, _ := New(nil)
+	l.limit = 2
+
+	for _, userId := range []string{"user-1", "user-2", "user-1"} {
+		if err := l.Add(userId); err != nil {
+			t.Fatalf("Add(%s) error = %v", userId, err)
+		}
+	}
+	if err := l.Add("user-3"); err == nil {
+		t.Errorf("Add() to a full list expected an error")
+	}
+	if l.Len() != 2 || l.Contains("user-3") {
+		t.Errorf("a full list should keep its users only, Len() = %d", l.Len())
+	}
+}
+
+func TestList_Empty(t *testing.T) {
+	l, _ := New(nil)
+	if l.Contains("user-1") {
+		t.Errorf("Contains() on an empty list = true")
+	}
+}
+
+func TestHashSet_ZeroHash(t *testing.T) {
+	s := &hashSet{}
+	if !s.add(0) || s.add(0) {
+		t.Fatalf("add(0) should add the zero hash once")
+	}
+	if !s.contains(0) {
+		t.Errorf("contains(0) = false, want true")
+	}
+}
@@ -0,0 +1,254 @@
+package waitingroom
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"sync"
+	"time"
+
+	couponv1 "github.com/jackgihokim/coupon-issuance-system/protos/coupon/v1"
+)
+
+const (
+	// maxAdmissionsPerSecond keeps the interval between admissions at least a microsecond.
+	maxAdmissionsPerSecond = 1_000_000
+	// DefaultTokenTTL is how long an admission token stays valid unless the waiting room sets it.
+	DefaultTokenTTL = 5 * time.Minute
+	// DefaultMaxWaiting is how many tickets can wait at once unless the waiting room sets it.
+	DefaultMaxWaiting = 100_000
+)
+
+// Validate checks that the waiting room has an admission rate in range and a positive token TTL if set.
+func Validate(w *couponv1.WaitingRoom) error {
+	if w.AdmissionsPerSecond == 0 || w.AdmissionsPerSecond > maxAdmissionsPerSecond {
+		return errors.New("admissions per second must be between 1 and 1000000")
+	}
+	if w.TokenTtl != nil {
+		if err := w.TokenTtl.CheckValid(); err != nil {
+			return err
+		}
+		if w.TokenTtl.AsDuration() <= 0 {
+			return errors.New("admission token TTL must be positive")
+		}
+	}
+	return nil
+}
+
+// TokenTTL returns how long the admission tokens of the waiting room stay valid.
+func TokenTTL(w *couponv1.WaitingRoom) time.Duration {
+	if w.GetTokenTtl() == nil {
+		return DefaultTokenTTL
+	}
+	return w.TokenTtl.AsDuration()
+}
+
+// MaxWaiting returns how many tickets can wait in the waiting room at once.
+func MaxWaiting(w *couponv1.WaitingRoom) uint64 {
+	if w.GetMaxWaiting() == 0 {
+		return DefaultMaxWaiting
+	}
+	return uint64(w.MaxWaiting)
+}
+
+// Queue is a waiting room which admits tickets in the order they entered at a fixed rate.
+// Admission is worked out lazily from the time whenever the queue is accessed, so it needs no background worker.
+// Tickets are forgotten once their admission expires, so the queue only keeps the waiting and admitted ones.
+type Queue struct {
+	interval   time.Duration // the time between two admissions.
+	ttl        time.Duration // how long an admission stays valid.
+	maxWaiting uint64
+
+	mu      sync.Mutex
+	tickets map[string]*ticket
+	order   []*ticket         // the tickets by sequence number, from the sequence number first on.
+	first   uint64            // the sequence number of the first ticket kept in order.
+	users   map[string]string // the ticket of each user.
+	// entered is the number of tickets which entered, and admitted the number of them which were admitted.
+	// Tickets with a sequence number below admitted are admitted.
+	entered  uint64
+	admitted uint64
+	// lastAdmission is when the latest ticket was admitted, or when the queue became empty.
+	lastAdmission time.Time
+}
+
+type ticket struct {
+	id         string
+	seq        uint64
+	userId     string
+	admittedAt time.Time
+	claimed    bool
+}
+
+// Status is the place of a ticket in the queue.
+type Status struct {
+	Ticket string
+	UserId string
+	// Position is 1 for the next ticket to be admitted, and 0 once admitted.
+	Position   uint64
+	AdmittedAt time.Time
+}
+
+// Admitted reports whether the ticket is admitted.
+func (s Status) Admitted() bool {
+	return s.Position == 0
+}
+
+// NewQueue creates an empty queue for the waiting room, which must be valid.
+func NewQueue(w *couponv1.WaitingRoom, now time.Time) *Queue {
+	interval := time.Second / time.Duration(w.AdmissionsPerSecond)
+	return &Queue{
+		interval:   interval,
+		ttl:        TokenTTL(w),
+		maxWaiting: MaxWaiting(w),
+		tickets:    make(map[string]*ticket),
+		users:      make(map[string]string),
+		// The first ticket is admitted at once, as if the queue had been idle
+		lastAdmission: now.Add(-interval),
+	}
+}
+
+// Enter puts a new ticket for the user at the end of the queue. A user who already has a ticket gets it back instead,
+// until its admission expires.
+// Returns the status of the ticket or an error if the user ID is empty, the queue is full or no ticket could be created.
+func (q *Queue) Enter(userId string, now time.Time) (Status, error) {
+	if userId == "" {
+		return Status{}, errors.New("user ID is required")
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.admit(now)
+
+	if id, ok := q.users[userId]; ok {
+		return q.status(q.tickets[id]), nil
+	}
+	if q.entered-q.admitted >= q.maxWaiting {
+		return Status{}, errors.New("waiting room is full")
+	}
+
+	id, err := newTicketId()
+	if err != nil {
+		return Status{}, err
+	}
+	t := &ticket{id: id, seq: q.entered, userId: userId}
+	q.tickets[id] = t
+	q.order = append(q.order, t)
+	q.users[userId] = id
+	q.entered++
+
+	// The ticket may be admitted at once if nobody was waiting
+	q.admit(now)
+	return q.status(t), nil
+}
+
+// Status returns the place of the ticket at now. Returns an error if the ticket is unknown.
+func (q *Queue) Status(id string, now time.Time) (Status, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.admit(now)
+
+	t, ok := q.tickets[id]
+	if !ok {
+		return Status{}, errors.New("unknown ticket")
+	}
+	return q.status(t), nil
+}
+
+// NextAdmissionAt returns when the next ticket is admitted, if any is waiting.
+func (q *Queue) NextAdmissionAt() time.Time {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.lastAdmission.Add(q.interval)
+}
+
+// Claim marks the admitted ticket as used, so its admission can issue a single coupon.
+// Returns an error if the ticket is unknown, not admitted or already used.
+func (q *Queue) Claim(id string, now time.Time) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.admit(now)
+
+	t, ok := q.tickets[id]
+	switch {
+	case !ok:
+		return errors.New("unknown ticket")
+	case t.seq >= q.admitted:
+		return errors.New("ticket is not admitted yet")
+	case t.claimed:
+		return errors.New("admission is already used")
+	}
+	t.claimed = true
+	return nil
+}
+
+// Unclaim gives back the admission of a ticket whose coupon could not be issued, so it can be used again.
+func (q *Queue) Unclaim(id string) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if t, ok := q.tickets[id]; ok {
+		t.claimed = false
+	}
+}
+
+// admit admits the waiting tickets which are due at now, one per interval, and forgets the tickets whose admission
+// expired. The caller must hold mu.
+func (q *Queue) admit(now time.Time) {
+	defer q.evict(now)
+	if q.admitted == q.entered {
+		// Nobody is waiting, so the time passes without saving admissions up for a burst
+		if now.Sub(q.lastAdmission) > q.interval {
+			q.lastAdmission = now.Add(-q.interval)
+		}
+		return
+	}
+
+	if !now.After(q.lastAdmission) {
+		return
+	}
+	due := uint64(now.Sub(q.lastAdmission) / q.interval)
+	n := min(due, q.entered-q.admitted)
+	if n == 0 {
+		return
+	}
+
+	for _, t := range q.order[q.admitted-q.first : q.admitted-q.first+n] {
+		q.lastAdmission = q.lastAdmission.Add(q.interval)
+		t.admittedAt = q.lastAdmission
+	}
+	q.admitted += n
+}
+
+// evict forgets the tickets whose admission expired at now. They are admitted in order, so they are the first ones.
+// The caller must hold mu.
+func (q *Queue) evict(now time.Time) {
+	for q.first < q.admitted {
+		t := q.order[0]
+		if !now.After(t.admittedAt.Add(q.ttl)) {
+			return
+		}
+		delete(q.tickets, t.id)
+		delete(q.users, t.userId)
+		q.order[0] = nil
+		q.order = q.order[1:]
+		q.first++
+	}
+}
+
+// status returns the status of the ticket. The caller must hold mu.
+func (q *Queue) status(t *ticket) Status {
+	s := Status{Ticket: t.id, UserId: t.userId, AdmittedAt: t.admittedAt}
+	if t.seq >= q.admitted {
+		s.Position = t.seq - q.admitted + 1
+	}
+	return s
+}
+
+// newTicketId returns a random ticket ID which cannot be guessed.
+func newTicketId() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
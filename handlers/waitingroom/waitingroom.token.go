@@ -0,0 +1,92 @@
+package waitingroom
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Admission is what an admission token grants: issuing a coupon of the campaign with the ticket until it expires.
+type Admission struct {
+	CampaignId uint32
+	Ticket     string
+	UserId     string
+	ExpireAt   time.Time
+}
+
+// Signer signs admission tokens with HMAC-SHA256, so clients cannot forge or alter them.
+// Servers which share the key accept each other's tokens.
+type Signer struct {
+	key []byte
+}
+
+// NewSigner creates a signer with the key. Returns an error if the key is shorter than 32 bytes.
+func NewSigner(key []byte) (*Signer, error) {
+	if len(key) < sha256.Size {
+		return nil, fmt.Errorf("admission key must be at least %d bytes", sha256.Size)
+	}
+	return &Signer{key: key}, nil
+}
+
+// Sign returns the admission token of the admission.
+func (s *Signer) Sign(a Admission) string {
+	// The user ID goes last, so it can contain the separator
+	payload := strings.Join([]string{
+		strconv.FormatUint(uint64(a.CampaignId), 10),
+		a.Ticket,
+		strconv.FormatInt(a.ExpireAt.Unix(), 10),
+		a.UserId,
+	}, "|")
+	encoded := base64.RawURLEncoding.EncodeToString([]byte(payload))
+	return encoded + "." + base64.RawURLEncoding.EncodeToString(s.mac(encoded))
+}
+
+// Verify checks the signature and expiry of the admission token at now.
+// Returns the admission or an error if the token is malformed, forged or expired.
+func (s *Signer) Verify(token string, now time.Time) (Admission, error) {
+	encoded, signature, ok := strings.Cut(token, ".")
+	if !ok {
+		return Admission{}, errors.New("invalid admission token")
+	}
+	mac, err := base64.RawURLEncoding.DecodeString(signature)
+	if err != nil || !hmac.Equal(mac, s.mac(encoded)) {
+		return Admission{}, errors.New("invalid admission token")
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return Admission{}, errors.New("invalid admission token")
+	}
+	fields := strings.SplitN(string(payload), "|", 4)
+	if len(fields) != 4 {
+		return Admission{}, errors.New("invalid admission token")
+	}
+	campaignId, err1 := strconv.ParseUint(fields[0], 10, 32)
+	expireAt, err2 := strconv.ParseInt(fields[2], 10, 64)
+	if err1 != nil || err2 != nil {
+		return Admission{}, errors.New("invalid admission token")
+	}
+
+	a := Admission{
+		CampaignId: uint32(campaignId),
+		Ticket:     fields[1],
+		UserId:     fields[3],
+		ExpireAt:   time.Unix(expireAt, 0).UTC(),
+	}
+	if !now.Before(a.ExpireAt) {
+		return Admission{}, errors.New("admission token is expired")
+	}
+	return a, nil
+}
+
+// mac returns the HMAC of the encoded payload.
+func (s *Signer) mac(encoded string) []byte {
+	h := hmac.New(sha256.New, s.key)
+	h.Write([]byte(encoded))
+	return h.Sum(nil)
+}
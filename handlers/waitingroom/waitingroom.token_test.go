@@ -0,0 +1,55 @@
+package waitingroom
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSigner(t *testing.T) {
+	now := time.Date(2025, 5, 1, 10, 0, 0, 0, time.UTC)
+	signer, err := NewSigner(bytes.Repeat([]byte("k"), 32))
+	if err != nil {
+		t.Fatalf("NewSigner() error = %v", err)
+	}
+	other, _ := NewSigner(bytes.Repeat([]byte("o"), 32))
+
+	admission := Admission{CampaignId: 7, Ticket: "abc", UserId: "user|1", ExpireAt: now.Add(time.Minute)}
+	token := signer.Sign(admission)
+
+	got, err := signer.Verify(token, now)
+	if err != nil {
+		t.Fatalf("Verify() error = %v", err)
+	}
+	if got != admission {
+		t.Errorf("Verify() = %+v, want %+v", got, admission)
+	}
+
+	encoded, signature, _ := strings.Cut(token, ".")
+	testCases := []struct {
+		name    string
+		signer  *Signer
+		token   string
+		now     time.Time
+		wantErr string
+	}{
+		{"expired", signer, token, now.Add(time.Minute), "admission token is expired"},
+		{"signed with another key", other, token, now, "invalid admission token"},
+		{"altered payload", signer, "x" + encoded + "." + signature, now, "invalid admission token"},
+		{"malformed", signer, "token", now, "invalid admission token"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := tc.signer.Verify(tc.token, tc.now)
+			if err == nil || err.Error() != tc.wantErr {
+				t.Errorf("Verify() error = %v, want %s", err, tc.wantErr)
+			}
+		})
+	}
+
+	if _, err := NewSigner([]byte("short")); err == nil {
+		t.Errorf("NewSigner() with a short key expected an error")
+	}
+}
@@ -0,0 +1,153 @@
+package waitingroom
+
+import (
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	couponv1 "github.com/jackgihokim/coupon-issuance-system/protos/coupon/v1"
+)
+
+func TestValidate(t *testing.T) {
+	testCases := []struct {
+		name    string
+		room    *couponv1.WaitingRoom
+		wantErr bool
+	}{
+		{"valid waiting room", &couponv1.WaitingRoom{AdmissionsPerSecond: 100}, false},
+		{"with a token TTL", &couponv1.WaitingRoom{AdmissionsPerSecond: 100, TokenTtl: durationpb.New(time.Minute)}, false},
+		{"no admission rate", &couponv1.WaitingRoom{}, true},
+		{"too fast", &couponv1.WaitingRoom{AdmissionsPerSecond: 2_000_000}, true},
+		{"negative token TTL", &couponv1.WaitingRoom{AdmissionsPerSecond: 100, TokenTtl: durationpb.New(-time.Minute)}, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := Validate(tc.room)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestQueue_Admission(t *testing.T) {
+	now := time.Date(2025, 5, 1, 10, 0, 0, 0, time.UTC)
+	q := NewQueue(&couponv1.WaitingRoom{AdmissionsPerSecond: 2}, now.Add(-time.Hour))
+
+	// The first ticket enters an idle queue and is admitted at once, while the others wait in order
+	var tickets []Status
+	for _, user := range []string{"a", "b", "c", "d"} {
+		s, err := q.Enter(user, now)
+		if err != nil {
+			t.Fatalf("Enter() error = %v", err)
+		}
+		tickets = append(tickets, s)
+	}
+	for i, want := range []uint64{0, 1, 2, 3} {
+		if tickets[i].Position != want {
+			t.Errorf("ticket %d position = %d, want %d", i, tickets[i].Position, want)
+		}
+	}
+
+	again, _ := q.Enter("b", now)
+	if again.Ticket != tickets[1].Ticket {
+		t.Errorf("Enter() again returned another ticket")
+	}
+
+	// Two tickets per second are admitted, one every 500ms
+	s, _ := q.Status(tickets[2].Ticket, now.Add(999*time.Millisecond))
+	if s.Position != 1 {
+		t.Errorf("ticket 2 position = %d, want 1", s.Position)
+	}
+	s, _ = q.Status(tickets[2].Ticket, now.Add(time.Second))
+	if !s.Admitted() || !s.AdmittedAt.Equal(now.Add(time.Second)) {
+		t.Errorf("ticket 2 status = %+v, want admitted a second later", s)
+	}
+	s, _ = q.Status(tickets[3].Ticket, now.Add(time.Second))
+	if s.Position != 1 {
+		t.Errorf("ticket 3 position = %d, want 1", s.Position)
+	}
+	if next := q.NextAdmissionAt(); !next.Equal(now.Add(1500 * time.Millisecond)) {
+		t.Errorf("NextAdmissionAt() = %v, want 1.5s later", next)
+	}
+
+	if _, err := q.Status("unknown", now); err == nil {
+		t.Errorf("Status() of an unknown ticket expected an error")
+	}
+}
+
+func TestQueue_Claim(t *testing.T) {
+	now := time.Date(2025, 5, 1, 10, 0, 0, 0, time.UTC)
+	q := NewQueue(&couponv1.WaitingRoom{AdmissionsPerSecond: 1}, now.Add(-time.Hour))
+	first, _ := q.Enter("a", now)
+	second, _ := q.Enter("b", now)
+
+	if err := q.Claim(second.Ticket, now); err == nil || err.Error() != "ticket is not admitted yet" {
+		t.Errorf("Claim() of a waiting ticket error = %v", err)
+	}
+	if err := q.Claim(first.Ticket, now); err != nil {
+		t.Fatalf("Claim() error = %v", err)
+	}
+	if err := q.Claim(first.Ticket, now); err == nil || err.Error() != "admission is already used" {
+		t.Errorf("Claim() twice error = %v", err)
+	}
+
+	q.Unclaim(first.Ticket)
+	if err := q.Claim(first.Ticket, now); err != nil {
+		t.Errorf("Claim() after Unclaim() error = %v", err)
+	}
+}
+
+func TestQueue_Enter(t *testing.T) {
+	now := time.Date(2025, 5, 1, 10, 0, 0, 0, time.UTC)
+	q := NewQueue(&couponv1.WaitingRoom{AdmissionsPerSecond: 1, MaxWaiting: 2}, now.Add(-time.Hour))
+
+	if _, err := q.Enter("", now); err == nil || err.Error() != "user ID is required" {
+		t.Errorf("Enter() without a user ID error = %v", err)
+	}
+
+	// The first ticket is admitted at once, so two more can wait
+	for _, user := range []string{"a", "b", "c"} {
+		if _, err := q.Enter(user, now); err != nil {
+			t.Fatalf("Enter(%s) error = %v", user, err)
+		}
+	}
+	if _, err := q.Enter("d", now); err == nil || err.Error() != "waiting room is full" {
+		t.Errorf("Enter() into a full queue error = %v", err)
+	}
+	if _, err := q.Enter("b", now); err != nil {
+		t.Errorf("Enter() again into a full queue error = %v", err)
+	}
+
+	// A place frees up once the next ticket is admitted
+	if _, err := q.Enter("d", now.Add(time.Second)); err != nil {
+		t.Errorf("Enter() after an admission error = %v", err)
+	}
+}
+
+func TestQueue_Evict(t *testing.T) {
+	now := time.Date(2025, 5, 1, 10, 0, 0, 0, time.UTC)
+	q := NewQueue(&couponv1.WaitingRoom{AdmissionsPerSecond: 1, TokenTtl: durationpb.New(time.Minute)}, now.Add(-time.Hour))
+	first, _ := q.Enter("a", now)
+	second, _ := q.Enter("b", now)
+
+	// The first admission expires a minute after it, while the second one is still valid
+	later := now.Add(time.Minute + time.Millisecond)
+	if _, err := q.Status(first.Ticket, later); err == nil {
+		t.Errorf("Status() of an expired ticket expected an error")
+	}
+	if s, err := q.Status(second.Ticket, later); err != nil || !s.Admitted() {
+		t.Errorf("Status() of the second ticket = %+v, %v, want admitted", s, err)
+	}
+	if len(q.tickets) != 1 || len(q.order) != 1 || len(q.users) != 1 {
+		t.Errorf("queue keeps %d tickets, %d in order and %d users, want 1", len(q.tickets), len(q.order), len(q.users))
+	}
+
+	// The user of an expired ticket enters again with a new one
+	again, err := q.Enter("a", later)
+	if err != nil || again.Ticket == first.Ticket {
+		t.Errorf("Enter() after expiry = %+v, %v, want a new ticket", again, err)
+	}
+}
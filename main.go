@@ -1,6 +1,8 @@
 package main
 
 import (
+	_ "time/tzdata" // time zones of expiry policies must resolve without the host's zoneinfo.
+
 	"github.com/jackgihokim/coupon-issuance-system/server"
 )
 
@@ -9,6 +9,7 @@ package couponv1
 import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
 	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
@@ -22,30 +23,7206 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type CouponStatus int32
+
+const (
+	CouponStatus_COUPON_STATUS_UNSPECIFIED CouponStatus = 0
+	CouponStatus_COUPON_STATUS_ACTIVE      CouponStatus = 1
+	CouponStatus_COUPON_STATUS_REDEEMED    CouponStatus = 2
+	CouponStatus_COUPON_STATUS_REVOKED     CouponStatus = 3
+	CouponStatus_COUPON_STATUS_RESERVED    CouponStatus = 4 // held for a checkout until the reservation is committed, released or expires.
+)
+
+// Enum value maps for CouponStatus.
+var (
+	CouponStatus_name = map[int32]string{
+		0: "COUPON_STATUS_UNSPECIFIED",
+		1: "COUPON_STATUS_ACTIVE",
+		2: "COUPON_STATUS_REDEEMED",
+		3: "COUPON_STATUS_REVOKED",
+		4: "COUPON_STATUS_RESERVED",
+	}
+	CouponStatus_value = map[string]int32{
+		"COUPON_STATUS_UNSPECIFIED": 0,
+		"COUPON_STATUS_ACTIVE":      1,
+		"COUPON_STATUS_REDEEMED":    2,
+		"COUPON_STATUS_REVOKED":     3,
+		"COUPON_STATUS_RESERVED":    4,
+	}
+)
+
+func (x CouponStatus) Enum() *CouponStatus {
+	p := new(CouponStatus)
+	*p = x
+	return p
+}
+
+func (x CouponStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (CouponStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_protos_coupon_v1_coupon_proto_enumTypes[0].Descriptor()
+}
+
+func (CouponStatus) Type() protoreflect.EnumType {
+	return &file_protos_coupon_v1_coupon_proto_enumTypes[0]
+}
+
+func (x CouponStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use CouponStatus.Descriptor instead.
+func (CouponStatus) EnumDescriptor() ([]byte, []int) {
+	return file_protos_coupon_v1_coupon_proto_rawDescGZIP(), []int{0}
+}
+
+// ValidationReason explains why a coupon code is not valid. UNSPECIFIED means the code is valid.
+type ValidationReason int32
+
+const (
+	ValidationReason_VALIDATION_REASON_UNSPECIFIED                ValidationReason = 0
+	ValidationReason_VALIDATION_REASON_UNKNOWN_CODE               ValidationReason = 1
+	ValidationReason_VALIDATION_REASON_EXPIRED                    ValidationReason = 2
+	ValidationReason_VALIDATION_REASON_REVOKED                    ValidationReason = 3
+	ValidationReason_VALIDATION_REASON_REDEEMED                   ValidationReason = 4
+	ValidationReason_VALIDATION_REASON_CAMPAIGN_ENDED             ValidationReason = 5 // the campaign was closed early, while coupons outlive its natural end.
+	ValidationReason_VALIDATION_REASON_CHANNEL_NOT_ALLOWED        ValidationReason = 6
+	ValidationReason_VALIDATION_REASON_PAYMENT_METHOD_NOT_ALLOWED ValidationReason = 7
+	ValidationReason_VALIDATION_REASON_RESERVED                   ValidationReason = 8
+)
+
+// Enum value maps for ValidationReason.
+var (
+	ValidationReason_name = map[int32]string{
+		0: "VALIDATION_REASON_UNSPECIFIED",
+		1: "VALIDATION_REASON_UNKNOWN_CODE",
+		2: "VALIDATION_REASON_EXPIRED",
+		3: "VALIDATION_REASON_REVOKED",
+		4: "VALIDATION_REASON_REDEEMED",
+		5: "VALIDATION_REASON_CAMPAIGN_ENDED",
+		6: "VALIDATION_REASON_CHANNEL_NOT_ALLOWED",
+		7: "VALIDATION_REASON_PAYMENT_METHOD_NOT_ALLOWED",
+		8: "VALIDATION_REASON_RESERVED",
+	}
+	ValidationReason_value = map[string]int32{
+		"VALIDATION_REASON_UNSPECIFIED":                0,
+		"VALIDATION_REASON_UNKNOWN_CODE":               1,
+		"VALIDATION_REASON_EXPIRED":                    2,
+		"VALIDATION_REASON_REVOKED":                    3,
+		"VALIDATION_REASON_REDEEMED":                   4,
+		"VALIDATION_REASON_CAMPAIGN_ENDED":             5,
+		"VALIDATION_REASON_CHANNEL_NOT_ALLOWED":        6,
+		"VALIDATION_REASON_PAYMENT_METHOD_NOT_ALLOWED": 7,
+		"VALIDATION_REASON_RESERVED":                   8,
+	}
+)
+
+func (x ValidationReason) Enum() *ValidationReason {
+	p := new(ValidationReason)
+	*p = x
+	return p
+}
+
+func (x ValidationReason) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ValidationReason) Descriptor() protoreflect.EnumDescriptor {
+	return file_protos_coupon_v1_coupon_proto_enumTypes[1].Descriptor()
+}
+
+func (ValidationReason) Type() protoreflect.EnumType {
+	return &file_protos_coupon_v1_coupon_proto_enumTypes[1]
+}
+
+func (x ValidationReason) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ValidationReason.Descriptor instead.
+func (ValidationReason) EnumDescriptor() ([]byte, []int) {
+	return file_protos_coupon_v1_coupon_proto_rawDescGZIP(), []int{1}
+}
+
+type Channel int32
+
+const (
+	Channel_CHANNEL_UNSPECIFIED Channel = 0
+	Channel_CHANNEL_WEB         Channel = 1
+	Channel_CHANNEL_APP         Channel = 2
+	Channel_CHANNEL_STORE       Channel = 3
+)
+
+// Enum value maps for Channel.
+var (
+	Channel_name = map[int32]string{
+		0: "CHANNEL_UNSPECIFIED",
+		1: "CHANNEL_WEB",
+		2: "CHANNEL_APP",
+		3: "CHANNEL_STORE",
+	}
+	Channel_value = map[string]int32{
+		"CHANNEL_UNSPECIFIED": 0,
+		"CHANNEL_WEB":         1,
+		"CHANNEL_APP":         2,
+		"CHANNEL_STORE":       3,
+	}
+)
+
+func (x Channel) Enum() *Channel {
+	p := new(Channel)
+	*p = x
+	return p
+}
+
+func (x Channel) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Channel) Descriptor() protoreflect.EnumDescriptor {
+	return file_protos_coupon_v1_coupon_proto_enumTypes[2].Descriptor()
+}
+
+func (Channel) Type() protoreflect.EnumType {
+	return &file_protos_coupon_v1_coupon_proto_enumTypes[2]
+}
+
+func (x Channel) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Channel.Descriptor instead.
+func (Channel) EnumDescriptor() ([]byte, []int) {
+	return file_protos_coupon_v1_coupon_proto_rawDescGZIP(), []int{2}
+}
+
+// CampaignState is the lifecycle state of a campaign. Draft, paused and closed campaigns are set so by operators,
+// while the others follow from the campaign period and the remaining coupons.
+type CampaignState int32
+
+const (
+	CampaignState_CAMPAIGN_STATE_UNSPECIFIED CampaignState = 0
+	CampaignState_CAMPAIGN_STATE_DRAFT       CampaignState = 1 // not published yet.
+	CampaignState_CAMPAIGN_STATE_SCHEDULED   CampaignState = 2 // published, but not started yet.
+	CampaignState_CAMPAIGN_STATE_ACTIVE      CampaignState = 3
+	CampaignState_CAMPAIGN_STATE_PAUSED      CampaignState = 4
+	CampaignState_CAMPAIGN_STATE_SOLD_OUT    CampaignState = 5
+	CampaignState_CAMPAIGN_STATE_ENDED       CampaignState = 6 // over at end_at, or closed early after it started.
+	CampaignState_CAMPAIGN_STATE_CANCELLED   CampaignState = 7 // closed before it started.
+)
+
+// Enum value maps for CampaignState.
+var (
+	CampaignState_name = map[int32]string{
+		0: "CAMPAIGN_STATE_UNSPECIFIED",
+		1: "CAMPAIGN_STATE_DRAFT",
+		2: "CAMPAIGN_STATE_SCHEDULED",
+		3: "CAMPAIGN_STATE_ACTIVE",
+		4: "CAMPAIGN_STATE_PAUSED",
+		5: "CAMPAIGN_STATE_SOLD_OUT",
+		6: "CAMPAIGN_STATE_ENDED",
+		7: "CAMPAIGN_STATE_CANCELLED",
+	}
+	CampaignState_value = map[string]int32{
+		"CAMPAIGN_STATE_UNSPECIFIED": 0,
+		"CAMPAIGN_STATE_DRAFT":       1,
+		"CAMPAIGN_STATE_SCHEDULED":   2,
+		"CAMPAIGN_STATE_ACTIVE":      3,
+		"CAMPAIGN_STATE_PAUSED":      4,
+		"CAMPAIGN_STATE_SOLD_OUT":    5,
+		"CAMPAIGN_STATE_ENDED":       6,
+		"CAMPAIGN_STATE_CANCELLED":   7,
+	}
+)
+
+func (x CampaignState) Enum() *CampaignState {
+	p := new(CampaignState)
+	*p = x
+	return p
+}
+
+func (x CampaignState) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (CampaignState) Descriptor() protoreflect.EnumDescriptor {
+	return file_protos_coupon_v1_coupon_proto_enumTypes[3].Descriptor()
+}
+
+func (CampaignState) Type() protoreflect.EnumType {
+	return &file_protos_coupon_v1_coupon_proto_enumTypes[3]
+}
+
+func (x CampaignState) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use CampaignState.Descriptor instead.
+func (CampaignState) EnumDescriptor() ([]byte, []int) {
+	return file_protos_coupon_v1_coupon_proto_rawDescGZIP(), []int{3}
+}
+
+type CouponEventType int32
+
+const (
+	CouponEventType_COUPON_EVENT_TYPE_UNSPECIFIED         CouponEventType = 0
+	CouponEventType_COUPON_EVENT_TYPE_REDEMPTION_REVERSED CouponEventType = 1
+)
+
+// Enum value maps for CouponEventType.
+var (
+	CouponEventType_name = map[int32]string{
+		0: "COUPON_EVENT_TYPE_UNSPECIFIED",
+		1: "COUPON_EVENT_TYPE_REDEMPTION_REVERSED",
+	}
+	CouponEventType_value = map[string]int32{
+		"COUPON_EVENT_TYPE_UNSPECIFIED":         0,
+		"COUPON_EVENT_TYPE_REDEMPTION_REVERSED": 1,
+	}
+)
+
+func (x CouponEventType) Enum() *CouponEventType {
+	p := new(CouponEventType)
+	*p = x
+	return p
+}
+
+func (x CouponEventType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (CouponEventType) Descriptor() protoreflect.EnumDescriptor {
+	return file_protos_coupon_v1_coupon_proto_enumTypes[4].Descriptor()
+}
+
+func (CouponEventType) Type() protoreflect.EnumType {
+	return &file_protos_coupon_v1_coupon_proto_enumTypes[4]
+}
+
+func (x CouponEventType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use CouponEventType.Descriptor instead.
+func (CouponEventType) EnumDescriptor() ([]byte, []int) {
+	return file_protos_coupon_v1_coupon_proto_rawDescGZIP(), []int{4}
+}
+
+// RestorePolicy decides whether reversing a redemption, e.g. for a refunded order, makes the coupon usable again
+// or credits a stored-value coupon back.
+type RestorePolicy int32
+
+const (
+	RestorePolicy_RESTORE_POLICY_UNSPECIFIED    RestorePolicy = 0 // the same as RESTORE_POLICY_IF_NOT_EXPIRED.
+	RestorePolicy_RESTORE_POLICY_ALWAYS         RestorePolicy = 1 // restores an expired coupon too, with the validity it had left when redeemed.
+	RestorePolicy_RESTORE_POLICY_IF_NOT_EXPIRED RestorePolicy = 2
+	RestorePolicy_RESTORE_POLICY_NEVER          RestorePolicy = 3
+)
+
+// Enum value maps for RestorePolicy.
+var (
+	RestorePolicy_name = map[int32]string{
+		0: "RESTORE_POLICY_UNSPECIFIED",
+		1: "RESTORE_POLICY_ALWAYS",
+		2: "RESTORE_POLICY_IF_NOT_EXPIRED",
+		3: "RESTORE_POLICY_NEVER",
+	}
+	RestorePolicy_value = map[string]int32{
+		"RESTORE_POLICY_UNSPECIFIED":    0,
+		"RESTORE_POLICY_ALWAYS":         1,
+		"RESTORE_POLICY_IF_NOT_EXPIRED": 2,
+		"RESTORE_POLICY_NEVER":          3,
+	}
+)
+
+func (x RestorePolicy) Enum() *RestorePolicy {
+	p := new(RestorePolicy)
+	*p = x
+	return p
+}
+
+func (x RestorePolicy) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (RestorePolicy) Descriptor() protoreflect.EnumDescriptor {
+	return file_protos_coupon_v1_coupon_proto_enumTypes[5].Descriptor()
+}
+
+func (RestorePolicy) Type() protoreflect.EnumType {
+	return &file_protos_coupon_v1_coupon_proto_enumTypes[5]
+}
+
+func (x RestorePolicy) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use RestorePolicy.Descriptor instead.
+func (RestorePolicy) EnumDescriptor() ([]byte, []int) {
+	return file_protos_coupon_v1_coupon_proto_rawDescGZIP(), []int{5}
+}
+
+type LedgerEntryType int32
+
+const (
+	LedgerEntryType_LEDGER_ENTRY_TYPE_UNSPECIFIED LedgerEntryType = 0
+	LedgerEntryType_LEDGER_ENTRY_TYPE_REDEMPTION  LedgerEntryType = 1
+	LedgerEntryType_LEDGER_ENTRY_TYPE_REFUND      LedgerEntryType = 2
+)
+
+// Enum value maps for LedgerEntryType.
+var (
+	LedgerEntryType_name = map[int32]string{
+		0: "LEDGER_ENTRY_TYPE_UNSPECIFIED",
+		1: "LEDGER_ENTRY_TYPE_REDEMPTION",
+		2: "LEDGER_ENTRY_TYPE_REFUND",
+	}
+	LedgerEntryType_value = map[string]int32{
+		"LEDGER_ENTRY_TYPE_UNSPECIFIED": 0,
+		"LEDGER_ENTRY_TYPE_REDEMPTION":  1,
+		"LEDGER_ENTRY_TYPE_REFUND":      2,
+	}
+)
+
+func (x LedgerEntryType) Enum() *LedgerEntryType {
+	p := new(LedgerEntryType)
+	*p = x
+	return p
+}
+
+func (x LedgerEntryType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (LedgerEntryType) Descriptor() protoreflect.EnumDescriptor {
+	return file_protos_coupon_v1_coupon_proto_enumTypes[6].Descriptor()
+}
+
+func (LedgerEntryType) Type() protoreflect.EnumType {
+	return &file_protos_coupon_v1_coupon_proto_enumTypes[6]
+}
+
+func (x LedgerEntryType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use LedgerEntryType.Descriptor instead.
+func (LedgerEntryType) EnumDescriptor() ([]byte, []int) {
+	return file_protos_coupon_v1_coupon_proto_rawDescGZIP(), []int{6}
+}
+
+type UserListKind int32
+
+const (
+	UserListKind_USER_LIST_KIND_UNSPECIFIED UserListKind = 0
+	UserListKind_USER_LIST_KIND_ALLOWLIST   UserListKind = 1 // only the listed users can be issued coupons.
+	UserListKind_USER_LIST_KIND_BLOCKLIST   UserListKind = 2 // the listed users cannot be issued coupons.
+)
+
+// Enum value maps for UserListKind.
+var (
+	UserListKind_name = map[int32]string{
+		0: "USER_LIST_KIND_UNSPECIFIED",
+		1: "USER_LIST_KIND_ALLOWLIST",
+		2: "USER_LIST_KIND_BLOCKLIST",
+	}
+	UserListKind_value = map[string]int32{
+		"USER_LIST_KIND_UNSPECIFIED": 0,
+		"USER_LIST_KIND_ALLOWLIST":   1,
+		"USER_LIST_KIND_BLOCKLIST":   2,
+	}
+)
+
+func (x UserListKind) Enum() *UserListKind {
+	p := new(UserListKind)
+	*p = x
+	return p
+}
+
+func (x UserListKind) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (UserListKind) Descriptor() protoreflect.EnumDescriptor {
+	return file_protos_coupon_v1_coupon_proto_enumTypes[7].Descriptor()
+}
+
+func (UserListKind) Type() protoreflect.EnumType {
+	return &file_protos_coupon_v1_coupon_proto_enumTypes[7]
+}
+
+func (x UserListKind) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use UserListKind.Descriptor instead.
+func (UserListKind) EnumDescriptor() ([]byte, []int) {
+	return file_protos_coupon_v1_coupon_proto_rawDescGZIP(), []int{7}
+}
+
+type StackingMode int32
+
+const (
+	StackingMode_STACKING_MODE_UNSPECIFIED          StackingMode = 0 // same as STACKABLE_WITH_ALL.
+	StackingMode_STACKING_MODE_EXCLUSIVE            StackingMode = 1 // cannot be combined with any other coupon.
+	StackingMode_STACKING_MODE_STACKABLE_WITH_GROUP StackingMode = 2 // can be combined only with coupons of the same group.
+	StackingMode_STACKING_MODE_STACKABLE_WITH_ALL   StackingMode = 3 // can be combined with any coupon that is not exclusive or limited to another group.
+)
+
+// Enum value maps for StackingMode.
+var (
+	StackingMode_name = map[int32]string{
+		0: "STACKING_MODE_UNSPECIFIED",
+		1: "STACKING_MODE_EXCLUSIVE",
+		2: "STACKING_MODE_STACKABLE_WITH_GROUP",
+		3: "STACKING_MODE_STACKABLE_WITH_ALL",
+	}
+	StackingMode_value = map[string]int32{
+		"STACKING_MODE_UNSPECIFIED":          0,
+		"STACKING_MODE_EXCLUSIVE":            1,
+		"STACKING_MODE_STACKABLE_WITH_GROUP": 2,
+		"STACKING_MODE_STACKABLE_WITH_ALL":   3,
+	}
+)
+
+func (x StackingMode) Enum() *StackingMode {
+	p := new(StackingMode)
+	*p = x
+	return p
+}
+
+func (x StackingMode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (StackingMode) Descriptor() protoreflect.EnumDescriptor {
+	return file_protos_coupon_v1_coupon_proto_enumTypes[8].Descriptor()
+}
+
+func (StackingMode) Type() protoreflect.EnumType {
+	return &file_protos_coupon_v1_coupon_proto_enumTypes[8]
+}
+
+func (x StackingMode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use StackingMode.Descriptor instead.
+func (StackingMode) EnumDescriptor() ([]byte, []int) {
+	return file_protos_coupon_v1_coupon_proto_rawDescGZIP(), []int{8}
+}
+
+type CampaignEventType int32
+
+const (
+	CampaignEventType_CAMPAIGN_EVENT_TYPE_UNSPECIFIED         CampaignEventType = 0
+	CampaignEventType_CAMPAIGN_EVENT_TYPE_COUPON_REVOKED      CampaignEventType = 1
+	CampaignEventType_CAMPAIGN_EVENT_TYPE_SLOT_RETURNED       CampaignEventType = 2
+	CampaignEventType_CAMPAIGN_EVENT_TYPE_PAUSED              CampaignEventType = 3
+	CampaignEventType_CAMPAIGN_EVENT_TYPE_RESUMED             CampaignEventType = 4
+	CampaignEventType_CAMPAIGN_EVENT_TYPE_CLOSED              CampaignEventType = 5
+	CampaignEventType_CAMPAIGN_EVENT_TYPE_LOTTERY_DRAWN       CampaignEventType = 6
+	CampaignEventType_CAMPAIGN_EVENT_TYPE_WAITLIST_ISSUED     CampaignEventType = 7
+	CampaignEventType_CAMPAIGN_EVENT_TYPE_REDEMPTION_REVERSED CampaignEventType = 8
+	CampaignEventType_CAMPAIGN_EVENT_TYPE_COUPON_TRANSFERRED  CampaignEventType = 9
+	CampaignEventType_CAMPAIGN_EVENT_TYPE_REFERRAL_REWARDED   CampaignEventType = 10
+	CampaignEventType_CAMPAIGN_EVENT_TYPE_BUDGET_ALERT        CampaignEventType = 11 // the amount spent reached an alert threshold of the budget.
+)
+
+// Enum value maps for CampaignEventType.
+var (
+	CampaignEventType_name = map[int32]string{
+		0:  "CAMPAIGN_EVENT_TYPE_UNSPECIFIED",
+		1:  "CAMPAIGN_EVENT_TYPE_COUPON_REVOKED",
+		2:  "CAMPAIGN_EVENT_TYPE_SLOT_RETURNED",
+		3:  "CAMPAIGN_EVENT_TYPE_PAUSED",
+		4:  "CAMPAIGN_EVENT_TYPE_RESUMED",
+		5:  "CAMPAIGN_EVENT_TYPE_CLOSED",
+		6:  "CAMPAIGN_EVENT_TYPE_LOTTERY_DRAWN",
+		7:  "CAMPAIGN_EVENT_TYPE_WAITLIST_ISSUED",
+		8:  "CAMPAIGN_EVENT_TYPE_REDEMPTION_REVERSED",
+		9:  "CAMPAIGN_EVENT_TYPE_COUPON_TRANSFERRED",
+		10: "CAMPAIGN_EVENT_TYPE_REFERRAL_REWARDED",
+		11: "CAMPAIGN_EVENT_TYPE_BUDGET_ALERT",
+	}
+	CampaignEventType_value = map[string]int32{
+		"CAMPAIGN_EVENT_TYPE_UNSPECIFIED":         0,
+		"CAMPAIGN_EVENT_TYPE_COUPON_REVOKED":      1,
+		"CAMPAIGN_EVENT_TYPE_SLOT_RETURNED":       2,
+		"CAMPAIGN_EVENT_TYPE_PAUSED":              3,
+		"CAMPAIGN_EVENT_TYPE_RESUMED":             4,
+		"CAMPAIGN_EVENT_TYPE_CLOSED":              5,
+		"CAMPAIGN_EVENT_TYPE_LOTTERY_DRAWN":       6,
+		"CAMPAIGN_EVENT_TYPE_WAITLIST_ISSUED":     7,
+		"CAMPAIGN_EVENT_TYPE_REDEMPTION_REVERSED": 8,
+		"CAMPAIGN_EVENT_TYPE_COUPON_TRANSFERRED":  9,
+		"CAMPAIGN_EVENT_TYPE_REFERRAL_REWARDED":   10,
+		"CAMPAIGN_EVENT_TYPE_BUDGET_ALERT":        11,
+	}
+)
+
+func (x CampaignEventType) Enum() *CampaignEventType {
+	p := new(CampaignEventType)
+	*p = x
+	return p
+}
+
+func (x CampaignEventType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (CampaignEventType) Descriptor() protoreflect.EnumDescriptor {
+	return file_protos_coupon_v1_coupon_proto_enumTypes[9].Descriptor()
+}
+
+func (CampaignEventType) Type() protoreflect.EnumType {
+	return &file_protos_coupon_v1_coupon_proto_enumTypes[9]
+}
+
+func (x CampaignEventType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use CampaignEventType.Descriptor instead.
+func (CampaignEventType) EnumDescriptor() ([]byte, []int) {
+	return file_protos_coupon_v1_coupon_proto_rawDescGZIP(), []int{9}
+}
+
+// RejectionReason explains why a coupon code is not applied to a cart.
+type RejectionReason int32
+
+const (
+	RejectionReason_REJECTION_REASON_UNSPECIFIED       RejectionReason = 0
+	RejectionReason_REJECTION_REASON_INVALID_COUPON    RejectionReason = 1 // see validation_reason.
+	RejectionReason_REJECTION_REASON_DUPLICATE_CODE    RejectionReason = 2
+	RejectionReason_REJECTION_REASON_NO_DISCOUNT       RejectionReason = 3
+	RejectionReason_REJECTION_REASON_CURRENCY_MISMATCH RejectionReason = 4
+	RejectionReason_REJECTION_REASON_MIN_ORDER_NOT_MET RejectionReason = 5
+	RejectionReason_REJECTION_REASON_NOT_APPLICABLE    RejectionReason = 6 // nothing in the cart can be discounted, see message for the failed rules.
+)
+
+// Enum value maps for RejectionReason.
+var (
+	RejectionReason_name = map[int32]string{
+		0: "REJECTION_REASON_UNSPECIFIED",
+		1: "REJECTION_REASON_INVALID_COUPON",
+		2: "REJECTION_REASON_DUPLICATE_CODE",
+		3: "REJECTION_REASON_NO_DISCOUNT",
+		4: "REJECTION_REASON_CURRENCY_MISMATCH",
+		5: "REJECTION_REASON_MIN_ORDER_NOT_MET",
+		6: "REJECTION_REASON_NOT_APPLICABLE",
+	}
+	RejectionReason_value = map[string]int32{
+		"REJECTION_REASON_UNSPECIFIED":       0,
+		"REJECTION_REASON_INVALID_COUPON":    1,
+		"REJECTION_REASON_DUPLICATE_CODE":    2,
+		"REJECTION_REASON_NO_DISCOUNT":       3,
+		"REJECTION_REASON_CURRENCY_MISMATCH": 4,
+		"REJECTION_REASON_MIN_ORDER_NOT_MET": 5,
+		"REJECTION_REASON_NOT_APPLICABLE":    6,
+	}
+)
+
+func (x RejectionReason) Enum() *RejectionReason {
+	p := new(RejectionReason)
+	*p = x
+	return p
+}
+
+func (x RejectionReason) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (RejectionReason) Descriptor() protoreflect.EnumDescriptor {
+	return file_protos_coupon_v1_coupon_proto_enumTypes[10].Descriptor()
+}
+
+func (RejectionReason) Type() protoreflect.EnumType {
+	return &file_protos_coupon_v1_coupon_proto_enumTypes[10]
+}
+
+func (x RejectionReason) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use RejectionReason.Descriptor instead.
+func (RejectionReason) EnumDescriptor() ([]byte, []int) {
+	return file_protos_coupon_v1_coupon_proto_rawDescGZIP(), []int{10}
+}
+
 type Coupon struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
-	ExpireAt      *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=expire_at,json=expireAt,proto3" json:"expire_at,omitempty"`
-	IssuedAt      *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=issued_at,json=issuedAt,proto3" json:"issued_at,omitempty"`
+	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	ExpireAt      *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=expire_at,json=expireAt,proto3" json:"expire_at,omitempty"`
+	IssuedAt      *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=issued_at,json=issuedAt,proto3" json:"issued_at,omitempty"`
+	CampaignId    uint32                 `protobuf:"varint,4,opt,name=campaign_id,json=campaignId,proto3" json:"campaign_id,omitempty"`
+	Status        CouponStatus           `protobuf:"varint,5,opt,name=status,proto3,enum=protos.coupon.v1.CouponStatus" json:"status,omitempty"`
+	RedeemedAt    *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=redeemed_at,json=redeemedAt,proto3" json:"redeemed_at,omitempty"`
+	RevokedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=revoked_at,json=revokedAt,proto3" json:"revoked_at,omitempty"`
+	RevokeReason  string                 `protobuf:"bytes,8,opt,name=revoke_reason,json=revokeReason,proto3" json:"revoke_reason,omitempty"`
+	Discount      *Discount              `protobuf:"bytes,9,opt,name=discount,proto3" json:"discount,omitempty"` // a snapshot of the campaign's discount at issue time.
+	UserId        string                 `protobuf:"bytes,10,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Reservation   *Reservation           `protobuf:"bytes,11,opt,name=reservation,proto3" json:"reservation,omitempty"`                          // the latest reservation of the coupon.
+	Balance       *Money                 `protobuf:"bytes,12,opt,name=balance,proto3" json:"balance,omitempty"`                                  // what is left of a stored-value coupon, which is redeemed once it runs out.
+	RedemptionId  string                 `protobuf:"bytes,13,opt,name=redemption_id,json=redemptionId,proto3" json:"redemption_id,omitempty"`    // set while redeemed, identifying the redemption to reverse.
+	OrderId       string                 `protobuf:"bytes,14,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`                   // the order the coupon was redeemed for, if given.
+	Transfers     []*Transfer            `protobuf:"bytes,15,rep,name=transfers,proto3" json:"transfers,omitempty"`                              // the ownership chain after the user the coupon was issued to, in order.
+	TransferOffer *TransferOffer         `protobuf:"bytes,16,opt,name=transfer_offer,json=transferOffer,proto3" json:"transfer_offer,omitempty"` // the claim link the owner offered the coupon with, if any.
+	ReferralCode  string                 `protobuf:"bytes,17,opt,name=referral_code,json=referralCode,proto3" json:"referral_code,omitempty"`    // the referral code the coupon was issued for, to the referee or as the reward.
+	Tier          string                 `protobuf:"bytes,18,opt,name=tier,proto3" json:"tier,omitempty"`                                        // the name of the tier the coupon was issued in, if the campaign is tiered.
+	Variant       string                 `protobuf:"bytes,19,opt,name=variant,proto3" json:"variant,omitempty"`                                  // the name of the variant the user was assigned, if the campaign has variants.
+	Channel       string                 `protobuf:"bytes,20,opt,name=channel,proto3" json:"channel,omitempty"`                                  // the channel or partner the coupon was issued through, if the campaign is allocated.
+	History       []*CouponEvent         `protobuf:"bytes,21,rep,name=history,proto3" json:"history,omitempty"`                                  // what happened to the coupon since it was issued, in order.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Coupon) Reset() {
+	*x = Coupon{}
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Coupon) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Coupon) ProtoMessage() {}
+
+func (x *Coupon) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Coupon.ProtoReflect.Descriptor instead.
+func (*Coupon) Descriptor() ([]byte, []int) {
+	return file_protos_coupon_v1_coupon_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Coupon) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *Coupon) GetExpireAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpireAt
+	}
+	return nil
+}
+
+func (x *Coupon) GetIssuedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.IssuedAt
+	}
+	return nil
+}
+
+func (x *Coupon) GetCampaignId() uint32 {
+	if x != nil {
+		return x.CampaignId
+	}
+	return 0
+}
+
+func (x *Coupon) GetStatus() CouponStatus {
+	if x != nil {
+		return x.Status
+	}
+	return CouponStatus_COUPON_STATUS_UNSPECIFIED
+}
+
+func (x *Coupon) GetRedeemedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.RedeemedAt
+	}
+	return nil
+}
+
+func (x *Coupon) GetRevokedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.RevokedAt
+	}
+	return nil
+}
+
+func (x *Coupon) GetRevokeReason() string {
+	if x != nil {
+		return x.RevokeReason
+	}
+	return ""
+}
+
+func (x *Coupon) GetDiscount() *Discount {
+	if x != nil {
+		return x.Discount
+	}
+	return nil
+}
+
+func (x *Coupon) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *Coupon) GetReservation() *Reservation {
+	if x != nil {
+		return x.Reservation
+	}
+	return nil
+}
+
+func (x *Coupon) GetBalance() *Money {
+	if x != nil {
+		return x.Balance
+	}
+	return nil
+}
+
+func (x *Coupon) GetRedemptionId() string {
+	if x != nil {
+		return x.RedemptionId
+	}
+	return ""
+}
+
+func (x *Coupon) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *Coupon) GetTransfers() []*Transfer {
+	if x != nil {
+		return x.Transfers
+	}
+	return nil
+}
+
+func (x *Coupon) GetTransferOffer() *TransferOffer {
+	if x != nil {
+		return x.TransferOffer
+	}
+	return nil
+}
+
+func (x *Coupon) GetReferralCode() string {
+	if x != nil {
+		return x.ReferralCode
+	}
+	return ""
+}
+
+func (x *Coupon) GetTier() string {
+	if x != nil {
+		return x.Tier
+	}
+	return ""
+}
+
+func (x *Coupon) GetVariant() string {
+	if x != nil {
+		return x.Variant
+	}
+	return ""
+}
+
+func (x *Coupon) GetChannel() string {
+	if x != nil {
+		return x.Channel
+	}
+	return ""
+}
+
+func (x *Coupon) GetHistory() []*CouponEvent {
+	if x != nil {
+		return x.History
+	}
+	return nil
+}
+
+// CouponEvent is something that happened to a coupon, recorded in its history.
+type CouponEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Type          CouponEventType        `protobuf:"varint,1,opt,name=type,proto3,enum=protos.coupon.v1.CouponEventType" json:"type,omitempty"`
+	RedemptionId  string                 `protobuf:"bytes,2,opt,name=redemption_id,json=redemptionId,proto3" json:"redemption_id,omitempty"`
+	OrderId       string                 `protobuf:"bytes,3,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Restored      bool                   `protobuf:"varint,4,opt,name=restored,proto3" json:"restored,omitempty"` // whether the reversal restored the coupon.
+	OccurredAt    *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=occurred_at,json=occurredAt,proto3" json:"occurred_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CouponEvent) Reset() {
+	*x = CouponEvent{}
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CouponEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CouponEvent) ProtoMessage() {}
+
+func (x *CouponEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CouponEvent.ProtoReflect.Descriptor instead.
+func (*CouponEvent) Descriptor() ([]byte, []int) {
+	return file_protos_coupon_v1_coupon_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *CouponEvent) GetType() CouponEventType {
+	if x != nil {
+		return x.Type
+	}
+	return CouponEventType_COUPON_EVENT_TYPE_UNSPECIFIED
+}
+
+func (x *CouponEvent) GetRedemptionId() string {
+	if x != nil {
+		return x.RedemptionId
+	}
+	return ""
+}
+
+func (x *CouponEvent) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *CouponEvent) GetRestored() bool {
+	if x != nil {
+		return x.Restored
+	}
+	return false
+}
+
+func (x *CouponEvent) GetOccurredAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.OccurredAt
+	}
+	return nil
+}
+
+// Transfer moves the ownership of a coupon from one user to another.
+type Transfer struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FromUserId    string                 `protobuf:"bytes,1,opt,name=from_user_id,json=fromUserId,proto3" json:"from_user_id,omitempty"`
+	ToUserId      string                 `protobuf:"bytes,2,opt,name=to_user_id,json=toUserId,proto3" json:"to_user_id,omitempty"`
+	TransferredAt *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=transferred_at,json=transferredAt,proto3" json:"transferred_at,omitempty"`
+	Claimed       bool                   `protobuf:"varint,4,opt,name=claimed,proto3" json:"claimed,omitempty"` // whether the recipient accepted a claim link.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Transfer) Reset() {
+	*x = Transfer{}
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Transfer) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Transfer) ProtoMessage() {}
+
+func (x *Transfer) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Transfer.ProtoReflect.Descriptor instead.
+func (*Transfer) Descriptor() ([]byte, []int) {
+	return file_protos_coupon_v1_coupon_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Transfer) GetFromUserId() string {
+	if x != nil {
+		return x.FromUserId
+	}
+	return ""
+}
+
+func (x *Transfer) GetToUserId() string {
+	if x != nil {
+		return x.ToUserId
+	}
+	return ""
+}
+
+func (x *Transfer) GetTransferredAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.TransferredAt
+	}
+	return nil
+}
+
+func (x *Transfer) GetClaimed() bool {
+	if x != nil {
+		return x.Claimed
+	}
+	return false
+}
+
+// TransferOffer is a claim link offering a coupon to whoever accepts it with its one-time token first.
+// The token itself is only returned to the owner who made the offer.
+type TransferOffer struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FromUserId    string                 `protobuf:"bytes,1,opt,name=from_user_id,json=fromUserId,proto3" json:"from_user_id,omitempty"`
+	OfferedAt     *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=offered_at,json=offeredAt,proto3" json:"offered_at,omitempty"`
+	ExpireAt      *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=expire_at,json=expireAt,proto3" json:"expire_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TransferOffer) Reset() {
+	*x = TransferOffer{}
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TransferOffer) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransferOffer) ProtoMessage() {}
+
+func (x *TransferOffer) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransferOffer.ProtoReflect.Descriptor instead.
+func (*TransferOffer) Descriptor() ([]byte, []int) {
+	return file_protos_coupon_v1_coupon_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *TransferOffer) GetFromUserId() string {
+	if x != nil {
+		return x.FromUserId
+	}
+	return ""
+}
+
+func (x *TransferOffer) GetOfferedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.OfferedAt
+	}
+	return nil
+}
+
+func (x *TransferOffer) GetExpireAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpireAt
+	}
+	return nil
+}
+
+// TransferPolicy lets the owners of the coupons of a campaign transfer them to other users, e.g. as a gift.
+type TransferPolicy struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	MaxTransfers     uint32                 `protobuf:"varint,1,opt,name=max_transfers,json=maxTransfers,proto3" json:"max_transfers,omitempty"`             // how many times a coupon can change hands. Unlimited if 0.
+	CheckEligibility bool                   `protobuf:"varint,2,opt,name=check_eligibility,json=checkEligibility,proto3" json:"check_eligibility,omitempty"` // requires recipients to be allowed and eligible as if they were issued the coupon.
+	ClaimTtl         *durationpb.Duration   `protobuf:"bytes,3,opt,name=claim_ttl,json=claimTtl,proto3" json:"claim_ttl,omitempty"`                          // how long a claim link stays valid. 7 days if unset.
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *TransferPolicy) Reset() {
+	*x = TransferPolicy{}
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TransferPolicy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransferPolicy) ProtoMessage() {}
+
+func (x *TransferPolicy) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransferPolicy.ProtoReflect.Descriptor instead.
+func (*TransferPolicy) Descriptor() ([]byte, []int) {
+	return file_protos_coupon_v1_coupon_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *TransferPolicy) GetMaxTransfers() uint32 {
+	if x != nil {
+		return x.MaxTransfers
+	}
+	return 0
+}
+
+func (x *TransferPolicy) GetCheckEligibility() bool {
+	if x != nil {
+		return x.CheckEligibility
+	}
+	return false
+}
+
+func (x *TransferPolicy) GetClaimTtl() *durationpb.Duration {
+	if x != nil {
+		return x.ClaimTtl
+	}
+	return nil
+}
+
+// Reversal is the outcome of reversing a redemption. Reversing it again returns the same reversal.
+type Reversal struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RedemptionId  string                 `protobuf:"bytes,1,opt,name=redemption_id,json=redemptionId,proto3" json:"redemption_id,omitempty"`
+	Code          string                 `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"`
+	OrderId       string                 `protobuf:"bytes,3,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Restored      bool                   `protobuf:"varint,4,opt,name=restored,proto3" json:"restored,omitempty"` // whether the coupon became usable again, or the amount was credited back.
+	Refund        *LedgerEntry           `protobuf:"bytes,5,opt,name=refund,proto3" json:"refund,omitempty"`      // the credit of a stored-value coupon.
+	ReversedAt    *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=reversed_at,json=reversedAt,proto3" json:"reversed_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Reversal) Reset() {
+	*x = Reversal{}
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Reversal) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Reversal) ProtoMessage() {}
+
+func (x *Reversal) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Reversal.ProtoReflect.Descriptor instead.
+func (*Reversal) Descriptor() ([]byte, []int) {
+	return file_protos_coupon_v1_coupon_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *Reversal) GetRedemptionId() string {
+	if x != nil {
+		return x.RedemptionId
+	}
+	return ""
+}
+
+func (x *Reversal) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *Reversal) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *Reversal) GetRestored() bool {
+	if x != nil {
+		return x.Restored
+	}
+	return false
+}
+
+func (x *Reversal) GetRefund() *LedgerEntry {
+	if x != nil {
+		return x.Refund
+	}
+	return nil
+}
+
+func (x *Reversal) GetReversedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ReversedAt
+	}
+	return nil
+}
+
+// LedgerEntry is a change of the balance of a stored-value coupon.
+type LedgerEntry struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Type          LedgerEntryType        `protobuf:"varint,2,opt,name=type,proto3,enum=protos.coupon.v1.LedgerEntryType" json:"type,omitempty"`
+	Amount        *Money                 `protobuf:"bytes,3,opt,name=amount,proto3" json:"amount,omitempty"`
+	Balance       *Money                 `protobuf:"bytes,4,opt,name=balance,proto3" json:"balance,omitempty"` // the balance after the entry.
+	OrderId       string                 `protobuf:"bytes,5,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	OccurredAt    *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=occurred_at,json=occurredAt,proto3" json:"occurred_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LedgerEntry) Reset() {
+	*x = LedgerEntry{}
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LedgerEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LedgerEntry) ProtoMessage() {}
+
+func (x *LedgerEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LedgerEntry.ProtoReflect.Descriptor instead.
+func (*LedgerEntry) Descriptor() ([]byte, []int) {
+	return file_protos_coupon_v1_coupon_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *LedgerEntry) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *LedgerEntry) GetType() LedgerEntryType {
+	if x != nil {
+		return x.Type
+	}
+	return LedgerEntryType_LEDGER_ENTRY_TYPE_UNSPECIFIED
+}
+
+func (x *LedgerEntry) GetAmount() *Money {
+	if x != nil {
+		return x.Amount
+	}
+	return nil
+}
+
+func (x *LedgerEntry) GetBalance() *Money {
+	if x != nil {
+		return x.Balance
+	}
+	return nil
+}
+
+func (x *LedgerEntry) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *LedgerEntry) GetOccurredAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.OccurredAt
+	}
+	return nil
+}
+
+// Reservation holds a coupon for a checkout, so it cannot be redeemed elsewhere until it is committed,
+// released or expires.
+type Reservation struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ReservedAt    *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=reserved_at,json=reservedAt,proto3" json:"reserved_at,omitempty"`
+	ExpireAt      *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=expire_at,json=expireAt,proto3" json:"expire_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Reservation) Reset() {
+	*x = Reservation{}
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Reservation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Reservation) ProtoMessage() {}
+
+func (x *Reservation) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Reservation.ProtoReflect.Descriptor instead.
+func (*Reservation) Descriptor() ([]byte, []int) {
+	return file_protos_coupon_v1_coupon_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *Reservation) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Reservation) GetReservedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ReservedAt
+	}
+	return nil
+}
+
+func (x *Reservation) GetExpireAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpireAt
+	}
+	return nil
+}
+
+type Campaign struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Id                uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	CouponLimit       uint32                 `protobuf:"varint,2,opt,name=coupon_limit,json=couponLimit,proto3" json:"coupon_limit,omitempty"`
+	Name              string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Description       string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	CreatedAt         *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	StartAt           *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=start_at,json=startAt,proto3" json:"start_at,omitempty"`
+	EndAt             *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=end_at,json=endAt,proto3" json:"end_at,omitempty"`
+	Coupons           []*Coupon              `protobuf:"bytes,8,rep,name=coupons,proto3" json:"coupons,omitempty"`
+	History           []*CampaignEvent       `protobuf:"bytes,9,rep,name=history,proto3" json:"history,omitempty"`
+	ExpiryPolicy      *ExpiryPolicy          `protobuf:"bytes,10,opt,name=expiry_policy,json=expiryPolicy,proto3" json:"expiry_policy,omitempty"`
+	Discount          *Discount              `protobuf:"bytes,11,opt,name=discount,proto3" json:"discount,omitempty"`
+	Applicability     *Applicability         `protobuf:"bytes,12,opt,name=applicability,proto3" json:"applicability,omitempty"`
+	Stacking          *StackingPolicy        `protobuf:"bytes,13,opt,name=stacking,proto3" json:"stacking,omitempty"`
+	Eligibility       string                 `protobuf:"bytes,14,opt,name=eligibility,proto3" json:"eligibility,omitempty"`
+	Allowlist         *UserList              `protobuf:"bytes,15,opt,name=allowlist,proto3" json:"allowlist,omitempty"`
+	Blocklist         *UserList              `protobuf:"bytes,16,opt,name=blocklist,proto3" json:"blocklist,omitempty"`
+	State             CampaignState          `protobuf:"varint,17,opt,name=state,proto3,enum=protos.coupon.v1.CampaignState" json:"state,omitempty"`
+	ClosedAt          *timestamppb.Timestamp `protobuf:"bytes,18,opt,name=closed_at,json=closedAt,proto3" json:"closed_at,omitempty"`
+	Recurrence        *Recurrence            `protobuf:"bytes,19,opt,name=recurrence,proto3" json:"recurrence,omitempty"`
+	CurrentOccurrence *Occurrence            `protobuf:"bytes,20,opt,name=current_occurrence,json=currentOccurrence,proto3" json:"current_occurrence,omitempty"` // the open issuance window, if any.
+	NextOccurrence    *Occurrence            `protobuf:"bytes,21,opt,name=next_occurrence,json=nextOccurrence,proto3" json:"next_occurrence,omitempty"`
+	Occurrences       []*Occurrence          `protobuf:"bytes,22,rep,name=occurrences,proto3" json:"occurrences,omitempty"` // the issuance stats of the occurrences which issued coupons.
+	Throttle          *Throttle              `protobuf:"bytes,23,opt,name=throttle,proto3" json:"throttle,omitempty"`
+	WaitingRoom       *WaitingRoom           `protobuf:"bytes,24,opt,name=waiting_room,json=waitingRoom,proto3" json:"waiting_room,omitempty"`
+	Lottery           *Lottery               `protobuf:"bytes,25,opt,name=lottery,proto3" json:"lottery,omitempty"`                            // set if the campaign draws its coupons by lottery.
+	Waitlist          *Waitlist              `protobuf:"bytes,26,opt,name=waitlist,proto3" json:"waitlist,omitempty"`                          // set if users can join a waitlist once the campaign is sold out.
+	StoredValue       *Money                 `protobuf:"bytes,27,opt,name=stored_value,json=storedValue,proto3" json:"stored_value,omitempty"` // the balance the stored-value coupons of the campaign start with.
+	RestorePolicy     RestorePolicy          `protobuf:"varint,28,opt,name=restore_policy,json=restorePolicy,proto3,enum=protos.coupon.v1.RestorePolicy" json:"restore_policy,omitempty"`
+	Transfer          *TransferPolicy        `protobuf:"bytes,29,opt,name=transfer,proto3" json:"transfer,omitempty"` // set if the coupons can be transferred between users.
+	Referral          *ReferralPolicy        `protobuf:"bytes,30,opt,name=referral,proto3" json:"referral,omitempty"` // set if the coupons are issued for referrals.
+	Tiers             []*Tier                `protobuf:"bytes,31,rep,name=tiers,proto3" json:"tiers,omitempty"`
+	TierStats         []*TierStats           `protobuf:"bytes,32,rep,name=tier_stats,json=tierStats,proto3" json:"tier_stats,omitempty"` // in the latest occurrence which issued coupons if the campaign recurs.
+	Variants          []*Variant             `protobuf:"bytes,33,rep,name=variants,proto3" json:"variants,omitempty"`
+	VariantStats      []*VariantStats        `protobuf:"bytes,34,rep,name=variant_stats,json=variantStats,proto3" json:"variant_stats,omitempty"` // in the latest occurrence which issued coupons if the campaign recurs.
+	Budget            *Budget                `protobuf:"bytes,35,opt,name=budget,proto3" json:"budget,omitempty"`                                 // set if what the coupons cost is capped.
+	BudgetStats       *BudgetStats           `protobuf:"bytes,36,opt,name=budget_stats,json=budgetStats,proto3" json:"budget_stats,omitempty"`
+	Allocation        *AllocationPolicy      `protobuf:"bytes,37,opt,name=allocation,proto3" json:"allocation,omitempty"`                                  // set if the coupon limit is split across channels.
+	AllocationStats   []*AllocationStats     `protobuf:"bytes,38,rep,name=allocation_stats,json=allocationStats,proto3" json:"allocation_stats,omitempty"` // in the latest occurrence which issued coupons if the campaign recurs.
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *Campaign) Reset() {
+	*x = Campaign{}
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Campaign) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Campaign) ProtoMessage() {}
+
+func (x *Campaign) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Campaign.ProtoReflect.Descriptor instead.
+func (*Campaign) Descriptor() ([]byte, []int) {
+	return file_protos_coupon_v1_coupon_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *Campaign) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Campaign) GetCouponLimit() uint32 {
+	if x != nil {
+		return x.CouponLimit
+	}
+	return 0
+}
+
+func (x *Campaign) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Campaign) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Campaign) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Campaign) GetStartAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartAt
+	}
+	return nil
+}
+
+func (x *Campaign) GetEndAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndAt
+	}
+	return nil
+}
+
+func (x *Campaign) GetCoupons() []*Coupon {
+	if x != nil {
+		return x.Coupons
+	}
+	return nil
+}
+
+func (x *Campaign) GetHistory() []*CampaignEvent {
+	if x != nil {
+		return x.History
+	}
+	return nil
+}
+
+func (x *Campaign) GetExpiryPolicy() *ExpiryPolicy {
+	if x != nil {
+		return x.ExpiryPolicy
+	}
+	return nil
+}
+
+func (x *Campaign) GetDiscount() *Discount {
+	if x != nil {
+		return x.Discount
+	}
+	return nil
+}
+
+func (x *Campaign) GetApplicability() *Applicability {
+	if x != nil {
+		return x.Applicability
+	}
+	return nil
+}
+
+func (x *Campaign) GetStacking() *StackingPolicy {
+	if x != nil {
+		return x.Stacking
+	}
+	return nil
+}
+
+func (x *Campaign) GetEligibility() string {
+	if x != nil {
+		return x.Eligibility
+	}
+	return ""
+}
+
+func (x *Campaign) GetAllowlist() *UserList {
+	if x != nil {
+		return x.Allowlist
+	}
+	return nil
+}
+
+func (x *Campaign) GetBlocklist() *UserList {
+	if x != nil {
+		return x.Blocklist
+	}
+	return nil
+}
+
+func (x *Campaign) GetState() CampaignState {
+	if x != nil {
+		return x.State
+	}
+	return CampaignState_CAMPAIGN_STATE_UNSPECIFIED
+}
+
+func (x *Campaign) GetClosedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ClosedAt
+	}
+	return nil
+}
+
+func (x *Campaign) GetRecurrence() *Recurrence {
+	if x != nil {
+		return x.Recurrence
+	}
+	return nil
+}
+
+func (x *Campaign) GetCurrentOccurrence() *Occurrence {
+	if x != nil {
+		return x.CurrentOccurrence
+	}
+	return nil
+}
+
+func (x *Campaign) GetNextOccurrence() *Occurrence {
+	if x != nil {
+		return x.NextOccurrence
+	}
+	return nil
+}
+
+func (x *Campaign) GetOccurrences() []*Occurrence {
+	if x != nil {
+		return x.Occurrences
+	}
+	return nil
+}
+
+func (x *Campaign) GetThrottle() *Throttle {
+	if x != nil {
+		return x.Throttle
+	}
+	return nil
+}
+
+func (x *Campaign) GetWaitingRoom() *WaitingRoom {
+	if x != nil {
+		return x.WaitingRoom
+	}
+	return nil
+}
+
+func (x *Campaign) GetLottery() *Lottery {
+	if x != nil {
+		return x.Lottery
+	}
+	return nil
+}
+
+func (x *Campaign) GetWaitlist() *Waitlist {
+	if x != nil {
+		return x.Waitlist
+	}
+	return nil
+}
+
+func (x *Campaign) GetStoredValue() *Money {
+	if x != nil {
+		return x.StoredValue
+	}
+	return nil
+}
+
+func (x *Campaign) GetRestorePolicy() RestorePolicy {
+	if x != nil {
+		return x.RestorePolicy
+	}
+	return RestorePolicy_RESTORE_POLICY_UNSPECIFIED
+}
+
+func (x *Campaign) GetTransfer() *TransferPolicy {
+	if x != nil {
+		return x.Transfer
+	}
+	return nil
+}
+
+func (x *Campaign) GetReferral() *ReferralPolicy {
+	if x != nil {
+		return x.Referral
+	}
+	return nil
+}
+
+func (x *Campaign) GetTiers() []*Tier {
+	if x != nil {
+		return x.Tiers
+	}
+	return nil
+}
+
+func (x *Campaign) GetTierStats() []*TierStats {
+	if x != nil {
+		return x.TierStats
+	}
+	return nil
+}
+
+func (x *Campaign) GetVariants() []*Variant {
+	if x != nil {
+		return x.Variants
+	}
+	return nil
+}
+
+func (x *Campaign) GetVariantStats() []*VariantStats {
+	if x != nil {
+		return x.VariantStats
+	}
+	return nil
+}
+
+func (x *Campaign) GetBudget() *Budget {
+	if x != nil {
+		return x.Budget
+	}
+	return nil
+}
+
+func (x *Campaign) GetBudgetStats() *BudgetStats {
+	if x != nil {
+		return x.BudgetStats
+	}
+	return nil
+}
+
+func (x *Campaign) GetAllocation() *AllocationPolicy {
+	if x != nil {
+		return x.Allocation
+	}
+	return nil
+}
+
+func (x *Campaign) GetAllocationStats() []*AllocationStats {
+	if x != nil {
+		return x.AllocationStats
+	}
+	return nil
+}
+
+// Tier gives the coupons issued in a range of the issuance sequence their own discount, e.g. 50% off for the first
+// 100 and 20% for the next 900. A coupon is issued in the first tier which holds fewer coupons than its limit.
+// The slot of a coupon revoked back to the pool or expiring unused goes back to its tier, so the first 100 are the
+// first 100 outstanding, and the next coupon issued takes the 50% off in its place.
+type Tier struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Limit         uint32                 `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"` // unlimited if 0, which only the last tier can be, taking the rest of the coupon limit.
+	Discount      *Discount              `protobuf:"bytes,3,opt,name=discount,proto3" json:"discount,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Tier) Reset() {
+	*x = Tier{}
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Tier) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Tier) ProtoMessage() {}
+
+func (x *Tier) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Tier.ProtoReflect.Descriptor instead.
+func (*Tier) Descriptor() ([]byte, []int) {
+	return file_protos_coupon_v1_coupon_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *Tier) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Tier) GetLimit() uint32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *Tier) GetDiscount() *Discount {
+	if x != nil {
+		return x.Discount
+	}
+	return nil
+}
+
+type TierStats struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Issued        uint32                 `protobuf:"varint,2,opt,name=issued,proto3" json:"issued,omitempty"` // including the coupons whose slots were given back.
+	Remaining     uint32                 `protobuf:"varint,3,opt,name=remaining,proto3" json:"remaining,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TierStats) Reset() {
+	*x = TierStats{}
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TierStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TierStats) ProtoMessage() {}
+
+func (x *TierStats) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TierStats.ProtoReflect.Descriptor instead.
+func (*TierStats) Descriptor() ([]byte, []int) {
+	return file_protos_coupon_v1_coupon_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *TierStats) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *TierStats) GetIssued() uint32 {
+	if x != nil {
+		return x.Issued
+	}
+	return 0
+}
+
+func (x *TierStats) GetRemaining() uint32 {
+	if x != nil {
+		return x.Remaining
+	}
+	return 0
+}
+
+// Variant is an arm of an A/B test of the campaign's coupons. Each user is assigned a variant by a hash of the
+// campaign and user IDs, weighted by the variants' weights, so the same user always gets the same variant.
+type Variant struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Name     string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Weight   uint32                 `protobuf:"varint,2,opt,name=weight,proto3" json:"weight,omitempty"`
+	Discount *Discount              `protobuf:"bytes,3,opt,name=discount,proto3" json:"discount,omitempty"`
+	// Caps the coupons outstanding, as slots given back go back to the variant. Limited by the coupon limit only if 0,
+	// and per occurrence if the campaign recurs.
+	Limit         uint32 `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Variant) Reset() {
+	*x = Variant{}
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Variant) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Variant) ProtoMessage() {}
+
+func (x *Variant) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Variant.ProtoReflect.Descriptor instead.
+func (*Variant) Descriptor() ([]byte, []int) {
+	return file_protos_coupon_v1_coupon_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *Variant) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Variant) GetWeight() uint32 {
+	if x != nil {
+		return x.Weight
+	}
+	return 0
+}
+
+func (x *Variant) GetDiscount() *Discount {
+	if x != nil {
+		return x.Discount
+	}
+	return nil
+}
+
+func (x *Variant) GetLimit() uint32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type VariantStats struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Issued        uint32                 `protobuf:"varint,2,opt,name=issued,proto3" json:"issued,omitempty"`     // including the coupons whose slots were given back.
+	Redeemed      uint32                 `protobuf:"varint,3,opt,name=redeemed,proto3" json:"redeemed,omitempty"` // the coupons redeemed at least once.
+	Remaining     uint32                 `protobuf:"varint,4,opt,name=remaining,proto3" json:"remaining,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VariantStats) Reset() {
+	*x = VariantStats{}
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VariantStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VariantStats) ProtoMessage() {}
+
+func (x *VariantStats) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VariantStats.ProtoReflect.Descriptor instead.
+func (*VariantStats) Descriptor() ([]byte, []int) {
+	return file_protos_coupon_v1_coupon_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *VariantStats) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *VariantStats) GetIssued() uint32 {
+	if x != nil {
+		return x.Issued
+	}
+	return 0
+}
+
+func (x *VariantStats) GetRedeemed() uint32 {
+	if x != nil {
+		return x.Redeemed
+	}
+	return 0
+}
+
+func (x *VariantStats) GetRemaining() uint32 {
+	if x != nil {
+		return x.Remaining
+	}
+	return 0
+}
+
+// Budget caps what the coupons of a campaign cost, as redemptions spend what they discount. Each coupon issued reserves
+// what it is expected to cost until it is redeemed, revoked or expires unused, which is the projected cost if set, or
+// else the stored value or the largest fixed amount of the discounts the coupons can be issued with, and coupons are
+// issued only while the remaining budget covers another one.
+type Budget struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Amount *Money                 `protobuf:"bytes,1,opt,name=amount,proto3" json:"amount,omitempty"`
+	// What a coupon is expected to cost. Without it, coupons whose discounts depend on the order reserve nothing.
+	ProjectedCost   *Money   `protobuf:"bytes,2,opt,name=projected_cost,json=projectedCost,proto3" json:"projected_cost,omitempty"`
+	AlertThresholds []uint32 `protobuf:"varint,3,rep,packed,name=alert_thresholds,json=alertThresholds,proto3" json:"alert_thresholds,omitempty"` // in basis points of the amount spent, e.g. 8000 alerts once 80% is spent.
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *Budget) Reset() {
+	*x = Budget{}
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Budget) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Budget) ProtoMessage() {}
+
+func (x *Budget) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Budget.ProtoReflect.Descriptor instead.
+func (*Budget) Descriptor() ([]byte, []int) {
+	return file_protos_coupon_v1_coupon_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *Budget) GetAmount() *Money {
+	if x != nil {
+		return x.Amount
+	}
+	return nil
+}
+
+func (x *Budget) GetProjectedCost() *Money {
+	if x != nil {
+		return x.ProjectedCost
+	}
+	return nil
+}
+
+func (x *Budget) GetAlertThresholds() []uint32 {
+	if x != nil {
+		return x.AlertThresholds
+	}
+	return nil
+}
+
+type BudgetStats struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Spent         *Money                 `protobuf:"bytes,1,opt,name=spent,proto3" json:"spent,omitempty"`
+	Reserved      *Money                 `protobuf:"bytes,2,opt,name=reserved,proto3" json:"reserved,omitempty"`
+	Remaining     *Money                 `protobuf:"bytes,3,opt,name=remaining,proto3" json:"remaining,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BudgetStats) Reset() {
+	*x = BudgetStats{}
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BudgetStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BudgetStats) ProtoMessage() {}
+
+func (x *BudgetStats) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BudgetStats.ProtoReflect.Descriptor instead.
+func (*BudgetStats) Descriptor() ([]byte, []int) {
+	return file_protos_coupon_v1_coupon_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *BudgetStats) GetSpent() *Money {
+	if x != nil {
+		return x.Spent
+	}
+	return nil
+}
+
+func (x *BudgetStats) GetReserved() *Money {
+	if x != nil {
+		return x.Reserved
+	}
+	return nil
+}
+
+func (x *BudgetStats) GetRemaining() *Money {
+	if x != nil {
+		return x.Remaining
+	}
+	return nil
+}
+
+// AllocationPolicy splits the coupon limit of a campaign across the channels or partners which issue its coupons,
+// e.g. app, web or a partner's name. The limits of the allocations add up to the coupon limit.
+type AllocationPolicy struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Allocations []*Allocation          `protobuf:"bytes,1,rep,name=allocations,proto3" json:"allocations,omitempty"`
+	// after which the channels can issue what the others left of their allocations, if set.
+	SpilloverAt   *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=spillover_at,json=spilloverAt,proto3" json:"spillover_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AllocationPolicy) Reset() {
+	*x = AllocationPolicy{}
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AllocationPolicy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AllocationPolicy) ProtoMessage() {}
+
+func (x *AllocationPolicy) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AllocationPolicy.ProtoReflect.Descriptor instead.
+func (*AllocationPolicy) Descriptor() ([]byte, []int) {
+	return file_protos_coupon_v1_coupon_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *AllocationPolicy) GetAllocations() []*Allocation {
+	if x != nil {
+		return x.Allocations
+	}
+	return nil
+}
+
+func (x *AllocationPolicy) GetSpilloverAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.SpilloverAt
+	}
+	return nil
+}
+
+type Allocation struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Channel       string                 `protobuf:"bytes,1,opt,name=channel,proto3" json:"channel,omitempty"`
+	Limit         uint32                 `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Allocation) Reset() {
+	*x = Allocation{}
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Allocation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Allocation) ProtoMessage() {}
+
+func (x *Allocation) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Allocation.ProtoReflect.Descriptor instead.
+func (*Allocation) Descriptor() ([]byte, []int) {
+	return file_protos_coupon_v1_coupon_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *Allocation) GetChannel() string {
+	if x != nil {
+		return x.Channel
+	}
+	return ""
+}
+
+func (x *Allocation) GetLimit() uint32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type AllocationStats struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Channel       string                 `protobuf:"bytes,1,opt,name=channel,proto3" json:"channel,omitempty"`
+	Issued        uint32                 `protobuf:"varint,2,opt,name=issued,proto3" json:"issued,omitempty"`
+	Remaining     uint32                 `protobuf:"varint,3,opt,name=remaining,proto3" json:"remaining,omitempty"` // including what spilled over from the other channels.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AllocationStats) Reset() {
+	*x = AllocationStats{}
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AllocationStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AllocationStats) ProtoMessage() {}
+
+func (x *AllocationStats) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AllocationStats.ProtoReflect.Descriptor instead.
+func (*AllocationStats) Descriptor() ([]byte, []int) {
+	return file_protos_coupon_v1_coupon_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *AllocationStats) GetChannel() string {
+	if x != nil {
+		return x.Channel
+	}
+	return ""
+}
+
+func (x *AllocationStats) GetIssued() uint32 {
+	if x != nil {
+		return x.Issued
+	}
+	return 0
+}
+
+func (x *AllocationStats) GetRemaining() uint32 {
+	if x != nil {
+		return x.Remaining
+	}
+	return 0
+}
+
+// ReferralPolicy makes a campaign issue its coupons to users who were referred with a referral code, and a reward
+// coupon to the referrer once the referee first redeems theirs. The eligibility rule applies to the referees,
+// e.g. new_user to refer new users only. Rewards are not counted against the coupon limit.
+type ReferralPolicy struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MaxReferrals  uint32                 `protobuf:"varint,1,opt,name=max_referrals,json=maxReferrals,proto3" json:"max_referrals,omitempty"` // how many users each referrer can refer. Unlimited if 0.
+	Reward        *Discount              `protobuf:"bytes,2,opt,name=reward,proto3" json:"reward,omitempty"`                                  // what the reward coupons are worth. The same as the referees' coupons if unset.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReferralPolicy) Reset() {
+	*x = ReferralPolicy{}
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReferralPolicy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReferralPolicy) ProtoMessage() {}
+
+func (x *ReferralPolicy) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReferralPolicy.ProtoReflect.Descriptor instead.
+func (*ReferralPolicy) Descriptor() ([]byte, []int) {
+	return file_protos_coupon_v1_coupon_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ReferralPolicy) GetMaxReferrals() uint32 {
+	if x != nil {
+		return x.MaxReferrals
+	}
+	return 0
+}
+
+func (x *ReferralPolicy) GetReward() *Discount {
+	if x != nil {
+		return x.Reward
+	}
+	return nil
+}
+
+// Bundle issues one coupon of each of its campaigns together, e.g. as a welcome pack.
+type Bundle struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	CampaignIds   []uint32               `protobuf:"varint,4,rep,packed,name=campaign_ids,json=campaignIds,proto3" json:"campaign_ids,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Bundle) Reset() {
+	*x = Bundle{}
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Bundle) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Bundle) ProtoMessage() {}
+
+func (x *Bundle) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Bundle.ProtoReflect.Descriptor instead.
+func (*Bundle) Descriptor() ([]byte, []int) {
+	return file_protos_coupon_v1_coupon_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *Bundle) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Bundle) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Bundle) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Bundle) GetCampaignIds() []uint32 {
+	if x != nil {
+		return x.CampaignIds
+	}
+	return nil
+}
+
+func (x *Bundle) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+// ReferralCode is the code a referrer shares, with how it was used.
+type ReferralCode struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	CampaignId    uint32                 `protobuf:"varint,2,opt,name=campaign_id,json=campaignId,proto3" json:"campaign_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // the referrer.
+	Referrals     uint32                 `protobuf:"varint,4,opt,name=referrals,proto3" json:"referrals,omitempty"`        // the users issued a coupon with the code.
+	Rewards       uint32                 `protobuf:"varint,5,opt,name=rewards,proto3" json:"rewards,omitempty"`            // the reward coupons issued to the referrer.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReferralCode) Reset() {
+	*x = ReferralCode{}
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReferralCode) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReferralCode) ProtoMessage() {}
+
+func (x *ReferralCode) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReferralCode.ProtoReflect.Descriptor instead.
+func (*ReferralCode) Descriptor() ([]byte, []int) {
+	return file_protos_coupon_v1_coupon_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ReferralCode) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *ReferralCode) GetCampaignId() uint32 {
+	if x != nil {
+		return x.CampaignId
+	}
+	return 0
+}
+
+func (x *ReferralCode) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ReferralCode) GetReferrals() uint32 {
+	if x != nil {
+		return x.Referrals
+	}
+	return 0
+}
+
+func (x *ReferralCode) GetRewards() uint32 {
+	if x != nil {
+		return x.Rewards
+	}
+	return 0
+}
+
+// Waitlist queues users once a campaign is sold out. Each slot given back to the campaign, by a coupon revoked
+// back to the pool, expiring unused or reversed without being restored, is issued to the user who joined first.
+// The user is told of the coupon by WatchWaitlist as soon as it is issued, or finds it with GetWaitlistStatus,
+// and it is recorded as a CAMPAIGN_EVENT_TYPE_WAITLIST_ISSUED event.
+type Waitlist struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Waiting       uint64                 `protobuf:"varint,1,opt,name=waiting,proto3" json:"waiting,omitempty"`
+	Issued        uint64                 `protobuf:"varint,2,opt,name=issued,proto3" json:"issued,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Waitlist) Reset() {
+	*x = Waitlist{}
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Waitlist) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Waitlist) ProtoMessage() {}
+
+func (x *Waitlist) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Waitlist.ProtoReflect.Descriptor instead.
+func (*Waitlist) Descriptor() ([]byte, []int) {
+	return file_protos_coupon_v1_coupon_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *Waitlist) GetWaiting() uint64 {
+	if x != nil {
+		return x.Waiting
+	}
+	return 0
+}
+
+func (x *Waitlist) GetIssued() uint64 {
+	if x != nil {
+		return x.Issued
+	}
+	return 0
+}
+
+// Lottery collects one entry per user until end_at, then draws coupon_limit winners who are issued the coupons.
+// The winners are the entries with the lowest HMAC-SHA256 of their user IDs keyed with the seed, so anyone can
+// verify the draw from the revealed seed and the entries.
+type Lottery struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SeedHash      []byte                 `protobuf:"bytes,1,opt,name=seed_hash,json=seedHash,proto3" json:"seed_hash,omitempty"` // the SHA-256 of the seed, published before the draw so the seed cannot change.
+	Seed          []byte                 `protobuf:"bytes,2,opt,name=seed,proto3" json:"seed,omitempty"`                         // revealed once drawn.
+	Entries       uint64                 `protobuf:"varint,3,opt,name=entries,proto3" json:"entries,omitempty"`
+	DrawnAt       *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=drawn_at,json=drawnAt,proto3" json:"drawn_at,omitempty"`
+	Winners       uint32                 `protobuf:"varint,5,opt,name=winners,proto3" json:"winners,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Lottery) Reset() {
+	*x = Lottery{}
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Lottery) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Lottery) ProtoMessage() {}
+
+func (x *Lottery) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Lottery.ProtoReflect.Descriptor instead.
+func (*Lottery) Descriptor() ([]byte, []int) {
+	return file_protos_coupon_v1_coupon_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *Lottery) GetSeedHash() []byte {
+	if x != nil {
+		return x.SeedHash
+	}
+	return nil
+}
+
+func (x *Lottery) GetSeed() []byte {
+	if x != nil {
+		return x.Seed
+	}
+	return nil
+}
+
+func (x *Lottery) GetEntries() uint64 {
+	if x != nil {
+		return x.Entries
+	}
+	return 0
+}
+
+func (x *Lottery) GetDrawnAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DrawnAt
+	}
+	return nil
+}
+
+func (x *Lottery) GetWinners() uint32 {
+	if x != nil {
+		return x.Winners
+	}
+	return 0
+}
+
+// WaitingRoom makes users queue for a campaign. Tickets are admitted in order at a fixed rate,
+// and IssueCoupon requires the admission token of an admitted ticket.
+type WaitingRoom struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	AdmissionsPerSecond uint32                 `protobuf:"varint,1,opt,name=admissions_per_second,json=admissionsPerSecond,proto3" json:"admissions_per_second,omitempty"`
+	TokenTtl            *durationpb.Duration   `protobuf:"bytes,2,opt,name=token_ttl,json=tokenTtl,proto3" json:"token_ttl,omitempty"`        // how long an admission token stays valid. 5 minutes if unset.
+	MaxWaiting          uint32                 `protobuf:"varint,3,opt,name=max_waiting,json=maxWaiting,proto3" json:"max_waiting,omitempty"` // how many tickets can wait at once. 100000 if unset.
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *WaitingRoom) Reset() {
+	*x = WaitingRoom{}
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WaitingRoom) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WaitingRoom) ProtoMessage() {}
+
+func (x *WaitingRoom) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WaitingRoom.ProtoReflect.Descriptor instead.
+func (*WaitingRoom) Descriptor() ([]byte, []int) {
+	return file_protos_coupon_v1_coupon_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *WaitingRoom) GetAdmissionsPerSecond() uint32 {
+	if x != nil {
+		return x.AdmissionsPerSecond
+	}
+	return 0
+}
+
+func (x *WaitingRoom) GetTokenTtl() *durationpb.Duration {
+	if x != nil {
+		return x.TokenTtl
+	}
+	return nil
+}
+
+func (x *WaitingRoom) GetMaxWaiting() uint32 {
+	if x != nil {
+		return x.MaxWaiting
+	}
+	return 0
+}
+
+// Throttle spreads the coupon limit over time slices counted from the start of the campaign,
+// or of each occurrence of a recurring campaign.
+type Throttle struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Slice         *durationpb.Duration   `protobuf:"bytes,1,opt,name=slice,proto3" json:"slice,omitempty"`        // e.g. 600s.
+	Quota         uint32                 `protobuf:"varint,2,opt,name=quota,proto3" json:"quota,omitempty"`       // the coupons each slice can issue.
+	Rollover      bool                   `protobuf:"varint,3,opt,name=rollover,proto3" json:"rollover,omitempty"` // rolls the unused quota of a slice into the next ones.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Throttle) Reset() {
+	*x = Throttle{}
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Throttle) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Throttle) ProtoMessage() {}
+
+func (x *Throttle) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Throttle.ProtoReflect.Descriptor instead.
+func (*Throttle) Descriptor() ([]byte, []int) {
+	return file_protos_coupon_v1_coupon_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *Throttle) GetSlice() *durationpb.Duration {
+	if x != nil {
+		return x.Slice
+	}
+	return nil
+}
+
+func (x *Throttle) GetQuota() uint32 {
+	if x != nil {
+		return x.Quota
+	}
+	return 0
+}
+
+func (x *Throttle) GetRollover() bool {
+	if x != nil {
+		return x.Rollover
+	}
+	return false
+}
+
+// IssueThrottled is the error detail of an issuance rejected because the current slice used up its quota.
+type IssueThrottled struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	NextSliceAt   *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=next_slice_at,json=nextSliceAt,proto3" json:"next_slice_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IssueThrottled) Reset() {
+	*x = IssueThrottled{}
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IssueThrottled) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IssueThrottled) ProtoMessage() {}
+
+func (x *IssueThrottled) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IssueThrottled.ProtoReflect.Descriptor instead.
+func (*IssueThrottled) Descriptor() ([]byte, []int) {
+	return file_protos_coupon_v1_coupon_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *IssueThrottled) GetNextSliceAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.NextSliceAt
+	}
+	return nil
+}
+
+// Recurrence opens a campaign in recurring windows, e.g. every day at 10:00 in Asia/Seoul.
+// The coupon limit of a recurring campaign applies per occurrence.
+type Recurrence struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Schedule      string                 `protobuf:"bytes,1,opt,name=schedule,proto3" json:"schedule,omitempty"`                 // a cron expression of minute, hour, day of month, month and day of week, e.g. "0 10 * * *".
+	TimeZone      string                 `protobuf:"bytes,2,opt,name=time_zone,json=timeZone,proto3" json:"time_zone,omitempty"` // an IANA time zone the schedule is in. UTC if empty.
+	Window        *durationpb.Duration   `protobuf:"bytes,3,opt,name=window,proto3" json:"window,omitempty"`                     // how long each occurrence stays open. Until the next occurrence if unset.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Recurrence) Reset() {
+	*x = Recurrence{}
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Recurrence) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Recurrence) ProtoMessage() {}
+
+func (x *Recurrence) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Recurrence.ProtoReflect.Descriptor instead.
+func (*Recurrence) Descriptor() ([]byte, []int) {
+	return file_protos_coupon_v1_coupon_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *Recurrence) GetSchedule() string {
+	if x != nil {
+		return x.Schedule
+	}
+	return ""
+}
+
+func (x *Recurrence) GetTimeZone() string {
+	if x != nil {
+		return x.TimeZone
+	}
+	return ""
+}
+
+func (x *Recurrence) GetWindow() *durationpb.Duration {
+	if x != nil {
+		return x.Window
+	}
+	return nil
+}
+
+// Occurrence is an issuance window of a recurring campaign.
+type Occurrence struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	StartAt       *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=start_at,json=startAt,proto3" json:"start_at,omitempty"`
+	EndAt         *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=end_at,json=endAt,proto3" json:"end_at,omitempty"`
+	Issued        uint32                 `protobuf:"varint,3,opt,name=issued,proto3" json:"issued,omitempty"`
+	Remaining     uint32                 `protobuf:"varint,4,opt,name=remaining,proto3" json:"remaining,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Occurrence) Reset() {
+	*x = Occurrence{}
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Occurrence) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Occurrence) ProtoMessage() {}
+
+func (x *Occurrence) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Occurrence.ProtoReflect.Descriptor instead.
+func (*Occurrence) Descriptor() ([]byte, []int) {
+	return file_protos_coupon_v1_coupon_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *Occurrence) GetStartAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartAt
+	}
+	return nil
+}
+
+func (x *Occurrence) GetEndAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndAt
+	}
+	return nil
+}
+
+func (x *Occurrence) GetIssued() uint32 {
+	if x != nil {
+		return x.Issued
+	}
+	return 0
+}
+
+func (x *Occurrence) GetRemaining() uint32 {
+	if x != nil {
+		return x.Remaining
+	}
+	return 0
+}
+
+// BloomFilter checks a user list in a fixed amount of memory before the list itself,
+// so most unlisted users are told apart without probing the list. It takes its memory on top of the list's.
+type BloomFilter struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	ExpectedUsers     uint64                 `protobuf:"varint,1,opt,name=expected_users,json=expectedUsers,proto3" json:"expected_users,omitempty"`                // the number of users the filter is sized for, at most 10,000,000.
+	FalsePositiveRate float64                `protobuf:"fixed64,2,opt,name=false_positive_rate,json=falsePositiveRate,proto3" json:"false_positive_rate,omitempty"` // the rate once expected_users are added, e.g. 0.001.
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *BloomFilter) Reset() {
+	*x = BloomFilter{}
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BloomFilter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BloomFilter) ProtoMessage() {}
+
+func (x *BloomFilter) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BloomFilter.ProtoReflect.Descriptor instead.
+func (*BloomFilter) Descriptor() ([]byte, []int) {
+	return file_protos_coupon_v1_coupon_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *BloomFilter) GetExpectedUsers() uint64 {
+	if x != nil {
+		return x.ExpectedUsers
+	}
+	return 0
+}
+
+func (x *BloomFilter) GetFalsePositiveRate() float64 {
+	if x != nil {
+		return x.FalsePositiveRate
+	}
+	return 0
+}
+
+// UserList summarizes a user list attached to a campaign.
+type UserList struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Kind          UserListKind           `protobuf:"varint,1,opt,name=kind,proto3,enum=protos.coupon.v1.UserListKind" json:"kind,omitempty"`
+	UserCount     uint64                 `protobuf:"varint,2,opt,name=user_count,json=userCount,proto3" json:"user_count,omitempty"` // the distinct users.
+	SizeBytes     uint64                 `protobuf:"varint,3,opt,name=size_bytes,json=sizeBytes,proto3" json:"size_bytes,omitempty"` // including the Bloom filter.
+	BloomFilter   *BloomFilter           `protobuf:"bytes,4,opt,name=bloom_filter,json=bloomFilter,proto3" json:"bloom_filter,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UserList) Reset() {
+	*x = UserList{}
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserList) ProtoMessage() {}
+
+func (x *UserList) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserList.ProtoReflect.Descriptor instead.
+func (*UserList) Descriptor() ([]byte, []int) {
+	return file_protos_coupon_v1_coupon_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *UserList) GetKind() UserListKind {
+	if x != nil {
+		return x.Kind
+	}
+	return UserListKind_USER_LIST_KIND_UNSPECIFIED
+}
+
+func (x *UserList) GetUserCount() uint64 {
+	if x != nil {
+		return x.UserCount
+	}
+	return 0
+}
+
+func (x *UserList) GetSizeBytes() uint64 {
+	if x != nil {
+		return x.SizeBytes
+	}
+	return 0
+}
+
+func (x *UserList) GetBloomFilter() *BloomFilter {
+	if x != nil {
+		return x.BloomFilter
+	}
+	return nil
+}
+
+// UserAttributes describe a user for the eligibility expressions of campaigns, e.g.
+// `new_user || (tier >= "gold" && "vip-event" in segments)`.
+type UserAttributes struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	NewUser       bool                   `protobuf:"varint,1,opt,name=new_user,json=newUser,proto3" json:"new_user,omitempty"`
+	Tier          string                 `protobuf:"bytes,2,opt,name=tier,proto3" json:"tier,omitempty"` // one of bronze, silver, gold, platinum and diamond, in ascending order.
+	Segments      []string               `protobuf:"bytes,3,rep,name=segments,proto3" json:"segments,omitempty"`
+	OrderCount    int64                  `protobuf:"varint,4,opt,name=order_count,json=orderCount,proto3" json:"order_count,omitempty"`
+	Country       string                 `protobuf:"bytes,5,opt,name=country,proto3" json:"country,omitempty"` // an ISO 3166-1 alpha-2 country code, e.g. KR.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UserAttributes) Reset() {
+	*x = UserAttributes{}
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserAttributes) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserAttributes) ProtoMessage() {}
+
+func (x *UserAttributes) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserAttributes.ProtoReflect.Descriptor instead.
+func (*UserAttributes) Descriptor() ([]byte, []int) {
+	return file_protos_coupon_v1_coupon_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *UserAttributes) GetNewUser() bool {
+	if x != nil {
+		return x.NewUser
+	}
+	return false
+}
+
+func (x *UserAttributes) GetTier() string {
+	if x != nil {
+		return x.Tier
+	}
+	return ""
+}
+
+func (x *UserAttributes) GetSegments() []string {
+	if x != nil {
+		return x.Segments
+	}
+	return nil
+}
+
+func (x *UserAttributes) GetOrderCount() int64 {
+	if x != nil {
+		return x.OrderCount
+	}
+	return 0
+}
+
+func (x *UserAttributes) GetCountry() string {
+	if x != nil {
+		return x.Country
+	}
+	return ""
+}
+
+// StackingPolicy decides which coupons can be combined in a cart, and in which order they are applied.
+type StackingPolicy struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Mode          StackingMode           `protobuf:"varint,1,opt,name=mode,proto3,enum=protos.coupon.v1.StackingMode" json:"mode,omitempty"`
+	Group         string                 `protobuf:"bytes,2,opt,name=group,proto3" json:"group,omitempty"`
+	Priority      int32                  `protobuf:"varint,3,opt,name=priority,proto3" json:"priority,omitempty"` // coupons of higher priority are applied first, and preferred on ties.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StackingPolicy) Reset() {
+	*x = StackingPolicy{}
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StackingPolicy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StackingPolicy) ProtoMessage() {}
+
+func (x *StackingPolicy) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StackingPolicy.ProtoReflect.Descriptor instead.
+func (*StackingPolicy) Descriptor() ([]byte, []int) {
+	return file_protos_coupon_v1_coupon_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *StackingPolicy) GetMode() StackingMode {
+	if x != nil {
+		return x.Mode
+	}
+	return StackingMode_STACKING_MODE_UNSPECIFIED
+}
+
+func (x *StackingPolicy) GetGroup() string {
+	if x != nil {
+		return x.Group
+	}
+	return ""
+}
+
+func (x *StackingPolicy) GetPriority() int32 {
+	if x != nil {
+		return x.Priority
+	}
+	return 0
+}
+
+// Applicability limits what a coupon can be used for. Empty lists don't limit anything.
+// A line item is eligible if it matches every include list that is set and none of the exclude lists.
+type Applicability struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	IncludeSkus       []string               `protobuf:"bytes,1,rep,name=include_skus,json=includeSkus,proto3" json:"include_skus,omitempty"`
+	ExcludeSkus       []string               `protobuf:"bytes,2,rep,name=exclude_skus,json=excludeSkus,proto3" json:"exclude_skus,omitempty"`
+	IncludeCategories []string               `protobuf:"bytes,3,rep,name=include_categories,json=includeCategories,proto3" json:"include_categories,omitempty"`
+	ExcludeCategories []string               `protobuf:"bytes,4,rep,name=exclude_categories,json=excludeCategories,proto3" json:"exclude_categories,omitempty"`
+	IncludeBrands     []string               `protobuf:"bytes,5,rep,name=include_brands,json=includeBrands,proto3" json:"include_brands,omitempty"`
+	ExcludeBrands     []string               `protobuf:"bytes,6,rep,name=exclude_brands,json=excludeBrands,proto3" json:"exclude_brands,omitempty"`
+	Channels          []Channel              `protobuf:"varint,7,rep,packed,name=channels,proto3,enum=protos.coupon.v1.Channel" json:"channels,omitempty"`
+	PaymentMethods    []string               `protobuf:"bytes,8,rep,name=payment_methods,json=paymentMethods,proto3" json:"payment_methods,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *Applicability) Reset() {
+	*x = Applicability{}
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Applicability) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Applicability) ProtoMessage() {}
+
+func (x *Applicability) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Applicability.ProtoReflect.Descriptor instead.
+func (*Applicability) Descriptor() ([]byte, []int) {
+	return file_protos_coupon_v1_coupon_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *Applicability) GetIncludeSkus() []string {
+	if x != nil {
+		return x.IncludeSkus
+	}
+	return nil
+}
+
+func (x *Applicability) GetExcludeSkus() []string {
+	if x != nil {
+		return x.ExcludeSkus
+	}
+	return nil
+}
+
+func (x *Applicability) GetIncludeCategories() []string {
+	if x != nil {
+		return x.IncludeCategories
+	}
+	return nil
+}
+
+func (x *Applicability) GetExcludeCategories() []string {
+	if x != nil {
+		return x.ExcludeCategories
+	}
+	return nil
+}
+
+func (x *Applicability) GetIncludeBrands() []string {
+	if x != nil {
+		return x.IncludeBrands
+	}
+	return nil
+}
+
+func (x *Applicability) GetExcludeBrands() []string {
+	if x != nil {
+		return x.ExcludeBrands
+	}
+	return nil
+}
+
+func (x *Applicability) GetChannels() []Channel {
+	if x != nil {
+		return x.Channels
+	}
+	return nil
+}
+
+func (x *Applicability) GetPaymentMethods() []string {
+	if x != nil {
+		return x.PaymentMethods
+	}
+	return nil
+}
+
+// Money is an exact amount in the minor unit of the currency, e.g. cents for USD and won for KRW.
+type Money struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Currency      string                 `protobuf:"bytes,1,opt,name=currency,proto3" json:"currency,omitempty"` // an ISO 4217 currency code, e.g. KRW.
+	Amount        int64                  `protobuf:"varint,2,opt,name=amount,proto3" json:"amount,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Money) Reset() {
+	*x = Money{}
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Money) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Money) ProtoMessage() {}
+
+func (x *Money) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Money.ProtoReflect.Descriptor instead.
+func (*Money) Descriptor() ([]byte, []int) {
+	return file_protos_coupon_v1_coupon_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *Money) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *Money) GetAmount() int64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+// Discount describes what a coupon is worth.
+type Discount struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Kind:
+	//
+	//	*Discount_FixedAmount_
+	//	*Discount_Percentage_
+	//	*Discount_FreeShipping_
+	//	*Discount_BuyXGetY_
+	Kind           isDiscount_Kind `protobuf_oneof:"kind"`
+	MinOrderAmount *Money          `protobuf:"bytes,5,opt,name=min_order_amount,json=minOrderAmount,proto3" json:"min_order_amount,omitempty"` // the discount applies only to orders of at least this amount.
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *Discount) Reset() {
+	*x = Discount{}
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Discount) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Discount) ProtoMessage() {}
+
+func (x *Discount) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Discount.ProtoReflect.Descriptor instead.
+func (*Discount) Descriptor() ([]byte, []int) {
+	return file_protos_coupon_v1_coupon_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *Discount) GetKind() isDiscount_Kind {
+	if x != nil {
+		return x.Kind
+	}
+	return nil
+}
+
+func (x *Discount) GetFixedAmount() *Discount_FixedAmount {
+	if x != nil {
+		if x, ok := x.Kind.(*Discount_FixedAmount_); ok {
+			return x.FixedAmount
+		}
+	}
+	return nil
+}
+
+func (x *Discount) GetPercentage() *Discount_Percentage {
+	if x != nil {
+		if x, ok := x.Kind.(*Discount_Percentage_); ok {
+			return x.Percentage
+		}
+	}
+	return nil
+}
+
+func (x *Discount) GetFreeShipping() *Discount_FreeShipping {
+	if x != nil {
+		if x, ok := x.Kind.(*Discount_FreeShipping_); ok {
+			return x.FreeShipping
+		}
+	}
+	return nil
+}
+
+func (x *Discount) GetBuyXGetY() *Discount_BuyXGetY {
+	if x != nil {
+		if x, ok := x.Kind.(*Discount_BuyXGetY_); ok {
+			return x.BuyXGetY
+		}
+	}
+	return nil
+}
+
+func (x *Discount) GetMinOrderAmount() *Money {
+	if x != nil {
+		return x.MinOrderAmount
+	}
+	return nil
+}
+
+type isDiscount_Kind interface {
+	isDiscount_Kind()
+}
+
+type Discount_FixedAmount_ struct {
+	FixedAmount *Discount_FixedAmount `protobuf:"bytes,1,opt,name=fixed_amount,json=fixedAmount,proto3,oneof"`
+}
+
+type Discount_Percentage_ struct {
+	Percentage *Discount_Percentage `protobuf:"bytes,2,opt,name=percentage,proto3,oneof"`
+}
+
+type Discount_FreeShipping_ struct {
+	FreeShipping *Discount_FreeShipping `protobuf:"bytes,3,opt,name=free_shipping,json=freeShipping,proto3,oneof"`
+}
+
+type Discount_BuyXGetY_ struct {
+	BuyXGetY *Discount_BuyXGetY `protobuf:"bytes,4,opt,name=buy_x_get_y,json=buyXGetY,proto3,oneof"`
+}
+
+func (*Discount_FixedAmount_) isDiscount_Kind() {}
+
+func (*Discount_Percentage_) isDiscount_Kind() {}
+
+func (*Discount_FreeShipping_) isDiscount_Kind() {}
+
+func (*Discount_BuyXGetY_) isDiscount_Kind() {}
+
+// ExpiryPolicy decides when an issued coupon expires. It is evaluated at issue time.
+// Coupons expire at the end of their campaign if no policy is set.
+type ExpiryPolicy struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Policy:
+	//
+	//	*ExpiryPolicy_FixedAt
+	//	*ExpiryPolicy_Ttl
+	//	*ExpiryPolicy_EndOfDay_
+	//	*ExpiryPolicy_Earliest_
+	Policy        isExpiryPolicy_Policy `protobuf_oneof:"policy"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExpiryPolicy) Reset() {
+	*x = ExpiryPolicy{}
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExpiryPolicy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExpiryPolicy) ProtoMessage() {}
+
+func (x *ExpiryPolicy) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExpiryPolicy.ProtoReflect.Descriptor instead.
+func (*ExpiryPolicy) Descriptor() ([]byte, []int) {
+	return file_protos_coupon_v1_coupon_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *ExpiryPolicy) GetPolicy() isExpiryPolicy_Policy {
+	if x != nil {
+		return x.Policy
+	}
+	return nil
+}
+
+func (x *ExpiryPolicy) GetFixedAt() *timestamppb.Timestamp {
+	if x != nil {
+		if x, ok := x.Policy.(*ExpiryPolicy_FixedAt); ok {
+			return x.FixedAt
+		}
+	}
+	return nil
+}
+
+func (x *ExpiryPolicy) GetTtl() *durationpb.Duration {
+	if x != nil {
+		if x, ok := x.Policy.(*ExpiryPolicy_Ttl); ok {
+			return x.Ttl
+		}
+	}
+	return nil
+}
+
+func (x *ExpiryPolicy) GetEndOfDay() *ExpiryPolicy_EndOfDay {
+	if x != nil {
+		if x, ok := x.Policy.(*ExpiryPolicy_EndOfDay_); ok {
+			return x.EndOfDay
+		}
+	}
+	return nil
+}
+
+func (x *ExpiryPolicy) GetEarliest() *ExpiryPolicy_Earliest {
+	if x != nil {
+		if x, ok := x.Policy.(*ExpiryPolicy_Earliest_); ok {
+			return x.Earliest
+		}
+	}
+	return nil
+}
+
+type isExpiryPolicy_Policy interface {
+	isExpiryPolicy_Policy()
+}
+
+type ExpiryPolicy_FixedAt struct {
+	FixedAt *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=fixed_at,json=fixedAt,proto3,oneof"` // an absolute date.
+}
+
+type ExpiryPolicy_Ttl struct {
+	Ttl *durationpb.Duration `protobuf:"bytes,2,opt,name=ttl,proto3,oneof"` // a time to live from issuance, e.g. 72h.
+}
+
+type ExpiryPolicy_EndOfDay_ struct {
+	EndOfDay *ExpiryPolicy_EndOfDay `protobuf:"bytes,3,opt,name=end_of_day,json=endOfDay,proto3,oneof"`
+}
+
+type ExpiryPolicy_Earliest_ struct {
+	Earliest *ExpiryPolicy_Earliest `protobuf:"bytes,4,opt,name=earliest,proto3,oneof"`
+}
+
+func (*ExpiryPolicy_FixedAt) isExpiryPolicy_Policy() {}
+
+func (*ExpiryPolicy_Ttl) isExpiryPolicy_Policy() {}
+
+func (*ExpiryPolicy_EndOfDay_) isExpiryPolicy_Policy() {}
+
+func (*ExpiryPolicy_Earliest_) isExpiryPolicy_Policy() {}
+
+type CampaignEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Type          CampaignEventType      `protobuf:"varint,1,opt,name=type,proto3,enum=protos.coupon.v1.CampaignEventType" json:"type,omitempty"`
+	Code          string                 `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"`
+	Reason        string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	OccurredAt    *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=occurred_at,json=occurredAt,proto3" json:"occurred_at,omitempty"`
+	UserId        string                 `protobuf:"bytes,5,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // the user the event is for, if any.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CampaignEvent) Reset() {
+	*x = CampaignEvent{}
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CampaignEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CampaignEvent) ProtoMessage() {}
+
+func (x *CampaignEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CampaignEvent.ProtoReflect.Descriptor instead.
+func (*CampaignEvent) Descriptor() ([]byte, []int) {
+	return file_protos_coupon_v1_coupon_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *CampaignEvent) GetType() CampaignEventType {
+	if x != nil {
+		return x.Type
+	}
+	return CampaignEventType_CAMPAIGN_EVENT_TYPE_UNSPECIFIED
+}
+
+func (x *CampaignEvent) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *CampaignEvent) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *CampaignEvent) GetOccurredAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.OccurredAt
+	}
+	return nil
+}
+
+func (x *CampaignEvent) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type CreateCampaignRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CouponLimit   uint32                 `protobuf:"varint,1,opt,name=coupon_limit,json=couponLimit,proto3" json:"coupon_limit,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	StartAt       *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=start_at,json=startAt,proto3" json:"start_at,omitempty"`
+	EndAt         *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=end_at,json=endAt,proto3" json:"end_at,omitempty"`
+	ExpiryPolicy  *ExpiryPolicy          `protobuf:"bytes,6,opt,name=expiry_policy,json=expiryPolicy,proto3" json:"expiry_policy,omitempty"`
+	Discount      *Discount              `protobuf:"bytes,7,opt,name=discount,proto3" json:"discount,omitempty"`
+	Applicability *Applicability         `protobuf:"bytes,8,opt,name=applicability,proto3" json:"applicability,omitempty"`
+	Stacking      *StackingPolicy        `protobuf:"bytes,9,opt,name=stacking,proto3" json:"stacking,omitempty"`
+	Eligibility   string                 `protobuf:"bytes,10,opt,name=eligibility,proto3" json:"eligibility,omitempty"` // a boolean expression over UserAttributes. Anyone is eligible if empty.
+	Draft         bool                   `protobuf:"varint,11,opt,name=draft,proto3" json:"draft,omitempty"`            // creates the campaign unpublished, until ResumeCampaign publishes it.
+	Recurrence    *Recurrence            `protobuf:"bytes,12,opt,name=recurrence,proto3" json:"recurrence,omitempty"`
+	Throttle      *Throttle              `protobuf:"bytes,13,opt,name=throttle,proto3" json:"throttle,omitempty"`
+	WaitingRoom   *WaitingRoom           `protobuf:"bytes,14,opt,name=waiting_room,json=waitingRoom,proto3" json:"waiting_room,omitempty"`
+	Lottery       bool                   `protobuf:"varint,15,opt,name=lottery,proto3" json:"lottery,omitempty"`                                                                      // draws the coupons among entries at end_at instead of issuing them first come, first served.
+	Waitlist      bool                   `protobuf:"varint,16,opt,name=waitlist,proto3" json:"waitlist,omitempty"`                                                                    // lets users join a waitlist once the campaign is sold out.
+	StoredValue   *Money                 `protobuf:"bytes,17,opt,name=stored_value,json=storedValue,proto3" json:"stored_value,omitempty"`                                            // issues gift card style coupons starting with this balance instead of a discount.
+	RestorePolicy RestorePolicy          `protobuf:"varint,18,opt,name=restore_policy,json=restorePolicy,proto3,enum=protos.coupon.v1.RestorePolicy" json:"restore_policy,omitempty"` // what reversing a redemption of the coupons does.
+	Transfer      *TransferPolicy        `protobuf:"bytes,19,opt,name=transfer,proto3" json:"transfer,omitempty"`                                                                     // lets the coupons be transferred between users. Not transferable if unset.
+	Referral      *ReferralPolicy        `protobuf:"bytes,20,opt,name=referral,proto3" json:"referral,omitempty"`                                                                     // issues the coupons for referrals only.
+	Tiers         []*Tier                `protobuf:"bytes,21,rep,name=tiers,proto3" json:"tiers,omitempty"`                                                                           // in order, instead of a discount. The limits add up to the coupon limit unless the last is 0.
+	Variants      []*Variant             `protobuf:"bytes,22,rep,name=variants,proto3" json:"variants,omitempty"`                                                                     // instead of a discount. The coupons of the campaign are issued to users only.
+	Budget        *Budget                `protobuf:"bytes,23,opt,name=budget,proto3" json:"budget,omitempty"`
+	Allocation    *AllocationPolicy      `protobuf:"bytes,24,opt,name=allocation,proto3" json:"allocation,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateCampaignRequest) Reset() {
+	*x = CreateCampaignRequest{}
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateCampaignRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateCampaignRequest) ProtoMessage() {}
+
+func (x *CreateCampaignRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateCampaignRequest.ProtoReflect.Descriptor instead.
+func (*CreateCampaignRequest) Descriptor() ([]byte, []int) {
+	return file_protos_coupon_v1_coupon_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *CreateCampaignRequest) GetCouponLimit() uint32 {
+	if x != nil {
+		return x.CouponLimit
+	}
+	return 0
+}
+
+func (x *CreateCampaignRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateCampaignRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *CreateCampaignRequest) GetStartAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartAt
+	}
+	return nil
+}
+
+func (x *CreateCampaignRequest) GetEndAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndAt
+	}
+	return nil
+}
+
+func (x *CreateCampaignRequest) GetExpiryPolicy() *ExpiryPolicy {
+	if x != nil {
+		return x.ExpiryPolicy
+	}
+	return nil
+}
+
+func (x *CreateCampaignRequest) GetDiscount() *Discount {
+	if x != nil {
+		return x.Discount
+	}
+	return nil
+}
+
+func (x *CreateCampaignRequest) GetApplicability() *Applicability {
+	if x != nil {
+		return x.Applicability
+	}
+	return nil
+}
+
+func (x *CreateCampaignRequest) GetStacking() *StackingPolicy {
+	if x != nil {
+		return x.Stacking
+	}
+	return nil
+}
+
+func (x *CreateCampaignRequest) GetEligibility() string {
+	if x != nil {
+		return x.Eligibility
+	}
+	return ""
+}
+
+func (x *CreateCampaignRequest) GetDraft() bool {
+	if x != nil {
+		return x.Draft
+	}
+	return false
+}
+
+func (x *CreateCampaignRequest) GetRecurrence() *Recurrence {
+	if x != nil {
+		return x.Recurrence
+	}
+	return nil
+}
+
+func (x *CreateCampaignRequest) GetThrottle() *Throttle {
+	if x != nil {
+		return x.Throttle
+	}
+	return nil
+}
+
+func (x *CreateCampaignRequest) GetWaitingRoom() *WaitingRoom {
+	if x != nil {
+		return x.WaitingRoom
+	}
+	return nil
+}
+
+func (x *CreateCampaignRequest) GetLottery() bool {
+	if x != nil {
+		return x.Lottery
+	}
+	return false
+}
+
+func (x *CreateCampaignRequest) GetWaitlist() bool {
+	if x != nil {
+		return x.Waitlist
+	}
+	return false
+}
+
+func (x *CreateCampaignRequest) GetStoredValue() *Money {
+	if x != nil {
+		return x.StoredValue
+	}
+	return nil
+}
+
+func (x *CreateCampaignRequest) GetRestorePolicy() RestorePolicy {
+	if x != nil {
+		return x.RestorePolicy
+	}
+	return RestorePolicy_RESTORE_POLICY_UNSPECIFIED
+}
+
+func (x *CreateCampaignRequest) GetTransfer() *TransferPolicy {
+	if x != nil {
+		return x.Transfer
+	}
+	return nil
+}
+
+func (x *CreateCampaignRequest) GetReferral() *ReferralPolicy {
+	if x != nil {
+		return x.Referral
+	}
+	return nil
+}
+
+func (x *CreateCampaignRequest) GetTiers() []*Tier {
+	if x != nil {
+		return x.Tiers
+	}
+	return nil
+}
+
+func (x *CreateCampaignRequest) GetVariants() []*Variant {
+	if x != nil {
+		return x.Variants
+	}
+	return nil
+}
+
+func (x *CreateCampaignRequest) GetBudget() *Budget {
+	if x != nil {
+		return x.Budget
+	}
+	return nil
+}
+
+func (x *CreateCampaignRequest) GetAllocation() *AllocationPolicy {
+	if x != nil {
+		return x.Allocation
+	}
+	return nil
+}
+
+type CreateCampaignResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Campaign      *Campaign              `protobuf:"bytes,1,opt,name=campaign,proto3" json:"campaign,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateCampaignResponse) Reset() {
+	*x = CreateCampaignResponse{}
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateCampaignResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateCampaignResponse) ProtoMessage() {}
+
+func (x *CreateCampaignResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateCampaignResponse.ProtoReflect.Descriptor instead.
+func (*CreateCampaignResponse) Descriptor() ([]byte, []int) {
+	return file_protos_coupon_v1_coupon_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *CreateCampaignResponse) GetCampaign() *Campaign {
+	if x != nil {
+		return x.Campaign
+	}
+	return nil
+}
+
+type GetCampaignRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CampaignId    uint32                 `protobuf:"varint,1,opt,name=campaign_id,json=campaignId,proto3" json:"campaign_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCampaignRequest) Reset() {
+	*x = GetCampaignRequest{}
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCampaignRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCampaignRequest) ProtoMessage() {}
+
+func (x *GetCampaignRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCampaignRequest.ProtoReflect.Descriptor instead.
+func (*GetCampaignRequest) Descriptor() ([]byte, []int) {
+	return file_protos_coupon_v1_coupon_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *GetCampaignRequest) GetCampaignId() uint32 {
+	if x != nil {
+		return x.CampaignId
+	}
+	return 0
+}
+
+type GetCampaignResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Campaign      *Campaign              `protobuf:"bytes,1,opt,name=campaign,proto3" json:"campaign,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCampaignResponse) Reset() {
+	*x = GetCampaignResponse{}
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCampaignResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCampaignResponse) ProtoMessage() {}
+
+func (x *GetCampaignResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCampaignResponse.ProtoReflect.Descriptor instead.
+func (*GetCampaignResponse) Descriptor() ([]byte, []int) {
+	return file_protos_coupon_v1_coupon_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *GetCampaignResponse) GetCampaign() *Campaign {
+	if x != nil {
+		return x.Campaign
+	}
+	return nil
+}
+
+type PauseCampaignRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CampaignId    uint32                 `protobuf:"varint,1,opt,name=campaign_id,json=campaignId,proto3" json:"campaign_id,omitempty"`
+	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PauseCampaignRequest) Reset() {
+	*x = PauseCampaignRequest{}
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PauseCampaignRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PauseCampaignRequest) ProtoMessage() {}
+
+func (x *PauseCampaignRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PauseCampaignRequest.ProtoReflect.Descriptor instead.
+func (*PauseCampaignRequest) Descriptor() ([]byte, []int) {
+	return file_protos_coupon_v1_coupon_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *PauseCampaignRequest) GetCampaignId() uint32 {
+	if x != nil {
+		return x.CampaignId
+	}
+	return 0
+}
+
+func (x *PauseCampaignRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type PauseCampaignResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Campaign      *Campaign              `protobuf:"bytes,1,opt,name=campaign,proto3" json:"campaign,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PauseCampaignResponse) Reset() {
+	*x = PauseCampaignResponse{}
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PauseCampaignResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PauseCampaignResponse) ProtoMessage() {}
+
+func (x *PauseCampaignResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PauseCampaignResponse.ProtoReflect.Descriptor instead.
+func (*PauseCampaignResponse) Descriptor() ([]byte, []int) {
+	return file_protos_coupon_v1_coupon_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *PauseCampaignResponse) GetCampaign() *Campaign {
+	if x != nil {
+		return x.Campaign
+	}
+	return nil
+}
+
+// ResumeCampaignRequest resumes a paused campaign, or publishes a draft.
+type ResumeCampaignRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CampaignId    uint32                 `protobuf:"varint,1,opt,name=campaign_id,json=campaignId,proto3" json:"campaign_id,omitempty"`
+	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResumeCampaignRequest) Reset() {
+	*x = ResumeCampaignRequest{}
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResumeCampaignRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResumeCampaignRequest) ProtoMessage() {}
+
+func (x *ResumeCampaignRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResumeCampaignRequest.ProtoReflect.Descriptor instead.
+func (*ResumeCampaignRequest) Descriptor() ([]byte, []int) {
+	return file_protos_coupon_v1_coupon_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *ResumeCampaignRequest) GetCampaignId() uint32 {
+	if x != nil {
+		return x.CampaignId
+	}
+	return 0
+}
+
+func (x *ResumeCampaignRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type ResumeCampaignResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Campaign      *Campaign              `protobuf:"bytes,1,opt,name=campaign,proto3" json:"campaign,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResumeCampaignResponse) Reset() {
+	*x = ResumeCampaignResponse{}
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResumeCampaignResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResumeCampaignResponse) ProtoMessage() {}
+
+func (x *ResumeCampaignResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResumeCampaignResponse.ProtoReflect.Descriptor instead.
+func (*ResumeCampaignResponse) Descriptor() ([]byte, []int) {
+	return file_protos_coupon_v1_coupon_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *ResumeCampaignResponse) GetCampaign() *Campaign {
+	if x != nil {
+		return x.Campaign
+	}
+	return nil
+}
+
+// CloseCampaignRequest stops a campaign for good. Its issued coupons are no longer valid.
+type CloseCampaignRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CampaignId    uint32                 `protobuf:"varint,1,opt,name=campaign_id,json=campaignId,proto3" json:"campaign_id,omitempty"`
+	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CloseCampaignRequest) Reset() {
+	*x = CloseCampaignRequest{}
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CloseCampaignRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CloseCampaignRequest) ProtoMessage() {}
+
+func (x *CloseCampaignRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CloseCampaignRequest.ProtoReflect.Descriptor instead.
+func (*CloseCampaignRequest) Descriptor() ([]byte, []int) {
+	return file_protos_coupon_v1_coupon_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *CloseCampaignRequest) GetCampaignId() uint32 {
+	if x != nil {
+		return x.CampaignId
+	}
+	return 0
+}
+
+func (x *CloseCampaignRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type CloseCampaignResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Campaign      *Campaign              `protobuf:"bytes,1,opt,name=campaign,proto3" json:"campaign,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CloseCampaignResponse) Reset() {
+	*x = CloseCampaignResponse{}
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CloseCampaignResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CloseCampaignResponse) ProtoMessage() {}
+
+func (x *CloseCampaignResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CloseCampaignResponse.ProtoReflect.Descriptor instead.
+func (*CloseCampaignResponse) Descriptor() ([]byte, []int) {
+	return file_protos_coupon_v1_coupon_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *CloseCampaignResponse) GetCampaign() *Campaign {
+	if x != nil {
+		return x.Campaign
+	}
+	return nil
+}
+
+type IssueCouponRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	CampaignId     uint32                 `protobuf:"varint,1,opt,name=campaign_id,json=campaignId,proto3" json:"campaign_id,omitempty"`
+	UserId         string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	UserAttributes *UserAttributes        `protobuf:"bytes,3,opt,name=user_attributes,json=userAttributes,proto3" json:"user_attributes,omitempty"` // resolved by the server's attribute provider if not given.
+	AdmissionToken string                 `protobuf:"bytes,4,opt,name=admission_token,json=admissionToken,proto3" json:"admission_token,omitempty"` // required if the campaign has a waiting room.
+	ReferralCode   string                 `protobuf:"bytes,5,opt,name=referral_code,json=referralCode,proto3" json:"referral_code,omitempty"`       // required if the campaign is a referral campaign.
+	Channel        string                 `protobuf:"bytes,6,opt,name=channel,proto3" json:"channel,omitempty"`                                     // the channel or partner issuing, required if the campaign is allocated.
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *IssueCouponRequest) Reset() {
+	*x = IssueCouponRequest{}
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IssueCouponRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IssueCouponRequest) ProtoMessage() {}
+
+func (x *IssueCouponRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IssueCouponRequest.ProtoReflect.Descriptor instead.
+func (*IssueCouponRequest) Descriptor() ([]byte, []int) {
+	return file_protos_coupon_v1_coupon_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *IssueCouponRequest) GetCampaignId() uint32 {
+	if x != nil {
+		return x.CampaignId
+	}
+	return 0
+}
+
+func (x *IssueCouponRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *IssueCouponRequest) GetUserAttributes() *UserAttributes {
+	if x != nil {
+		return x.UserAttributes
+	}
+	return nil
+}
+
+func (x *IssueCouponRequest) GetAdmissionToken() string {
+	if x != nil {
+		return x.AdmissionToken
+	}
+	return ""
+}
+
+func (x *IssueCouponRequest) GetReferralCode() string {
+	if x != nil {
+		return x.ReferralCode
+	}
+	return ""
+}
+
+func (x *IssueCouponRequest) GetChannel() string {
+	if x != nil {
+		return x.Channel
+	}
+	return ""
+}
+
+type IssueCouponResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Coupon        *Coupon                `protobuf:"bytes,1,opt,name=coupon,proto3" json:"coupon,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IssueCouponResponse) Reset() {
+	*x = IssueCouponResponse{}
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IssueCouponResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IssueCouponResponse) ProtoMessage() {}
+
+func (x *IssueCouponResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IssueCouponResponse.ProtoReflect.Descriptor instead.
+func (*IssueCouponResponse) Descriptor() ([]byte, []int) {
+	return file_protos_coupon_v1_coupon_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *IssueCouponResponse) GetCoupon() *Coupon {
+	if x != nil {
+		return x.Coupon
+	}
+	return nil
+}
+
+type EnterQueueRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CampaignId    uint32                 `protobuf:"varint,1,opt,name=campaign_id,json=campaignId,proto3" json:"campaign_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // required. A user entering again gets the same ticket until its admission expires.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EnterQueueRequest) Reset() {
+	*x = EnterQueueRequest{}
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EnterQueueRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EnterQueueRequest) ProtoMessage() {}
+
+func (x *EnterQueueRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EnterQueueRequest.ProtoReflect.Descriptor instead.
+func (*EnterQueueRequest) Descriptor() ([]byte, []int) {
+	return file_protos_coupon_v1_coupon_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *EnterQueueRequest) GetCampaignId() uint32 {
+	if x != nil {
+		return x.CampaignId
+	}
+	return 0
+}
+
+func (x *EnterQueueRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type EnterQueueResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Status        *QueueStatus           `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EnterQueueResponse) Reset() {
+	*x = EnterQueueResponse{}
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EnterQueueResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EnterQueueResponse) ProtoMessage() {}
+
+func (x *EnterQueueResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EnterQueueResponse.ProtoReflect.Descriptor instead.
+func (*EnterQueueResponse) Descriptor() ([]byte, []int) {
+	return file_protos_coupon_v1_coupon_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *EnterQueueResponse) GetStatus() *QueueStatus {
+	if x != nil {
+		return x.Status
+	}
+	return nil
+}
+
+type WatchQueueRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CampaignId    uint32                 `protobuf:"varint,1,opt,name=campaign_id,json=campaignId,proto3" json:"campaign_id,omitempty"`
+	Ticket        string                 `protobuf:"bytes,2,opt,name=ticket,proto3" json:"ticket,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchQueueRequest) Reset() {
+	*x = WatchQueueRequest{}
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchQueueRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchQueueRequest) ProtoMessage() {}
+
+func (x *WatchQueueRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchQueueRequest.ProtoReflect.Descriptor instead.
+func (*WatchQueueRequest) Descriptor() ([]byte, []int) {
+	return file_protos_coupon_v1_coupon_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *WatchQueueRequest) GetCampaignId() uint32 {
+	if x != nil {
+		return x.CampaignId
+	}
+	return 0
+}
+
+func (x *WatchQueueRequest) GetTicket() string {
+	if x != nil {
+		return x.Ticket
+	}
+	return ""
+}
+
+// QueueStatus is the place of a ticket in a waiting room. The stream of WatchQueue ends once the ticket is admitted.
+type QueueStatus struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Ticket         string                 `protobuf:"bytes,1,opt,name=ticket,proto3" json:"ticket,omitempty"`
+	Position       uint64                 `protobuf:"varint,2,opt,name=position,proto3" json:"position,omitempty"` // 1 for the next ticket to be admitted, and 0 once admitted.
+	Admitted       bool                   `protobuf:"varint,3,opt,name=admitted,proto3" json:"admitted,omitempty"`
+	AdmissionToken string                 `protobuf:"bytes,4,opt,name=admission_token,json=admissionToken,proto3" json:"admission_token,omitempty"`
+	TokenExpireAt  *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=token_expire_at,json=tokenExpireAt,proto3" json:"token_expire_at,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *QueueStatus) Reset() {
+	*x = QueueStatus{}
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QueueStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueueStatus) ProtoMessage() {}
+
+func (x *QueueStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueueStatus.ProtoReflect.Descriptor instead.
+func (*QueueStatus) Descriptor() ([]byte, []int) {
+	return file_protos_coupon_v1_coupon_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *QueueStatus) GetTicket() string {
+	if x != nil {
+		return x.Ticket
+	}
+	return ""
+}
+
+func (x *QueueStatus) GetPosition() uint64 {
+	if x != nil {
+		return x.Position
+	}
+	return 0
+}
+
+func (x *QueueStatus) GetAdmitted() bool {
+	if x != nil {
+		return x.Admitted
+	}
+	return false
+}
+
+func (x *QueueStatus) GetAdmissionToken() string {
+	if x != nil {
+		return x.AdmissionToken
+	}
+	return ""
+}
+
+func (x *QueueStatus) GetTokenExpireAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.TokenExpireAt
+	}
+	return nil
+}
+
+type EnterLotteryRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	CampaignId     uint32                 `protobuf:"varint,1,opt,name=campaign_id,json=campaignId,proto3" json:"campaign_id,omitempty"`
+	UserId         string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	UserAttributes *UserAttributes        `protobuf:"bytes,3,opt,name=user_attributes,json=userAttributes,proto3" json:"user_attributes,omitempty"` // resolved by the server's attribute provider if not given.
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *EnterLotteryRequest) Reset() {
+	*x = EnterLotteryRequest{}
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EnterLotteryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EnterLotteryRequest) ProtoMessage() {}
+
+func (x *EnterLotteryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EnterLotteryRequest.ProtoReflect.Descriptor instead.
+func (*EnterLotteryRequest) Descriptor() ([]byte, []int) {
+	return file_protos_coupon_v1_coupon_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *EnterLotteryRequest) GetCampaignId() uint32 {
+	if x != nil {
+		return x.CampaignId
+	}
+	return 0
+}
+
+func (x *EnterLotteryRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *EnterLotteryRequest) GetUserAttributes() *UserAttributes {
+	if x != nil {
+		return x.UserAttributes
+	}
+	return nil
+}
+
+type EnterLotteryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Entries       uint64                 `protobuf:"varint,1,opt,name=entries,proto3" json:"entries,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EnterLotteryResponse) Reset() {
+	*x = EnterLotteryResponse{}
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EnterLotteryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EnterLotteryResponse) ProtoMessage() {}
+
+func (x *EnterLotteryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EnterLotteryResponse.ProtoReflect.Descriptor instead.
+func (*EnterLotteryResponse) Descriptor() ([]byte, []int) {
+	return file_protos_coupon_v1_coupon_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *EnterLotteryResponse) GetEntries() uint64 {
+	if x != nil {
+		return x.Entries
+	}
+	return 0
+}
+
+type GetLotteryResultRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CampaignId    uint32                 `protobuf:"varint,1,opt,name=campaign_id,json=campaignId,proto3" json:"campaign_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetLotteryResultRequest) Reset() {
+	*x = GetLotteryResultRequest{}
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetLotteryResultRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLotteryResultRequest) ProtoMessage() {}
+
+func (x *GetLotteryResultRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLotteryResultRequest.ProtoReflect.Descriptor instead.
+func (*GetLotteryResultRequest) Descriptor() ([]byte, []int) {
+	return file_protos_coupon_v1_coupon_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *GetLotteryResultRequest) GetCampaignId() uint32 {
+	if x != nil {
+		return x.CampaignId
+	}
+	return 0
+}
+
+func (x *GetLotteryResultRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type GetLotteryResultResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Drawn         bool                   `protobuf:"varint,1,opt,name=drawn,proto3" json:"drawn,omitempty"`
+	Won           bool                   `protobuf:"varint,2,opt,name=won,proto3" json:"won,omitempty"`
+	Coupon        *Coupon                `protobuf:"bytes,3,opt,name=coupon,proto3" json:"coupon,omitempty"` // the coupon issued to the winner.
+	Lottery       *Lottery               `protobuf:"bytes,4,opt,name=lottery,proto3" json:"lottery,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetLotteryResultResponse) Reset() {
+	*x = GetLotteryResultResponse{}
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetLotteryResultResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLotteryResultResponse) ProtoMessage() {}
+
+func (x *GetLotteryResultResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLotteryResultResponse.ProtoReflect.Descriptor instead.
+func (*GetLotteryResultResponse) Descriptor() ([]byte, []int) {
+	return file_protos_coupon_v1_coupon_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *GetLotteryResultResponse) GetDrawn() bool {
+	if x != nil {
+		return x.Drawn
+	}
+	return false
+}
+
+func (x *GetLotteryResultResponse) GetWon() bool {
+	if x != nil {
+		return x.Won
+	}
+	return false
+}
+
+func (x *GetLotteryResultResponse) GetCoupon() *Coupon {
+	if x != nil {
+		return x.Coupon
+	}
+	return nil
+}
+
+func (x *GetLotteryResultResponse) GetLottery() *Lottery {
+	if x != nil {
+		return x.Lottery
+	}
+	return nil
+}
+
+type JoinWaitlistRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	CampaignId     uint32                 `protobuf:"varint,1,opt,name=campaign_id,json=campaignId,proto3" json:"campaign_id,omitempty"`
+	UserId         string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	UserAttributes *UserAttributes        `protobuf:"bytes,3,opt,name=user_attributes,json=userAttributes,proto3" json:"user_attributes,omitempty"` // resolved by the server's attribute provider if not given.
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *JoinWaitlistRequest) Reset() {
+	*x = JoinWaitlistRequest{}
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *JoinWaitlistRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JoinWaitlistRequest) ProtoMessage() {}
+
+func (x *JoinWaitlistRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JoinWaitlistRequest.ProtoReflect.Descriptor instead.
+func (*JoinWaitlistRequest) Descriptor() ([]byte, []int) {
+	return file_protos_coupon_v1_coupon_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *JoinWaitlistRequest) GetCampaignId() uint32 {
+	if x != nil {
+		return x.CampaignId
+	}
+	return 0
+}
+
+func (x *JoinWaitlistRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *JoinWaitlistRequest) GetUserAttributes() *UserAttributes {
+	if x != nil {
+		return x.UserAttributes
+	}
+	return nil
+}
+
+type JoinWaitlistResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Position      uint64                 `protobuf:"varint,1,opt,name=position,proto3" json:"position,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *JoinWaitlistResponse) Reset() {
+	*x = JoinWaitlistResponse{}
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *JoinWaitlistResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JoinWaitlistResponse) ProtoMessage() {}
+
+func (x *JoinWaitlistResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[58]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JoinWaitlistResponse.ProtoReflect.Descriptor instead.
+func (*JoinWaitlistResponse) Descriptor() ([]byte, []int) {
+	return file_protos_coupon_v1_coupon_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *JoinWaitlistResponse) GetPosition() uint64 {
+	if x != nil {
+		return x.Position
+	}
+	return 0
+}
+
+type GetWaitlistStatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CampaignId    uint32                 `protobuf:"varint,1,opt,name=campaign_id,json=campaignId,proto3" json:"campaign_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetWaitlistStatusRequest) Reset() {
+	*x = GetWaitlistStatusRequest{}
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[59]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetWaitlistStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetWaitlistStatusRequest) ProtoMessage() {}
+
+func (x *GetWaitlistStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[59]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetWaitlistStatusRequest.ProtoReflect.Descriptor instead.
+func (*GetWaitlistStatusRequest) Descriptor() ([]byte, []int) {
+	return file_protos_coupon_v1_coupon_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *GetWaitlistStatusRequest) GetCampaignId() uint32 {
+	if x != nil {
+		return x.CampaignId
+	}
+	return 0
+}
+
+func (x *GetWaitlistStatusRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type GetWaitlistStatusResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Position      uint64                 `protobuf:"varint,1,opt,name=position,proto3" json:"position,omitempty"` // 1 for the next user to be issued a coupon, 0 once issued.
+	Coupon        *Coupon                `protobuf:"bytes,2,opt,name=coupon,proto3" json:"coupon,omitempty"`      // the coupon issued to the user from the waitlist.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetWaitlistStatusResponse) Reset() {
+	*x = GetWaitlistStatusResponse{}
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[60]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetWaitlistStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetWaitlistStatusResponse) ProtoMessage() {}
+
+func (x *GetWaitlistStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[60]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetWaitlistStatusResponse.ProtoReflect.Descriptor instead.
+func (*GetWaitlistStatusResponse) Descriptor() ([]byte, []int) {
+	return file_protos_coupon_v1_coupon_proto_rawDescGZIP(), []int{60}
+}
+
+func (x *GetWaitlistStatusResponse) GetPosition() uint64 {
+	if x != nil {
+		return x.Position
+	}
+	return 0
+}
+
+func (x *GetWaitlistStatusResponse) GetCoupon() *Coupon {
+	if x != nil {
+		return x.Coupon
+	}
+	return nil
+}
+
+type WatchWaitlistRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CampaignId    uint32                 `protobuf:"varint,1,opt,name=campaign_id,json=campaignId,proto3" json:"campaign_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchWaitlistRequest) Reset() {
+	*x = WatchWaitlistRequest{}
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[61]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchWaitlistRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchWaitlistRequest) ProtoMessage() {}
+
+func (x *WatchWaitlistRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[61]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchWaitlistRequest.ProtoReflect.Descriptor instead.
+func (*WatchWaitlistRequest) Descriptor() ([]byte, []int) {
+	return file_protos_coupon_v1_coupon_proto_rawDescGZIP(), []int{61}
+}
+
+func (x *WatchWaitlistRequest) GetCampaignId() uint32 {
+	if x != nil {
+		return x.CampaignId
+	}
+	return 0
+}
+
+func (x *WatchWaitlistRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+// WaitlistStatus is the place of a user on a waitlist. The stream of WatchWaitlist ends once the user is issued a coupon.
+type WaitlistStatus struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Position      uint64                 `protobuf:"varint,1,opt,name=position,proto3" json:"position,omitempty"` // 1 for the next user to be issued a coupon, 0 once issued.
+	Coupon        *Coupon                `protobuf:"bytes,2,opt,name=coupon,proto3" json:"coupon,omitempty"`      // the coupon issued to the user from the waitlist.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WaitlistStatus) Reset() {
+	*x = WaitlistStatus{}
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[62]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WaitlistStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WaitlistStatus) ProtoMessage() {}
+
+func (x *WaitlistStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[62]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WaitlistStatus.ProtoReflect.Descriptor instead.
+func (*WaitlistStatus) Descriptor() ([]byte, []int) {
+	return file_protos_coupon_v1_coupon_proto_rawDescGZIP(), []int{62}
+}
+
+func (x *WaitlistStatus) GetPosition() uint64 {
+	if x != nil {
+		return x.Position
+	}
+	return 0
+}
+
+func (x *WaitlistStatus) GetCoupon() *Coupon {
+	if x != nil {
+		return x.Coupon
+	}
+	return nil
+}
+
+type ValidateCouponRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	Channel       Channel                `protobuf:"varint,2,opt,name=channel,proto3,enum=protos.coupon.v1.Channel" json:"channel,omitempty"`   // checked against the campaign's applicability if set.
+	PaymentMethod string                 `protobuf:"bytes,3,opt,name=payment_method,json=paymentMethod,proto3" json:"payment_method,omitempty"` // checked against the campaign's applicability if set.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ValidateCouponRequest) Reset() {
+	*x = ValidateCouponRequest{}
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[63]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ValidateCouponRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidateCouponRequest) ProtoMessage() {}
+
+func (x *ValidateCouponRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[63]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidateCouponRequest.ProtoReflect.Descriptor instead.
+func (*ValidateCouponRequest) Descriptor() ([]byte, []int) {
+	return file_protos_coupon_v1_coupon_proto_rawDescGZIP(), []int{63}
+}
+
+func (x *ValidateCouponRequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *ValidateCouponRequest) GetChannel() Channel {
+	if x != nil {
+		return x.Channel
+	}
+	return Channel_CHANNEL_UNSPECIFIED
+}
+
+func (x *ValidateCouponRequest) GetPaymentMethod() string {
+	if x != nil {
+		return x.PaymentMethod
+	}
+	return ""
+}
+
+type ValidateCouponResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Valid         bool                   `protobuf:"varint,1,opt,name=valid,proto3" json:"valid,omitempty"`
+	Reason        ValidationReason       `protobuf:"varint,2,opt,name=reason,proto3,enum=protos.coupon.v1.ValidationReason" json:"reason,omitempty"`
+	Coupon        *Coupon                `protobuf:"bytes,3,opt,name=coupon,proto3" json:"coupon,omitempty"`
+	Campaign      *Campaign              `protobuf:"bytes,4,opt,name=campaign,proto3" json:"campaign,omitempty"` // issued coupons are omitted.
+	Status        CouponStatus           `protobuf:"varint,5,opt,name=status,proto3,enum=protos.coupon.v1.CouponStatus" json:"status,omitempty"`
+	ExpireAt      *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=expire_at,json=expireAt,proto3" json:"expire_at,omitempty"`
+	Message       string                 `protobuf:"bytes,7,opt,name=message,proto3" json:"message,omitempty"` // explains the reason.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ValidateCouponResponse) Reset() {
+	*x = ValidateCouponResponse{}
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[64]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ValidateCouponResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidateCouponResponse) ProtoMessage() {}
+
+func (x *ValidateCouponResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[64]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidateCouponResponse.ProtoReflect.Descriptor instead.
+func (*ValidateCouponResponse) Descriptor() ([]byte, []int) {
+	return file_protos_coupon_v1_coupon_proto_rawDescGZIP(), []int{64}
+}
+
+func (x *ValidateCouponResponse) GetValid() bool {
+	if x != nil {
+		return x.Valid
+	}
+	return false
+}
+
+func (x *ValidateCouponResponse) GetReason() ValidationReason {
+	if x != nil {
+		return x.Reason
+	}
+	return ValidationReason_VALIDATION_REASON_UNSPECIFIED
+}
+
+func (x *ValidateCouponResponse) GetCoupon() *Coupon {
+	if x != nil {
+		return x.Coupon
+	}
+	return nil
+}
+
+func (x *ValidateCouponResponse) GetCampaign() *Campaign {
+	if x != nil {
+		return x.Campaign
+	}
+	return nil
+}
+
+func (x *ValidateCouponResponse) GetStatus() CouponStatus {
+	if x != nil {
+		return x.Status
+	}
+	return CouponStatus_COUPON_STATUS_UNSPECIFIED
+}
+
+func (x *ValidateCouponResponse) GetExpireAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpireAt
+	}
+	return nil
+}
+
+func (x *ValidateCouponResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type RedeemCouponRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Code    string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	OrderId string                 `protobuf:"bytes,2,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"` // lets the redemption be reversed by the order.
+	// What the coupon discounted, spent from the campaign's budget if it has one, up to the cap of a percentage discount.
+	// A fixed amount discount spends its amount whatever is given.
+	DiscountAmount *Money `protobuf:"bytes,3,opt,name=discount_amount,json=discountAmount,proto3" json:"discount_amount,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *RedeemCouponRequest) Reset() {
+	*x = RedeemCouponRequest{}
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[65]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RedeemCouponRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RedeemCouponRequest) ProtoMessage() {}
+
+func (x *RedeemCouponRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[65]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RedeemCouponRequest.ProtoReflect.Descriptor instead.
+func (*RedeemCouponRequest) Descriptor() ([]byte, []int) {
+	return file_protos_coupon_v1_coupon_proto_rawDescGZIP(), []int{65}
+}
+
+func (x *RedeemCouponRequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *RedeemCouponRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *RedeemCouponRequest) GetDiscountAmount() *Money {
+	if x != nil {
+		return x.DiscountAmount
+	}
+	return nil
+}
+
+type RedeemCouponResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Coupon        *Coupon                `protobuf:"bytes,1,opt,name=coupon,proto3" json:"coupon,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RedeemCouponResponse) Reset() {
+	*x = RedeemCouponResponse{}
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[66]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RedeemCouponResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RedeemCouponResponse) ProtoMessage() {}
+
+func (x *RedeemCouponResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[66]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RedeemCouponResponse.ProtoReflect.Descriptor instead.
+func (*RedeemCouponResponse) Descriptor() ([]byte, []int) {
+	return file_protos_coupon_v1_coupon_proto_rawDescGZIP(), []int{66}
+}
+
+func (x *RedeemCouponResponse) GetCoupon() *Coupon {
+	if x != nil {
+		return x.Coupon
+	}
+	return nil
+}
+
+type RevokeCouponRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	ReturnToPool  bool                   `protobuf:"varint,3,opt,name=return_to_pool,json=returnToPool,proto3" json:"return_to_pool,omitempty"` // gives the slot back to the campaign, so another coupon can be issued.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeCouponRequest) Reset() {
+	*x = RevokeCouponRequest{}
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[67]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeCouponRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeCouponRequest) ProtoMessage() {}
+
+func (x *RevokeCouponRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[67]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeCouponRequest.ProtoReflect.Descriptor instead.
+func (*RevokeCouponRequest) Descriptor() ([]byte, []int) {
+	return file_protos_coupon_v1_coupon_proto_rawDescGZIP(), []int{67}
+}
+
+func (x *RevokeCouponRequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *RevokeCouponRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *RevokeCouponRequest) GetReturnToPool() bool {
+	if x != nil {
+		return x.ReturnToPool
+	}
+	return false
+}
+
+type RevokeCouponResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Coupon        *Coupon                `protobuf:"bytes,1,opt,name=coupon,proto3" json:"coupon,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeCouponResponse) Reset() {
+	*x = RevokeCouponResponse{}
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[68]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeCouponResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeCouponResponse) ProtoMessage() {}
+
+func (x *RevokeCouponResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[68]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeCouponResponse.ProtoReflect.Descriptor instead.
+func (*RevokeCouponResponse) Descriptor() ([]byte, []int) {
+	return file_protos_coupon_v1_coupon_proto_rawDescGZIP(), []int{68}
+}
+
+func (x *RevokeCouponResponse) GetCoupon() *Coupon {
+	if x != nil {
+		return x.Coupon
+	}
+	return nil
+}
+
+type ReserveCouponRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	Ttl           *durationpb.Duration   `protobuf:"bytes,2,opt,name=ttl,proto3" json:"ttl,omitempty"` // how long the coupon is held, at most and by default 15 minutes.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReserveCouponRequest) Reset() {
+	*x = ReserveCouponRequest{}
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[69]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReserveCouponRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReserveCouponRequest) ProtoMessage() {}
+
+func (x *ReserveCouponRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[69]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReserveCouponRequest.ProtoReflect.Descriptor instead.
+func (*ReserveCouponRequest) Descriptor() ([]byte, []int) {
+	return file_protos_coupon_v1_coupon_proto_rawDescGZIP(), []int{69}
+}
+
+func (x *ReserveCouponRequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *ReserveCouponRequest) GetTtl() *durationpb.Duration {
+	if x != nil {
+		return x.Ttl
+	}
+	return nil
+}
+
+type ReserveCouponResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Coupon        *Coupon                `protobuf:"bytes,1,opt,name=coupon,proto3" json:"coupon,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReserveCouponResponse) Reset() {
+	*x = ReserveCouponResponse{}
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[70]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReserveCouponResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReserveCouponResponse) ProtoMessage() {}
+
+func (x *ReserveCouponResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[70]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReserveCouponResponse.ProtoReflect.Descriptor instead.
+func (*ReserveCouponResponse) Descriptor() ([]byte, []int) {
+	return file_protos_coupon_v1_coupon_proto_rawDescGZIP(), []int{70}
+}
+
+func (x *ReserveCouponResponse) GetCoupon() *Coupon {
+	if x != nil {
+		return x.Coupon
+	}
+	return nil
+}
+
+type CommitReservationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	ReservationId string                 `protobuf:"bytes,2,opt,name=reservation_id,json=reservationId,proto3" json:"reservation_id,omitempty"`
+	OrderId       string                 `protobuf:"bytes,3,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"` // lets the redemption be reversed by the order.
+	// What the coupon discounted, spent from the campaign's budget if it has one, up to the cap of a percentage discount.
+	// A fixed amount discount spends its amount whatever is given.
+	DiscountAmount *Money `protobuf:"bytes,4,opt,name=discount_amount,json=discountAmount,proto3" json:"discount_amount,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *CommitReservationRequest) Reset() {
+	*x = CommitReservationRequest{}
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[71]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CommitReservationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CommitReservationRequest) ProtoMessage() {}
+
+func (x *CommitReservationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[71]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CommitReservationRequest.ProtoReflect.Descriptor instead.
+func (*CommitReservationRequest) Descriptor() ([]byte, []int) {
+	return file_protos_coupon_v1_coupon_proto_rawDescGZIP(), []int{71}
+}
+
+func (x *CommitReservationRequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *CommitReservationRequest) GetReservationId() string {
+	if x != nil {
+		return x.ReservationId
+	}
+	return ""
+}
+
+func (x *CommitReservationRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *CommitReservationRequest) GetDiscountAmount() *Money {
+	if x != nil {
+		return x.DiscountAmount
+	}
+	return nil
+}
+
+type CommitReservationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Coupon        *Coupon                `protobuf:"bytes,1,opt,name=coupon,proto3" json:"coupon,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CommitReservationResponse) Reset() {
+	*x = CommitReservationResponse{}
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[72]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CommitReservationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CommitReservationResponse) ProtoMessage() {}
+
+func (x *CommitReservationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[72]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CommitReservationResponse.ProtoReflect.Descriptor instead.
+func (*CommitReservationResponse) Descriptor() ([]byte, []int) {
+	return file_protos_coupon_v1_coupon_proto_rawDescGZIP(), []int{72}
+}
+
+func (x *CommitReservationResponse) GetCoupon() *Coupon {
+	if x != nil {
+		return x.Coupon
+	}
+	return nil
+}
+
+type ReleaseReservationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	ReservationId string                 `protobuf:"bytes,2,opt,name=reservation_id,json=reservationId,proto3" json:"reservation_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReleaseReservationRequest) Reset() {
+	*x = ReleaseReservationRequest{}
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[73]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReleaseReservationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReleaseReservationRequest) ProtoMessage() {}
+
+func (x *ReleaseReservationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[73]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReleaseReservationRequest.ProtoReflect.Descriptor instead.
+func (*ReleaseReservationRequest) Descriptor() ([]byte, []int) {
+	return file_protos_coupon_v1_coupon_proto_rawDescGZIP(), []int{73}
+}
+
+func (x *ReleaseReservationRequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *ReleaseReservationRequest) GetReservationId() string {
+	if x != nil {
+		return x.ReservationId
+	}
+	return ""
+}
+
+type ReleaseReservationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Coupon        *Coupon                `protobuf:"bytes,1,opt,name=coupon,proto3" json:"coupon,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReleaseReservationResponse) Reset() {
+	*x = ReleaseReservationResponse{}
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[74]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReleaseReservationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReleaseReservationResponse) ProtoMessage() {}
+
+func (x *ReleaseReservationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[74]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReleaseReservationResponse.ProtoReflect.Descriptor instead.
+func (*ReleaseReservationResponse) Descriptor() ([]byte, []int) {
+	return file_protos_coupon_v1_coupon_proto_rawDescGZIP(), []int{74}
+}
+
+func (x *ReleaseReservationResponse) GetCoupon() *Coupon {
+	if x != nil {
+		return x.Coupon
+	}
+	return nil
+}
+
+// RedeemAmountRequest redeems a part of the balance of a stored-value coupon for an order.
+type RedeemAmountRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	Amount        *Money                 `protobuf:"bytes,2,opt,name=amount,proto3" json:"amount,omitempty"`
+	OrderId       string                 `protobuf:"bytes,3,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RedeemAmountRequest) Reset() {
+	*x = RedeemAmountRequest{}
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[75]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RedeemAmountRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RedeemAmountRequest) ProtoMessage() {}
+
+func (x *RedeemAmountRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[75]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RedeemAmountRequest.ProtoReflect.Descriptor instead.
+func (*RedeemAmountRequest) Descriptor() ([]byte, []int) {
+	return file_protos_coupon_v1_coupon_proto_rawDescGZIP(), []int{75}
+}
+
+func (x *RedeemAmountRequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *RedeemAmountRequest) GetAmount() *Money {
+	if x != nil {
+		return x.Amount
+	}
+	return nil
+}
+
+func (x *RedeemAmountRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+type RedeemAmountResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Coupon        *Coupon                `protobuf:"bytes,1,opt,name=coupon,proto3" json:"coupon,omitempty"`
+	Entry         *LedgerEntry           `protobuf:"bytes,2,opt,name=entry,proto3" json:"entry,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RedeemAmountResponse) Reset() {
+	*x = RedeemAmountResponse{}
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[76]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RedeemAmountResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RedeemAmountResponse) ProtoMessage() {}
+
+func (x *RedeemAmountResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[76]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RedeemAmountResponse.ProtoReflect.Descriptor instead.
+func (*RedeemAmountResponse) Descriptor() ([]byte, []int) {
+	return file_protos_coupon_v1_coupon_proto_rawDescGZIP(), []int{76}
+}
+
+func (x *RedeemAmountResponse) GetCoupon() *Coupon {
+	if x != nil {
+		return x.Coupon
+	}
+	return nil
+}
+
+func (x *RedeemAmountResponse) GetEntry() *LedgerEntry {
+	if x != nil {
+		return x.Entry
+	}
+	return nil
+}
+
+// ReverseRedemptionRequest reverses a redemption, or all the redemptions of an order, as the campaigns'
+// restore policies decide.
+type ReverseRedemptionRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Key:
+	//
+	//	*ReverseRedemptionRequest_RedemptionId
+	//	*ReverseRedemptionRequest_OrderId
+	Key           isReverseRedemptionRequest_Key `protobuf_oneof:"key"`
+	Reason        string                         `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReverseRedemptionRequest) Reset() {
+	*x = ReverseRedemptionRequest{}
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[77]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReverseRedemptionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReverseRedemptionRequest) ProtoMessage() {}
+
+func (x *ReverseRedemptionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[77]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReverseRedemptionRequest.ProtoReflect.Descriptor instead.
+func (*ReverseRedemptionRequest) Descriptor() ([]byte, []int) {
+	return file_protos_coupon_v1_coupon_proto_rawDescGZIP(), []int{77}
+}
+
+func (x *ReverseRedemptionRequest) GetKey() isReverseRedemptionRequest_Key {
+	if x != nil {
+		return x.Key
+	}
+	return nil
+}
+
+func (x *ReverseRedemptionRequest) GetRedemptionId() string {
+	if x != nil {
+		if x, ok := x.Key.(*ReverseRedemptionRequest_RedemptionId); ok {
+			return x.RedemptionId
+		}
+	}
+	return ""
+}
+
+func (x *ReverseRedemptionRequest) GetOrderId() string {
+	if x != nil {
+		if x, ok := x.Key.(*ReverseRedemptionRequest_OrderId); ok {
+			return x.OrderId
+		}
+	}
+	return ""
+}
+
+func (x *ReverseRedemptionRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type isReverseRedemptionRequest_Key interface {
+	isReverseRedemptionRequest_Key()
+}
+
+type ReverseRedemptionRequest_RedemptionId struct {
+	RedemptionId string `protobuf:"bytes,1,opt,name=redemption_id,json=redemptionId,proto3,oneof"` // the redemption_id of a coupon or the id of a ledger entry.
+}
+
+type ReverseRedemptionRequest_OrderId struct {
+	OrderId string `protobuf:"bytes,2,opt,name=order_id,json=orderId,proto3,oneof"`
+}
+
+func (*ReverseRedemptionRequest_RedemptionId) isReverseRedemptionRequest_Key() {}
+
+func (*ReverseRedemptionRequest_OrderId) isReverseRedemptionRequest_Key() {}
+
+type ReverseRedemptionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Reversals     []*Reversal            `protobuf:"bytes,1,rep,name=reversals,proto3" json:"reversals,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReverseRedemptionResponse) Reset() {
+	*x = ReverseRedemptionResponse{}
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[78]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReverseRedemptionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReverseRedemptionResponse) ProtoMessage() {}
+
+func (x *ReverseRedemptionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[78]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReverseRedemptionResponse.ProtoReflect.Descriptor instead.
+func (*ReverseRedemptionResponse) Descriptor() ([]byte, []int) {
+	return file_protos_coupon_v1_coupon_proto_rawDescGZIP(), []int{78}
+}
+
+func (x *ReverseRedemptionResponse) GetReversals() []*Reversal {
+	if x != nil {
+		return x.Reversals
+	}
+	return nil
+}
+
+// TransferCouponRequest transfers a usable coupon from its owner to another user, or offers it with a claim link
+// if to_user_id is empty.
+type TransferCouponRequest struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Code             string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	FromUserId       string                 `protobuf:"bytes,2,opt,name=from_user_id,json=fromUserId,proto3" json:"from_user_id,omitempty"` // must be the owner of the coupon.
+	ToUserId         string                 `protobuf:"bytes,3,opt,name=to_user_id,json=toUserId,proto3" json:"to_user_id,omitempty"`
+	ToUserAttributes *UserAttributes        `protobuf:"bytes,4,opt,name=to_user_attributes,json=toUserAttributes,proto3" json:"to_user_attributes,omitempty"` // resolved by the server's attribute provider if not given.
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *TransferCouponRequest) Reset() {
+	*x = TransferCouponRequest{}
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[79]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TransferCouponRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransferCouponRequest) ProtoMessage() {}
+
+func (x *TransferCouponRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[79]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransferCouponRequest.ProtoReflect.Descriptor instead.
+func (*TransferCouponRequest) Descriptor() ([]byte, []int) {
+	return file_protos_coupon_v1_coupon_proto_rawDescGZIP(), []int{79}
+}
+
+func (x *TransferCouponRequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *TransferCouponRequest) GetFromUserId() string {
+	if x != nil {
+		return x.FromUserId
+	}
+	return ""
+}
+
+func (x *TransferCouponRequest) GetToUserId() string {
+	if x != nil {
+		return x.ToUserId
+	}
+	return ""
+}
+
+func (x *TransferCouponRequest) GetToUserAttributes() *UserAttributes {
+	if x != nil {
+		return x.ToUserAttributes
+	}
+	return nil
+}
+
+type TransferCouponResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Coupon        *Coupon                `protobuf:"bytes,1,opt,name=coupon,proto3" json:"coupon,omitempty"`
+	ClaimToken    string                 `protobuf:"bytes,2,opt,name=claim_token,json=claimToken,proto3" json:"claim_token,omitempty"` // the one-time token of the claim link, to be shared with the recipient.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TransferCouponResponse) Reset() {
+	*x = TransferCouponResponse{}
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[80]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TransferCouponResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransferCouponResponse) ProtoMessage() {}
+
+func (x *TransferCouponResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[80]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransferCouponResponse.ProtoReflect.Descriptor instead.
+func (*TransferCouponResponse) Descriptor() ([]byte, []int) {
+	return file_protos_coupon_v1_coupon_proto_rawDescGZIP(), []int{80}
+}
+
+func (x *TransferCouponResponse) GetCoupon() *Coupon {
+	if x != nil {
+		return x.Coupon
+	}
+	return nil
+}
+
+func (x *TransferCouponResponse) GetClaimToken() string {
+	if x != nil {
+		return x.ClaimToken
+	}
+	return ""
+}
+
+type ClaimCouponRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Code           string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	ClaimToken     string                 `protobuf:"bytes,2,opt,name=claim_token,json=claimToken,proto3" json:"claim_token,omitempty"`
+	UserId         string                 `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	UserAttributes *UserAttributes        `protobuf:"bytes,4,opt,name=user_attributes,json=userAttributes,proto3" json:"user_attributes,omitempty"` // resolved by the server's attribute provider if not given.
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ClaimCouponRequest) Reset() {
+	*x = ClaimCouponRequest{}
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[81]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ClaimCouponRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClaimCouponRequest) ProtoMessage() {}
+
+func (x *ClaimCouponRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[81]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClaimCouponRequest.ProtoReflect.Descriptor instead.
+func (*ClaimCouponRequest) Descriptor() ([]byte, []int) {
+	return file_protos_coupon_v1_coupon_proto_rawDescGZIP(), []int{81}
+}
+
+func (x *ClaimCouponRequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *ClaimCouponRequest) GetClaimToken() string {
+	if x != nil {
+		return x.ClaimToken
+	}
+	return ""
+}
+
+func (x *ClaimCouponRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ClaimCouponRequest) GetUserAttributes() *UserAttributes {
+	if x != nil {
+		return x.UserAttributes
+	}
+	return nil
+}
+
+type ClaimCouponResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Coupon        *Coupon                `protobuf:"bytes,1,opt,name=coupon,proto3" json:"coupon,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ClaimCouponResponse) Reset() {
+	*x = ClaimCouponResponse{}
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[82]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ClaimCouponResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClaimCouponResponse) ProtoMessage() {}
+
+func (x *ClaimCouponResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[82]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClaimCouponResponse.ProtoReflect.Descriptor instead.
+func (*ClaimCouponResponse) Descriptor() ([]byte, []int) {
+	return file_protos_coupon_v1_coupon_proto_rawDescGZIP(), []int{82}
+}
+
+func (x *ClaimCouponResponse) GetCoupon() *Coupon {
+	if x != nil {
+		return x.Coupon
+	}
+	return nil
+}
+
+// CreateBundleRequest defines a bundle of different campaigns. Lottery, referral and waiting room campaigns
+// issue their coupons on their own terms, so they cannot be bundled.
+type CreateBundleRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Description   string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	CampaignIds   []uint32               `protobuf:"varint,3,rep,packed,name=campaign_ids,json=campaignIds,proto3" json:"campaign_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateBundleRequest) Reset() {
+	*x = CreateBundleRequest{}
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[83]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateBundleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateBundleRequest) ProtoMessage() {}
+
+func (x *CreateBundleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[83]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateBundleRequest.ProtoReflect.Descriptor instead.
+func (*CreateBundleRequest) Descriptor() ([]byte, []int) {
+	return file_protos_coupon_v1_coupon_proto_rawDescGZIP(), []int{83}
+}
+
+func (x *CreateBundleRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateBundleRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *CreateBundleRequest) GetCampaignIds() []uint32 {
+	if x != nil {
+		return x.CampaignIds
+	}
+	return nil
+}
+
+type CreateBundleResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Bundle        *Bundle                `protobuf:"bytes,1,opt,name=bundle,proto3" json:"bundle,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateBundleResponse) Reset() {
+	*x = CreateBundleResponse{}
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[84]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateBundleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateBundleResponse) ProtoMessage() {}
+
+func (x *CreateBundleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[84]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateBundleResponse.ProtoReflect.Descriptor instead.
+func (*CreateBundleResponse) Descriptor() ([]byte, []int) {
+	return file_protos_coupon_v1_coupon_proto_rawDescGZIP(), []int{84}
+}
+
+func (x *CreateBundleResponse) GetBundle() *Bundle {
+	if x != nil {
+		return x.Bundle
+	}
+	return nil
+}
+
+// IssueBundleRequest issues a coupon of each campaign of the bundle to the user, all or nothing: if any campaign
+// cannot issue one, e.g. because it is sold out or the user is not eligible, none is issued.
+type IssueBundleRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	BundleId       uint32                 `protobuf:"varint,1,opt,name=bundle_id,json=bundleId,proto3" json:"bundle_id,omitempty"`
+	UserId         string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	UserAttributes *UserAttributes        `protobuf:"bytes,3,opt,name=user_attributes,json=userAttributes,proto3" json:"user_attributes,omitempty"` // resolved by the server's attribute provider if not given.
+	Channel        string                 `protobuf:"bytes,4,opt,name=channel,proto3" json:"channel,omitempty"`                                     // the channel or partner issuing, required if a campaign of the bundle is allocated.
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *IssueBundleRequest) Reset() {
+	*x = IssueBundleRequest{}
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[85]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IssueBundleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IssueBundleRequest) ProtoMessage() {}
+
+func (x *IssueBundleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[85]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IssueBundleRequest.ProtoReflect.Descriptor instead.
+func (*IssueBundleRequest) Descriptor() ([]byte, []int) {
+	return file_protos_coupon_v1_coupon_proto_rawDescGZIP(), []int{85}
+}
+
+func (x *IssueBundleRequest) GetBundleId() uint32 {
+	if x != nil {
+		return x.BundleId
+	}
+	return 0
+}
+
+func (x *IssueBundleRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *IssueBundleRequest) GetUserAttributes() *UserAttributes {
+	if x != nil {
+		return x.UserAttributes
+	}
+	return nil
+}
+
+func (x *IssueBundleRequest) GetChannel() string {
+	if x != nil {
+		return x.Channel
+	}
+	return ""
+}
+
+type IssueBundleResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Coupons       []*Coupon              `protobuf:"bytes,1,rep,name=coupons,proto3" json:"coupons,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IssueBundleResponse) Reset() {
+	*x = IssueBundleResponse{}
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[86]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IssueBundleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IssueBundleResponse) ProtoMessage() {}
+
+func (x *IssueBundleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[86]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IssueBundleResponse.ProtoReflect.Descriptor instead.
+func (*IssueBundleResponse) Descriptor() ([]byte, []int) {
+	return file_protos_coupon_v1_coupon_proto_rawDescGZIP(), []int{86}
+}
+
+func (x *IssueBundleResponse) GetCoupons() []*Coupon {
+	if x != nil {
+		return x.Coupons
+	}
+	return nil
+}
+
+// CreateReferralCodeRequest returns the referral code of the user, creating it on the first request.
+type CreateReferralCodeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CampaignId    uint32                 `protobuf:"varint,1,opt,name=campaign_id,json=campaignId,proto3" json:"campaign_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateReferralCodeRequest) Reset() {
+	*x = CreateReferralCodeRequest{}
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[87]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateReferralCodeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateReferralCodeRequest) ProtoMessage() {}
+
+func (x *CreateReferralCodeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[87]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateReferralCodeRequest.ProtoReflect.Descriptor instead.
+func (*CreateReferralCodeRequest) Descriptor() ([]byte, []int) {
+	return file_protos_coupon_v1_coupon_proto_rawDescGZIP(), []int{87}
+}
+
+func (x *CreateReferralCodeRequest) GetCampaignId() uint32 {
+	if x != nil {
+		return x.CampaignId
+	}
+	return 0
+}
+
+func (x *CreateReferralCodeRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type CreateReferralCodeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ReferralCode  *ReferralCode          `protobuf:"bytes,1,opt,name=referral_code,json=referralCode,proto3" json:"referral_code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateReferralCodeResponse) Reset() {
+	*x = CreateReferralCodeResponse{}
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[88]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateReferralCodeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateReferralCodeResponse) ProtoMessage() {}
+
+func (x *CreateReferralCodeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[88]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateReferralCodeResponse.ProtoReflect.Descriptor instead.
+func (*CreateReferralCodeResponse) Descriptor() ([]byte, []int) {
+	return file_protos_coupon_v1_coupon_proto_rawDescGZIP(), []int{88}
+}
+
+func (x *CreateReferralCodeResponse) GetReferralCode() *ReferralCode {
+	if x != nil {
+		return x.ReferralCode
+	}
+	return nil
+}
+
+type ListLedgerEntriesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListLedgerEntriesRequest) Reset() {
+	*x = ListLedgerEntriesRequest{}
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[89]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListLedgerEntriesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListLedgerEntriesRequest) ProtoMessage() {}
+
+func (x *ListLedgerEntriesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[89]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListLedgerEntriesRequest.ProtoReflect.Descriptor instead.
+func (*ListLedgerEntriesRequest) Descriptor() ([]byte, []int) {
+	return file_protos_coupon_v1_coupon_proto_rawDescGZIP(), []int{89}
+}
+
+func (x *ListLedgerEntriesRequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+type ListLedgerEntriesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Entries       []*LedgerEntry         `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListLedgerEntriesResponse) Reset() {
+	*x = ListLedgerEntriesResponse{}
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[90]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListLedgerEntriesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListLedgerEntriesResponse) ProtoMessage() {}
+
+func (x *ListLedgerEntriesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[90]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListLedgerEntriesResponse.ProtoReflect.Descriptor instead.
+func (*ListLedgerEntriesResponse) Descriptor() ([]byte, []int) {
+	return file_protos_coupon_v1_coupon_proto_rawDescGZIP(), []int{90}
+}
+
+func (x *ListLedgerEntriesResponse) GetEntries() []*LedgerEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+type LineItem struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Sku           string                 `protobuf:"bytes,1,opt,name=sku,proto3" json:"sku,omitempty"`
+	Category      string                 `protobuf:"bytes,2,opt,name=category,proto3" json:"category,omitempty"`
+	UnitPrice     *Money                 `protobuf:"bytes,3,opt,name=unit_price,json=unitPrice,proto3" json:"unit_price,omitempty"`
+	Quantity      uint32                 `protobuf:"varint,4,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	Brand         string                 `protobuf:"bytes,5,opt,name=brand,proto3" json:"brand,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LineItem) Reset() {
+	*x = LineItem{}
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[91]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LineItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LineItem) ProtoMessage() {}
+
+func (x *LineItem) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[91]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LineItem.ProtoReflect.Descriptor instead.
+func (*LineItem) Descriptor() ([]byte, []int) {
+	return file_protos_coupon_v1_coupon_proto_rawDescGZIP(), []int{91}
+}
+
+func (x *LineItem) GetSku() string {
+	if x != nil {
+		return x.Sku
+	}
+	return ""
+}
+
+func (x *LineItem) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *LineItem) GetUnitPrice() *Money {
+	if x != nil {
+		return x.UnitPrice
+	}
+	return nil
+}
+
+func (x *LineItem) GetQuantity() uint32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *LineItem) GetBrand() string {
+	if x != nil {
+		return x.Brand
+	}
+	return ""
+}
+
+type LineResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Index         uint32                 `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"` // the index of the line item in the request.
+	Sku           string                 `protobuf:"bytes,2,opt,name=sku,proto3" json:"sku,omitempty"`
+	Subtotal      *Money                 `protobuf:"bytes,3,opt,name=subtotal,proto3" json:"subtotal,omitempty"`
+	Discount      *Money                 `protobuf:"bytes,4,opt,name=discount,proto3" json:"discount,omitempty"`
+	Total         *Money                 `protobuf:"bytes,5,opt,name=total,proto3" json:"total,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LineResult) Reset() {
+	*x = LineResult{}
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[92]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LineResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LineResult) ProtoMessage() {}
+
+func (x *LineResult) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[92]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LineResult.ProtoReflect.Descriptor instead.
+func (*LineResult) Descriptor() ([]byte, []int) {
+	return file_protos_coupon_v1_coupon_proto_rawDescGZIP(), []int{92}
+}
+
+func (x *LineResult) GetIndex() uint32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *LineResult) GetSku() string {
+	if x != nil {
+		return x.Sku
+	}
+	return ""
+}
+
+func (x *LineResult) GetSubtotal() *Money {
+	if x != nil {
+		return x.Subtotal
+	}
+	return nil
+}
+
+func (x *LineResult) GetDiscount() *Money {
+	if x != nil {
+		return x.Discount
+	}
+	return nil
+}
+
+func (x *LineResult) GetTotal() *Money {
+	if x != nil {
+		return x.Total
+	}
+	return nil
+}
+
+type AppliedCoupon struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Code             string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	CampaignId       uint32                 `protobuf:"varint,2,opt,name=campaign_id,json=campaignId,proto3" json:"campaign_id,omitempty"`
+	Discount         *Money                 `protobuf:"bytes,3,opt,name=discount,proto3" json:"discount,omitempty"` // the discount on line items.
+	ShippingDiscount *Money                 `protobuf:"bytes,4,opt,name=shipping_discount,json=shippingDiscount,proto3" json:"shipping_discount,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *AppliedCoupon) Reset() {
+	*x = AppliedCoupon{}
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[93]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AppliedCoupon) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AppliedCoupon) ProtoMessage() {}
+
+func (x *AppliedCoupon) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[93]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AppliedCoupon.ProtoReflect.Descriptor instead.
+func (*AppliedCoupon) Descriptor() ([]byte, []int) {
+	return file_protos_coupon_v1_coupon_proto_rawDescGZIP(), []int{93}
+}
+
+func (x *AppliedCoupon) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *AppliedCoupon) GetCampaignId() uint32 {
+	if x != nil {
+		return x.CampaignId
+	}
+	return 0
+}
+
+func (x *AppliedCoupon) GetDiscount() *Money {
+	if x != nil {
+		return x.Discount
+	}
+	return nil
+}
+
+func (x *AppliedCoupon) GetShippingDiscount() *Money {
+	if x != nil {
+		return x.ShippingDiscount
+	}
+	return nil
+}
+
+type RejectedCoupon struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Code             string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	Reason           RejectionReason        `protobuf:"varint,2,opt,name=reason,proto3,enum=protos.coupon.v1.RejectionReason" json:"reason,omitempty"`
+	ValidationReason ValidationReason       `protobuf:"varint,3,opt,name=validation_reason,json=validationReason,proto3,enum=protos.coupon.v1.ValidationReason" json:"validation_reason,omitempty"`
+	Message          string                 `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *RejectedCoupon) Reset() {
+	*x = RejectedCoupon{}
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[94]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RejectedCoupon) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RejectedCoupon) ProtoMessage() {}
+
+func (x *RejectedCoupon) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[94]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RejectedCoupon.ProtoReflect.Descriptor instead.
+func (*RejectedCoupon) Descriptor() ([]byte, []int) {
+	return file_protos_coupon_v1_coupon_proto_rawDescGZIP(), []int{94}
+}
+
+func (x *RejectedCoupon) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *RejectedCoupon) GetReason() RejectionReason {
+	if x != nil {
+		return x.Reason
+	}
+	return RejectionReason_REJECTION_REASON_UNSPECIFIED
+}
+
+func (x *RejectedCoupon) GetValidationReason() ValidationReason {
+	if x != nil {
+		return x.ValidationReason
+	}
+	return ValidationReason_VALIDATION_REASON_UNSPECIFIED
+}
+
+func (x *RejectedCoupon) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// StackingConflict reports a coupon code which is dropped because it cannot be combined with an applied one.
+type StackingConflict struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	ConflictsWith string                 `protobuf:"bytes,2,opt,name=conflicts_with,json=conflictsWith,proto3" json:"conflicts_with,omitempty"`
+	Message       string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StackingConflict) Reset() {
+	*x = StackingConflict{}
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[95]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StackingConflict) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StackingConflict) ProtoMessage() {}
+
+func (x *StackingConflict) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[95]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StackingConflict.ProtoReflect.Descriptor instead.
+func (*StackingConflict) Descriptor() ([]byte, []int) {
+	return file_protos_coupon_v1_coupon_proto_rawDescGZIP(), []int{95}
+}
+
+func (x *StackingConflict) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *StackingConflict) GetConflictsWith() string {
+	if x != nil {
+		return x.ConflictsWith
+	}
+	return ""
+}
+
+func (x *StackingConflict) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// UploadUserListRequest is a chunk of a user list upload. The campaign, kind and Bloom filter are read
+// from the first message only. The upload replaces the campaign's list of the kind once the stream ends,
+// and fails once it has more than 10,000,000 distinct users.
+type UploadUserListRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CampaignId    uint32                 `protobuf:"varint,1,opt,name=campaign_id,json=campaignId,proto3" json:"campaign_id,omitempty"`
+	Kind          UserListKind           `protobuf:"varint,2,opt,name=kind,proto3,enum=protos.coupon.v1.UserListKind" json:"kind,omitempty"`
+	BloomFilter   *BloomFilter           `protobuf:"bytes,3,opt,name=bloom_filter,json=bloomFilter,proto3" json:"bloom_filter,omitempty"`
+	UserIds       []string               `protobuf:"bytes,4,rep,name=user_ids,json=userIds,proto3" json:"user_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UploadUserListRequest) Reset() {
+	*x = UploadUserListRequest{}
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[96]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UploadUserListRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadUserListRequest) ProtoMessage() {}
+
+func (x *UploadUserListRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[96]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadUserListRequest.ProtoReflect.Descriptor instead.
+func (*UploadUserListRequest) Descriptor() ([]byte, []int) {
+	return file_protos_coupon_v1_coupon_proto_rawDescGZIP(), []int{96}
+}
+
+func (x *UploadUserListRequest) GetCampaignId() uint32 {
+	if x != nil {
+		return x.CampaignId
+	}
+	return 0
+}
+
+func (x *UploadUserListRequest) GetKind() UserListKind {
+	if x != nil {
+		return x.Kind
+	}
+	return UserListKind_USER_LIST_KIND_UNSPECIFIED
+}
+
+func (x *UploadUserListRequest) GetBloomFilter() *BloomFilter {
+	if x != nil {
+		return x.BloomFilter
+	}
+	return nil
+}
+
+func (x *UploadUserListRequest) GetUserIds() []string {
+	if x != nil {
+		return x.UserIds
+	}
+	return nil
+}
+
+type UploadUserListResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CampaignId    uint32                 `protobuf:"varint,1,opt,name=campaign_id,json=campaignId,proto3" json:"campaign_id,omitempty"`
+	List          *UserList              `protobuf:"bytes,2,opt,name=list,proto3" json:"list,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UploadUserListResponse) Reset() {
+	*x = UploadUserListResponse{}
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[97]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UploadUserListResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadUserListResponse) ProtoMessage() {}
+
+func (x *UploadUserListResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[97]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadUserListResponse.ProtoReflect.Descriptor instead.
+func (*UploadUserListResponse) Descriptor() ([]byte, []int) {
+	return file_protos_coupon_v1_coupon_proto_rawDescGZIP(), []int{97}
+}
+
+func (x *UploadUserListResponse) GetCampaignId() uint32 {
+	if x != nil {
+		return x.CampaignId
+	}
+	return 0
+}
+
+func (x *UploadUserListResponse) GetList() *UserList {
+	if x != nil {
+		return x.List
+	}
+	return nil
+}
+
+type EvaluateCartRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Items         []*LineItem            `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	Codes         []string               `protobuf:"bytes,2,rep,name=codes,proto3" json:"codes,omitempty"`
+	Shipping      *Money                 `protobuf:"bytes,3,opt,name=shipping,proto3" json:"shipping,omitempty"` // the shipping fee which free shipping discounts.
+	Channel       Channel                `protobuf:"varint,4,opt,name=channel,proto3,enum=protos.coupon.v1.Channel" json:"channel,omitempty"`
+	PaymentMethod string                 `protobuf:"bytes,5,opt,name=payment_method,json=paymentMethod,proto3" json:"payment_method,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Coupon) Reset() {
-	*x = Coupon{}
-	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[0]
+func (x *EvaluateCartRequest) Reset() {
+	*x = EvaluateCartRequest{}
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[98]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Coupon) String() string {
+func (x *EvaluateCartRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Coupon) ProtoMessage() {}
+func (*EvaluateCartRequest) ProtoMessage() {}
 
-func (x *Coupon) ProtoReflect() protoreflect.Message {
-	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[0]
+func (x *EvaluateCartRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[98]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -56,61 +7233,75 @@ func (x *Coupon) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Coupon.ProtoReflect.Descriptor instead.
-func (*Coupon) Descriptor() ([]byte, []int) {
-	return file_protos_coupon_v1_coupon_proto_rawDescGZIP(), []int{0}
+// Deprecated: Use EvaluateCartRequest.ProtoReflect.Descriptor instead.
+func (*EvaluateCartRequest) Descriptor() ([]byte, []int) {
+	return file_protos_coupon_v1_coupon_proto_rawDescGZIP(), []int{98}
 }
 
-func (x *Coupon) GetCode() string {
+func (x *EvaluateCartRequest) GetItems() []*LineItem {
 	if x != nil {
-		return x.Code
+		return x.Items
 	}
-	return ""
+	return nil
 }
 
-func (x *Coupon) GetExpireAt() *timestamppb.Timestamp {
+func (x *EvaluateCartRequest) GetCodes() []string {
 	if x != nil {
-		return x.ExpireAt
+		return x.Codes
 	}
 	return nil
 }
 
-func (x *Coupon) GetIssuedAt() *timestamppb.Timestamp {
+func (x *EvaluateCartRequest) GetShipping() *Money {
 	if x != nil {
-		return x.IssuedAt
+		return x.Shipping
 	}
 	return nil
 }
 
-type Campaign struct {
+func (x *EvaluateCartRequest) GetChannel() Channel {
+	if x != nil {
+		return x.Channel
+	}
+	return Channel_CHANNEL_UNSPECIFIED
+}
+
+func (x *EvaluateCartRequest) GetPaymentMethod() string {
+	if x != nil {
+		return x.PaymentMethod
+	}
+	return ""
+}
+
+type EvaluateCartResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	CouponLimit   uint32                 `protobuf:"varint,2,opt,name=coupon_limit,json=couponLimit,proto3" json:"coupon_limit,omitempty"`
-	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
-	Description   string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
-	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	StartAt       *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=start_at,json=startAt,proto3" json:"start_at,omitempty"`
-	EndAt         *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=end_at,json=endAt,proto3" json:"end_at,omitempty"`
-	Coupons       []*Coupon              `protobuf:"bytes,8,rep,name=coupons,proto3" json:"coupons,omitempty"`
+	Lines         []*LineResult          `protobuf:"bytes,1,rep,name=lines,proto3" json:"lines,omitempty"`
+	Applied       []*AppliedCoupon       `protobuf:"bytes,2,rep,name=applied,proto3" json:"applied,omitempty"`
+	Rejected      []*RejectedCoupon      `protobuf:"bytes,3,rep,name=rejected,proto3" json:"rejected,omitempty"`
+	Conflicts     []*StackingConflict    `protobuf:"bytes,4,rep,name=conflicts,proto3" json:"conflicts,omitempty"`
+	Subtotal      *Money                 `protobuf:"bytes,5,opt,name=subtotal,proto3" json:"subtotal,omitempty"`
+	Shipping      *Money                 `protobuf:"bytes,6,opt,name=shipping,proto3" json:"shipping,omitempty"`
+	DiscountTotal *Money                 `protobuf:"bytes,7,opt,name=discount_total,json=discountTotal,proto3" json:"discount_total,omitempty"` // the discount on line items and shipping.
+	Total         *Money                 `protobuf:"bytes,8,opt,name=total,proto3" json:"total,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Campaign) Reset() {
-	*x = Campaign{}
-	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[1]
+func (x *EvaluateCartResponse) Reset() {
+	*x = EvaluateCartResponse{}
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[99]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Campaign) String() string {
+func (x *EvaluateCartResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Campaign) ProtoMessage() {}
+func (*EvaluateCartResponse) ProtoMessage() {}
 
-func (x *Campaign) ProtoReflect() protoreflect.Message {
-	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[1]
+func (x *EvaluateCartResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[99]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -121,93 +7312,89 @@ func (x *Campaign) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Campaign.ProtoReflect.Descriptor instead.
-func (*Campaign) Descriptor() ([]byte, []int) {
-	return file_protos_coupon_v1_coupon_proto_rawDescGZIP(), []int{1}
+// Deprecated: Use EvaluateCartResponse.ProtoReflect.Descriptor instead.
+func (*EvaluateCartResponse) Descriptor() ([]byte, []int) {
+	return file_protos_coupon_v1_coupon_proto_rawDescGZIP(), []int{99}
 }
 
-func (x *Campaign) GetId() uint32 {
+func (x *EvaluateCartResponse) GetLines() []*LineResult {
 	if x != nil {
-		return x.Id
+		return x.Lines
 	}
-	return 0
+	return nil
 }
 
-func (x *Campaign) GetCouponLimit() uint32 {
+func (x *EvaluateCartResponse) GetApplied() []*AppliedCoupon {
 	if x != nil {
-		return x.CouponLimit
+		return x.Applied
 	}
-	return 0
+	return nil
 }
 
-func (x *Campaign) GetName() string {
+func (x *EvaluateCartResponse) GetRejected() []*RejectedCoupon {
 	if x != nil {
-		return x.Name
+		return x.Rejected
 	}
-	return ""
+	return nil
 }
 
-func (x *Campaign) GetDescription() string {
+func (x *EvaluateCartResponse) GetConflicts() []*StackingConflict {
 	if x != nil {
-		return x.Description
+		return x.Conflicts
 	}
-	return ""
+	return nil
 }
 
-func (x *Campaign) GetCreatedAt() *timestamppb.Timestamp {
+func (x *EvaluateCartResponse) GetSubtotal() *Money {
 	if x != nil {
-		return x.CreatedAt
+		return x.Subtotal
 	}
 	return nil
 }
 
-func (x *Campaign) GetStartAt() *timestamppb.Timestamp {
+func (x *EvaluateCartResponse) GetShipping() *Money {
 	if x != nil {
-		return x.StartAt
+		return x.Shipping
 	}
 	return nil
 }
 
-func (x *Campaign) GetEndAt() *timestamppb.Timestamp {
+func (x *EvaluateCartResponse) GetDiscountTotal() *Money {
 	if x != nil {
-		return x.EndAt
+		return x.DiscountTotal
 	}
 	return nil
 }
 
-func (x *Campaign) GetCoupons() []*Coupon {
+func (x *EvaluateCartResponse) GetTotal() *Money {
 	if x != nil {
-		return x.Coupons
+		return x.Total
 	}
 	return nil
 }
 
-type CreateCampaignRequest struct {
+type Discount_FixedAmount struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	CouponLimit   uint32                 `protobuf:"varint,1,opt,name=coupon_limit,json=couponLimit,proto3" json:"coupon_limit,omitempty"`
-	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
-	StartAt       *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=start_at,json=startAt,proto3" json:"start_at,omitempty"`
-	EndAt         *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=end_at,json=endAt,proto3" json:"end_at,omitempty"`
+	Amount        *Money                 `protobuf:"bytes,1,opt,name=amount,proto3" json:"amount,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreateCampaignRequest) Reset() {
-	*x = CreateCampaignRequest{}
-	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[2]
+func (x *Discount_FixedAmount) Reset() {
+	*x = Discount_FixedAmount{}
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[100]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateCampaignRequest) String() string {
+func (x *Discount_FixedAmount) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateCampaignRequest) ProtoMessage() {}
+func (*Discount_FixedAmount) ProtoMessage() {}
 
-func (x *CreateCampaignRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[2]
+func (x *Discount_FixedAmount) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[100]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -218,68 +7405,41 @@ func (x *CreateCampaignRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateCampaignRequest.ProtoReflect.Descriptor instead.
-func (*CreateCampaignRequest) Descriptor() ([]byte, []int) {
-	return file_protos_coupon_v1_coupon_proto_rawDescGZIP(), []int{2}
-}
-
-func (x *CreateCampaignRequest) GetCouponLimit() uint32 {
-	if x != nil {
-		return x.CouponLimit
-	}
-	return 0
-}
-
-func (x *CreateCampaignRequest) GetName() string {
-	if x != nil {
-		return x.Name
-	}
-	return ""
-}
-
-func (x *CreateCampaignRequest) GetDescription() string {
-	if x != nil {
-		return x.Description
-	}
-	return ""
-}
-
-func (x *CreateCampaignRequest) GetStartAt() *timestamppb.Timestamp {
-	if x != nil {
-		return x.StartAt
-	}
-	return nil
+// Deprecated: Use Discount_FixedAmount.ProtoReflect.Descriptor instead.
+func (*Discount_FixedAmount) Descriptor() ([]byte, []int) {
+	return file_protos_coupon_v1_coupon_proto_rawDescGZIP(), []int{34, 0}
 }
 
-func (x *CreateCampaignRequest) GetEndAt() *timestamppb.Timestamp {
+func (x *Discount_FixedAmount) GetAmount() *Money {
 	if x != nil {
-		return x.EndAt
+		return x.Amount
 	}
 	return nil
 }
 
-type CreateCampaignResponse struct {
+type Discount_Percentage struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Campaign      *Campaign              `protobuf:"bytes,1,opt,name=campaign,proto3" json:"campaign,omitempty"`
+	BasisPoints   uint32                 `protobuf:"varint,1,opt,name=basis_points,json=basisPoints,proto3" json:"basis_points,omitempty"` // 1 basis point is 0.01%, so 1000 is 10%.
+	Cap           *Money                 `protobuf:"bytes,2,opt,name=cap,proto3" json:"cap,omitempty"`                                     // the maximum discount, unlimited if not set.
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreateCampaignResponse) Reset() {
-	*x = CreateCampaignResponse{}
-	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[3]
+func (x *Discount_Percentage) Reset() {
+	*x = Discount_Percentage{}
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[101]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateCampaignResponse) String() string {
+func (x *Discount_Percentage) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateCampaignResponse) ProtoMessage() {}
+func (*Discount_Percentage) ProtoMessage() {}
 
-func (x *CreateCampaignResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[3]
+func (x *Discount_Percentage) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[101]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -290,40 +7450,46 @@ func (x *CreateCampaignResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateCampaignResponse.ProtoReflect.Descriptor instead.
-func (*CreateCampaignResponse) Descriptor() ([]byte, []int) {
-	return file_protos_coupon_v1_coupon_proto_rawDescGZIP(), []int{3}
+// Deprecated: Use Discount_Percentage.ProtoReflect.Descriptor instead.
+func (*Discount_Percentage) Descriptor() ([]byte, []int) {
+	return file_protos_coupon_v1_coupon_proto_rawDescGZIP(), []int{34, 1}
 }
 
-func (x *CreateCampaignResponse) GetCampaign() *Campaign {
+func (x *Discount_Percentage) GetBasisPoints() uint32 {
 	if x != nil {
-		return x.Campaign
+		return x.BasisPoints
+	}
+	return 0
+}
+
+func (x *Discount_Percentage) GetCap() *Money {
+	if x != nil {
+		return x.Cap
 	}
 	return nil
 }
 
-type GetCampaignRequest struct {
+type Discount_FreeShipping struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	CampaignId    uint32                 `protobuf:"varint,1,opt,name=campaign_id,json=campaignId,proto3" json:"campaign_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetCampaignRequest) Reset() {
-	*x = GetCampaignRequest{}
-	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[4]
+func (x *Discount_FreeShipping) Reset() {
+	*x = Discount_FreeShipping{}
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[102]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetCampaignRequest) String() string {
+func (x *Discount_FreeShipping) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetCampaignRequest) ProtoMessage() {}
+func (*Discount_FreeShipping) ProtoMessage() {}
 
-func (x *GetCampaignRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[4]
+func (x *Discount_FreeShipping) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[102]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -334,40 +7500,35 @@ func (x *GetCampaignRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetCampaignRequest.ProtoReflect.Descriptor instead.
-func (*GetCampaignRequest) Descriptor() ([]byte, []int) {
-	return file_protos_coupon_v1_coupon_proto_rawDescGZIP(), []int{4}
-}
-
-func (x *GetCampaignRequest) GetCampaignId() uint32 {
-	if x != nil {
-		return x.CampaignId
-	}
-	return 0
+// Deprecated: Use Discount_FreeShipping.ProtoReflect.Descriptor instead.
+func (*Discount_FreeShipping) Descriptor() ([]byte, []int) {
+	return file_protos_coupon_v1_coupon_proto_rawDescGZIP(), []int{34, 2}
 }
 
-type GetCampaignResponse struct {
+// BuyXGetY gives get_quantity items for free for every buy_quantity items bought.
+type Discount_BuyXGetY struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Campaign      *Campaign              `protobuf:"bytes,1,opt,name=campaign,proto3" json:"campaign,omitempty"`
+	BuyQuantity   uint32                 `protobuf:"varint,1,opt,name=buy_quantity,json=buyQuantity,proto3" json:"buy_quantity,omitempty"`
+	GetQuantity   uint32                 `protobuf:"varint,2,opt,name=get_quantity,json=getQuantity,proto3" json:"get_quantity,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetCampaignResponse) Reset() {
-	*x = GetCampaignResponse{}
-	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[5]
+func (x *Discount_BuyXGetY) Reset() {
+	*x = Discount_BuyXGetY{}
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[103]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetCampaignResponse) String() string {
+func (x *Discount_BuyXGetY) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetCampaignResponse) ProtoMessage() {}
+func (*Discount_BuyXGetY) ProtoMessage() {}
 
-func (x *GetCampaignResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[5]
+func (x *Discount_BuyXGetY) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[103]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -378,40 +7539,49 @@ func (x *GetCampaignResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetCampaignResponse.ProtoReflect.Descriptor instead.
-func (*GetCampaignResponse) Descriptor() ([]byte, []int) {
-	return file_protos_coupon_v1_coupon_proto_rawDescGZIP(), []int{5}
+// Deprecated: Use Discount_BuyXGetY.ProtoReflect.Descriptor instead.
+func (*Discount_BuyXGetY) Descriptor() ([]byte, []int) {
+	return file_protos_coupon_v1_coupon_proto_rawDescGZIP(), []int{34, 3}
 }
 
-func (x *GetCampaignResponse) GetCampaign() *Campaign {
+func (x *Discount_BuyXGetY) GetBuyQuantity() uint32 {
 	if x != nil {
-		return x.Campaign
+		return x.BuyQuantity
 	}
-	return nil
+	return 0
 }
 
-type IssueCouponRequest struct {
+func (x *Discount_BuyXGetY) GetGetQuantity() uint32 {
+	if x != nil {
+		return x.GetQuantity
+	}
+	return 0
+}
+
+// EndOfDay expires coupons at the end of the day that is `days` days after issuance in `time_zone`.
+type ExpiryPolicy_EndOfDay struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	CampaignId    uint32                 `protobuf:"varint,1,opt,name=campaign_id,json=campaignId,proto3" json:"campaign_id,omitempty"`
+	Days          uint32                 `protobuf:"varint,1,opt,name=days,proto3" json:"days,omitempty"`
+	TimeZone      string                 `protobuf:"bytes,2,opt,name=time_zone,json=timeZone,proto3" json:"time_zone,omitempty"` // an IANA time zone name, e.g. Asia/Seoul.
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *IssueCouponRequest) Reset() {
-	*x = IssueCouponRequest{}
-	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[6]
+func (x *ExpiryPolicy_EndOfDay) Reset() {
+	*x = ExpiryPolicy_EndOfDay{}
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[104]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *IssueCouponRequest) String() string {
+func (x *ExpiryPolicy_EndOfDay) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*IssueCouponRequest) ProtoMessage() {}
+func (*ExpiryPolicy_EndOfDay) ProtoMessage() {}
 
-func (x *IssueCouponRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[6]
+func (x *ExpiryPolicy_EndOfDay) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[104]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -422,40 +7592,48 @@ func (x *IssueCouponRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use IssueCouponRequest.ProtoReflect.Descriptor instead.
-func (*IssueCouponRequest) Descriptor() ([]byte, []int) {
-	return file_protos_coupon_v1_coupon_proto_rawDescGZIP(), []int{6}
+// Deprecated: Use ExpiryPolicy_EndOfDay.ProtoReflect.Descriptor instead.
+func (*ExpiryPolicy_EndOfDay) Descriptor() ([]byte, []int) {
+	return file_protos_coupon_v1_coupon_proto_rawDescGZIP(), []int{35, 0}
 }
 
-func (x *IssueCouponRequest) GetCampaignId() uint32 {
+func (x *ExpiryPolicy_EndOfDay) GetDays() uint32 {
 	if x != nil {
-		return x.CampaignId
+		return x.Days
 	}
 	return 0
 }
 
-type IssueCouponResponse struct {
+func (x *ExpiryPolicy_EndOfDay) GetTimeZone() string {
+	if x != nil {
+		return x.TimeZone
+	}
+	return ""
+}
+
+// Earliest expires coupons at the earliest expiration of several policies.
+type ExpiryPolicy_Earliest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Coupon        *Coupon                `protobuf:"bytes,1,opt,name=coupon,proto3" json:"coupon,omitempty"`
+	Policies      []*ExpiryPolicy        `protobuf:"bytes,1,rep,name=policies,proto3" json:"policies,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *IssueCouponResponse) Reset() {
-	*x = IssueCouponResponse{}
-	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[7]
+func (x *ExpiryPolicy_Earliest) Reset() {
+	*x = ExpiryPolicy_Earliest{}
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[105]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *IssueCouponResponse) String() string {
+func (x *ExpiryPolicy_Earliest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*IssueCouponResponse) ProtoMessage() {}
+func (*ExpiryPolicy_Earliest) ProtoMessage() {}
 
-func (x *IssueCouponResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[7]
+func (x *ExpiryPolicy_Earliest) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_coupon_v1_coupon_proto_msgTypes[105]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -466,14 +7644,14 @@ func (x *IssueCouponResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use IssueCouponResponse.ProtoReflect.Descriptor instead.
-func (*IssueCouponResponse) Descriptor() ([]byte, []int) {
-	return file_protos_coupon_v1_coupon_proto_rawDescGZIP(), []int{7}
+// Deprecated: Use ExpiryPolicy_Earliest.ProtoReflect.Descriptor instead.
+func (*ExpiryPolicy_Earliest) Descriptor() ([]byte, []int) {
+	return file_protos_coupon_v1_coupon_proto_rawDescGZIP(), []int{35, 1}
 }
 
-func (x *IssueCouponResponse) GetCoupon() *Coupon {
+func (x *ExpiryPolicy_Earliest) GetPolicies() []*ExpiryPolicy {
 	if x != nil {
-		return x.Coupon
+		return x.Policies
 	}
 	return nil
 }
@@ -482,11 +7660,78 @@ var File_protos_coupon_v1_coupon_proto protoreflect.FileDescriptor
 
 const file_protos_coupon_v1_coupon_proto_rawDesc = "" +
 	"\n" +
-	"\x1dprotos/coupon/v1/coupon.proto\x12\x10protos.coupon.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\x8e\x01\n" +
+	"\x1dprotos/coupon/v1/coupon.proto\x12\x10protos.coupon.v1\x1a\x1egoogle/protobuf/duration.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xb1\a\n" +
 	"\x06Coupon\x12\x12\n" +
 	"\x04code\x18\x01 \x01(\tR\x04code\x127\n" +
 	"\texpire_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\bexpireAt\x127\n" +
-	"\tissued_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\bissuedAt\"\xcc\x02\n" +
+	"\tissued_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\bissuedAt\x12\x1f\n" +
+	"\vcampaign_id\x18\x04 \x01(\rR\n" +
+	"campaignId\x126\n" +
+	"\x06status\x18\x05 \x01(\x0e2\x1e.protos.coupon.v1.CouponStatusR\x06status\x12;\n" +
+	"\vredeemed_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"redeemedAt\x129\n" +
+	"\n" +
+	"revoked_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\trevokedAt\x12#\n" +
+	"\rrevoke_reason\x18\b \x01(\tR\frevokeReason\x126\n" +
+	"\bdiscount\x18\t \x01(\v2\x1a.protos.coupon.v1.DiscountR\bdiscount\x12\x17\n" +
+	"\auser_id\x18\n" +
+	" \x01(\tR\x06userId\x12?\n" +
+	"\vreservation\x18\v \x01(\v2\x1d.protos.coupon.v1.ReservationR\vreservation\x121\n" +
+	"\abalance\x18\f \x01(\v2\x17.protos.coupon.v1.MoneyR\abalance\x12#\n" +
+	"\rredemption_id\x18\r \x01(\tR\fredemptionId\x12\x19\n" +
+	"\border_id\x18\x0e \x01(\tR\aorderId\x128\n" +
+	"\ttransfers\x18\x0f \x03(\v2\x1a.protos.coupon.v1.TransferR\ttransfers\x12F\n" +
+	"\x0etransfer_offer\x18\x10 \x01(\v2\x1f.protos.coupon.v1.TransferOfferR\rtransferOffer\x12#\n" +
+	"\rreferral_code\x18\x11 \x01(\tR\freferralCode\x12\x12\n" +
+	"\x04tier\x18\x12 \x01(\tR\x04tier\x12\x18\n" +
+	"\avariant\x18\x13 \x01(\tR\avariant\x12\x18\n" +
+	"\achannel\x18\x14 \x01(\tR\achannel\x127\n" +
+	"\ahistory\x18\x15 \x03(\v2\x1d.protos.coupon.v1.CouponEventR\ahistory\"\xdd\x01\n" +
+	"\vCouponEvent\x125\n" +
+	"\x04type\x18\x01 \x01(\x0e2!.protos.coupon.v1.CouponEventTypeR\x04type\x12#\n" +
+	"\rredemption_id\x18\x02 \x01(\tR\fredemptionId\x12\x19\n" +
+	"\border_id\x18\x03 \x01(\tR\aorderId\x12\x1a\n" +
+	"\brestored\x18\x04 \x01(\bR\brestored\x12;\n" +
+	"\voccurred_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"occurredAt\"\xa7\x01\n" +
+	"\bTransfer\x12 \n" +
+	"\ffrom_user_id\x18\x01 \x01(\tR\n" +
+	"fromUserId\x12\x1c\n" +
+	"\n" +
+	"to_user_id\x18\x02 \x01(\tR\btoUserId\x12A\n" +
+	"\x0etransferred_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\rtransferredAt\x12\x18\n" +
+	"\aclaimed\x18\x04 \x01(\bR\aclaimed\"\xa5\x01\n" +
+	"\rTransferOffer\x12 \n" +
+	"\ffrom_user_id\x18\x01 \x01(\tR\n" +
+	"fromUserId\x129\n" +
+	"\n" +
+	"offered_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\tofferedAt\x127\n" +
+	"\texpire_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\bexpireAt\"\x9a\x01\n" +
+	"\x0eTransferPolicy\x12#\n" +
+	"\rmax_transfers\x18\x01 \x01(\rR\fmaxTransfers\x12+\n" +
+	"\x11check_eligibility\x18\x02 \x01(\bR\x10checkEligibility\x126\n" +
+	"\tclaim_ttl\x18\x03 \x01(\v2\x19.google.protobuf.DurationR\bclaimTtl\"\xee\x01\n" +
+	"\bReversal\x12#\n" +
+	"\rredemption_id\x18\x01 \x01(\tR\fredemptionId\x12\x12\n" +
+	"\x04code\x18\x02 \x01(\tR\x04code\x12\x19\n" +
+	"\border_id\x18\x03 \x01(\tR\aorderId\x12\x1a\n" +
+	"\brestored\x18\x04 \x01(\bR\brestored\x125\n" +
+	"\x06refund\x18\x05 \x01(\v2\x1d.protos.coupon.v1.LedgerEntryR\x06refund\x12;\n" +
+	"\vreversed_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"reversedAt\"\x90\x02\n" +
+	"\vLedgerEntry\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x125\n" +
+	"\x04type\x18\x02 \x01(\x0e2!.protos.coupon.v1.LedgerEntryTypeR\x04type\x12/\n" +
+	"\x06amount\x18\x03 \x01(\v2\x17.protos.coupon.v1.MoneyR\x06amount\x121\n" +
+	"\abalance\x18\x04 \x01(\v2\x17.protos.coupon.v1.MoneyR\abalance\x12\x19\n" +
+	"\border_id\x18\x05 \x01(\tR\aorderId\x12;\n" +
+	"\voccurred_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"occurredAt\"\x93\x01\n" +
+	"\vReservation\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12;\n" +
+	"\vreserved_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"reservedAt\x127\n" +
+	"\texpire_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\bexpireAt\"\xf4\x10\n" +
 	"\bCampaign\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\rR\x02id\x12!\n" +
 	"\fcoupon_limit\x18\x02 \x01(\rR\vcouponLimit\x12\x12\n" +
@@ -496,29 +7741,573 @@ const file_protos_coupon_v1_coupon_proto_rawDesc = "" +
 	"created_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x125\n" +
 	"\bstart_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\astartAt\x121\n" +
 	"\x06end_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\x05endAt\x122\n" +
-	"\acoupons\x18\b \x03(\v2\x18.protos.coupon.v1.CouponR\acoupons\"\xda\x01\n" +
+	"\acoupons\x18\b \x03(\v2\x18.protos.coupon.v1.CouponR\acoupons\x129\n" +
+	"\ahistory\x18\t \x03(\v2\x1f.protos.coupon.v1.CampaignEventR\ahistory\x12C\n" +
+	"\rexpiry_policy\x18\n" +
+	" \x01(\v2\x1e.protos.coupon.v1.ExpiryPolicyR\fexpiryPolicy\x126\n" +
+	"\bdiscount\x18\v \x01(\v2\x1a.protos.coupon.v1.DiscountR\bdiscount\x12E\n" +
+	"\rapplicability\x18\f \x01(\v2\x1f.protos.coupon.v1.ApplicabilityR\rapplicability\x12<\n" +
+	"\bstacking\x18\r \x01(\v2 .protos.coupon.v1.StackingPolicyR\bstacking\x12 \n" +
+	"\veligibility\x18\x0e \x01(\tR\veligibility\x128\n" +
+	"\tallowlist\x18\x0f \x01(\v2\x1a.protos.coupon.v1.UserListR\tallowlist\x128\n" +
+	"\tblocklist\x18\x10 \x01(\v2\x1a.protos.coupon.v1.UserListR\tblocklist\x125\n" +
+	"\x05state\x18\x11 \x01(\x0e2\x1f.protos.coupon.v1.CampaignStateR\x05state\x127\n" +
+	"\tclosed_at\x18\x12 \x01(\v2\x1a.google.protobuf.TimestampR\bclosedAt\x12<\n" +
+	"\n" +
+	"recurrence\x18\x13 \x01(\v2\x1c.protos.coupon.v1.RecurrenceR\n" +
+	"recurrence\x12K\n" +
+	"\x12current_occurrence\x18\x14 \x01(\v2\x1c.protos.coupon.v1.OccurrenceR\x11currentOccurrence\x12E\n" +
+	"\x0fnext_occurrence\x18\x15 \x01(\v2\x1c.protos.coupon.v1.OccurrenceR\x0enextOccurrence\x12>\n" +
+	"\voccurrences\x18\x16 \x03(\v2\x1c.protos.coupon.v1.OccurrenceR\voccurrences\x126\n" +
+	"\bthrottle\x18\x17 \x01(\v2\x1a.protos.coupon.v1.ThrottleR\bthrottle\x12@\n" +
+	"\fwaiting_room\x18\x18 \x01(\v2\x1d.protos.coupon.v1.WaitingRoomR\vwaitingRoom\x123\n" +
+	"\alottery\x18\x19 \x01(\v2\x19.protos.coupon.v1.LotteryR\alottery\x126\n" +
+	"\bwaitlist\x18\x1a \x01(\v2\x1a.protos.coupon.v1.WaitlistR\bwaitlist\x12:\n" +
+	"\fstored_value\x18\x1b \x01(\v2\x17.protos.coupon.v1.MoneyR\vstoredValue\x12F\n" +
+	"\x0erestore_policy\x18\x1c \x01(\x0e2\x1f.protos.coupon.v1.RestorePolicyR\rrestorePolicy\x12<\n" +
+	"\btransfer\x18\x1d \x01(\v2 .protos.coupon.v1.TransferPolicyR\btransfer\x12<\n" +
+	"\breferral\x18\x1e \x01(\v2 .protos.coupon.v1.ReferralPolicyR\breferral\x12,\n" +
+	"\x05tiers\x18\x1f \x03(\v2\x16.protos.coupon.v1.TierR\x05tiers\x12:\n" +
+	"\n" +
+	"tier_stats\x18  \x03(\v2\x1b.protos.coupon.v1.TierStatsR\ttierStats\x125\n" +
+	"\bvariants\x18! \x03(\v2\x19.protos.coupon.v1.VariantR\bvariants\x12C\n" +
+	"\rvariant_stats\x18\" \x03(\v2\x1e.protos.coupon.v1.VariantStatsR\fvariantStats\x120\n" +
+	"\x06budget\x18# \x01(\v2\x18.protos.coupon.v1.BudgetR\x06budget\x12@\n" +
+	"\fbudget_stats\x18$ \x01(\v2\x1d.protos.coupon.v1.BudgetStatsR\vbudgetStats\x12B\n" +
+	"\n" +
+	"allocation\x18% \x01(\v2\".protos.coupon.v1.AllocationPolicyR\n" +
+	"allocation\x12L\n" +
+	"\x10allocation_stats\x18& \x03(\v2!.protos.coupon.v1.AllocationStatsR\x0fallocationStats\"h\n" +
+	"\x04Tier\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\rR\x05limit\x126\n" +
+	"\bdiscount\x18\x03 \x01(\v2\x1a.protos.coupon.v1.DiscountR\bdiscount\"U\n" +
+	"\tTierStats\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x16\n" +
+	"\x06issued\x18\x02 \x01(\rR\x06issued\x12\x1c\n" +
+	"\tremaining\x18\x03 \x01(\rR\tremaining\"\x83\x01\n" +
+	"\aVariant\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x16\n" +
+	"\x06weight\x18\x02 \x01(\rR\x06weight\x126\n" +
+	"\bdiscount\x18\x03 \x01(\v2\x1a.protos.coupon.v1.DiscountR\bdiscount\x12\x14\n" +
+	"\x05limit\x18\x04 \x01(\rR\x05limit\"t\n" +
+	"\fVariantStats\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x16\n" +
+	"\x06issued\x18\x02 \x01(\rR\x06issued\x12\x1a\n" +
+	"\bredeemed\x18\x03 \x01(\rR\bredeemed\x12\x1c\n" +
+	"\tremaining\x18\x04 \x01(\rR\tremaining\"\xa4\x01\n" +
+	"\x06Budget\x12/\n" +
+	"\x06amount\x18\x01 \x01(\v2\x17.protos.coupon.v1.MoneyR\x06amount\x12>\n" +
+	"\x0eprojected_cost\x18\x02 \x01(\v2\x17.protos.coupon.v1.MoneyR\rprojectedCost\x12)\n" +
+	"\x10alert_thresholds\x18\x03 \x03(\rR\x0falertThresholds\"\xa8\x01\n" +
+	"\vBudgetStats\x12-\n" +
+	"\x05spent\x18\x01 \x01(\v2\x17.protos.coupon.v1.MoneyR\x05spent\x123\n" +
+	"\breserved\x18\x02 \x01(\v2\x17.protos.coupon.v1.MoneyR\breserved\x125\n" +
+	"\tremaining\x18\x03 \x01(\v2\x17.protos.coupon.v1.MoneyR\tremaining\"\x91\x01\n" +
+	"\x10AllocationPolicy\x12>\n" +
+	"\vallocations\x18\x01 \x03(\v2\x1c.protos.coupon.v1.AllocationR\vallocations\x12=\n" +
+	"\fspillover_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\vspilloverAt\"<\n" +
+	"\n" +
+	"Allocation\x12\x18\n" +
+	"\achannel\x18\x01 \x01(\tR\achannel\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\rR\x05limit\"a\n" +
+	"\x0fAllocationStats\x12\x18\n" +
+	"\achannel\x18\x01 \x01(\tR\achannel\x12\x16\n" +
+	"\x06issued\x18\x02 \x01(\rR\x06issued\x12\x1c\n" +
+	"\tremaining\x18\x03 \x01(\rR\tremaining\"i\n" +
+	"\x0eReferralPolicy\x12#\n" +
+	"\rmax_referrals\x18\x01 \x01(\rR\fmaxReferrals\x122\n" +
+	"\x06reward\x18\x02 \x01(\v2\x1a.protos.coupon.v1.DiscountR\x06reward\"\xac\x01\n" +
+	"\x06Bundle\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12!\n" +
+	"\fcampaign_ids\x18\x04 \x03(\rR\vcampaignIds\x129\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\x94\x01\n" +
+	"\fReferralCode\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\x12\x1f\n" +
+	"\vcampaign_id\x18\x02 \x01(\rR\n" +
+	"campaignId\x12\x17\n" +
+	"\auser_id\x18\x03 \x01(\tR\x06userId\x12\x1c\n" +
+	"\treferrals\x18\x04 \x01(\rR\treferrals\x12\x18\n" +
+	"\arewards\x18\x05 \x01(\rR\arewards\"<\n" +
+	"\bWaitlist\x12\x18\n" +
+	"\awaiting\x18\x01 \x01(\x04R\awaiting\x12\x16\n" +
+	"\x06issued\x18\x02 \x01(\x04R\x06issued\"\xa5\x01\n" +
+	"\aLottery\x12\x1b\n" +
+	"\tseed_hash\x18\x01 \x01(\fR\bseedHash\x12\x12\n" +
+	"\x04seed\x18\x02 \x01(\fR\x04seed\x12\x18\n" +
+	"\aentries\x18\x03 \x01(\x04R\aentries\x125\n" +
+	"\bdrawn_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\adrawnAt\x12\x18\n" +
+	"\awinners\x18\x05 \x01(\rR\awinners\"\x9a\x01\n" +
+	"\vWaitingRoom\x122\n" +
+	"\x15admissions_per_second\x18\x01 \x01(\rR\x13admissionsPerSecond\x126\n" +
+	"\ttoken_ttl\x18\x02 \x01(\v2\x19.google.protobuf.DurationR\btokenTtl\x12\x1f\n" +
+	"\vmax_waiting\x18\x03 \x01(\rR\n" +
+	"maxWaiting\"m\n" +
+	"\bThrottle\x12/\n" +
+	"\x05slice\x18\x01 \x01(\v2\x19.google.protobuf.DurationR\x05slice\x12\x14\n" +
+	"\x05quota\x18\x02 \x01(\rR\x05quota\x12\x1a\n" +
+	"\brollover\x18\x03 \x01(\bR\brollover\"P\n" +
+	"\x0eIssueThrottled\x12>\n" +
+	"\rnext_slice_at\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\vnextSliceAt\"x\n" +
+	"\n" +
+	"Recurrence\x12\x1a\n" +
+	"\bschedule\x18\x01 \x01(\tR\bschedule\x12\x1b\n" +
+	"\ttime_zone\x18\x02 \x01(\tR\btimeZone\x121\n" +
+	"\x06window\x18\x03 \x01(\v2\x19.google.protobuf.DurationR\x06window\"\xac\x01\n" +
+	"\n" +
+	"Occurrence\x125\n" +
+	"\bstart_at\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\astartAt\x121\n" +
+	"\x06end_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x05endAt\x12\x16\n" +
+	"\x06issued\x18\x03 \x01(\rR\x06issued\x12\x1c\n" +
+	"\tremaining\x18\x04 \x01(\rR\tremaining\"d\n" +
+	"\vBloomFilter\x12%\n" +
+	"\x0eexpected_users\x18\x01 \x01(\x04R\rexpectedUsers\x12.\n" +
+	"\x13false_positive_rate\x18\x02 \x01(\x01R\x11falsePositiveRate\"\xbe\x01\n" +
+	"\bUserList\x122\n" +
+	"\x04kind\x18\x01 \x01(\x0e2\x1e.protos.coupon.v1.UserListKindR\x04kind\x12\x1d\n" +
+	"\n" +
+	"user_count\x18\x02 \x01(\x04R\tuserCount\x12\x1d\n" +
+	"\n" +
+	"size_bytes\x18\x03 \x01(\x04R\tsizeBytes\x12@\n" +
+	"\fbloom_filter\x18\x04 \x01(\v2\x1d.protos.coupon.v1.BloomFilterR\vbloomFilter\"\x96\x01\n" +
+	"\x0eUserAttributes\x12\x19\n" +
+	"\bnew_user\x18\x01 \x01(\bR\anewUser\x12\x12\n" +
+	"\x04tier\x18\x02 \x01(\tR\x04tier\x12\x1a\n" +
+	"\bsegments\x18\x03 \x03(\tR\bsegments\x12\x1f\n" +
+	"\vorder_count\x18\x04 \x01(\x03R\n" +
+	"orderCount\x12\x18\n" +
+	"\acountry\x18\x05 \x01(\tR\acountry\"v\n" +
+	"\x0eStackingPolicy\x122\n" +
+	"\x04mode\x18\x01 \x01(\x0e2\x1e.protos.coupon.v1.StackingModeR\x04mode\x12\x14\n" +
+	"\x05group\x18\x02 \x01(\tR\x05group\x12\x1a\n" +
+	"\bpriority\x18\x03 \x01(\x05R\bpriority\"\xe1\x02\n" +
+	"\rApplicability\x12!\n" +
+	"\finclude_skus\x18\x01 \x03(\tR\vincludeSkus\x12!\n" +
+	"\fexclude_skus\x18\x02 \x03(\tR\vexcludeSkus\x12-\n" +
+	"\x12include_categories\x18\x03 \x03(\tR\x11includeCategories\x12-\n" +
+	"\x12exclude_categories\x18\x04 \x03(\tR\x11excludeCategories\x12%\n" +
+	"\x0einclude_brands\x18\x05 \x03(\tR\rincludeBrands\x12%\n" +
+	"\x0eexclude_brands\x18\x06 \x03(\tR\rexcludeBrands\x125\n" +
+	"\bchannels\x18\a \x03(\x0e2\x19.protos.coupon.v1.ChannelR\bchannels\x12'\n" +
+	"\x0fpayment_methods\x18\b \x03(\tR\x0epaymentMethods\";\n" +
+	"\x05Money\x12\x1a\n" +
+	"\bcurrency\x18\x01 \x01(\tR\bcurrency\x12\x16\n" +
+	"\x06amount\x18\x02 \x01(\x03R\x06amount\"\xff\x04\n" +
+	"\bDiscount\x12K\n" +
+	"\ffixed_amount\x18\x01 \x01(\v2&.protos.coupon.v1.Discount.FixedAmountH\x00R\vfixedAmount\x12G\n" +
+	"\n" +
+	"percentage\x18\x02 \x01(\v2%.protos.coupon.v1.Discount.PercentageH\x00R\n" +
+	"percentage\x12N\n" +
+	"\rfree_shipping\x18\x03 \x01(\v2'.protos.coupon.v1.Discount.FreeShippingH\x00R\ffreeShipping\x12D\n" +
+	"\vbuy_x_get_y\x18\x04 \x01(\v2#.protos.coupon.v1.Discount.BuyXGetYH\x00R\bbuyXGetY\x12A\n" +
+	"\x10min_order_amount\x18\x05 \x01(\v2\x17.protos.coupon.v1.MoneyR\x0eminOrderAmount\x1a>\n" +
+	"\vFixedAmount\x12/\n" +
+	"\x06amount\x18\x01 \x01(\v2\x17.protos.coupon.v1.MoneyR\x06amount\x1aZ\n" +
+	"\n" +
+	"Percentage\x12!\n" +
+	"\fbasis_points\x18\x01 \x01(\rR\vbasisPoints\x12)\n" +
+	"\x03cap\x18\x02 \x01(\v2\x17.protos.coupon.v1.MoneyR\x03cap\x1a\x0e\n" +
+	"\fFreeShipping\x1aP\n" +
+	"\bBuyXGetY\x12!\n" +
+	"\fbuy_quantity\x18\x01 \x01(\rR\vbuyQuantity\x12!\n" +
+	"\fget_quantity\x18\x02 \x01(\rR\vgetQuantityB\x06\n" +
+	"\x04kind\"\x95\x03\n" +
+	"\fExpiryPolicy\x127\n" +
+	"\bfixed_at\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampH\x00R\afixedAt\x12-\n" +
+	"\x03ttl\x18\x02 \x01(\v2\x19.google.protobuf.DurationH\x00R\x03ttl\x12G\n" +
+	"\n" +
+	"end_of_day\x18\x03 \x01(\v2'.protos.coupon.v1.ExpiryPolicy.EndOfDayH\x00R\bendOfDay\x12E\n" +
+	"\bearliest\x18\x04 \x01(\v2'.protos.coupon.v1.ExpiryPolicy.EarliestH\x00R\bearliest\x1a;\n" +
+	"\bEndOfDay\x12\x12\n" +
+	"\x04days\x18\x01 \x01(\rR\x04days\x12\x1b\n" +
+	"\ttime_zone\x18\x02 \x01(\tR\btimeZone\x1aF\n" +
+	"\bEarliest\x12:\n" +
+	"\bpolicies\x18\x01 \x03(\v2\x1e.protos.coupon.v1.ExpiryPolicyR\bpoliciesB\b\n" +
+	"\x06policy\"\xca\x01\n" +
+	"\rCampaignEvent\x127\n" +
+	"\x04type\x18\x01 \x01(\x0e2#.protos.coupon.v1.CampaignEventTypeR\x04type\x12\x12\n" +
+	"\x04code\x18\x02 \x01(\tR\x04code\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\x12;\n" +
+	"\voccurred_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"occurredAt\x12\x17\n" +
+	"\auser_id\x18\x05 \x01(\tR\x06userId\"\xdd\t\n" +
 	"\x15CreateCampaignRequest\x12!\n" +
 	"\fcoupon_limit\x18\x01 \x01(\rR\vcouponLimit\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
 	"\vdescription\x18\x03 \x01(\tR\vdescription\x125\n" +
 	"\bstart_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\astartAt\x121\n" +
-	"\x06end_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\x05endAt\"P\n" +
+	"\x06end_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\x05endAt\x12C\n" +
+	"\rexpiry_policy\x18\x06 \x01(\v2\x1e.protos.coupon.v1.ExpiryPolicyR\fexpiryPolicy\x126\n" +
+	"\bdiscount\x18\a \x01(\v2\x1a.protos.coupon.v1.DiscountR\bdiscount\x12E\n" +
+	"\rapplicability\x18\b \x01(\v2\x1f.protos.coupon.v1.ApplicabilityR\rapplicability\x12<\n" +
+	"\bstacking\x18\t \x01(\v2 .protos.coupon.v1.StackingPolicyR\bstacking\x12 \n" +
+	"\veligibility\x18\n" +
+	" \x01(\tR\veligibility\x12\x14\n" +
+	"\x05draft\x18\v \x01(\bR\x05draft\x12<\n" +
+	"\n" +
+	"recurrence\x18\f \x01(\v2\x1c.protos.coupon.v1.RecurrenceR\n" +
+	"recurrence\x126\n" +
+	"\bthrottle\x18\r \x01(\v2\x1a.protos.coupon.v1.ThrottleR\bthrottle\x12@\n" +
+	"\fwaiting_room\x18\x0e \x01(\v2\x1d.protos.coupon.v1.WaitingRoomR\vwaitingRoom\x12\x18\n" +
+	"\alottery\x18\x0f \x01(\bR\alottery\x12\x1a\n" +
+	"\bwaitlist\x18\x10 \x01(\bR\bwaitlist\x12:\n" +
+	"\fstored_value\x18\x11 \x01(\v2\x17.protos.coupon.v1.MoneyR\vstoredValue\x12F\n" +
+	"\x0erestore_policy\x18\x12 \x01(\x0e2\x1f.protos.coupon.v1.RestorePolicyR\rrestorePolicy\x12<\n" +
+	"\btransfer\x18\x13 \x01(\v2 .protos.coupon.v1.TransferPolicyR\btransfer\x12<\n" +
+	"\breferral\x18\x14 \x01(\v2 .protos.coupon.v1.ReferralPolicyR\breferral\x12,\n" +
+	"\x05tiers\x18\x15 \x03(\v2\x16.protos.coupon.v1.TierR\x05tiers\x125\n" +
+	"\bvariants\x18\x16 \x03(\v2\x19.protos.coupon.v1.VariantR\bvariants\x120\n" +
+	"\x06budget\x18\x17 \x01(\v2\x18.protos.coupon.v1.BudgetR\x06budget\x12B\n" +
+	"\n" +
+	"allocation\x18\x18 \x01(\v2\".protos.coupon.v1.AllocationPolicyR\n" +
+	"allocation\"P\n" +
 	"\x16CreateCampaignResponse\x126\n" +
 	"\bcampaign\x18\x01 \x01(\v2\x1a.protos.coupon.v1.CampaignR\bcampaign\"5\n" +
 	"\x12GetCampaignRequest\x12\x1f\n" +
 	"\vcampaign_id\x18\x01 \x01(\rR\n" +
 	"campaignId\"M\n" +
 	"\x13GetCampaignResponse\x126\n" +
-	"\bcampaign\x18\x01 \x01(\v2\x1a.protos.coupon.v1.CampaignR\bcampaign\"5\n" +
+	"\bcampaign\x18\x01 \x01(\v2\x1a.protos.coupon.v1.CampaignR\bcampaign\"O\n" +
+	"\x14PauseCampaignRequest\x12\x1f\n" +
+	"\vcampaign_id\x18\x01 \x01(\rR\n" +
+	"campaignId\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\"O\n" +
+	"\x15PauseCampaignResponse\x126\n" +
+	"\bcampaign\x18\x01 \x01(\v2\x1a.protos.coupon.v1.CampaignR\bcampaign\"P\n" +
+	"\x15ResumeCampaignRequest\x12\x1f\n" +
+	"\vcampaign_id\x18\x01 \x01(\rR\n" +
+	"campaignId\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\"P\n" +
+	"\x16ResumeCampaignResponse\x126\n" +
+	"\bcampaign\x18\x01 \x01(\v2\x1a.protos.coupon.v1.CampaignR\bcampaign\"O\n" +
+	"\x14CloseCampaignRequest\x12\x1f\n" +
+	"\vcampaign_id\x18\x01 \x01(\rR\n" +
+	"campaignId\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\"O\n" +
+	"\x15CloseCampaignResponse\x126\n" +
+	"\bcampaign\x18\x01 \x01(\v2\x1a.protos.coupon.v1.CampaignR\bcampaign\"\x81\x02\n" +
 	"\x12IssueCouponRequest\x12\x1f\n" +
 	"\vcampaign_id\x18\x01 \x01(\rR\n" +
-	"campaignId\"G\n" +
+	"campaignId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12I\n" +
+	"\x0fuser_attributes\x18\x03 \x01(\v2 .protos.coupon.v1.UserAttributesR\x0euserAttributes\x12'\n" +
+	"\x0fadmission_token\x18\x04 \x01(\tR\x0eadmissionToken\x12#\n" +
+	"\rreferral_code\x18\x05 \x01(\tR\freferralCode\x12\x18\n" +
+	"\achannel\x18\x06 \x01(\tR\achannel\"G\n" +
 	"\x13IssueCouponResponse\x120\n" +
-	"\x06coupon\x18\x01 \x01(\v2\x18.protos.coupon.v1.CouponR\x06coupon2\xba\x02\n" +
+	"\x06coupon\x18\x01 \x01(\v2\x18.protos.coupon.v1.CouponR\x06coupon\"M\n" +
+	"\x11EnterQueueRequest\x12\x1f\n" +
+	"\vcampaign_id\x18\x01 \x01(\rR\n" +
+	"campaignId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\"K\n" +
+	"\x12EnterQueueResponse\x125\n" +
+	"\x06status\x18\x01 \x01(\v2\x1d.protos.coupon.v1.QueueStatusR\x06status\"L\n" +
+	"\x11WatchQueueRequest\x12\x1f\n" +
+	"\vcampaign_id\x18\x01 \x01(\rR\n" +
+	"campaignId\x12\x16\n" +
+	"\x06ticket\x18\x02 \x01(\tR\x06ticket\"\xca\x01\n" +
+	"\vQueueStatus\x12\x16\n" +
+	"\x06ticket\x18\x01 \x01(\tR\x06ticket\x12\x1a\n" +
+	"\bposition\x18\x02 \x01(\x04R\bposition\x12\x1a\n" +
+	"\badmitted\x18\x03 \x01(\bR\badmitted\x12'\n" +
+	"\x0fadmission_token\x18\x04 \x01(\tR\x0eadmissionToken\x12B\n" +
+	"\x0ftoken_expire_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\rtokenExpireAt\"\x9a\x01\n" +
+	"\x13EnterLotteryRequest\x12\x1f\n" +
+	"\vcampaign_id\x18\x01 \x01(\rR\n" +
+	"campaignId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12I\n" +
+	"\x0fuser_attributes\x18\x03 \x01(\v2 .protos.coupon.v1.UserAttributesR\x0euserAttributes\"0\n" +
+	"\x14EnterLotteryResponse\x12\x18\n" +
+	"\aentries\x18\x01 \x01(\x04R\aentries\"S\n" +
+	"\x17GetLotteryResultRequest\x12\x1f\n" +
+	"\vcampaign_id\x18\x01 \x01(\rR\n" +
+	"campaignId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\"\xa9\x01\n" +
+	"\x18GetLotteryResultResponse\x12\x14\n" +
+	"\x05drawn\x18\x01 \x01(\bR\x05drawn\x12\x10\n" +
+	"\x03won\x18\x02 \x01(\bR\x03won\x120\n" +
+	"\x06coupon\x18\x03 \x01(\v2\x18.protos.coupon.v1.CouponR\x06coupon\x123\n" +
+	"\alottery\x18\x04 \x01(\v2\x19.protos.coupon.v1.LotteryR\alottery\"\x9a\x01\n" +
+	"\x13JoinWaitlistRequest\x12\x1f\n" +
+	"\vcampaign_id\x18\x01 \x01(\rR\n" +
+	"campaignId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12I\n" +
+	"\x0fuser_attributes\x18\x03 \x01(\v2 .protos.coupon.v1.UserAttributesR\x0euserAttributes\"2\n" +
+	"\x14JoinWaitlistResponse\x12\x1a\n" +
+	"\bposition\x18\x01 \x01(\x04R\bposition\"T\n" +
+	"\x18GetWaitlistStatusRequest\x12\x1f\n" +
+	"\vcampaign_id\x18\x01 \x01(\rR\n" +
+	"campaignId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\"i\n" +
+	"\x19GetWaitlistStatusResponse\x12\x1a\n" +
+	"\bposition\x18\x01 \x01(\x04R\bposition\x120\n" +
+	"\x06coupon\x18\x02 \x01(\v2\x18.protos.coupon.v1.CouponR\x06coupon\"P\n" +
+	"\x14WatchWaitlistRequest\x12\x1f\n" +
+	"\vcampaign_id\x18\x01 \x01(\rR\n" +
+	"campaignId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\"^\n" +
+	"\x0eWaitlistStatus\x12\x1a\n" +
+	"\bposition\x18\x01 \x01(\x04R\bposition\x120\n" +
+	"\x06coupon\x18\x02 \x01(\v2\x18.protos.coupon.v1.CouponR\x06coupon\"\x87\x01\n" +
+	"\x15ValidateCouponRequest\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\x123\n" +
+	"\achannel\x18\x02 \x01(\x0e2\x19.protos.coupon.v1.ChannelR\achannel\x12%\n" +
+	"\x0epayment_method\x18\x03 \x01(\tR\rpaymentMethod\"\xdf\x02\n" +
+	"\x16ValidateCouponResponse\x12\x14\n" +
+	"\x05valid\x18\x01 \x01(\bR\x05valid\x12:\n" +
+	"\x06reason\x18\x02 \x01(\x0e2\".protos.coupon.v1.ValidationReasonR\x06reason\x120\n" +
+	"\x06coupon\x18\x03 \x01(\v2\x18.protos.coupon.v1.CouponR\x06coupon\x126\n" +
+	"\bcampaign\x18\x04 \x01(\v2\x1a.protos.coupon.v1.CampaignR\bcampaign\x126\n" +
+	"\x06status\x18\x05 \x01(\x0e2\x1e.protos.coupon.v1.CouponStatusR\x06status\x127\n" +
+	"\texpire_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\bexpireAt\x12\x18\n" +
+	"\amessage\x18\a \x01(\tR\amessage\"\x86\x01\n" +
+	"\x13RedeemCouponRequest\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\x12\x19\n" +
+	"\border_id\x18\x02 \x01(\tR\aorderId\x12@\n" +
+	"\x0fdiscount_amount\x18\x03 \x01(\v2\x17.protos.coupon.v1.MoneyR\x0ediscountAmount\"H\n" +
+	"\x14RedeemCouponResponse\x120\n" +
+	"\x06coupon\x18\x01 \x01(\v2\x18.protos.coupon.v1.CouponR\x06coupon\"g\n" +
+	"\x13RevokeCouponRequest\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\x12$\n" +
+	"\x0ereturn_to_pool\x18\x03 \x01(\bR\freturnToPool\"H\n" +
+	"\x14RevokeCouponResponse\x120\n" +
+	"\x06coupon\x18\x01 \x01(\v2\x18.protos.coupon.v1.CouponR\x06coupon\"W\n" +
+	"\x14ReserveCouponRequest\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\x12+\n" +
+	"\x03ttl\x18\x02 \x01(\v2\x19.google.protobuf.DurationR\x03ttl\"I\n" +
+	"\x15ReserveCouponResponse\x120\n" +
+	"\x06coupon\x18\x01 \x01(\v2\x18.protos.coupon.v1.CouponR\x06coupon\"\xb2\x01\n" +
+	"\x18CommitReservationRequest\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\x12%\n" +
+	"\x0ereservation_id\x18\x02 \x01(\tR\rreservationId\x12\x19\n" +
+	"\border_id\x18\x03 \x01(\tR\aorderId\x12@\n" +
+	"\x0fdiscount_amount\x18\x04 \x01(\v2\x17.protos.coupon.v1.MoneyR\x0ediscountAmount\"M\n" +
+	"\x19CommitReservationResponse\x120\n" +
+	"\x06coupon\x18\x01 \x01(\v2\x18.protos.coupon.v1.CouponR\x06coupon\"V\n" +
+	"\x19ReleaseReservationRequest\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\x12%\n" +
+	"\x0ereservation_id\x18\x02 \x01(\tR\rreservationId\"N\n" +
+	"\x1aReleaseReservationResponse\x120\n" +
+	"\x06coupon\x18\x01 \x01(\v2\x18.protos.coupon.v1.CouponR\x06coupon\"u\n" +
+	"\x13RedeemAmountRequest\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\x12/\n" +
+	"\x06amount\x18\x02 \x01(\v2\x17.protos.coupon.v1.MoneyR\x06amount\x12\x19\n" +
+	"\border_id\x18\x03 \x01(\tR\aorderId\"}\n" +
+	"\x14RedeemAmountResponse\x120\n" +
+	"\x06coupon\x18\x01 \x01(\v2\x18.protos.coupon.v1.CouponR\x06coupon\x123\n" +
+	"\x05entry\x18\x02 \x01(\v2\x1d.protos.coupon.v1.LedgerEntryR\x05entry\"}\n" +
+	"\x18ReverseRedemptionRequest\x12%\n" +
+	"\rredemption_id\x18\x01 \x01(\tH\x00R\fredemptionId\x12\x1b\n" +
+	"\border_id\x18\x02 \x01(\tH\x00R\aorderId\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reasonB\x05\n" +
+	"\x03key\"U\n" +
+	"\x19ReverseRedemptionResponse\x128\n" +
+	"\treversals\x18\x01 \x03(\v2\x1a.protos.coupon.v1.ReversalR\treversals\"\xbb\x01\n" +
+	"\x15TransferCouponRequest\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\x12 \n" +
+	"\ffrom_user_id\x18\x02 \x01(\tR\n" +
+	"fromUserId\x12\x1c\n" +
+	"\n" +
+	"to_user_id\x18\x03 \x01(\tR\btoUserId\x12N\n" +
+	"\x12to_user_attributes\x18\x04 \x01(\v2 .protos.coupon.v1.UserAttributesR\x10toUserAttributes\"k\n" +
+	"\x16TransferCouponResponse\x120\n" +
+	"\x06coupon\x18\x01 \x01(\v2\x18.protos.coupon.v1.CouponR\x06coupon\x12\x1f\n" +
+	"\vclaim_token\x18\x02 \x01(\tR\n" +
+	"claimToken\"\xad\x01\n" +
+	"\x12ClaimCouponRequest\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\x12\x1f\n" +
+	"\vclaim_token\x18\x02 \x01(\tR\n" +
+	"claimToken\x12\x17\n" +
+	"\auser_id\x18\x03 \x01(\tR\x06userId\x12I\n" +
+	"\x0fuser_attributes\x18\x04 \x01(\v2 .protos.coupon.v1.UserAttributesR\x0euserAttributes\"G\n" +
+	"\x13ClaimCouponResponse\x120\n" +
+	"\x06coupon\x18\x01 \x01(\v2\x18.protos.coupon.v1.CouponR\x06coupon\"n\n" +
+	"\x13CreateBundleRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\x12!\n" +
+	"\fcampaign_ids\x18\x03 \x03(\rR\vcampaignIds\"H\n" +
+	"\x14CreateBundleResponse\x120\n" +
+	"\x06bundle\x18\x01 \x01(\v2\x18.protos.coupon.v1.BundleR\x06bundle\"\xaf\x01\n" +
+	"\x12IssueBundleRequest\x12\x1b\n" +
+	"\tbundle_id\x18\x01 \x01(\rR\bbundleId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12I\n" +
+	"\x0fuser_attributes\x18\x03 \x01(\v2 .protos.coupon.v1.UserAttributesR\x0euserAttributes\x12\x18\n" +
+	"\achannel\x18\x04 \x01(\tR\achannel\"I\n" +
+	"\x13IssueBundleResponse\x122\n" +
+	"\acoupons\x18\x01 \x03(\v2\x18.protos.coupon.v1.CouponR\acoupons\"U\n" +
+	"\x19CreateReferralCodeRequest\x12\x1f\n" +
+	"\vcampaign_id\x18\x01 \x01(\rR\n" +
+	"campaignId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\"a\n" +
+	"\x1aCreateReferralCodeResponse\x12C\n" +
+	"\rreferral_code\x18\x01 \x01(\v2\x1e.protos.coupon.v1.ReferralCodeR\freferralCode\".\n" +
+	"\x18ListLedgerEntriesRequest\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\"T\n" +
+	"\x19ListLedgerEntriesResponse\x127\n" +
+	"\aentries\x18\x01 \x03(\v2\x1d.protos.coupon.v1.LedgerEntryR\aentries\"\xa2\x01\n" +
+	"\bLineItem\x12\x10\n" +
+	"\x03sku\x18\x01 \x01(\tR\x03sku\x12\x1a\n" +
+	"\bcategory\x18\x02 \x01(\tR\bcategory\x126\n" +
+	"\n" +
+	"unit_price\x18\x03 \x01(\v2\x17.protos.coupon.v1.MoneyR\tunitPrice\x12\x1a\n" +
+	"\bquantity\x18\x04 \x01(\rR\bquantity\x12\x14\n" +
+	"\x05brand\x18\x05 \x01(\tR\x05brand\"\xcd\x01\n" +
+	"\n" +
+	"LineResult\x12\x14\n" +
+	"\x05index\x18\x01 \x01(\rR\x05index\x12\x10\n" +
+	"\x03sku\x18\x02 \x01(\tR\x03sku\x123\n" +
+	"\bsubtotal\x18\x03 \x01(\v2\x17.protos.coupon.v1.MoneyR\bsubtotal\x123\n" +
+	"\bdiscount\x18\x04 \x01(\v2\x17.protos.coupon.v1.MoneyR\bdiscount\x12-\n" +
+	"\x05total\x18\x05 \x01(\v2\x17.protos.coupon.v1.MoneyR\x05total\"\xbf\x01\n" +
+	"\rAppliedCoupon\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\x12\x1f\n" +
+	"\vcampaign_id\x18\x02 \x01(\rR\n" +
+	"campaignId\x123\n" +
+	"\bdiscount\x18\x03 \x01(\v2\x17.protos.coupon.v1.MoneyR\bdiscount\x12D\n" +
+	"\x11shipping_discount\x18\x04 \x01(\v2\x17.protos.coupon.v1.MoneyR\x10shippingDiscount\"\xca\x01\n" +
+	"\x0eRejectedCoupon\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\x129\n" +
+	"\x06reason\x18\x02 \x01(\x0e2!.protos.coupon.v1.RejectionReasonR\x06reason\x12O\n" +
+	"\x11validation_reason\x18\x03 \x01(\x0e2\".protos.coupon.v1.ValidationReasonR\x10validationReason\x12\x18\n" +
+	"\amessage\x18\x04 \x01(\tR\amessage\"g\n" +
+	"\x10StackingConflict\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\x12%\n" +
+	"\x0econflicts_with\x18\x02 \x01(\tR\rconflictsWith\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\"\xc9\x01\n" +
+	"\x15UploadUserListRequest\x12\x1f\n" +
+	"\vcampaign_id\x18\x01 \x01(\rR\n" +
+	"campaignId\x122\n" +
+	"\x04kind\x18\x02 \x01(\x0e2\x1e.protos.coupon.v1.UserListKindR\x04kind\x12@\n" +
+	"\fbloom_filter\x18\x03 \x01(\v2\x1d.protos.coupon.v1.BloomFilterR\vbloomFilter\x12\x19\n" +
+	"\buser_ids\x18\x04 \x03(\tR\auserIds\"i\n" +
+	"\x16UploadUserListResponse\x12\x1f\n" +
+	"\vcampaign_id\x18\x01 \x01(\rR\n" +
+	"campaignId\x12.\n" +
+	"\x04list\x18\x02 \x01(\v2\x1a.protos.coupon.v1.UserListR\x04list\"\xee\x01\n" +
+	"\x13EvaluateCartRequest\x120\n" +
+	"\x05items\x18\x01 \x03(\v2\x1a.protos.coupon.v1.LineItemR\x05items\x12\x14\n" +
+	"\x05codes\x18\x02 \x03(\tR\x05codes\x123\n" +
+	"\bshipping\x18\x03 \x01(\v2\x17.protos.coupon.v1.MoneyR\bshipping\x123\n" +
+	"\achannel\x18\x04 \x01(\x0e2\x19.protos.coupon.v1.ChannelR\achannel\x12%\n" +
+	"\x0epayment_method\x18\x05 \x01(\tR\rpaymentMethod\"\xde\x03\n" +
+	"\x14EvaluateCartResponse\x122\n" +
+	"\x05lines\x18\x01 \x03(\v2\x1c.protos.coupon.v1.LineResultR\x05lines\x129\n" +
+	"\aapplied\x18\x02 \x03(\v2\x1f.protos.coupon.v1.AppliedCouponR\aapplied\x12<\n" +
+	"\brejected\x18\x03 \x03(\v2 .protos.coupon.v1.RejectedCouponR\brejected\x12@\n" +
+	"\tconflicts\x18\x04 \x03(\v2\".protos.coupon.v1.StackingConflictR\tconflicts\x123\n" +
+	"\bsubtotal\x18\x05 \x01(\v2\x17.protos.coupon.v1.MoneyR\bsubtotal\x123\n" +
+	"\bshipping\x18\x06 \x01(\v2\x17.protos.coupon.v1.MoneyR\bshipping\x12>\n" +
+	"\x0ediscount_total\x18\a \x01(\v2\x17.protos.coupon.v1.MoneyR\rdiscountTotal\x12-\n" +
+	"\x05total\x18\b \x01(\v2\x17.protos.coupon.v1.MoneyR\x05total*\x9a\x01\n" +
+	"\fCouponStatus\x12\x1d\n" +
+	"\x19COUPON_STATUS_UNSPECIFIED\x10\x00\x12\x18\n" +
+	"\x14COUPON_STATUS_ACTIVE\x10\x01\x12\x1a\n" +
+	"\x16COUPON_STATUS_REDEEMED\x10\x02\x12\x19\n" +
+	"\x15COUPON_STATUS_REVOKED\x10\x03\x12\x1a\n" +
+	"\x16COUPON_STATUS_RESERVED\x10\x04*\xda\x02\n" +
+	"\x10ValidationReason\x12!\n" +
+	"\x1dVALIDATION_REASON_UNSPECIFIED\x10\x00\x12\"\n" +
+	"\x1eVALIDATION_REASON_UNKNOWN_CODE\x10\x01\x12\x1d\n" +
+	"\x19VALIDATION_REASON_EXPIRED\x10\x02\x12\x1d\n" +
+	"\x19VALIDATION_REASON_REVOKED\x10\x03\x12\x1e\n" +
+	"\x1aVALIDATION_REASON_REDEEMED\x10\x04\x12$\n" +
+	" VALIDATION_REASON_CAMPAIGN_ENDED\x10\x05\x12)\n" +
+	"%VALIDATION_REASON_CHANNEL_NOT_ALLOWED\x10\x06\x120\n" +
+	",VALIDATION_REASON_PAYMENT_METHOD_NOT_ALLOWED\x10\a\x12\x1e\n" +
+	"\x1aVALIDATION_REASON_RESERVED\x10\b*W\n" +
+	"\aChannel\x12\x17\n" +
+	"\x13CHANNEL_UNSPECIFIED\x10\x00\x12\x0f\n" +
+	"\vCHANNEL_WEB\x10\x01\x12\x0f\n" +
+	"\vCHANNEL_APP\x10\x02\x12\x11\n" +
+	"\rCHANNEL_STORE\x10\x03*\xf2\x01\n" +
+	"\rCampaignState\x12\x1e\n" +
+	"\x1aCAMPAIGN_STATE_UNSPECIFIED\x10\x00\x12\x18\n" +
+	"\x14CAMPAIGN_STATE_DRAFT\x10\x01\x12\x1c\n" +
+	"\x18CAMPAIGN_STATE_SCHEDULED\x10\x02\x12\x19\n" +
+	"\x15CAMPAIGN_STATE_ACTIVE\x10\x03\x12\x19\n" +
+	"\x15CAMPAIGN_STATE_PAUSED\x10\x04\x12\x1b\n" +
+	"\x17CAMPAIGN_STATE_SOLD_OUT\x10\x05\x12\x18\n" +
+	"\x14CAMPAIGN_STATE_ENDED\x10\x06\x12\x1c\n" +
+	"\x18CAMPAIGN_STATE_CANCELLED\x10\a*_\n" +
+	"\x0fCouponEventType\x12!\n" +
+	"\x1dCOUPON_EVENT_TYPE_UNSPECIFIED\x10\x00\x12)\n" +
+	"%COUPON_EVENT_TYPE_REDEMPTION_REVERSED\x10\x01*\x87\x01\n" +
+	"\rRestorePolicy\x12\x1e\n" +
+	"\x1aRESTORE_POLICY_UNSPECIFIED\x10\x00\x12\x19\n" +
+	"\x15RESTORE_POLICY_ALWAYS\x10\x01\x12!\n" +
+	"\x1dRESTORE_POLICY_IF_NOT_EXPIRED\x10\x02\x12\x18\n" +
+	"\x14RESTORE_POLICY_NEVER\x10\x03*t\n" +
+	"\x0fLedgerEntryType\x12!\n" +
+	"\x1dLEDGER_ENTRY_TYPE_UNSPECIFIED\x10\x00\x12 \n" +
+	"\x1cLEDGER_ENTRY_TYPE_REDEMPTION\x10\x01\x12\x1c\n" +
+	"\x18LEDGER_ENTRY_TYPE_REFUND\x10\x02*j\n" +
+	"\fUserListKind\x12\x1e\n" +
+	"\x1aUSER_LIST_KIND_UNSPECIFIED\x10\x00\x12\x1c\n" +
+	"\x18USER_LIST_KIND_ALLOWLIST\x10\x01\x12\x1c\n" +
+	"\x18USER_LIST_KIND_BLOCKLIST\x10\x02*\x98\x01\n" +
+	"\fStackingMode\x12\x1d\n" +
+	"\x19STACKING_MODE_UNSPECIFIED\x10\x00\x12\x1b\n" +
+	"\x17STACKING_MODE_EXCLUSIVE\x10\x01\x12&\n" +
+	"\"STACKING_MODE_STACKABLE_WITH_GROUP\x10\x02\x12$\n" +
+	" STACKING_MODE_STACKABLE_WITH_ALL\x10\x03*\xe2\x03\n" +
+	"\x11CampaignEventType\x12#\n" +
+	"\x1fCAMPAIGN_EVENT_TYPE_UNSPECIFIED\x10\x00\x12&\n" +
+	"\"CAMPAIGN_EVENT_TYPE_COUPON_REVOKED\x10\x01\x12%\n" +
+	"!CAMPAIGN_EVENT_TYPE_SLOT_RETURNED\x10\x02\x12\x1e\n" +
+	"\x1aCAMPAIGN_EVENT_TYPE_PAUSED\x10\x03\x12\x1f\n" +
+	"\x1bCAMPAIGN_EVENT_TYPE_RESUMED\x10\x04\x12\x1e\n" +
+	"\x1aCAMPAIGN_EVENT_TYPE_CLOSED\x10\x05\x12%\n" +
+	"!CAMPAIGN_EVENT_TYPE_LOTTERY_DRAWN\x10\x06\x12'\n" +
+	"#CAMPAIGN_EVENT_TYPE_WAITLIST_ISSUED\x10\a\x12+\n" +
+	"'CAMPAIGN_EVENT_TYPE_REDEMPTION_REVERSED\x10\b\x12*\n" +
+	"&CAMPAIGN_EVENT_TYPE_COUPON_TRANSFERRED\x10\t\x12)\n" +
+	"%CAMPAIGN_EVENT_TYPE_REFERRAL_REWARDED\x10\n" +
+	"\x12$\n" +
+	" CAMPAIGN_EVENT_TYPE_BUDGET_ALERT\x10\v*\x94\x02\n" +
+	"\x0fRejectionReason\x12 \n" +
+	"\x1cREJECTION_REASON_UNSPECIFIED\x10\x00\x12#\n" +
+	"\x1fREJECTION_REASON_INVALID_COUPON\x10\x01\x12#\n" +
+	"\x1fREJECTION_REASON_DUPLICATE_CODE\x10\x02\x12 \n" +
+	"\x1cREJECTION_REASON_NO_DISCOUNT\x10\x03\x12&\n" +
+	"\"REJECTION_REASON_CURRENCY_MISMATCH\x10\x04\x12&\n" +
+	"\"REJECTION_REASON_MIN_ORDER_NOT_MET\x10\x05\x12#\n" +
+	"\x1fREJECTION_REASON_NOT_APPLICABLE\x10\x062\x8a\x17\n" +
 	"\x15CouponIssuanceService\x12e\n" +
 	"\x0eCreateCampaign\x12'.protos.coupon.v1.CreateCampaignRequest\x1a(.protos.coupon.v1.CreateCampaignResponse\"\x00\x12\\\n" +
 	"\vGetCampaign\x12$.protos.coupon.v1.GetCampaignRequest\x1a%.protos.coupon.v1.GetCampaignResponse\"\x00\x12\\\n" +
-	"\vIssueCoupon\x12$.protos.coupon.v1.IssueCouponRequest\x1a%.protos.coupon.v1.IssueCouponResponse\"\x00BIZGgithub.com/jackgihokim/coupon-issuance-system/protos/coupon/v1;couponv1b\x06proto3"
+	"\vIssueCoupon\x12$.protos.coupon.v1.IssueCouponRequest\x1a%.protos.coupon.v1.IssueCouponResponse\"\x00\x12e\n" +
+	"\x0eValidateCoupon\x12'.protos.coupon.v1.ValidateCouponRequest\x1a(.protos.coupon.v1.ValidateCouponResponse\"\x00\x12_\n" +
+	"\fRedeemCoupon\x12%.protos.coupon.v1.RedeemCouponRequest\x1a&.protos.coupon.v1.RedeemCouponResponse\"\x00\x12_\n" +
+	"\fRevokeCoupon\x12%.protos.coupon.v1.RevokeCouponRequest\x1a&.protos.coupon.v1.RevokeCouponResponse\"\x00\x12_\n" +
+	"\fEvaluateCart\x12%.protos.coupon.v1.EvaluateCartRequest\x1a&.protos.coupon.v1.EvaluateCartResponse\"\x00\x12g\n" +
+	"\x0eUploadUserList\x12'.protos.coupon.v1.UploadUserListRequest\x1a(.protos.coupon.v1.UploadUserListResponse\"\x00(\x01\x12b\n" +
+	"\rPauseCampaign\x12&.protos.coupon.v1.PauseCampaignRequest\x1a'.protos.coupon.v1.PauseCampaignResponse\"\x00\x12e\n" +
+	"\x0eResumeCampaign\x12'.protos.coupon.v1.ResumeCampaignRequest\x1a(.protos.coupon.v1.ResumeCampaignResponse\"\x00\x12b\n" +
+	"\rCloseCampaign\x12&.protos.coupon.v1.CloseCampaignRequest\x1a'.protos.coupon.v1.CloseCampaignResponse\"\x00\x12Y\n" +
+	"\n" +
+	"EnterQueue\x12#.protos.coupon.v1.EnterQueueRequest\x1a$.protos.coupon.v1.EnterQueueResponse\"\x00\x12T\n" +
+	"\n" +
+	"WatchQueue\x12#.protos.coupon.v1.WatchQueueRequest\x1a\x1d.protos.coupon.v1.QueueStatus\"\x000\x01\x12_\n" +
+	"\fEnterLottery\x12%.protos.coupon.v1.EnterLotteryRequest\x1a&.protos.coupon.v1.EnterLotteryResponse\"\x00\x12k\n" +
+	"\x10GetLotteryResult\x12).protos.coupon.v1.GetLotteryResultRequest\x1a*.protos.coupon.v1.GetLotteryResultResponse\"\x00\x12_\n" +
+	"\fJoinWaitlist\x12%.protos.coupon.v1.JoinWaitlistRequest\x1a&.protos.coupon.v1.JoinWaitlistResponse\"\x00\x12n\n" +
+	"\x11GetWaitlistStatus\x12*.protos.coupon.v1.GetWaitlistStatusRequest\x1a+.protos.coupon.v1.GetWaitlistStatusResponse\"\x00\x12]\n" +
+	"\rWatchWaitlist\x12&.protos.coupon.v1.WatchWaitlistRequest\x1a .protos.coupon.v1.WaitlistStatus\"\x000\x01\x12b\n" +
+	"\rReserveCoupon\x12&.protos.coupon.v1.ReserveCouponRequest\x1a'.protos.coupon.v1.ReserveCouponResponse\"\x00\x12n\n" +
+	"\x11CommitReservation\x12*.protos.coupon.v1.CommitReservationRequest\x1a+.protos.coupon.v1.CommitReservationResponse\"\x00\x12q\n" +
+	"\x12ReleaseReservation\x12+.protos.coupon.v1.ReleaseReservationRequest\x1a,.protos.coupon.v1.ReleaseReservationResponse\"\x00\x12_\n" +
+	"\fRedeemAmount\x12%.protos.coupon.v1.RedeemAmountRequest\x1a&.protos.coupon.v1.RedeemAmountResponse\"\x00\x12n\n" +
+	"\x11ListLedgerEntries\x12*.protos.coupon.v1.ListLedgerEntriesRequest\x1a+.protos.coupon.v1.ListLedgerEntriesResponse\"\x00\x12n\n" +
+	"\x11ReverseRedemption\x12*.protos.coupon.v1.ReverseRedemptionRequest\x1a+.protos.coupon.v1.ReverseRedemptionResponse\"\x00\x12e\n" +
+	"\x0eTransferCoupon\x12'.protos.coupon.v1.TransferCouponRequest\x1a(.protos.coupon.v1.TransferCouponResponse\"\x00\x12\\\n" +
+	"\vClaimCoupon\x12$.protos.coupon.v1.ClaimCouponRequest\x1a%.protos.coupon.v1.ClaimCouponResponse\"\x00\x12q\n" +
+	"\x12CreateReferralCode\x12+.protos.coupon.v1.CreateReferralCodeRequest\x1a,.protos.coupon.v1.CreateReferralCodeResponse\"\x00\x12_\n" +
+	"\fCreateBundle\x12%.protos.coupon.v1.CreateBundleRequest\x1a&.protos.coupon.v1.CreateBundleResponse\"\x00\x12\\\n" +
+	"\vIssueBundle\x12$.protos.coupon.v1.IssueBundleRequest\x1a%.protos.coupon.v1.IssueBundleResponse\"\x00BIZGgithub.com/jackgihokim/coupon-issuance-system/protos/coupon/v1;couponv1b\x06proto3"
 
 var (
 	file_protos_coupon_v1_coupon_proto_rawDescOnce sync.Once
@@ -532,41 +8321,368 @@ func file_protos_coupon_v1_coupon_proto_rawDescGZIP() []byte {
 	return file_protos_coupon_v1_coupon_proto_rawDescData
 }
 
-var file_protos_coupon_v1_coupon_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_protos_coupon_v1_coupon_proto_enumTypes = make([]protoimpl.EnumInfo, 11)
+var file_protos_coupon_v1_coupon_proto_msgTypes = make([]protoimpl.MessageInfo, 106)
 var file_protos_coupon_v1_coupon_proto_goTypes = []any{
-	(*Coupon)(nil),                 // 0: protos.coupon.v1.Coupon
-	(*Campaign)(nil),               // 1: protos.coupon.v1.Campaign
-	(*CreateCampaignRequest)(nil),  // 2: protos.coupon.v1.CreateCampaignRequest
-	(*CreateCampaignResponse)(nil), // 3: protos.coupon.v1.CreateCampaignResponse
-	(*GetCampaignRequest)(nil),     // 4: protos.coupon.v1.GetCampaignRequest
-	(*GetCampaignResponse)(nil),    // 5: protos.coupon.v1.GetCampaignResponse
-	(*IssueCouponRequest)(nil),     // 6: protos.coupon.v1.IssueCouponRequest
-	(*IssueCouponResponse)(nil),    // 7: protos.coupon.v1.IssueCouponResponse
-	(*timestamppb.Timestamp)(nil),  // 8: google.protobuf.Timestamp
+	(CouponStatus)(0),                  // 0: protos.coupon.v1.CouponStatus
+	(ValidationReason)(0),              // 1: protos.coupon.v1.ValidationReason
+	(Channel)(0),                       // 2: protos.coupon.v1.Channel
+	(CampaignState)(0),                 // 3: protos.coupon.v1.CampaignState
+	(CouponEventType)(0),               // 4: protos.coupon.v1.CouponEventType
+	(RestorePolicy)(0),                 // 5: protos.coupon.v1.RestorePolicy
+	(LedgerEntryType)(0),               // 6: protos.coupon.v1.LedgerEntryType
+	(UserListKind)(0),                  // 7: protos.coupon.v1.UserListKind
+	(StackingMode)(0),                  // 8: protos.coupon.v1.StackingMode
+	(CampaignEventType)(0),             // 9: protos.coupon.v1.CampaignEventType
+	(RejectionReason)(0),               // 10: protos.coupon.v1.RejectionReason
+	(*Coupon)(nil),                     // 11: protos.coupon.v1.Coupon
+	(*CouponEvent)(nil),                // 12: protos.coupon.v1.CouponEvent
+	(*Transfer)(nil),                   // 13: protos.coupon.v1.Transfer
+	(*TransferOffer)(nil),              // 14: protos.coupon.v1.TransferOffer
+	(*TransferPolicy)(nil),             // 15: protos.coupon.v1.TransferPolicy
+	(*Reversal)(nil),                   // 16: protos.coupon.v1.Reversal
+	(*LedgerEntry)(nil),                // 17: protos.coupon.v1.LedgerEntry
+	(*Reservation)(nil),                // 18: protos.coupon.v1.Reservation
+	(*Campaign)(nil),                   // 19: protos.coupon.v1.Campaign
+	(*Tier)(nil),                       // 20: protos.coupon.v1.Tier
+	(*TierStats)(nil),                  // 21: protos.coupon.v1.TierStats
+	(*Variant)(nil),                    // 22: protos.coupon.v1.Variant
+	(*VariantStats)(nil),               // 23: protos.coupon.v1.VariantStats
+	(*Budget)(nil),                     // 24: protos.coupon.v1.Budget
+	(*BudgetStats)(nil),                // 25: protos.coupon.v1.BudgetStats
+	(*AllocationPolicy)(nil),           // 26: protos.coupon.v1.AllocationPolicy
+	(*Allocation)(nil),                 // 27: protos.coupon.v1.Allocation
+	(*AllocationStats)(nil),            // 28: protos.coupon.v1.AllocationStats
+	(*ReferralPolicy)(nil),             // 29: protos.coupon.v1.ReferralPolicy
+	(*Bundle)(nil),                     // 30: protos.coupon.v1.Bundle
+	(*ReferralCode)(nil),               // 31: protos.coupon.v1.ReferralCode
+	(*Waitlist)(nil),                   // 32: protos.coupon.v1.Waitlist
+	(*Lottery)(nil),                    // 33: protos.coupon.v1.Lottery
+	(*WaitingRoom)(nil),                // 34: protos.coupon.v1.WaitingRoom
+	(*Throttle)(nil),                   // 35: protos.coupon.v1.Throttle
+	(*IssueThrottled)(nil),             // 36: protos.coupon.v1.IssueThrottled
+	(*Recurrence)(nil),                 // 37: protos.coupon.v1.Recurrence
+	(*Occurrence)(nil),                 // 38: protos.coupon.v1.Occurrence
+	(*BloomFilter)(nil),                // 39: protos.coupon.v1.BloomFilter
+	(*UserList)(nil),                   // 40: protos.coupon.v1.UserList
+	(*UserAttributes)(nil),             // 41: protos.coupon.v1.UserAttributes
+	(*StackingPolicy)(nil),             // 42: protos.coupon.v1.StackingPolicy
+	(*Applicability)(nil),              // 43: protos.coupon.v1.Applicability
+	(*Money)(nil),                      // 44: protos.coupon.v1.Money
+	(*Discount)(nil),                   // 45: protos.coupon.v1.Discount
+	(*ExpiryPolicy)(nil),               // 46: protos.coupon.v1.ExpiryPolicy
+	(*CampaignEvent)(nil),              // 47: protos.coupon.v1.CampaignEvent
+	(*CreateCampaignRequest)(nil),      // 48: protos.coupon.v1.CreateCampaignRequest
+	(*CreateCampaignResponse)(nil),     // 49: protos.coupon.v1.CreateCampaignResponse
+	(*GetCampaignRequest)(nil),         // 50: protos.coupon.v1.GetCampaignRequest
+	(*GetCampaignResponse)(nil),        // 51: protos.coupon.v1.GetCampaignResponse
+	(*PauseCampaignRequest)(nil),       // 52: protos.coupon.v1.PauseCampaignRequest
+	(*PauseCampaignResponse)(nil),      // 53: protos.coupon.v1.PauseCampaignResponse
+	(*ResumeCampaignRequest)(nil),      // 54: protos.coupon.v1.ResumeCampaignRequest
+	(*ResumeCampaignResponse)(nil),     // 55: protos.coupon.v1.ResumeCampaignResponse
+	(*CloseCampaignRequest)(nil),       // 56: protos.coupon.v1.CloseCampaignRequest
+	(*CloseCampaignResponse)(nil),      // 57: protos.coupon.v1.CloseCampaignResponse
+	(*IssueCouponRequest)(nil),         // 58: protos.coupon.v1.IssueCouponRequest
+	(*IssueCouponResponse)(nil),        // 59: protos.coupon.v1.IssueCouponResponse
+	(*EnterQueueRequest)(nil),          // 60: protos.coupon.v1.EnterQueueRequest
+	(*EnterQueueResponse)(nil),         // 61: protos.coupon.v1.EnterQueueResponse
+	(*WatchQueueRequest)(nil),          // 62: protos.coupon.v1.WatchQueueRequest
+	(*QueueStatus)(nil),                // 63: protos.coupon.v1.QueueStatus
+	(*EnterLotteryRequest)(nil),        // 64: protos.coupon.v1.EnterLotteryRequest
+	(*EnterLotteryResponse)(nil),       // 65: protos.coupon.v1.EnterLotteryResponse
+	(*GetLotteryResultRequest)(nil),    // 66: protos.coupon.v1.GetLotteryResultRequest
+	(*GetLotteryResultResponse)(nil),   // 67: protos.coupon.v1.GetLotteryResultResponse
+	(*JoinWaitlistRequest)(nil),        // 68: protos.coupon.v1.JoinWaitlistRequest
+	(*JoinWaitlistResponse)(nil),       // 69: protos.coupon.v1.JoinWaitlistResponse
+	(*GetWaitlistStatusRequest)(nil),   // 70: protos.coupon.v1.GetWaitlistStatusRequest
+	(*GetWaitlistStatusResponse)(nil),  // 71: protos.coupon.v1.GetWaitlistStatusResponse
+	(*WatchWaitlistRequest)(nil),       // 72: protos.coupon.v1.WatchWaitlistRequest
+	(*WaitlistStatus)(nil),             // 73: protos.coupon.v1.WaitlistStatus
+	(*ValidateCouponRequest)(nil),      // 74: protos.coupon.v1.ValidateCouponRequest
+	(*ValidateCouponResponse)(nil),     // 75: protos.coupon.v1.ValidateCouponResponse
+	(*RedeemCouponRequest)(nil),        // 76: protos.coupon.v1.RedeemCouponRequest
+	(*RedeemCouponResponse)(nil),       // 77: protos.coupon.v1.RedeemCouponResponse
+	(*RevokeCouponRequest)(nil),        // 78: protos.coupon.v1.RevokeCouponRequest
+	(*RevokeCouponResponse)(nil),       // 79: protos.coupon.v1.RevokeCouponResponse
+	(*ReserveCouponRequest)(nil),       // 80: protos.coupon.v1.ReserveCouponRequest
+	(*ReserveCouponResponse)(nil),      // 81: protos.coupon.v1.ReserveCouponResponse
+	(*CommitReservationRequest)(nil),   // 82: protos.coupon.v1.CommitReservationRequest
+	(*CommitReservationResponse)(nil),  // 83: protos.coupon.v1.CommitReservationResponse
+	(*ReleaseReservationRequest)(nil),  // 84: protos.coupon.v1.ReleaseReservationRequest
+	(*ReleaseReservationResponse)(nil), // 85: protos.coupon.v1.ReleaseReservationResponse
+	(*RedeemAmountRequest)(nil),        // 86: protos.coupon.v1.RedeemAmountRequest
+	(*RedeemAmountResponse)(nil),       // 87: protos.coupon.v1.RedeemAmountResponse
+	(*ReverseRedemptionRequest)(nil),   // 88: protos.coupon.v1.ReverseRedemptionRequest
+	(*ReverseRedemptionResponse)(nil),  // 89: protos.coupon.v1.ReverseRedemptionResponse
+	(*TransferCouponRequest)(nil),      // 90: protos.coupon.v1.TransferCouponRequest
+	(*TransferCouponResponse)(nil),     // 91: protos.coupon.v1.TransferCouponResponse
+	(*ClaimCouponRequest)(nil),         // 92: protos.coupon.v1.ClaimCouponRequest
+	(*ClaimCouponResponse)(nil),        // 93: protos.coupon.v1.ClaimCouponResponse
+	(*CreateBundleRequest)(nil),        // 94: protos.coupon.v1.CreateBundleRequest
+	(*CreateBundleResponse)(nil),       // 95: protos.coupon.v1.CreateBundleResponse
+	(*IssueBundleRequest)(nil),         // 96: protos.coupon.v1.IssueBundleRequest
+	(*IssueBundleResponse)(nil),        // 97: protos.coupon.v1.IssueBundleResponse
+	(*CreateReferralCodeRequest)(nil),  // 98: protos.coupon.v1.CreateReferralCodeRequest
+	(*CreateReferralCodeResponse)(nil), // 99: protos.coupon.v1.CreateReferralCodeResponse
+	(*ListLedgerEntriesRequest)(nil),   // 100: protos.coupon.v1.ListLedgerEntriesRequest
+	(*ListLedgerEntriesResponse)(nil),  // 101: protos.coupon.v1.ListLedgerEntriesResponse
+	(*LineItem)(nil),                   // 102: protos.coupon.v1.LineItem
+	(*LineResult)(nil),                 // 103: protos.coupon.v1.LineResult
+	(*AppliedCoupon)(nil),              // 104: protos.coupon.v1.AppliedCoupon
+	(*RejectedCoupon)(nil),             // 105: protos.coupon.v1.RejectedCoupon
+	(*StackingConflict)(nil),           // 106: protos.coupon.v1.StackingConflict
+	(*UploadUserListRequest)(nil),      // 107: protos.coupon.v1.UploadUserListRequest
+	(*UploadUserListResponse)(nil),     // 108: protos.coupon.v1.UploadUserListResponse
+	(*EvaluateCartRequest)(nil),        // 109: protos.coupon.v1.EvaluateCartRequest
+	(*EvaluateCartResponse)(nil),       // 110: protos.coupon.v1.EvaluateCartResponse
+	(*Discount_FixedAmount)(nil),       // 111: protos.coupon.v1.Discount.FixedAmount
+	(*Discount_Percentage)(nil),        // 112: protos.coupon.v1.Discount.Percentage
+	(*Discount_FreeShipping)(nil),      // 113: protos.coupon.v1.Discount.FreeShipping
+	(*Discount_BuyXGetY)(nil),          // 114: protos.coupon.v1.Discount.BuyXGetY
+	(*ExpiryPolicy_EndOfDay)(nil),      // 115: protos.coupon.v1.ExpiryPolicy.EndOfDay
+	(*ExpiryPolicy_Earliest)(nil),      // 116: protos.coupon.v1.ExpiryPolicy.Earliest
+	(*timestamppb.Timestamp)(nil),      // 117: google.protobuf.Timestamp
+	(*durationpb.Duration)(nil),        // 118: google.protobuf.Duration
 }
 var file_protos_coupon_v1_coupon_proto_depIdxs = []int32{
-	8,  // 0: protos.coupon.v1.Coupon.expire_at:type_name -> google.protobuf.Timestamp
-	8,  // 1: protos.coupon.v1.Coupon.issued_at:type_name -> google.protobuf.Timestamp
-	8,  // 2: protos.coupon.v1.Campaign.created_at:type_name -> google.protobuf.Timestamp
-	8,  // 3: protos.coupon.v1.Campaign.start_at:type_name -> google.protobuf.Timestamp
-	8,  // 4: protos.coupon.v1.Campaign.end_at:type_name -> google.protobuf.Timestamp
-	0,  // 5: protos.coupon.v1.Campaign.coupons:type_name -> protos.coupon.v1.Coupon
-	8,  // 6: protos.coupon.v1.CreateCampaignRequest.start_at:type_name -> google.protobuf.Timestamp
-	8,  // 7: protos.coupon.v1.CreateCampaignRequest.end_at:type_name -> google.protobuf.Timestamp
-	1,  // 8: protos.coupon.v1.CreateCampaignResponse.campaign:type_name -> protos.coupon.v1.Campaign
-	1,  // 9: protos.coupon.v1.GetCampaignResponse.campaign:type_name -> protos.coupon.v1.Campaign
-	0,  // 10: protos.coupon.v1.IssueCouponResponse.coupon:type_name -> protos.coupon.v1.Coupon
-	2,  // 11: protos.coupon.v1.CouponIssuanceService.CreateCampaign:input_type -> protos.coupon.v1.CreateCampaignRequest
-	4,  // 12: protos.coupon.v1.CouponIssuanceService.GetCampaign:input_type -> protos.coupon.v1.GetCampaignRequest
-	6,  // 13: protos.coupon.v1.CouponIssuanceService.IssueCoupon:input_type -> protos.coupon.v1.IssueCouponRequest
-	3,  // 14: protos.coupon.v1.CouponIssuanceService.CreateCampaign:output_type -> protos.coupon.v1.CreateCampaignResponse
-	5,  // 15: protos.coupon.v1.CouponIssuanceService.GetCampaign:output_type -> protos.coupon.v1.GetCampaignResponse
-	7,  // 16: protos.coupon.v1.CouponIssuanceService.IssueCoupon:output_type -> protos.coupon.v1.IssueCouponResponse
-	14, // [14:17] is the sub-list for method output_type
-	11, // [11:14] is the sub-list for method input_type
-	11, // [11:11] is the sub-list for extension type_name
-	11, // [11:11] is the sub-list for extension extendee
-	0,  // [0:11] is the sub-list for field type_name
+	117, // 0: protos.coupon.v1.Coupon.expire_at:type_name -> google.protobuf.Timestamp
+	117, // 1: protos.coupon.v1.Coupon.issued_at:type_name -> google.protobuf.Timestamp
+	0,   // 2: protos.coupon.v1.Coupon.status:type_name -> protos.coupon.v1.CouponStatus
+	117, // 3: protos.coupon.v1.Coupon.redeemed_at:type_name -> google.protobuf.Timestamp
+	117, // 4: protos.coupon.v1.Coupon.revoked_at:type_name -> google.protobuf.Timestamp
+	45,  // 5: protos.coupon.v1.Coupon.discount:type_name -> protos.coupon.v1.Discount
+	18,  // 6: protos.coupon.v1.Coupon.reservation:type_name -> protos.coupon.v1.Reservation
+	44,  // 7: protos.coupon.v1.Coupon.balance:type_name -> protos.coupon.v1.Money
+	13,  // 8: protos.coupon.v1.Coupon.transfers:type_name -> protos.coupon.v1.Transfer
+	14,  // 9: protos.coupon.v1.Coupon.transfer_offer:type_name -> protos.coupon.v1.TransferOffer
+	12,  // 10: protos.coupon.v1.Coupon.history:type_name -> protos.coupon.v1.CouponEvent
+	4,   // 11: protos.coupon.v1.CouponEvent.type:type_name -> protos.coupon.v1.CouponEventType
+	117, // 12: protos.coupon.v1.CouponEvent.occurred_at:type_name -> google.protobuf.Timestamp
+	117, // 13: protos.coupon.v1.Transfer.transferred_at:type_name -> google.protobuf.Timestamp
+	117, // 14: protos.coupon.v1.TransferOffer.offered_at:type_name -> google.protobuf.Timestamp
+	117, // 15: protos.coupon.v1.TransferOffer.expire_at:type_name -> google.protobuf.Timestamp
+	118, // 16: protos.coupon.v1.TransferPolicy.claim_ttl:type_name -> google.protobuf.Duration
+	17,  // 17: protos.coupon.v1.Reversal.refund:type_name -> protos.coupon.v1.LedgerEntry
+	117, // 18: protos.coupon.v1.Reversal.reversed_at:type_name -> google.protobuf.Timestamp
+	6,   // 19: protos.coupon.v1.LedgerEntry.type:type_name -> protos.coupon.v1.LedgerEntryType
+	44,  // 20: protos.coupon.v1.LedgerEntry.amount:type_name -> protos.coupon.v1.Money
+	44,  // 21: protos.coupon.v1.LedgerEntry.balance:type_name -> protos.coupon.v1.Money
+	117, // 22: protos.coupon.v1.LedgerEntry.occurred_at:type_name -> google.protobuf.Timestamp
+	117, // 23: protos.coupon.v1.Reservation.reserved_at:type_name -> google.protobuf.Timestamp
+	117, // 24: protos.coupon.v1.Reservation.expire_at:type_name -> google.protobuf.Timestamp
+	117, // 25: protos.coupon.v1.Campaign.created_at:type_name -> google.protobuf.Timestamp
+	117, // 26: protos.coupon.v1.Campaign.start_at:type_name -> google.protobuf.Timestamp
+	117, // 27: protos.coupon.v1.Campaign.end_at:type_name -> google.protobuf.Timestamp
+	11,  // 28: protos.coupon.v1.Campaign.coupons:type_name -> protos.coupon.v1.Coupon
+	47,  // 29: protos.coupon.v1.Campaign.history:type_name -> protos.coupon.v1.CampaignEvent
+	46,  // 30: protos.coupon.v1.Campaign.expiry_policy:type_name -> protos.coupon.v1.ExpiryPolicy
+	45,  // 31: protos.coupon.v1.Campaign.discount:type_name -> protos.coupon.v1.Discount
+	43,  // 32: protos.coupon.v1.Campaign.applicability:type_name -> protos.coupon.v1.Applicability
+	42,  // 33: protos.coupon.v1.Campaign.stacking:type_name -> protos.coupon.v1.StackingPolicy
+	40,  // 34: protos.coupon.v1.Campaign.allowlist:type_name -> protos.coupon.v1.UserList
+	40,  // 35: protos.coupon.v1.Campaign.blocklist:type_name -> protos.coupon.v1.UserList
+	3,   // 36: protos.coupon.v1.Campaign.state:type_name -> protos.coupon.v1.CampaignState
+	117, // 37: protos.coupon.v1.Campaign.closed_at:type_name -> google.protobuf.Timestamp
+	37,  // 38: protos.coupon.v1.Campaign.recurrence:type_name -> protos.coupon.v1.Recurrence
+	38,  // 39: protos.coupon.v1.Campaign.current_occurrence:type_name -> protos.coupon.v1.Occurrence
+	38,  // 40: protos.coupon.v1.Campaign.next_occurrence:type_name -> protos.coupon.v1.Occurrence
+	38,  // 41: protos.coupon.v1.Campaign.occurrences:type_name -> protos.coupon.v1.Occurrence
+	35,  // 42: protos.coupon.v1.Campaign.throttle:type_name -> protos.coupon.v1.Throttle
+	34,  // 43: protos.coupon.v1.Campaign.waiting_room:type_name -> protos.coupon.v1.WaitingRoom
+	33,  // 44: protos.coupon.v1.Campaign.lottery:type_name -> protos.coupon.v1.Lottery
+	32,  // 45: protos.coupon.v1.Campaign.waitlist:type_name -> protos.coupon.v1.Waitlist
+	44,  // 46: protos.coupon.v1.Campaign.stored_value:type_name -> protos.coupon.v1.Money
+	5,   // 47: protos.coupon.v1.Campaign.restore_policy:type_name -> protos.coupon.v1.RestorePolicy
+	15,  // 48: protos.coupon.v1.Campaign.transfer:type_name -> protos.coupon.v1.TransferPolicy
+	29,  // 49: protos.coupon.v1.Campaign.referral:type_name -> protos.coupon.v1.ReferralPolicy
+	20,  // 50: protos.coupon.v1.Campaign.tiers:type_name -> protos.coupon.v1.Tier
+	21,  // 51: protos.coupon.v1.Campaign.tier_stats:type_name -> protos.coupon.v1.TierStats
+	22,  // 52: protos.coupon.v1.Campaign.variants:type_name -> protos.coupon.v1.Variant
+	23,  // 53: protos.coupon.v1.Campaign.variant_stats:type_name -> protos.coupon.v1.VariantStats
+	24,  // 54: protos.coupon.v1.Campaign.budget:type_name -> protos.coupon.v1.Budget
+	25,  // 55: protos.coupon.v1.Campaign.budget_stats:type_name -> protos.coupon.v1.BudgetStats
+	26,  // 56: protos.coupon.v1.Campaign.allocation:type_name -> protos.coupon.v1.AllocationPolicy
+	28,  // 57: protos.coupon.v1.Campaign.allocation_stats:type_name -> protos.coupon.v1.AllocationStats
+	45,  // 58: protos.coupon.v1.Tier.discount:type_name -> protos.coupon.v1.Discount
+	45,  // 59: protos.coupon.v1.Variant.discount:type_name -> protos.coupon.v1.Discount
+	44,  // 60: protos.coupon.v1.Budget.amount:type_name -> protos.coupon.v1.Money
+	44,  // 61: protos.coupon.v1.Budget.projected_cost:type_name -> protos.coupon.v1.Money
+	44,  // 62: protos.coupon.v1.BudgetStats.spent:type_name -> protos.coupon.v1.Money
+	44,  // 63: protos.coupon.v1.BudgetStats.reserved:type_name -> protos.coupon.v1.Money
+	44,  // 64: protos.coupon.v1.BudgetStats.remaining:type_name -> protos.coupon.v1.Money
+	27,  // 65: protos.coupon.v1.AllocationPolicy.allocations:type_name -> protos.coupon.v1.Allocation
+	117, // 66: protos.coupon.v1.AllocationPolicy.spillover_at:type_name -> google.protobuf.Timestamp
+	45,  // 67: protos.coupon.v1.ReferralPolicy.reward:type_name -> protos.coupon.v1.Discount
+	117, // 68: protos.coupon.v1.Bundle.created_at:type_name -> google.protobuf.Timestamp
+	117, // 69: protos.coupon.v1.Lottery.drawn_at:type_name -> google.protobuf.Timestamp
+	118, // 70: protos.coupon.v1.WaitingRoom.token_ttl:type_name -> google.protobuf.Duration
+	118, // 71: protos.coupon.v1.Throttle.slice:type_name -> google.protobuf.Duration
+	117, // 72: protos.coupon.v1.IssueThrottled.next_slice_at:type_name -> google.protobuf.Timestamp
+	118, // 73: protos.coupon.v1.Recurrence.window:type_name -> google.protobuf.Duration
+	117, // 74: protos.coupon.v1.Occurrence.start_at:type_name -> google.protobuf.Timestamp
+	117, // 75: protos.coupon.v1.Occurrence.end_at:type_name -> google.protobuf.Timestamp
+	7,   // 76: protos.coupon.v1.UserList.kind:type_name -> protos.coupon.v1.UserListKind
+	39,  // 77: protos.coupon.v1.UserList.bloom_filter:type_name -> protos.coupon.v1.BloomFilter
+	8,   // 78: protos.coupon.v1.StackingPolicy.mode:type_name -> protos.coupon.v1.StackingMode
+	2,   // 79: protos.coupon.v1.Applicability.channels:type_name -> protos.coupon.v1.Channel
+	111, // 80: protos.coupon.v1.Discount.fixed_amount:type_name -> protos.coupon.v1.Discount.FixedAmount
+	112, // 81: protos.coupon.v1.Discount.percentage:type_name -> protos.coupon.v1.Discount.Percentage
+	113, // 82: protos.coupon.v1.Discount.free_shipping:type_name -> protos.coupon.v1.Discount.FreeShipping
+	114, // 83: protos.coupon.v1.Discount.buy_x_get_y:type_name -> protos.coupon.v1.Discount.BuyXGetY
+	44,  // 84: protos.coupon.v1.Discount.min_order_amount:type_name -> protos.coupon.v1.Money
+	117, // 85: protos.coupon.v1.ExpiryPolicy.fixed_at:type_name -> google.protobuf.Timestamp
+	118, // 86: protos.coupon.v1.ExpiryPolicy.ttl:type_name -> google.protobuf.Duration
+	115, // 87: protos.coupon.v1.ExpiryPolicy.end_of_day:type_name -> protos.coupon.v1.ExpiryPolicy.EndOfDay
+	116, // 88: protos.coupon.v1.ExpiryPolicy.earliest:type_name -> protos.coupon.v1.ExpiryPolicy.Earliest
+	9,   // 89: protos.coupon.v1.CampaignEvent.type:type_name -> protos.coupon.v1.CampaignEventType
+	117, // 90: protos.coupon.v1.CampaignEvent.occurred_at:type_name -> google.protobuf.Timestamp
+	117, // 91: protos.coupon.v1.CreateCampaignRequest.start_at:type_name -> google.protobuf.Timestamp
+	117, // 92: protos.coupon.v1.CreateCampaignRequest.end_at:type_name -> google.protobuf.Timestamp
+	46,  // 93: protos.coupon.v1.CreateCampaignRequest.expiry_policy:type_name -> protos.coupon.v1.ExpiryPolicy
+	45,  // 94: protos.coupon.v1.CreateCampaignRequest.discount:type_name -> protos.coupon.v1.Discount
+	43,  // 95: protos.coupon.v1.CreateCampaignRequest.applicability:type_name -> protos.coupon.v1.Applicability
+	42,  // 96: protos.coupon.v1.CreateCampaignRequest.stacking:type_name -> protos.coupon.v1.StackingPolicy
+	37,  // 97: protos.coupon.v1.CreateCampaignRequest.recurrence:type_name -> protos.coupon.v1.Recurrence
+	35,  // 98: protos.coupon.v1.CreateCampaignRequest.throttle:type_name -> protos.coupon.v1.Throttle
+	34,  // 99: protos.coupon.v1.CreateCampaignRequest.waiting_room:type_name -> protos.coupon.v1.WaitingRoom
+	44,  // 100: protos.coupon.v1.CreateCampaignRequest.stored_value:type_name -> protos.coupon.v1.Money
+	5,   // 101: protos.coupon.v1.CreateCampaignRequest.restore_policy:type_name -> protos.coupon.v1.RestorePolicy
+	15,  // 102: protos.coupon.v1.CreateCampaignRequest.transfer:type_name -> protos.coupon.v1.TransferPolicy
+	29,  // 103: protos.coupon.v1.CreateCampaignRequest.referral:type_name -> protos.coupon.v1.ReferralPolicy
+	20,  // 104: protos.coupon.v1.CreateCampaignRequest.tiers:type_name -> protos.coupon.v1.Tier
+	22,  // 105: protos.coupon.v1.CreateCampaignRequest.variants:type_name -> protos.coupon.v1.Variant
+	24,  // 106: protos.coupon.v1.CreateCampaignRequest.budget:type_name -> protos.coupon.v1.Budget
+	26,  // 107: protos.coupon.v1.CreateCampaignRequest.allocation:type_name -> protos.coupon.v1.AllocationPolicy
+	19,  // 108: protos.coupon.v1.CreateCampaignResponse.campaign:type_name -> protos.coupon.v1.Campaign
+	19,  // 109: protos.coupon.v1.GetCampaignResponse.campaign:type_name -> protos.coupon.v1.Campaign
+	19,  // 110: protos.coupon.v1.PauseCampaignResponse.campaign:type_name -> protos.coupon.v1.Campaign
+	19,  // 111: protos.coupon.v1.ResumeCampaignResponse.campaign:type_name -> protos.coupon.v1.Campaign
+	19,  // 112: protos.coupon.v1.CloseCampaignResponse.campaign:type_name -> protos.coupon.v1.Campaign
+	41,  // 113: protos.coupon.v1.IssueCouponRequest.user_attributes:type_name -> protos.coupon.v1.UserAttributes
+	11,  // 114: protos.coupon.v1.IssueCouponResponse.coupon:type_name -> protos.coupon.v1.Coupon
+	63,  // 115: protos.coupon.v1.EnterQueueResponse.status:type_name -> protos.coupon.v1.QueueStatus
+	117, // 116: protos.coupon.v1.QueueStatus.token_expire_at:type_name -> google.protobuf.Timestamp
+	41,  // 117: protos.coupon.v1.EnterLotteryRequest.user_attributes:type_name -> protos.coupon.v1.UserAttributes
+	11,  // 118: protos.coupon.v1.GetLotteryResultResponse.coupon:type_name -> protos.coupon.v1.Coupon
+	33,  // 119: protos.coupon.v1.GetLotteryResultResponse.lottery:type_name -> protos.coupon.v1.Lottery
+	41,  // 120: protos.coupon.v1.JoinWaitlistRequest.user_attributes:type_name -> protos.coupon.v1.UserAttributes
+	11,  // 121: protos.coupon.v1.GetWaitlistStatusResponse.coupon:type_name -> protos.coupon.v1.Coupon
+	11,  // 122: protos.coupon.v1.WaitlistStatus.coupon:type_name -> protos.coupon.v1.Coupon
+	2,   // 123: protos.coupon.v1.ValidateCouponRequest.channel:type_name -> protos.coupon.v1.Channel
+	1,   // 124: protos.coupon.v1.ValidateCouponResponse.reason:type_name -> protos.coupon.v1.ValidationReason
+	11,  // 125: protos.coupon.v1.ValidateCouponResponse.coupon:type_name -> protos.coupon.v1.Coupon
+	19,  // 126: protos.coupon.v1.ValidateCouponResponse.campaign:type_name -> protos.coupon.v1.Campaign
+	0,   // 127: protos.coupon.v1.ValidateCouponResponse.status:type_name -> protos.coupon.v1.CouponStatus
+	117, // 128: protos.coupon.v1.ValidateCouponResponse.expire_at:type_name -> google.protobuf.Timestamp
+	44,  // 129: protos.coupon.v1.RedeemCouponRequest.discount_amount:type_name -> protos.coupon.v1.Money
+	11,  // 130: protos.coupon.v1.RedeemCouponResponse.coupon:type_name -> protos.coupon.v1.Coupon
+	11,  // 131: protos.coupon.v1.RevokeCouponResponse.coupon:type_name -> protos.coupon.v1.Coupon
+	118, // 132: protos.coupon.v1.ReserveCouponRequest.ttl:type_name -> google.protobuf.Duration
+	11,  // 133: protos.coupon.v1.ReserveCouponResponse.coupon:type_name -> protos.coupon.v1.Coupon
+	44,  // 134: protos.coupon.v1.CommitReservationRequest.discount_amount:type_name -> protos.coupon.v1.Money
+	11,  // 135: protos.coupon.v1.CommitReservationResponse.coupon:type_name -> protos.coupon.v1.Coupon
+	11,  // 136: protos.coupon.v1.ReleaseReservationResponse.coupon:type_name -> protos.coupon.v1.Coupon
+	44,  // 137: protos.coupon.v1.RedeemAmountRequest.amount:type_name -> protos.coupon.v1.Money
+	11,  // 138: protos.coupon.v1.RedeemAmountResponse.coupon:type_name -> protos.coupon.v1.Coupon
+	17,  // 139: protos.coupon.v1.RedeemAmountResponse.entry:type_name -> protos.coupon.v1.LedgerEntry
+	16,  // 140: protos.coupon.v1.ReverseRedemptionResponse.reversals:type_name -> protos.coupon.v1.Reversal
+	41,  // 141: protos.coupon.v1.TransferCouponRequest.to_user_attributes:type_name -> protos.coupon.v1.UserAttributes
+	11,  // 142: protos.coupon.v1.TransferCouponResponse.coupon:type_name -> protos.coupon.v1.Coupon
+	41,  // 143: protos.coupon.v1.ClaimCouponRequest.user_attributes:type_name -> protos.coupon.v1.UserAttributes
+	11,  // 144: protos.coupon.v1.ClaimCouponResponse.coupon:type_name -> protos.coupon.v1.Coupon
+	30,  // 145: protos.coupon.v1.CreateBundleResponse.bundle:type_name -> protos.coupon.v1.Bundle
+	41,  // 146: protos.coupon.v1.IssueBundleRequest.user_attributes:type_name -> protos.coupon.v1.UserAttributes
+	11,  // 147: protos.coupon.v1.IssueBundleResponse.coupons:type_name -> protos.coupon.v1.Coupon
+	31,  // 148: protos.coupon.v1.CreateReferralCodeResponse.referral_code:type_name -> protos.coupon.v1.ReferralCode
+	17,  // 149: protos.coupon.v1.ListLedgerEntriesResponse.entries:type_name -> protos.coupon.v1.LedgerEntry
+	44,  // 150: protos.coupon.v1.LineItem.unit_price:type_name -> protos.coupon.v1.Money
+	44,  // 151: protos.coupon.v1.LineResult.subtotal:type_name -> protos.coupon.v1.Money
+	44,  // 152: protos.coupon.v1.LineResult.discount:type_name -> protos.coupon.v1.Money
+	44,  // 153: protos.coupon.v1.LineResult.total:type_name -> protos.coupon.v1.Money
+	44,  // 154: protos.coupon.v1.AppliedCoupon.discount:type_name -> protos.coupon.v1.Money
+	44,  // 155: protos.coupon.v1.AppliedCoupon.shipping_discount:type_name -> protos.coupon.v1.Money
+	10,  // 156: protos.coupon.v1.RejectedCoupon.reason:type_name -> protos.coupon.v1.RejectionReason
+	1,   // 157: protos.coupon.v1.RejectedCoupon.validation_reason:type_name -> protos.coupon.v1.ValidationReason
+	7,   // 158: protos.coupon.v1.UploadUserListRequest.kind:type_name -> protos.coupon.v1.UserListKind
+	39,  // 159: protos.coupon.v1.UploadUserListRequest.bloom_filter:type_name -> protos.coupon.v1.BloomFilter
+	40,  // 160: protos.coupon.v1.UploadUserListResponse.list:type_name -> protos.coupon.v1.UserList
+	102, // 161: protos.coupon.v1.EvaluateCartRequest.items:type_name -> protos.coupon.v1.LineItem
+	44,  // 162: protos.coupon.v1.EvaluateCartRequest.shipping:type_name -> protos.coupon.v1.Money
+	2,   // 163: protos.coupon.v1.EvaluateCartRequest.channel:type_name -> protos.coupon.v1.Channel
+	103, // 164: protos.coupon.v1.EvaluateCartResponse.lines:type_name -> protos.coupon.v1.LineResult
+	104, // 165: protos.coupon.v1.EvaluateCartResponse.applied:type_name -> protos.coupon.v1.AppliedCoupon
+	105, // 166: protos.coupon.v1.EvaluateCartResponse.rejected:type_name -> protos.coupon.v1.RejectedCoupon
+	106, // 167: protos.coupon.v1.EvaluateCartResponse.conflicts:type_name -> protos.coupon.v1.StackingConflict
+	44,  // 168: protos.coupon.v1.EvaluateCartResponse.subtotal:type_name -> protos.coupon.v1.Money
+	44,  // 169: protos.coupon.v1.EvaluateCartResponse.shipping:type_name -> protos.coupon.v1.Money
+	44,  // 170: protos.coupon.v1.EvaluateCartResponse.discount_total:type_name -> protos.coupon.v1.Money
+	44,  // 171: protos.coupon.v1.EvaluateCartResponse.total:type_name -> protos.coupon.v1.Money
+	44,  // 172: protos.coupon.v1.Discount.FixedAmount.amount:type_name -> protos.coupon.v1.Money
+	44,  // 173: protos.coupon.v1.Discount.Percentage.cap:type_name -> protos.coupon.v1.Money
+	46,  // 174: protos.coupon.v1.ExpiryPolicy.Earliest.policies:type_name -> protos.coupon.v1.ExpiryPolicy
+	48,  // 175: protos.coupon.v1.CouponIssuanceService.CreateCampaign:input_type -> protos.coupon.v1.CreateCampaignRequest
+	50,  // 176: protos.coupon.v1.CouponIssuanceService.GetCampaign:input_type -> protos.coupon.v1.GetCampaignRequest
+	58,  // 177: protos.coupon.v1.CouponIssuanceService.IssueCoupon:input_type -> protos.coupon.v1.IssueCouponRequest
+	74,  // 178: protos.coupon.v1.CouponIssuanceService.ValidateCoupon:input_type -> protos.coupon.v1.ValidateCouponRequest
+	76,  // 179: protos.coupon.v1.CouponIssuanceService.RedeemCoupon:input_type -> protos.coupon.v1.RedeemCouponRequest
+	78,  // 180: protos.coupon.v1.CouponIssuanceService.RevokeCoupon:input_type -> protos.coupon.v1.RevokeCouponRequest
+	109, // 181: protos.coupon.v1.CouponIssuanceService.EvaluateCart:input_type -> protos.coupon.v1.EvaluateCartRequest
+	107, // 182: protos.coupon.v1.CouponIssuanceService.UploadUserList:input_type -> protos.coupon.v1.UploadUserListRequest
+	52,  // 183: protos.coupon.v1.CouponIssuanceService.PauseCampaign:input_type -> protos.coupon.v1.PauseCampaignRequest
+	54,  // 184: protos.coupon.v1.CouponIssuanceService.ResumeCampaign:input_type -> protos.coupon.v1.ResumeCampaignRequest
+	56,  // 185: protos.coupon.v1.CouponIssuanceService.CloseCampaign:input_type -> protos.coupon.v1.CloseCampaignRequest
+	60,  // 186: protos.coupon.v1.CouponIssuanceService.EnterQueue:input_type -> protos.coupon.v1.EnterQueueRequest
+	62,  // 187: protos.coupon.v1.CouponIssuanceService.WatchQueue:input_type -> protos.coupon.v1.WatchQueueRequest
+	64,  // 188: protos.coupon.v1.CouponIssuanceService.EnterLottery:input_type -> protos.coupon.v1.EnterLotteryRequest
+	66,  // 189: protos.coupon.v1.CouponIssuanceService.GetLotteryResult:input_type -> protos.coupon.v1.GetLotteryResultRequest
+	68,  // 190: protos.coupon.v1.CouponIssuanceService.JoinWaitlist:input_type -> protos.coupon.v1.JoinWaitlistRequest
+	70,  // 191: protos.coupon.v1.CouponIssuanceService.GetWaitlistStatus:input_type -> protos.coupon.v1.GetWaitlistStatusRequest
+	72,  // 192: protos.coupon.v1.CouponIssuanceService.WatchWaitlist:input_type -> protos.coupon.v1.WatchWaitlistRequest
+	80,  // 193: protos.coupon.v1.CouponIssuanceService.ReserveCoupon:input_type -> protos.coupon.v1.ReserveCouponRequest
+	82,  // 194: protos.coupon.v1.CouponIssuanceService.CommitReservation:input_type -> protos.coupon.v1.CommitReservationRequest
+	84,  // 195: protos.coupon.v1.CouponIssuanceService.ReleaseReservation:input_type -> protos.coupon.v1.ReleaseReservationRequest
+	86,  // 196: protos.coupon.v1.CouponIssuanceService.RedeemAmount:input_type -> protos.coupon.v1.RedeemAmountRequest
+	100, // 197: protos.coupon.v1.CouponIssuanceService.ListLedgerEntries:input_type -> protos.coupon.v1.ListLedgerEntriesRequest
+	88,  // 198: protos.coupon.v1.CouponIssuanceService.ReverseRedemption:input_type -> protos.coupon.v1.ReverseRedemptionRequest
+	90,  // 199: protos.coupon.v1.CouponIssuanceService.TransferCoupon:input_type -> protos.coupon.v1.TransferCouponRequest
+	92,  // 200: protos.coupon.v1.CouponIssuanceService.ClaimCoupon:input_type -> protos.coupon.v1.ClaimCouponRequest
+	98,  // 201: protos.coupon.v1.CouponIssuanceService.CreateReferralCode:input_type -> protos.coupon.v1.CreateReferralCodeRequest
+	94,  // 202: protos.coupon.v1.CouponIssuanceService.CreateBundle:input_type -> protos.coupon.v1.CreateBundleRequest
+	96,  // 203: protos.coupon.v1.CouponIssuanceService.IssueBundle:input_type -> protos.coupon.v1.IssueBundleRequest
+	49,  // 204: protos.coupon.v1.CouponIssuanceService.CreateCampaign:output_type -> protos.coupon.v1.CreateCampaignResponse
+	51,  // 205: protos.coupon.v1.CouponIssuanceService.GetCampaign:output_type -> protos.coupon.v1.GetCampaignResponse
+	59,  // 206: protos.coupon.v1.CouponIssuanceService.IssueCoupon:output_type -> protos.coupon.v1.IssueCouponResponse
+	75,  // 207: protos.coupon.v1.CouponIssuanceService.ValidateCoupon:output_type -> protos.coupon.v1.ValidateCouponResponse
+	77,  // 208: protos.coupon.v1.CouponIssuanceService.RedeemCoupon:output_type -> protos.coupon.v1.RedeemCouponResponse
+	79,  // 209: protos.coupon.v1.CouponIssuanceService.RevokeCoupon:output_type -> protos.coupon.v1.RevokeCouponResponse
+	110, // 210: protos.coupon.v1.CouponIssuanceService.EvaluateCart:output_type -> protos.coupon.v1.EvaluateCartResponse
+	108, // 211: protos.coupon.v1.CouponIssuanceService.UploadUserList:output_type -> protos.coupon.v1.UploadUserListResponse
+	53,  // 212: protos.coupon.v1.CouponIssuanceService.PauseCampaign:output_type -> protos.coupon.v1.PauseCampaignResponse
+	55,  // 213: protos.coupon.v1.CouponIssuanceService.ResumeCampaign:output_type -> protos.coupon.v1.ResumeCampaignResponse
+	57,  // 214: protos.coupon.v1.CouponIssuanceService.CloseCampaign:output_type -> protos.coupon.v1.CloseCampaignResponse
+	61,  // 215: protos.coupon.v1.CouponIssuanceService.EnterQueue:output_type -> protos.coupon.v1.EnterQueueResponse
+	63,  // 216: protos.coupon.v1.CouponIssuanceService.WatchQueue:output_type -> protos.coupon.v1.QueueStatus
+	65,  // 217: protos.coupon.v1.CouponIssuanceService.EnterLottery:output_type -> protos.coupon.v1.EnterLotteryResponse
+	67,  // 218: protos.coupon.v1.CouponIssuanceService.GetLotteryResult:output_type -> protos.coupon.v1.GetLotteryResultResponse
+	69,  // 219: protos.coupon.v1.CouponIssuanceService.JoinWaitlist:output_type -> protos.coupon.v1.JoinWaitlistResponse
+	71,  // 220: protos.coupon.v1.CouponIssuanceService.GetWaitlistStatus:output_type -> protos.coupon.v1.GetWaitlistStatusResponse
+	73,  // 221: protos.coupon.v1.CouponIssuanceService.WatchWaitlist:output_type -> protos.coupon.v1.WaitlistStatus
+	81,  // 222: protos.coupon.v1.CouponIssuanceService.ReserveCoupon:output_type -> protos.coupon.v1.ReserveCouponResponse
+	83,  // 223: protos.coupon.v1.CouponIssuanceService.CommitReservation:output_type -> protos.coupon.v1.CommitReservationResponse
+	85,  // 224: protos.coupon.v1.CouponIssuanceService.ReleaseReservation:output_type -> protos.coupon.v1.ReleaseReservationResponse
+	87,  // 225: protos.coupon.v1.CouponIssuanceService.RedeemAmount:output_type -> protos.coupon.v1.RedeemAmountResponse
+	101, // 226: protos.coupon.v1.CouponIssuanceService.ListLedgerEntries:output_type -> protos.coupon.v1.ListLedgerEntriesResponse
+	89,  // 227: protos.coupon.v1.CouponIssuanceService.ReverseRedemption:output_type -> protos.coupon.v1.ReverseRedemptionResponse
+	91,  // 228: protos.coupon.v1.CouponIssuanceService.TransferCoupon:output_type -> protos.coupon.v1.TransferCouponResponse
+	93,  // 229: protos.coupon.v1.CouponIssuanceService.ClaimCoupon:output_type -> protos.coupon.v1.ClaimCouponResponse
+	99,  // 230: protos.coupon.v1.CouponIssuanceService.CreateReferralCode:output_type -> protos.coupon.v1.CreateReferralCodeResponse
+	95,  // 231: protos.coupon.v1.CouponIssuanceService.CreateBundle:output_type -> protos.coupon.v1.CreateBundleResponse
+	97,  // 232: protos.coupon.v1.CouponIssuanceService.IssueBundle:output_type -> protos.coupon.v1.IssueBundleResponse
+	204, // [204:233] is the sub-list for method output_type
+	175, // [175:204] is the sub-list for method input_type
+	175, // [175:175] is the sub-list for extension type_name
+	175, // [175:175] is the sub-list for extension extendee
+	0,   // [0:175] is the sub-list for field type_name
 }
 
 func init() { file_protos_coupon_v1_coupon_proto_init() }
@@ -574,18 +8690,35 @@ func file_protos_coupon_v1_coupon_proto_init() {
 	if File_protos_coupon_v1_coupon_proto != nil {
 		return
 	}
+	file_protos_coupon_v1_coupon_proto_msgTypes[34].OneofWrappers = []any{
+		(*Discount_FixedAmount_)(nil),
+		(*Discount_Percentage_)(nil),
+		(*Discount_FreeShipping_)(nil),
+		(*Discount_BuyXGetY_)(nil),
+	}
+	file_protos_coupon_v1_coupon_proto_msgTypes[35].OneofWrappers = []any{
+		(*ExpiryPolicy_FixedAt)(nil),
+		(*ExpiryPolicy_Ttl)(nil),
+		(*ExpiryPolicy_EndOfDay_)(nil),
+		(*ExpiryPolicy_Earliest_)(nil),
+	}
+	file_protos_coupon_v1_coupon_proto_msgTypes[77].OneofWrappers = []any{
+		(*ReverseRedemptionRequest_RedemptionId)(nil),
+		(*ReverseRedemptionRequest_OrderId)(nil),
+	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_protos_coupon_v1_coupon_proto_rawDesc), len(file_protos_coupon_v1_coupon_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   8,
+			NumEnums:      11,
+			NumMessages:   106,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_protos_coupon_v1_coupon_proto_goTypes,
 		DependencyIndexes: file_protos_coupon_v1_coupon_proto_depIdxs,
+		EnumInfos:         file_protos_coupon_v1_coupon_proto_enumTypes,
 		MessageInfos:      file_protos_coupon_v1_coupon_proto_msgTypes,
 	}.Build()
 	File_protos_coupon_v1_coupon_proto = out.File
@@ -42,6 +42,84 @@ const (
 	// CouponIssuanceServiceIssueCouponProcedure is the fully-qualified name of the
 	// CouponIssuanceService's IssueCoupon RPC.
 	CouponIssuanceServiceIssueCouponProcedure = "/protos.coupon.v1.CouponIssuanceService/IssueCoupon"
+	// CouponIssuanceServiceValidateCouponProcedure is the fully-qualified name of the
+	// CouponIssuanceService's ValidateCoupon RPC.
+	CouponIssuanceServiceValidateCouponProcedure = "/protos.coupon.v1.CouponIssuanceService/ValidateCoupon"
+	// CouponIssuanceServiceRedeemCouponProcedure is the fully-qualified name of the
+	// CouponIssuanceService's RedeemCoupon RPC.
+	CouponIssuanceServiceRedeemCouponProcedure = "/protos.coupon.v1.CouponIssuanceService/RedeemCoupon"
+	// CouponIssuanceServiceRevokeCouponProcedure is the fully-qualified name of the
+	// CouponIssuanceService's RevokeCoupon RPC.
+	CouponIssuanceServiceRevokeCouponProcedure = "/protos.coupon.v1.CouponIssuanceService/RevokeCoupon"
+	// CouponIssuanceServiceEvaluateCartProcedure is the fully-qualified name of the
+	// CouponIssuanceService's EvaluateCart RPC.
+	CouponIssuanceServiceEvaluateCartProcedure = "/protos.coupon.v1.CouponIssuanceService/EvaluateCart"
+	// CouponIssuanceServiceUploadUserListProcedure is the fully-qualified name of the
+	// CouponIssuanceService's UploadUserList RPC.
+	CouponIssuanceServiceUploadUserListProcedure = "/protos.coupon.v1.CouponIssuanceService/UploadUserList"
+	// CouponIssuanceServicePauseCampaignProcedure is the fully-qualified name of the
+	// CouponIssuanceService's PauseCampaign RPC.
+	CouponIssuanceServicePauseCampaignProcedure = "/protos.coupon.v1.CouponIssuanceService/PauseCampaign"
+	// CouponIssuanceServiceResumeCampaignProcedure is the fully-qualified name of the
+	// CouponIssuanceService's ResumeCampaign RPC.
+	CouponIssuanceServiceResumeCampaignProcedure = "/protos.coupon.v1.CouponIssuanceService/ResumeCampaign"
+	// CouponIssuanceServiceCloseCampaignProcedure is the fully-qualified name of the
+	// CouponIssuanceService's CloseCampaign RPC.
+	CouponIssuanceServiceCloseCampaignProcedure = "/protos.coupon.v1.CouponIssuanceService/CloseCampaign"
+	// CouponIssuanceServiceEnterQueueProcedure is the fully-qualified name of the
+	// CouponIssuanceService's EnterQueue RPC.
+	CouponIssuanceServiceEnterQueueProcedure = "/protos.coupon.v1.CouponIssuanceService/EnterQueue"
+	// CouponIssuanceServiceWatchQueueProcedure is the fully-qualified name of the
+	// CouponIssuanceService's WatchQueue RPC.
+	CouponIssuanceServiceWatchQueueProcedure = "/protos.coupon.v1.CouponIssuanceService/WatchQueue"
+	// CouponIssuanceServiceEnterLotteryProcedure is the fully-qualified name of the
+	// CouponIssuanceService's EnterLottery RPC.
+	CouponIssuanceServiceEnterLotteryProcedure = "/protos.coupon.v1.CouponIssuanceService/EnterLottery"
+	// CouponIssuanceServiceGetLotteryResultProcedure is the fully-qualified name of the
+	// CouponIssuanceService's GetLotteryResult RPC.
+	CouponIssuanceServiceGetLotteryResultProcedure = "/protos.coupon.v1.CouponIssuanceService/GetLotteryResult"
+	// CouponIssuanceServiceJoinWaitlistProcedure is the fully-qualified name of the
+	// CouponIssuanceService's JoinWaitlist RPC.
+	CouponIssuanceServiceJoinWaitlistProcedure = "/protos.coupon.v1.CouponIssuanceService/JoinWaitlist"
+	// CouponIssuanceServiceGetWaitlistStatusProcedure is the fully-qualified name of the
+	// CouponIssuanceService's GetWaitlistStatus RPC.
+	CouponIssuanceServiceGetWaitlistStatusProcedure = "/protos.coupon.v1.CouponIssuanceService/GetWaitlistStatus"
+	// CouponIssuanceServiceWatchWaitlistProcedure is the fully-qualified name of the
+	// CouponIssuanceService's WatchWaitlist RPC.
+	CouponIssuanceServiceWatchWaitlistProcedure = "/protos.coupon.v1.CouponIssuanceService/WatchWaitlist"
+	// CouponIssuanceServiceReserveCouponProcedure is the fully-qualified name of the
+	// CouponIssuanceService's ReserveCoupon RPC.
+	CouponIssuanceServiceReserveCouponProcedure = "/protos.coupon.v1.CouponIssuanceService/ReserveCoupon"
+	// CouponIssuanceServiceCommitReservationProcedure is the fully-qualified name of the
+	// CouponIssuanceService's CommitReservation RPC.
+	CouponIssuanceServiceCommitReservationProcedure = "/protos.coupon.v1.CouponIssuanceService/CommitReservation"
+	// CouponIssuanceServiceReleaseReservationProcedure is the fully-qualified name of the
+	// CouponIssuanceService's ReleaseReservation RPC.
+	CouponIssuanceServiceReleaseReservationProcedure = "/protos.coupon.v1.CouponIssuanceService/ReleaseReservation"
+	// CouponIssuanceServiceRedeemAmountProcedure is the fully-qualified name of the
+	// CouponIssuanceService's RedeemAmount RPC.
+	CouponIssuanceServiceRedeemAmountProcedure = "/protos.coupon.v1.CouponIssuanceService/RedeemAmount"
+	// CouponIssuanceServiceListLedgerEntriesProcedure is the fully-qualified name of the
+	// CouponIssuanceService's ListLedgerEntries RPC.
+	CouponIssuanceServiceListLedgerEntriesProcedure = "/protos.coupon.v1.CouponIssuanceService/ListLedgerEntries"
+	// CouponIssuanceServiceReverseRedemptionProcedure is the fully-qualified name of the
+	// CouponIssuanceService's ReverseRedemption RPC.
+	CouponIssuanceServiceReverseRedemptionProcedure = "/protos.coupon.v1.CouponIssuanceService/ReverseRedemption"
+	// CouponIssuanceServiceTransferCouponProcedure is the fully-qualified name of the
+	// CouponIssuanceService's TransferCoupon RPC.
+	CouponIssuanceServiceTransferCouponProcedure = "/protos.coupon.v1.CouponIssuanceService/TransferCoupon"
+	// CouponIssuanceServiceClaimCouponProcedure is the fully-qualified name of the
+	// CouponIssuanceService's ClaimCoupon RPC.
+	CouponIssuanceServiceClaimCouponProcedure = "/protos.coupon.v1.CouponIssuanceService/ClaimCoupon"
+	// CouponIssuanceServiceCreateReferralCodeProcedure is the fully-qualified name of the
+	// CouponIssuanceService's CreateReferralCode RPC.
+	CouponIssuanceServiceCreateReferralCodeProcedure = "/protos.coupon.v1.CouponIssuanceService/CreateReferralCode"
+	// CouponIssuanceServiceCreateBundleProcedure is the fully-qualified name of the
+	// CouponIssuanceService's CreateBundle RPC.
+	CouponIssuanceServiceCreateBundleProcedure = "/protos.coupon.v1.CouponIssuanceService/CreateBundle"
+	// CouponIssuanceServiceIssueBundleProcedure is the fully-qualified name of the
+	// CouponIssuanceService's IssueBundle RPC.
+	CouponIssuanceServiceIssueBundleProcedure = "/protos.coupon.v1.CouponIssuanceService/IssueBundle"
 )
 
 // CouponIssuanceServiceClient is a client for the protos.coupon.v1.CouponIssuanceService service.
@@ -49,6 +127,32 @@ type CouponIssuanceServiceClient interface {
 	CreateCampaign(context.Context, *connect.Request[v1.CreateCampaignRequest]) (*connect.Response[v1.CreateCampaignResponse], error)
 	GetCampaign(context.Context, *connect.Request[v1.GetCampaignRequest]) (*connect.Response[v1.GetCampaignResponse], error)
 	IssueCoupon(context.Context, *connect.Request[v1.IssueCouponRequest]) (*connect.Response[v1.IssueCouponResponse], error)
+	ValidateCoupon(context.Context, *connect.Request[v1.ValidateCouponRequest]) (*connect.Response[v1.ValidateCouponResponse], error)
+	RedeemCoupon(context.Context, *connect.Request[v1.RedeemCouponRequest]) (*connect.Response[v1.RedeemCouponResponse], error)
+	RevokeCoupon(context.Context, *connect.Request[v1.RevokeCouponRequest]) (*connect.Response[v1.RevokeCouponResponse], error)
+	EvaluateCart(context.Context, *connect.Request[v1.EvaluateCartRequest]) (*connect.Response[v1.EvaluateCartResponse], error)
+	UploadUserList(context.Context) *connect.ClientStreamForClient[v1.UploadUserListRequest, v1.UploadUserListResponse]
+	PauseCampaign(context.Context, *connect.Request[v1.PauseCampaignRequest]) (*connect.Response[v1.PauseCampaignResponse], error)
+	ResumeCampaign(context.Context, *connect.Request[v1.ResumeCampaignRequest]) (*connect.Response[v1.ResumeCampaignResponse], error)
+	CloseCampaign(context.Context, *connect.Request[v1.CloseCampaignRequest]) (*connect.Response[v1.CloseCampaignResponse], error)
+	EnterQueue(context.Context, *connect.Request[v1.EnterQueueRequest]) (*connect.Response[v1.EnterQueueResponse], error)
+	WatchQueue(context.Context, *connect.Request[v1.WatchQueueRequest]) (*connect.ServerStreamForClient[v1.QueueStatus], error)
+	EnterLottery(context.Context, *connect.Request[v1.EnterLotteryRequest]) (*connect.Response[v1.EnterLotteryResponse], error)
+	GetLotteryResult(context.Context, *connect.Request[v1.GetLotteryResultRequest]) (*connect.Response[v1.GetLotteryResultResponse], error)
+	JoinWaitlist(context.Context, *connect.Request[v1.JoinWaitlistRequest]) (*connect.Response[v1.JoinWaitlistResponse], error)
+	GetWaitlistStatus(context.Context, *connect.Request[v1.GetWaitlistStatusRequest]) (*connect.Response[v1.GetWaitlistStatusResponse], error)
+	WatchWaitlist(context.Context, *connect.Request[v1.WatchWaitlistRequest]) (*connect.ServerStreamForClient[v1.WaitlistStatus], error)
+	ReserveCoupon(context.Context, *connect.Request[v1.ReserveCouponRequest]) (*connect.Response[v1.ReserveCouponResponse], error)
+	CommitReservation(context.Context, *connect.Request[v1.CommitReservationRequest]) (*connect.Response[v1.CommitReservationResponse], error)
+	ReleaseReservation(context.Context, *connect.Request[v1.ReleaseReservationRequest]) (*connect.Response[v1.ReleaseReservationResponse], error)
+	RedeemAmount(context.Context, *connect.Request[v1.RedeemAmountRequest]) (*connect.Response[v1.RedeemAmountResponse], error)
+	ListLedgerEntries(context.Context, *connect.Request[v1.ListLedgerEntriesRequest]) (*connect.Response[v1.ListLedgerEntriesResponse], error)
+	ReverseRedemption(context.Context, *connect.Request[v1.ReverseRedemptionRequest]) (*connect.Response[v1.ReverseRedemptionResponse], error)
+	TransferCoupon(context.Context, *connect.Request[v1.TransferCouponRequest]) (*connect.Response[v1.TransferCouponResponse], error)
+	ClaimCoupon(context.Context, *connect.Request[v1.ClaimCouponRequest]) (*connect.Response[v1.ClaimCouponResponse], error)
+	CreateReferralCode(context.Context, *connect.Request[v1.CreateReferralCodeRequest]) (*connect.Response[v1.CreateReferralCodeResponse], error)
+	CreateBundle(context.Context, *connect.Request[v1.CreateBundleRequest]) (*connect.Response[v1.CreateBundleResponse], error)
+	IssueBundle(context.Context, *connect.Request[v1.IssueBundleRequest]) (*connect.Response[v1.IssueBundleResponse], error)
 }
 
 // NewCouponIssuanceServiceClient constructs a client for the protos.coupon.v1.CouponIssuanceService
@@ -80,14 +184,196 @@ func NewCouponIssuanceServiceClient(httpClient connect.HTTPClient, baseURL strin
 			connect.WithSchema(couponIssuanceServiceMethods.ByName("IssueCoupon")),
 			connect.WithClientOptions(opts...),
 		),
+		validateCoupon: connect.NewClient[v1.ValidateCouponRequest, v1.ValidateCouponResponse](
+			httpClient,
+			baseURL+CouponIssuanceServiceValidateCouponProcedure,
+			connect.WithSchema(couponIssuanceServiceMethods.ByName("ValidateCoupon")),
+			connect.WithClientOptions(opts...),
+		),
+		redeemCoupon: connect.NewClient[v1.RedeemCouponRequest, v1.RedeemCouponResponse](
+			httpClient,
+			baseURL+CouponIssuanceServiceRedeemCouponProcedure,
+			connect.WithSchema(couponIssuanceServiceMethods.ByName("RedeemCoupon")),
+			connect.WithClientOptions(opts...),
+		),
+		revokeCoupon: connect.NewClient[v1.RevokeCouponRequest, v1.RevokeCouponResponse](
+			httpClient,
+			baseURL+CouponIssuanceServiceRevokeCouponProcedure,
+			connect.WithSchema(couponIssuanceServiceMethods.ByName("RevokeCoupon")),
+			connect.WithClientOptions(opts...),
+		),
+		evaluateCart: connect.NewClient[v1.EvaluateCartRequest, v1.EvaluateCartResponse](
+			httpClient,
+			baseURL+CouponIssuanceServiceEvaluateCartProcedure,
+			connect.WithSchema(couponIssuanceServiceMethods.ByName("EvaluateCart")),
+			connect.WithClientOptions(opts...),
+		),
+		uploadUserList: connect.NewClient[v1.UploadUserListRequest, v1.UploadUserListResponse](
+			httpClient,
+			baseURL+CouponIssuanceServiceUploadUserListProcedure,
+			connect.WithSchema(couponIssuanceServiceMethods.ByName("UploadUserList")),
+			connect.WithClientOptions(opts...),
+		),
+		pauseCampaign: connect.NewClient[v1.PauseCampaignRequest, v1.PauseCampaignResponse](
+			httpClient,
+			baseURL+CouponIssuanceServicePauseCampaignProcedure,
+			connect.WithSchema(couponIssuanceServiceMethods.ByName("PauseCampaign")),
+			connect.WithClientOptions(opts...),
+		),
+		resumeCampaign: connect.NewClient[v1.ResumeCampaignRequest, v1.ResumeCampaignResponse](
+			httpClient,
+			baseURL+CouponIssuanceServiceResumeCampaignProcedure,
+			connect.WithSchema(couponIssuanceServiceMethods.ByName("ResumeCampaign")),
+			connect.WithClientOptions(opts...),
+		),
+		closeCampaign: connect.NewClient[v1.CloseCampaignRequest, v1.CloseCampaignResponse](
+			httpClient,
+			baseURL+CouponIssuanceServiceCloseCampaignProcedure,
+			connect.WithSchema(couponIssuanceServiceMethods.ByName("CloseCampaign")),
+			connect.WithClientOptions(opts...),
+		),
+		enterQueue: connect.NewClient[v1.EnterQueueRequest, v1.EnterQueueResponse](
+			httpClient,
+			baseURL+CouponIssuanceServiceEnterQueueProcedure,
+			connect.WithSchema(couponIssuanceServiceMethods.ByName("EnterQueue")),
+			connect.WithClientOptions(opts...),
+		),
+		watchQueue: connect.NewClient[v1.WatchQueueRequest, v1.QueueStatus](
+			httpClient,
+			baseURL+CouponIssuanceServiceWatchQueueProcedure,
+			connect.WithSchema(couponIssuanceServiceMethods.ByName("WatchQueue")),
+			connect.WithClientOptions(opts...),
+		),
+		enterLottery: connect.NewClient[v1.EnterLotteryRequest, v1.EnterLotteryResponse](
+			httpClient,
+			baseURL+CouponIssuanceServiceEnterLotteryProcedure,
+			connect.WithSchema(couponIssuanceServiceMethods.ByName("EnterLottery")),
+			connect.WithClientOptions(opts...),
+		),
+		getLotteryResult: connect.NewClient[v1.GetLotteryResultRequest, v1.GetLotteryResultResponse](
+			httpClient,
+			baseURL+CouponIssuanceServiceGetLotteryResultProcedure,
+			connect.WithSchema(couponIssuanceServiceMethods.ByName("GetLotteryResult")),
+			connect.WithClientOptions(opts...),
+		),
+		joinWaitlist: connect.NewClient[v1.JoinWaitlistRequest, v1.JoinWaitlistResponse](
+			httpClient,
+			baseURL+CouponIssuanceServiceJoinWaitlistProcedure,
+			connect.WithSchema(couponIssuanceServiceMethods.ByName("JoinWaitlist")),
+			connect.WithClientOptions(opts...),
+		),
+		getWaitlistStatus: connect.NewClient[v1.GetWaitlistStatusRequest, v1.GetWaitlistStatusResponse](
+			httpClient,
+			baseURL+CouponIssuanceServiceGetWaitlistStatusProcedure,
+			connect.WithSchema(couponIssuanceServiceMethods.ByName("GetWaitlistStatus")),
+			connect.WithClientOptions(opts...),
+		),
+		watchWaitlist: connect.NewClient[v1.WatchWaitlistRequest, v1.WaitlistStatus](
+			httpClient,
+			baseURL+CouponIssuanceServiceWatchWaitlistProcedure,
+			connect.WithSchema(couponIssuanceServiceMethods.ByName("WatchWaitlist")),
+			connect.WithClientOptions(opts...),
+		),
+		reserveCoupon: connect.NewClient[v1.ReserveCouponRequest, v1.ReserveCouponResponse](
+			httpClient,
+			baseURL+CouponIssuanceServiceReserveCouponProcedure,
+			connect.WithSchema(couponIssuanceServiceMethods.ByName("ReserveCoupon")),
+			connect.WithClientOptions(opts...),
+		),
+		commitReservation: connect.NewClient[v1.CommitReservationRequest, v1.CommitReservationResponse](
+			httpClient,
+			baseURL+CouponIssuanceServiceCommitReservationProcedure,
+			connect.WithSchema(couponIssuanceServiceMethods.ByName("CommitReservation")),
+			connect.WithClientOptions(opts...),
+		),
+		releaseReservation: connect.NewClient[v1.ReleaseReservationRequest, v1.ReleaseReservationResponse](
+			httpClient,
+			baseURL+CouponIssuanceServiceReleaseReservationProcedure,
+			connect.WithSchema(couponIssuanceServiceMethods.ByName("ReleaseReservation")),
+			connect.WithClientOptions(opts...),
+		),
+		redeemAmount: connect.NewClient[v1.RedeemAmountRequest, v1.RedeemAmountResponse](
+			httpClient,
+			baseURL+CouponIssuanceServiceRedeemAmountProcedure,
+			connect.WithSchema(couponIssuanceServiceMethods.ByName("RedeemAmount")),
+			connect.WithClientOptions(opts...),
+		),
+		listLedgerEntries: connect.NewClient[v1.ListLedgerEntriesRequest, v1.ListLedgerEntriesResponse](
+			httpClient,
+			baseURL+CouponIssuanceServiceListLedgerEntriesProcedure,
+			connect.WithSchema(couponIssuanceServiceMethods.ByName("ListLedgerEntries")),
+			connect.WithClientOptions(opts...),
+		),
+		reverseRedemption: connect.NewClient[v1.ReverseRedemptionRequest, v1.ReverseRedemptionResponse](
+			httpClient,
+			baseURL+CouponIssuanceServiceReverseRedemptionProcedure,
+			connect.WithSchema(couponIssuanceServiceMethods.ByName("ReverseRedemption")),
+			connect.WithClientOptions(opts...),
+		),
+		transferCoupon: connect.NewClient[v1.TransferCouponRequest, v1.TransferCouponResponse](
+			httpClient,
+			baseURL+CouponIssuanceServiceTransferCouponProcedure,
+			connect.WithSchema(couponIssuanceServiceMethods.ByName("TransferCoupon")),
+			connect.WithClientOptions(opts...),
+		),
+		claimCoupon: connect.NewClient[v1.ClaimCouponRequest, v1.ClaimCouponResponse](
+			httpClient,
+			baseURL+CouponIssuanceServiceClaimCouponProcedure,
+			connect.WithSchema(couponIssuanceServiceMethods.ByName("ClaimCoupon")),
+			connect.WithClientOptions(opts...),
+		),
+		createReferralCode: connect.NewClient[v1.CreateReferralCodeRequest, v1.CreateReferralCodeResponse](
+			httpClient,
+			baseURL+CouponIssuanceServiceCreateReferralCodeProcedure,
+			connect.WithSchema(couponIssuanceServiceMethods.ByName("CreateReferralCode")),
+			connect.WithClientOptions(opts...),
+		),
+		createBundle: connect.NewClient[v1.CreateBundleRequest, v1.CreateBundleResponse](
+			httpClient,
+			baseURL+CouponIssuanceServiceCreateBundleProcedure,
+			connect.WithSchema(couponIssuanceServiceMethods.ByName("CreateBundle")),
+			connect.WithClientOptions(opts...),
+		),
+		issueBundle: connect.NewClient[v1.IssueBundleRequest, v1.IssueBundleResponse](
+			httpClient,
+			baseURL+CouponIssuanceServiceIssueBundleProcedure,
+			connect.WithSchema(couponIssuanceServiceMethods.ByName("IssueBundle")),
+			connect.WithClientOptions(opts...),
+		),
 	}
 }
 
 // couponIssuanceServiceClient implements CouponIssuanceServiceClient.
 type couponIssuanceServiceClient struct {
-	createCampaign *connect.Client[v1.CreateCampaignRequest, v1.CreateCampaignResponse]
-	getCampaign    *connect.Client[v1.GetCampaignRequest, v1.GetCampaignResponse]
-	issueCoupon    *connect.Client[v1.IssueCouponRequest, v1.IssueCouponResponse]
+	createCampaign     *connect.Client[v1.CreateCampaignRequest, v1.CreateCampaignResponse]
+	getCampaign        *connect.Client[v1.GetCampaignRequest, v1.GetCampaignResponse]
+	issueCoupon        *connect.Client[v1.IssueCouponRequest, v1.IssueCouponResponse]
+	validateCoupon     *connect.Client[v1.ValidateCouponRequest, v1.ValidateCouponResponse]
+	redeemCoupon       *connect.Client[v1.RedeemCouponRequest, v1.RedeemCouponResponse]
+	revokeCoupon       *connect.Client[v1.RevokeCouponRequest, v1.RevokeCouponResponse]
+	evaluateCart       *connect.Client[v1.EvaluateCartRequest, v1.EvaluateCartResponse]
+	uploadUserList     *connect.Client[v1.UploadUserListRequest, v1.UploadUserListResponse]
+	pauseCampaign      *connect.Client[v1.PauseCampaignRequest, v1.PauseCampaignResponse]
+	resumeCampaign     *connect.Client[v1.ResumeCampaignRequest, v1.ResumeCampaignResponse]
+	closeCampaign      *connect.Client[v1.CloseCampaignRequest, v1.CloseCampaignResponse]
+	enterQueue         *connect.Client[v1.EnterQueueRequest, v1.EnterQueueResponse]
+	watchQueue         *connect.Client[v1.WatchQueueRequest, v1.QueueStatus]
+	enterLottery       *connect.Client[v1.EnterLotteryRequest, v1.EnterLotteryResponse]
+	getLotteryResult   *connect.Client[v1.GetLotteryResultRequest, v1.GetLotteryResultResponse]
+	joinWaitlist       *connect.Client[v1.JoinWaitlistRequest, v1.JoinWaitlistResponse]
+	getWaitlistStatus  *connect.Client[v1.GetWaitlistStatusRequest, v1.GetWaitlistStatusResponse]
+	watchWaitlist      *connect.Client[v1.WatchWaitlistRequest, v1.WaitlistStatus]
+	reserveCoupon      *connect.Client[v1.ReserveCouponRequest, v1.ReserveCouponResponse]
+	commitReservation  *connect.Client[v1.CommitReservationRequest, v1.CommitReservationResponse]
+	releaseReservation *connect.Client[v1.ReleaseReservationRequest, v1.ReleaseReservationResponse]
+	redeemAmount       *connect.Client[v1.RedeemAmountRequest, v1.RedeemAmountResponse]
+	listLedgerEntries  *connect.Client[v1.ListLedgerEntriesRequest, v1.ListLedgerEntriesResponse]
+	reverseRedemption  *connect.Client[v1.ReverseRedemptionRequest, v1.ReverseRedemptionResponse]
+	transferCoupon     *connect.Client[v1.TransferCouponRequest, v1.TransferCouponResponse]
+	claimCoupon        *connect.Client[v1.ClaimCouponRequest, v1.ClaimCouponResponse]
+	createReferralCode *connect.Client[v1.CreateReferralCodeRequest, v1.CreateReferralCodeResponse]
+	createBundle       *connect.Client[v1.CreateBundleRequest, v1.CreateBundleResponse]
+	issueBundle        *connect.Client[v1.IssueBundleRequest, v1.IssueBundleResponse]
 }
 
 // CreateCampaign calls protos.coupon.v1.CouponIssuanceService.CreateCampaign.
@@ -105,12 +391,168 @@ func (c *couponIssuanceServiceClient) IssueCoupon(ctx context.Context, req *conn
 	return c.issueCoupon.CallUnary(ctx, req)
 }
 
+// ValidateCoupon calls protos.coupon.v1.CouponIssuanceService.ValidateCoupon.
+func (c *couponIssuanceServiceClient) ValidateCoupon(ctx context.Context, req *connect.Request[v1.ValidateCouponRequest]) (*connect.Response[v1.ValidateCouponResponse], error) {
+	return c.validateCoupon.CallUnary(ctx, req)
+}
+
+// RedeemCoupon calls protos.coupon.v1.CouponIssuanceService.RedeemCoupon.
+func (c *couponIssuanceServiceClient) RedeemCoupon(ctx context.Context, req *connect.Request[v1.RedeemCouponRequest]) (*connect.Response[v1.RedeemCouponResponse], error) {
+	return c.redeemCoupon.CallUnary(ctx, req)
+}
+
+// RevokeCoupon calls protos.coupon.v1.CouponIssuanceService.RevokeCoupon.
+func (c *couponIssuanceServiceClient) RevokeCoupon(ctx context.Context, req *connect.Request[v1.RevokeCouponRequest]) (*connect.Response[v1.RevokeCouponResponse], error) {
+	return c.revokeCoupon.CallUnary(ctx, req)
+}
+
+// EvaluateCart calls protos.coupon.v1.CouponIssuanceService.EvaluateCart.
+func (c *couponIssuanceServiceClient) EvaluateCart(ctx context.Context, req *connect.Request[v1.EvaluateCartRequest]) (*connect.Response[v1.EvaluateCartResponse], error) {
+	return c.evaluateCart.CallUnary(ctx, req)
+}
+
+// UploadUserList calls protos.coupon.v1.CouponIssuanceService.UploadUserList.
+func (c *couponIssuanceServiceClient) UploadUserList(ctx context.Context) *connect.ClientStreamForClient[v1.UploadUserListRequest, v1.UploadUserListResponse] {
+	return c.uploadUserList.CallClientStream(ctx)
+}
+
+// PauseCampaign calls protos.coupon.v1.CouponIssuanceService.PauseCampaign.
+func (c *couponIssuanceServiceClient) PauseCampaign(ctx context.Context, req *connect.Request[v1.PauseCampaignRequest]) (*connect.Response[v1.PauseCampaignResponse], error) {
+	return c.pauseCampaign.CallUnary(ctx, req)
+}
+
+// ResumeCampaign calls protos.coupon.v1.CouponIssuanceService.ResumeCampaign.
+func (c *couponIssuanceServiceClient) ResumeCampaign(ctx context.Context, req *connect.Request[v1.ResumeCampaignRequest]) (*connect.Response[v1.ResumeCampaignResponse], error) {
+	return c.resumeCampaign.CallUnary(ctx, req)
+}
+
+// CloseCampaign calls protos.coupon.v1.CouponIssuanceService.CloseCampaign.
+func (c *couponIssuanceServiceClient) CloseCampaign(ctx context.Context, req *connect.Request[v1.CloseCampaignRequest]) (*connect.Response[v1.CloseCampaignResponse], error) {
+	return c.closeCampaign.CallUnary(ctx, req)
+}
+
+// EnterQueue calls protos.coupon.v1.CouponIssuanceService.EnterQueue.
+func (c *couponIssuanceServiceClient) EnterQueue(ctx context.Context, req *connect.Request[v1.EnterQueueRequest]) (*connect.Response[v1.EnterQueueResponse], error) {
+	return c.enterQueue.CallUnary(ctx, req)
+}
+
+// WatchQueue calls protos.coupon.v1.CouponIssuanceService.WatchQueue.
+func (c *couponIssuanceServiceClient) WatchQueue(ctx context.Context, req *connect.Request[v1.WatchQueueRequest]) (*connect.ServerStreamForClient[v1.QueueStatus], error) {
+	return c.watchQueue.CallServerStream(ctx, req)
+}
+
+// EnterLottery calls protos.coupon.v1.CouponIssuanceService.EnterLottery.
+func (c *couponIssuanceServiceClient) EnterLottery(ctx context.Context, req *connect.Request[v1.EnterLotteryRequest]) (*connect.Response[v1.EnterLotteryResponse], error) {
+	return c.enterLottery.CallUnary(ctx, req)
+}
+
+// GetLotteryResult calls protos.coupon.v1.CouponIssuanceService.GetLotteryResult.
+func (c *couponIssuanceServiceClient) GetLotteryResult(ctx context.Context, req *connect.Request[v1.GetLotteryResultRequest]) (*connect.Response[v1.GetLotteryResultResponse], error) {
+	return c.getLotteryResult.CallUnary(ctx, req)
+}
+
+// JoinWaitlist calls protos.coupon.v1.CouponIssuanceService.JoinWaitlist.
+func (c *couponIssuanceServiceClient) JoinWaitlist(ctx context.Context, req *connect.Request[v1.JoinWaitlistRequest]) (*connect.Response[v1.JoinWaitlistResponse], error) {
+	return c.joinWaitlist.CallUnary(ctx, req)
+}
+
+// GetWaitlistStatus calls protos.coupon.v1.CouponIssuanceService.GetWaitlistStatus.
+func (c *couponIssuanceServiceClient) GetWaitlistStatus(ctx context.Context, req *connect.Request[v1.GetWaitlistStatusRequest]) (*connect.Response[v1.GetWaitlistStatusResponse], error) {
+	return c.getWaitlistStatus.CallUnary(ctx, req)
+}
+
+// WatchWaitlist calls protos.coupon.v1.CouponIssuanceService.WatchWaitlist.
+func (c *couponIssuanceServiceClient) WatchWaitlist(ctx context.Context, req *connect.Request[v1.WatchWaitlistRequest]) (*connect.ServerStreamForClient[v1.WaitlistStatus], error) {
+	return c.watchWaitlist.CallServerStream(ctx, req)
+}
+
+// ReserveCoupon calls protos.coupon.v1.CouponIssuanceService.ReserveCoupon.
+func (c *couponIssuanceServiceClient) ReserveCoupon(ctx context.Context, req *connect.Request[v1.ReserveCouponRequest]) (*connect.Response[v1.ReserveCouponResponse], error) {
+	return c.reserveCoupon.CallUnary(ctx, req)
+}
+
+// CommitReservation calls protos.coupon.v1.CouponIssuanceService.CommitReservation.
+func (c *couponIssuanceServiceClient) CommitReservation(ctx context.Context, req *connect.Request[v1.CommitReservationRequest]) (*connect.Response[v1.CommitReservationResponse], error) {
+	return c.commitReservation.CallUnary(ctx, req)
+}
+
+// ReleaseReservation calls protos.coupon.v1.CouponIssuanceService.ReleaseReservation.
+func (c *couponIssuanceServiceClient) ReleaseReservation(ctx context.Context, req *connect.Request[v1.ReleaseReservationRequest]) (*connect.Response[v1.ReleaseReservationResponse], error) {
+	return c.releaseReservation.CallUnary(ctx, req)
+}
+
+// RedeemAmount calls protos.coupon.v1.CouponIssuanceService.RedeemAmount.
+func (c *couponIssuanceServiceClient) RedeemAmount(ctx context.Context, req *connect.Request[v1.RedeemAmountRequest]) (*connect.Response[v1.RedeemAmountResponse], error) {
+	return c.redeemAmount.CallUnary(ctx, req)
+}
+
+// ListLedgerEntries calls protos.coupon.v1.CouponIssuanceService.ListLedgerEntries.
+func (c *couponIssuanceServiceClient) ListLedgerEntries(ctx context.Context, req *connect.Request[v1.ListLedgerEntriesRequest]) (*connect.Response[v1.ListLedgerEntriesResponse], error) {
+	return c.listLedgerEntries.CallUnary(ctx, req)
+}
+
+// ReverseRedemption calls protos.coupon.v1.CouponIssuanceService.ReverseRedemption.
+func (c *couponIssuanceServiceClient) ReverseRedemption(ctx context.Context, req *connect.Request[v1.ReverseRedemptionRequest]) (*connect.Response[v1.ReverseRedemptionResponse], error) {
+	return c.reverseRedemption.CallUnary(ctx, req)
+}
+
+// TransferCoupon calls protos.coupon.v1.CouponIssuanceService.TransferCoupon.
+func (c *couponIssuanceServiceClient) TransferCoupon(ctx context.Context, req *connect.Request[v1.TransferCouponRequest]) (*connect.Response[v1.TransferCouponResponse], error) {
+	return c.transferCoupon.CallUnary(ctx, req)
+}
+
+// ClaimCoupon calls protos.coupon.v1.CouponIssuanceService.ClaimCoupon.
+func (c *couponIssuanceServiceClient) ClaimCoupon(ctx context.Context, req *connect.Request[v1.ClaimCouponRequest]) (*connect.Response[v1.ClaimCouponResponse], error) {
+	return c.claimCoupon.CallUnary(ctx, req)
+}
+
+// CreateReferralCode calls protos.coupon.v1.CouponIssuanceService.CreateReferralCode.
+func (c *couponIssuanceServiceClient) CreateReferralCode(ctx context.Context, req *connect.Request[v1.CreateReferralCodeRequest]) (*connect.Response[v1.CreateReferralCodeResponse], error) {
+	return c.createReferralCode.CallUnary(ctx, req)
+}
+
+// CreateBundle calls protos.coupon.v1.CouponIssuanceService.CreateBundle.
+func (c *couponIssuanceServiceClient) CreateBundle(ctx context.Context, req *connect.Request[v1.CreateBundleRequest]) (*connect.Response[v1.CreateBundleResponse], error) {
+	return c.createBundle.CallUnary(ctx, req)
+}
+
+// IssueBundle calls protos.coupon.v1.CouponIssuanceService.IssueBundle.
+func (c *couponIssuanceServiceClient) IssueBundle(ctx context.Context, req *connect.Request[v1.IssueBundleRequest]) (*connect.Response[v1.IssueBundleResponse], error) {
+	return c.issueBundle.CallUnary(ctx, req)
+}
+
 // CouponIssuanceServiceHandler is an implementation of the protos.coupon.v1.CouponIssuanceService
 // service.
 type CouponIssuanceServiceHandler interface {
 	CreateCampaign(context.Context, *connect.Request[v1.CreateCampaignRequest]) (*connect.Response[v1.CreateCampaignResponse], error)
 	GetCampaign(context.Context, *connect.Request[v1.GetCampaignRequest]) (*connect.Response[v1.GetCampaignResponse], error)
 	IssueCoupon(context.Context, *connect.Request[v1.IssueCouponRequest]) (*connect.Response[v1.IssueCouponResponse], error)
+	ValidateCoupon(context.Context, *connect.Request[v1.ValidateCouponRequest]) (*connect.Response[v1.ValidateCouponResponse], error)
+	RedeemCoupon(context.Context, *connect.Request[v1.RedeemCouponRequest]) (*connect.Response[v1.RedeemCouponResponse], error)
+	RevokeCoupon(context.Context, *connect.Request[v1.RevokeCouponRequest]) (*connect.Response[v1.RevokeCouponResponse], error)
+	EvaluateCart(context.Context, *connect.Request[v1.EvaluateCartRequest]) (*connect.Response[v1.EvaluateCartResponse], error)
+	UploadUserList(context.Context, *connect.ClientStream[v1.UploadUserListRequest]) (*connect.Response[v1.UploadUserListResponse], error)
+	PauseCampaign(context.Context, *connect.Request[v1.PauseCampaignRequest]) (*connect.Response[v1.PauseCampaignResponse], error)
+	ResumeCampaign(context.Context, *connect.Request[v1.ResumeCampaignRequest]) (*connect.Response[v1.ResumeCampaignResponse], error)
+	CloseCampaign(context.Context, *connect.Request[v1.CloseCampaignRequest]) (*connect.Response[v1.CloseCampaignResponse], error)
+	EnterQueue(context.Context, *connect.Request[v1.EnterQueueRequest]) (*connect.Response[v1.EnterQueueResponse], error)
+	WatchQueue(context.Context, *connect.Request[v1.WatchQueueRequest], *connect.ServerStream[v1.QueueStatus]) error
+	EnterLottery(context.Context, *connect.Request[v1.EnterLotteryRequest]) (*connect.Response[v1.EnterLotteryResponse], error)
+	GetLotteryResult(context.Context, *connect.Request[v1.GetLotteryResultRequest]) (*connect.Response[v1.GetLotteryResultResponse], error)
+	JoinWaitlist(context.Context, *connect.Request[v1.JoinWaitlistRequest]) (*connect.Response[v1.JoinWaitlistResponse], error)
+	GetWaitlistStatus(context.Context, *connect.Request[v1.GetWaitlistStatusRequest]) (*connect.Response[v1.GetWaitlistStatusResponse], error)
+	WatchWaitlist(context.Context, *connect.Request[v1.WatchWaitlistRequest], *connect.ServerStream[v1.WaitlistStatus]) error
+	ReserveCoupon(context.Context, *connect.Request[v1.ReserveCouponRequest]) (*connect.Response[v1.ReserveCouponResponse], error)
+	CommitReservation(context.Context, *connect.Request[v1.CommitReservationRequest]) (*connect.Response[v1.CommitReservationResponse], error)
+	ReleaseReservation(context.Context, *connect.Request[v1.ReleaseReservationRequest]) (*connect.Response[v1.ReleaseReservationResponse], error)
+	RedeemAmount(context.Context, *connect.Request[v1.RedeemAmountRequest]) (*connect.Response[v1.RedeemAmountResponse], error)
+	ListLedgerEntries(context.Context, *connect.Request[v1.ListLedgerEntriesRequest]) (*connect.Response[v1.ListLedgerEntriesResponse], error)
+	ReverseRedemption(context.Context, *connect.Request[v1.ReverseRedemptionRequest]) (*connect.Response[v1.ReverseRedemptionResponse], error)
+	TransferCoupon(context.Context, *connect.Request[v1.TransferCouponRequest]) (*connect.Response[v1.TransferCouponResponse], error)
+	ClaimCoupon(context.Context, *connect.Request[v1.ClaimCouponRequest]) (*connect.Response[v1.ClaimCouponResponse], error)
+	CreateReferralCode(context.Context, *connect.Request[v1.CreateReferralCodeRequest]) (*connect.Response[v1.CreateReferralCodeResponse], error)
+	CreateBundle(context.Context, *connect.Request[v1.CreateBundleRequest]) (*connect.Response[v1.CreateBundleResponse], error)
+	IssueBundle(context.Context, *connect.Request[v1.IssueBundleRequest]) (*connect.Response[v1.IssueBundleResponse], error)
 }
 
 // NewCouponIssuanceServiceHandler builds an HTTP handler from the service implementation. It
@@ -138,6 +580,162 @@ func NewCouponIssuanceServiceHandler(svc CouponIssuanceServiceHandler, opts ...c
 		connect.WithSchema(couponIssuanceServiceMethods.ByName("IssueCoupon")),
 		connect.WithHandlerOptions(opts...),
 	)
+	couponIssuanceServiceValidateCouponHandler := connect.NewUnaryHandler(
+		CouponIssuanceServiceValidateCouponProcedure,
+		svc.ValidateCoupon,
+		connect.WithSchema(couponIssuanceServiceMethods.ByName("ValidateCoupon")),
+		connect.WithHandlerOptions(opts...),
+	)
+	couponIssuanceServiceRedeemCouponHandler := connect.NewUnaryHandler(
+		CouponIssuanceServiceRedeemCouponProcedure,
+		svc.RedeemCoupon,
+		connect.WithSchema(couponIssuanceServiceMethods.ByName("RedeemCoupon")),
+		connect.WithHandlerOptions(opts...),
+	)
+	couponIssuanceServiceRevokeCouponHandler := connect.NewUnaryHandler(
+		CouponIssuanceServiceRevokeCouponProcedure,
+		svc.RevokeCoupon,
+		connect.WithSchema(couponIssuanceServiceMethods.ByName("RevokeCoupon")),
+		connect.WithHandlerOptions(opts...),
+	)
+	couponIssuanceServiceEvaluateCartHandler := connect.NewUnaryHandler(
+		CouponIssuanceServiceEvaluateCartProcedure,
+		svc.EvaluateCart,
+		connect.WithSchema(couponIssuanceServiceMethods.ByName("EvaluateCart")),
+		connect.WithHandlerOptions(opts...),
+	)
+	couponIssuanceServiceUploadUserListHandler := connect.NewClientStreamHandler(
+		CouponIssuanceServiceUploadUserListProcedure,
+		svc.UploadUserList,
+		connect.WithSchema(couponIssuanceServiceMethods.ByName("UploadUserList")),
+		connect.WithHandlerOptions(opts...),
+	)
+	couponIssuanceServicePauseCampaignHandler := connect.NewUnaryHandler(
+		CouponIssuanceServicePauseCampaignProcedure,
+		svc.PauseCampaign,
+		connect.WithSchema(couponIssuanceServiceMethods.ByName("PauseCampaign")),
+		connect.WithHandlerOptions(opts...),
+	)
+	couponIssuanceServiceResumeCampaignHandler := connect.NewUnaryHandler(
+		CouponIssuanceServiceResumeCampaignProcedure,
+		svc.ResumeCampaign,
+		connect.WithSchema(couponIssuanceServiceMethods.ByName("ResumeCampaign")),
+		connect.WithHandlerOptions(opts...),
+	)
+	couponIssuanceServiceCloseCampaignHandler := connect.NewUnaryHandler(
+		CouponIssuanceServiceCloseCampaignProcedure,
+		svc.CloseCampaign,
+		connect.WithSchema(couponIssuanceServiceMethods.ByName("CloseCampaign")),
+		connect.WithHandlerOptions(opts...),
+	)
+	couponIssuanceServiceEnterQueueHandler := connect.NewUnaryHandler(
+		CouponIssuanceServiceEnterQueueProcedure,
+		svc.EnterQueue,
+		connect.WithSchema(couponIssuanceServiceMethods.ByName("EnterQueue")),
+		connect.WithHandlerOptions(opts...),
+	)
+	couponIssuanceServiceWatchQueueHandler := connect.NewServerStreamHandler(
+		CouponIssuanceServiceWatchQueueProcedure,
+		svc.WatchQueue,
+		connect.WithSchema(couponIssuanceServiceMethods.ByName("WatchQueue")),
+		connect.WithHandlerOptions(opts...),
+	)
+	couponIssuanceServiceEnterLotteryHandler := connect.NewUnaryHandler(
+		CouponIssuanceServiceEnterLotteryProcedure,
+		svc.EnterLottery,
+		connect.WithSchema(couponIssuanceServiceMethods.ByName("EnterLottery")),
+		connect.WithHandlerOptions(opts...),
+	)
+	couponIssuanceServiceGetLotteryResultHandler := connect.NewUnaryHandler(
+		CouponIssuanceServiceGetLotteryResultProcedure,
+		svc.GetLotteryResult,
+		connect.WithSchema(couponIssuanceServiceMethods.ByName("GetLotteryResult")),
+		connect.WithHandlerOptions(opts...),
+	)
+	couponIssuanceServiceJoinWaitlistHandler := connect.NewUnaryHandler(
+		CouponIssuanceServiceJoinWaitlistProcedure,
+		svc.JoinWaitlist,
+		connect.WithSchema(couponIssuanceServiceMethods.ByName("JoinWaitlist")),
+		connect.WithHandlerOptions(opts...),
+	)
+	couponIssuanceServiceGetWaitlistStatusHandler := connect.NewUnaryHandler(
+		CouponIssuanceServiceGetWaitlistStatusProcedure,
+		svc.GetWaitlistStatus,
+		connect.WithSchema(couponIssuanceServiceMethods.ByName("GetWaitlistStatus")),
+		connect.WithHandlerOptions(opts...),
+	)
+	couponIssuanceServiceWatchWaitlistHandler := connect.NewServerStreamHandler(
+		CouponIssuanceServiceWatchWaitlistProcedure,
+		svc.WatchWaitlist,
+		connect.WithSchema(couponIssuanceServiceMethods.ByName("WatchWaitlist")),
+		connect.WithHandlerOptions(opts...),
+	)
+	couponIssuanceServiceReserveCouponHandler := connect.NewUnaryHandler(
+		CouponIssuanceServiceReserveCouponProcedure,
+		svc.ReserveCoupon,
+		connect.WithSchema(couponIssuanceServiceMethods.ByName("ReserveCoupon")),
+		connect.WithHandlerOptions(opts...),
+	)
+	couponIssuanceServiceCommitReservationHandler := connect.NewUnaryHandler(
+		CouponIssuanceServiceCommitReservationProcedure,
+		svc.CommitReservation,
+		connect.WithSchema(couponIssuanceServiceMethods.ByName("CommitReservation")),
+		connect.WithHandlerOptions(opts...),
+	)
+	couponIssuanceServiceReleaseReservationHandler := connect.NewUnaryHandler(
+		CouponIssuanceServiceReleaseReservationProcedure,
+		svc.ReleaseReservation,
+		connect.WithSchema(couponIssuanceServiceMethods.ByName("ReleaseReservation")),
+		connect.WithHandlerOptions(opts...),
+	)
+	couponIssuanceServiceRedeemAmountHandler := connect.NewUnaryHandler(
+		CouponIssuanceServiceRedeemAmountProcedure,
+		svc.RedeemAmount,
+		connect.WithSchema(couponIssuanceServiceMethods.ByName("RedeemAmount")),
+		connect.WithHandlerOptions(opts...),
+	)
+	couponIssuanceServiceListLedgerEntriesHandler := connect.NewUnaryHandler(
+		CouponIssuanceServiceListLedgerEntriesProcedure,
+		svc.ListLedgerEntries,
+		connect.WithSchema(couponIssuanceServiceMethods.ByName("ListLedgerEntries")),
+		connect.WithHandlerOptions(opts...),
+	)
+	couponIssuanceServiceReverseRedemptionHandler := connect.NewUnaryHandler(
+		CouponIssuanceServiceReverseRedemptionProcedure,
+		svc.ReverseRedemption,
+		connect.WithSchema(couponIssuanceServiceMethods.ByName("ReverseRedemption")),
+		connect.WithHandlerOptions(opts...),
+	)
+	couponIssuanceServiceTransferCouponHandler := connect.NewUnaryHandler(
+		CouponIssuanceServiceTransferCouponProcedure,
+		svc.TransferCoupon,
+		connect.WithSchema(couponIssuanceServiceMethods.ByName("TransferCoupon")),
+		connect.WithHandlerOptions(opts...),
+	)
+	couponIssuanceServiceClaimCouponHandler := connect.NewUnaryHandler(
+		CouponIssuanceServiceClaimCouponProcedure,
+		svc.ClaimCoupon,
+		connect.WithSchema(couponIssuanceServiceMethods.ByName("ClaimCoupon")),
+		connect.WithHandlerOptions(opts...),
+	)
+	couponIssuanceServiceCreateReferralCodeHandler := connect.NewUnaryHandler(
+		CouponIssuanceServiceCreateReferralCodeProcedure,
+		svc.CreateReferralCode,
+		connect.WithSchema(couponIssuanceServiceMethods.ByName("CreateReferralCode")),
+		connect.WithHandlerOptions(opts...),
+	)
+	couponIssuanceServiceCreateBundleHandler := connect.NewUnaryHandler(
+		CouponIssuanceServiceCreateBundleProcedure,
+		svc.CreateBundle,
+		connect.WithSchema(couponIssuanceServiceMethods.ByName("CreateBundle")),
+		connect.WithHandlerOptions(opts...),
+	)
+	couponIssuanceServiceIssueBundleHandler := connect.NewUnaryHandler(
+		CouponIssuanceServiceIssueBundleProcedure,
+		svc.IssueBundle,
+		connect.WithSchema(couponIssuanceServiceMethods.ByName("IssueBundle")),
+		connect.WithHandlerOptions(opts...),
+	)
 	return "/protos.coupon.v1.CouponIssuanceService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.URL.Path {
 		case CouponIssuanceServiceCreateCampaignProcedure:
@@ -146,6 +744,58 @@ func NewCouponIssuanceServiceHandler(svc CouponIssuanceServiceHandler, opts ...c
 			couponIssuanceServiceGetCampaignHandler.ServeHTTP(w, r)
 		case CouponIssuanceServiceIssueCouponProcedure:
 			couponIssuanceServiceIssueCouponHandler.ServeHTTP(w, r)
+		case CouponIssuanceServiceValidateCouponProcedure:
+			couponIssuanceServiceValidateCouponHandler.ServeHTTP(w, r)
+		case CouponIssuanceServiceRedeemCouponProcedure:
+			couponIssuanceServiceRedeemCouponHandler.ServeHTTP(w, r)
+		case CouponIssuanceServiceRevokeCouponProcedure:
+			couponIssuanceServiceRevokeCouponHandler.ServeHTTP(w, r)
+		case CouponIssuanceServiceEvaluateCartProcedure:
+			couponIssuanceServiceEvaluateCartHandler.ServeHTTP(w, r)
+		case CouponIssuanceServiceUploadUserListProcedure:
+			couponIssuanceServiceUploadUserListHandler.ServeHTTP(w, r)
+		case CouponIssuanceServicePauseCampaignProcedure:
+			couponIssuanceServicePauseCampaignHandler.ServeHTTP(w, r)
+		case CouponIssuanceServiceResumeCampaignProcedure:
+			couponIssuanceServiceResumeCampaignHandler.ServeHTTP(w, r)
+		case CouponIssuanceServiceCloseCampaignProcedure:
+			couponIssuanceServiceCloseCampaignHandler.ServeHTTP(w, r)
+		case CouponIssuanceServiceEnterQueueProcedure:
+			couponIssuanceServiceEnterQueueHandler.ServeHTTP(w, r)
+		case CouponIssuanceServiceWatchQueueProcedure:
+			couponIssuanceServiceWatchQueueHandler.ServeHTTP(w, r)
+		case CouponIssuanceServiceEnterLotteryProcedure:
+			couponIssuanceServiceEnterLotteryHandler.ServeHTTP(w, r)
+		case CouponIssuanceServiceGetLotteryResultProcedure:
+			couponIssuanceServiceGetLotteryResultHandler.ServeHTTP(w, r)
+		case CouponIssuanceServiceJoinWaitlistProcedure:
+			couponIssuanceServiceJoinWaitlistHandler.ServeHTTP(w, r)
+		case CouponIssuanceServiceGetWaitlistStatusProcedure:
+			couponIssuanceServiceGetWaitlistStatusHandler.ServeHTTP(w, r)
+		case CouponIssuanceServiceWatchWaitlistProcedure:
+			couponIssuanceServiceWatchWaitlistHandler.ServeHTTP(w, r)
+		case CouponIssuanceServiceReserveCouponProcedure:
+			couponIssuanceServiceReserveCouponHandler.ServeHTTP(w, r)
+		case CouponIssuanceServiceCommitReservationProcedure:
+			couponIssuanceServiceCommitReservationHandler.ServeHTTP(w, r)
+		case CouponIssuanceServiceReleaseReservationProcedure:
+			couponIssuanceServiceReleaseReservationHandler.ServeHTTP(w, r)
+		case CouponIssuanceServiceRedeemAmountProcedure:
+			couponIssuanceServiceRedeemAmountHandler.ServeHTTP(w, r)
+		case CouponIssuanceServiceListLedgerEntriesProcedure:
+			couponIssuanceServiceListLedgerEntriesHandler.ServeHTTP(w, r)
+		case CouponIssuanceServiceReverseRedemptionProcedure:
+			couponIssuanceServiceReverseRedemptionHandler.ServeHTTP(w, r)
+		case CouponIssuanceServiceTransferCouponProcedure:
+			couponIssuanceServiceTransferCouponHandler.ServeHTTP(w, r)
+		case CouponIssuanceServiceClaimCouponProcedure:
+			couponIssuanceServiceClaimCouponHandler.ServeHTTP(w, r)
+		case CouponIssuanceServiceCreateReferralCodeProcedure:
+			couponIssuanceServiceCreateReferralCodeHandler.ServeHTTP(w, r)
+		case CouponIssuanceServiceCreateBundleProcedure:
+			couponIssuanceServiceCreateBundleHandler.ServeHTTP(w, r)
+		case CouponIssuanceServiceIssueBundleProcedure:
+			couponIssuanceServiceIssueBundleHandler.ServeHTTP(w, r)
 		default:
 			http.NotFound(w, r)
 		}
@@ -166,3 +816,107 @@ func (UnimplementedCouponIssuanceServiceHandler) GetCampaign(context.Context, *c
 func (UnimplementedCouponIssuanceServiceHandler) IssueCoupon(context.Context, *connect.Request[v1.IssueCouponRequest]) (*connect.Response[v1.IssueCouponResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("protos.coupon.v1.CouponIssuanceService.IssueCoupon is not implemented"))
 }
+
+func (UnimplementedCouponIssuanceServiceHandler) ValidateCoupon(context.Context, *connect.Request[v1.ValidateCouponRequest]) (*connect.Response[v1.ValidateCouponResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("protos.coupon.v1.CouponIssuanceService.ValidateCoupon is not implemented"))
+}
+
+func (UnimplementedCouponIssuanceServiceHandler) RedeemCoupon(context.Context, *connect.Request[v1.RedeemCouponRequest]) (*connect.Response[v1.RedeemCouponResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("protos.coupon.v1.CouponIssuanceService.RedeemCoupon is not implemented"))
+}
+
+func (UnimplementedCouponIssuanceServiceHandler) RevokeCoupon(context.Context, *connect.Request[v1.RevokeCouponRequest]) (*connect.Response[v1.RevokeCouponResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("protos.coupon.v1.CouponIssuanceService.RevokeCoupon is not implemented"))
+}
+
+func (UnimplementedCouponIssuanceServiceHandler) EvaluateCart(context.Context, *connect.Request[v1.EvaluateCartRequest]) (*connect.Response[v1.EvaluateCartResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("protos.coupon.v1.CouponIssuanceService.EvaluateCart is not implemented"))
+}
+
+func (UnimplementedCouponIssuanceServiceHandler) UploadUserList(context.Context, *connect.ClientStream[v1.UploadUserListRequest]) (*connect.Response[v1.UploadUserListResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("protos.coupon.v1.CouponIssuanceService.UploadUserList is not implemented"))
+}
+
+func (UnimplementedCouponIssuanceServiceHandler) PauseCampaign(context.Context, *connect.Request[v1.PauseCampaignRequest]) (*connect.Response[v1.PauseCampaignResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("protos.coupon.v1.CouponIssuanceService.PauseCampaign is not implemented"))
+}
+
+func (UnimplementedCouponIssuanceServiceHandler) ResumeCampaign(context.Context, *connect.Request[v1.ResumeCampaignRequest]) (*connect.Response[v1.ResumeCampaignResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("protos.coupon.v1.CouponIssuanceService.ResumeCampaign is not implemented"))
+}
+
+func (UnimplementedCouponIssuanceServiceHandler) CloseCampaign(context.Context, *connect.Request[v1.CloseCampaignRequest]) (*connect.Response[v1.CloseCampaignResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("protos.coupon.v1.CouponIssuanceService.CloseCampaign is not implemented"))
+}
+
+func (UnimplementedCouponIssuanceServiceHandler) EnterQueue(context.Context, *connect.Request[v1.EnterQueueRequest]) (*connect.Response[v1.EnterQueueResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("protos.coupon.v1.CouponIssuanceService.EnterQueue is not implemented"))
+}
+
+func (UnimplementedCouponIssuanceServiceHandler) WatchQueue(context.Context, *connect.Request[v1.WatchQueueRequest], *connect.ServerStream[v1.QueueStatus]) error {
+	return connect.NewError(connect.CodeUnimplemented, errors.New("protos.coupon.v1.CouponIssuanceService.WatchQueue is not implemented"))
+}
+
+func (UnimplementedCouponIssuanceServiceHandler) EnterLottery(context.Context, *connect.Request[v1.EnterLotteryRequest]) (*connect.Response[v1.EnterLotteryResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("protos.coupon.v1.CouponIssuanceService.EnterLottery is not implemented"))
+}
+
+func (UnimplementedCouponIssuanceServiceHandler) GetLotteryResult(context.Context, *connect.Request[v1.GetLotteryResultRequest]) (*connect.Response[v1.GetLotteryResultResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("protos.coupon.v1.CouponIssuanceService.GetLotteryResult is not implemented"))
+}
+
+func (UnimplementedCouponIssuanceServiceHandler) JoinWaitlist(context.Context, *connect.Request[v1.JoinWaitlistRequest]) (*connect.Response[v1.JoinWaitlistResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("protos.coupon.v1.CouponIssuanceService.JoinWaitlist is not implemented"))
+}
+
+func (UnimplementedCouponIssuanceServiceHandler) GetWaitlistStatus(context.Context, *connect.Request[v1.GetWaitlistStatusRequest]) (*connect.Response[v1.GetWaitlistStatusResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("protos.coupon.v1.CouponIssuanceService.GetWaitlistStatus is not implemented"))
+}
+
+func (UnimplementedCouponIssuanceServiceHandler) WatchWaitlist(context.Context, *connect.Request[v1.WatchWaitlistRequest], *connect.ServerStream[v1.WaitlistStatus]) error {
+	return connect.NewError(connect.CodeUnimplemented, errors.New("protos.coupon.v1.CouponIssuanceService.WatchWaitlist is not implemented"))
+}
+
+func (UnimplementedCouponIssuanceServiceHandler) ReserveCoupon(context.Context, *connect.Request[v1.ReserveCouponRequest]) (*connect.Response[v1.ReserveCouponResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("protos.coupon.v1.CouponIssuanceService.ReserveCoupon is not implemented"))
+}
+
+func (UnimplementedCouponIssuanceServiceHandler) CommitReservation(context.Context, *connect.Request[v1.CommitReservationRequest]) (*connect.Response[v1.CommitReservationResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("protos.coupon.v1.CouponIssuanceService.CommitReservation is not implemented"))
+}
+
+func (UnimplementedCouponIssuanceServiceHandler) ReleaseReservation(context.Context, *connect.Request[v1.ReleaseReservationRequest]) (*connect.Response[v1.ReleaseReservationResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("protos.coupon.v1.CouponIssuanceService.ReleaseReservation is not implemented"))
+}
+
+func (UnimplementedCouponIssuanceServiceHandler) RedeemAmount(context.Context, *connect.Request[v1.RedeemAmountRequest]) (*connect.Response[v1.RedeemAmountResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("protos.coupon.v1.CouponIssuanceService.RedeemAmount is not implemented"))
+}
+
+func (UnimplementedCouponIssuanceServiceHandler) ListLedgerEntries(context.Context, *connect.Request[v1.ListLedgerEntriesRequest]) (*connect.Response[v1.ListLedgerEntriesResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("protos.coupon.v1.CouponIssuanceService.ListLedgerEntries is not implemented"))
+}
+
+func (UnimplementedCouponIssuanceServiceHandler) ReverseRedemption(context.Context, *connect.Request[v1.ReverseRedemptionRequest]) (*connect.Response[v1.ReverseRedemptionResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("protos.coupon.v1.CouponIssuanceService.ReverseRedemption is not implemented"))
+}
+
+func (UnimplementedCouponIssuanceServiceHandler) TransferCoupon(context.Context, *connect.Request[v1.TransferCouponRequest]) (*connect.Response[v1.TransferCouponResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("protos.coupon.v1.CouponIssuanceService.TransferCoupon is not implemented"))
+}
+
+func (UnimplementedCouponIssuanceServiceHandler) ClaimCoupon(context.Context, *connect.Request[v1.ClaimCouponRequest]) (*connect.Response[v1.ClaimCouponResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("protos.coupon.v1.CouponIssuanceService.ClaimCoupon is not implemented"))
+}
+
+func (UnimplementedCouponIssuanceServiceHandler) CreateReferralCode(context.Context, *connect.Request[v1.CreateReferralCodeRequest]) (*connect.Response[v1.CreateReferralCodeResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("protos.coupon.v1.CouponIssuanceService.CreateReferralCode is not implemented"))
+}
+
+func (UnimplementedCouponIssuanceServiceHandler) CreateBundle(context.Context, *connect.Request[v1.CreateBundleRequest]) (*connect.Response[v1.CreateBundleResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("protos.coupon.v1.CouponIssuanceService.CreateBundle is not implemented"))
+}
+
+func (UnimplementedCouponIssuanceServiceHandler) IssueBundle(context.Context, *connect.Request[v1.IssueBundleRequest]) (*connect.Response[v1.IssueBundleResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("protos.coupon.v1.CouponIssuanceService.IssueBundle is not implemented"))
+}
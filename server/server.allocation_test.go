@@ -0,0 +1,67 @@
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	couponv1 "github.com/jackgihokim/coupon-issuance-system/protos/coupon/v1"
+)
+
+func TestIssueCoupon_Allocation(t *testing.T) {
+	srv := NewCouponIssuanceServer()
+	ctx := context.Background()
+	now := time.Now().UTC()
+	resp, err := srv.CreateCampaign(ctx, connect.NewRequest(&couponv1.CreateCampaignRequest{
+		CouponLimit: 3,
+		Name:        "Allocated Test Campaign",
+		StartAt:     timestamppb.New(now.Add(-1 * time.Hour)),
+		EndAt:       timestamppb.New(now.Add(1 * time.Hour)),
+		Allocation: &couponv1.AllocationPolicy{
+			Allocations: []*couponv1.Allocation{{Channel: "app", Limit: 2}, {Channel: "partner-x", Limit: 1}},
+		},
+	}))
+	require.NoError(t, err)
+	campId := resp.Msg.Campaign.Id
+
+	issue := func(channel string) (*couponv1.Coupon, error) {
+		resp, err := srv.IssueCoupon(ctx, connect.NewRequest(&couponv1.IssueCouponRequest{
+			CampaignId: campId,
+			Channel:    channel,
+		}))
+		if err != nil {
+			return nil, err
+		}
+		return resp.Msg.Coupon, nil
+	}
+
+	coup, err := issue("partner-x")
+	require.NoError(t, err)
+	assert.Equal(t, "partner-x", coup.Channel)
+	_, err = issue("partner-x")
+	assert.EqualError(t, err, `channel "partner-x" has no more coupon`)
+
+	// Returning the slot of a revoked coupon gives it back to its channel
+	_, err = srv.RevokeCoupon(ctx, connect.NewRequest(&couponv1.RevokeCouponRequest{
+		Code:         coup.Code,
+		Reason:       "issued by mistake",
+		ReturnToPool: true,
+	}))
+	require.NoError(t, err)
+	_, err = issue("partner-x")
+	require.NoError(t, err)
+
+	_, err = issue("app")
+	require.NoError(t, err)
+	stats := getTestCampaign(t, srv, campId).AllocationStats
+	require.Len(t, stats, 2)
+	assert.Equal(t, "app", stats[0].Channel)
+	assert.Equal(t, uint32(1), stats[0].Issued)
+	assert.Equal(t, uint32(1), stats[0].Remaining)
+	assert.Equal(t, uint32(0), stats[1].Remaining)
+}
@@ -0,0 +1,67 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/jackgihokim/coupon-issuance-system/handlers/campaign"
+	"github.com/jackgihokim/coupon-issuance-system/handlers/coupon"
+	couponv1 "github.com/jackgihokim/coupon-issuance-system/protos/coupon/v1"
+)
+
+// redemptionCost returns what redeeming the coupon spends of the campaign's budget. The fixed amount of the coupon's
+// discount is what it costs whatever the amount given. The discount amount given is taken for a discount which
+// depends on the order, up to the cap of a percentage, or else the projected cost of the budget.
+// Returns 0 if the campaign has no budget, or an error if the cost is not known or the amount given is invalid.
+func redemptionCost(camp *campaign.Campaign, coup *couponv1.Coupon, given *couponv1.Money) (int64, error) {
+	if camp.Budget == nil {
+		return 0, nil
+	}
+	if fixed := coup.Discount.GetFixedAmount(); fixed != nil {
+		return fixed.Amount.GetAmount(), nil
+	}
+
+	currency := camp.Budget.Amount.Currency
+	if given == nil {
+		if camp.Budget.ProjectedCost == nil {
+			return 0, errors.New("discount amount is required for the campaign's budget")
+		}
+		return camp.Budget.ProjectedCost.Amount, nil
+	}
+	switch {
+	case given.Currency != currency:
+		return 0, fmt.Errorf("discount amount must be in %s", currency)
+	case given.Amount < 0:
+		return 0, errors.New("discount amount must not be negative")
+	}
+	if limit := coup.Discount.GetPercentage().GetCap(); limit != nil {
+		return min(given.Amount, limit.Amount), nil
+	}
+	return given.Amount, nil
+}
+
+// spendBudget spends the cost of the redemption of the coupon from the campaign's budget, and records an alert in
+// the campaign's history for each threshold the amount spent reached.
+func spendBudget(camp *campaign.Campaign, coup *couponv1.Coupon, redemptionId string, cost int64, now time.Time) {
+	redeemed := coup.Status == couponv1.CouponStatus_COUPON_STATUS_REDEEMED
+	for _, threshold := range camp.Coupons.SpendBudget(coup.Code, redemptionId, cost, redeemed) {
+		camp.History.Record(&couponv1.CampaignEvent{
+			Type:       couponv1.CampaignEventType_CAMPAIGN_EVENT_TYPE_BUDGET_ALERT,
+			Code:       coup.Code,
+			Reason:     fmt.Sprintf("%d.%02d%% of the budget spent", threshold/100, threshold%100),
+			OccurredAt: timestamppb.New(now),
+		})
+	}
+}
+
+// newBudgetStatsMessage converts the stats of a budget into its protobuf message.
+func newBudgetStatsMessage(stats coupon.BudgetStats) *couponv1.BudgetStats {
+	return &couponv1.BudgetStats{
+		Spent:     &couponv1.Money{Currency: stats.Currency, Amount: stats.Spent},
+		Reserved:  &couponv1.Money{Currency: stats.Currency, Amount: stats.Reserved},
+		Remaining: &couponv1.Money{Currency: stats.Currency, Amount: stats.Remaining},
+	}
+}
@@ -0,0 +1,144 @@
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	couponv1 "github.com/jackgihokim/coupon-issuance-system/protos/coupon/v1"
+)
+
+func TestBudget(t *testing.T) {
+	srv := NewCouponIssuanceServer()
+	ctx := context.Background()
+	now := time.Now().UTC()
+	krw := func(amount int64) *couponv1.Money {
+		return &couponv1.Money{Currency: "KRW", Amount: amount}
+	}
+	resp, err := srv.CreateCampaign(ctx, connect.NewRequest(&couponv1.CreateCampaignRequest{
+		CouponLimit: 10,
+		Name:        "Budget Test Campaign",
+		StartAt:     timestamppb.New(now.Add(-1 * time.Hour)),
+		EndAt:       timestamppb.New(now.Add(1 * time.Hour)),
+		Discount: &couponv1.Discount{Kind: &couponv1.Discount_FixedAmount_{FixedAmount: &couponv1.Discount_FixedAmount{
+			Amount: krw(4000),
+		}}},
+		Budget: &couponv1.Budget{Amount: krw(10000), ProjectedCost: krw(4000), AlertThresholds: []uint32{5000}},
+	}))
+	require.NoError(t, err)
+	campId := resp.Msg.Campaign.Id
+
+	first := issueTestCoupon(t, srv, campId)
+	second := issueTestCoupon(t, srv, campId)
+
+	// 8000 of 10000 is reserved, which cannot cover another coupon
+	_, err = srv.IssueCoupon(ctx, connect.NewRequest(&couponv1.IssueCouponRequest{CampaignId: campId}))
+	assert.Error(t, err)
+	assert.Equal(t, couponv1.CampaignState_CAMPAIGN_STATE_SOLD_OUT, getTestCampaign(t, srv, campId).State)
+
+	// The fixed amount of the discount is spent whatever discount amount is given
+	_, err = srv.RedeemCoupon(ctx, connect.NewRequest(&couponv1.RedeemCouponRequest{
+		Code:           first.Code,
+		DiscountAmount: krw(1000),
+	}))
+	require.NoError(t, err)
+	campMsg := getTestCampaign(t, srv, campId)
+	assert.Equal(t, int64(4000), campMsg.BudgetStats.Spent.Amount)
+	assert.Equal(t, int64(4000), campMsg.BudgetStats.Reserved.Amount)
+	assert.Equal(t, int64(2000), campMsg.BudgetStats.Remaining.Amount)
+	assert.Empty(t, campMsg.History)
+
+	// Without a discount amount, the fixed amount is spent all the same and reaches the alert threshold
+	redeemed, err := srv.RedeemCoupon(ctx, connect.NewRequest(&couponv1.RedeemCouponRequest{Code: second.Code}))
+	require.NoError(t, err)
+	campMsg = getTestCampaign(t, srv, campId)
+	assert.Equal(t, int64(8000), campMsg.BudgetStats.Spent.Amount)
+	require.Len(t, campMsg.History, 1)
+	assert.Equal(t, couponv1.CampaignEventType_CAMPAIGN_EVENT_TYPE_BUDGET_ALERT, campMsg.History[0].Type)
+	assert.Equal(t, "50.00% of the budget spent", campMsg.History[0].Reason)
+
+	// Reversing the redemption gives its cost back, so the budget covers another coupon
+	_, err = srv.ReverseRedemption(ctx, connect.NewRequest(&couponv1.ReverseRedemptionRequest{
+		Key: &couponv1.ReverseRedemptionRequest_RedemptionId{RedemptionId: redeemed.Msg.Coupon.RedemptionId},
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, int64(4000), getTestCampaign(t, srv, campId).BudgetStats.Spent.Amount)
+	issueTestCoupon(t, srv, campId)
+}
+
+func TestBudget_Percentage(t *testing.T) {
+	srv := NewCouponIssuanceServer()
+	ctx := context.Background()
+	now := time.Now().UTC()
+	krw := func(amount int64) *couponv1.Money {
+		return &couponv1.Money{Currency: "KRW", Amount: amount}
+	}
+	resp, err := srv.CreateCampaign(ctx, connect.NewRequest(&couponv1.CreateCampaignRequest{
+		CouponLimit: 10,
+		Name:        "Percentage Budget Test Campaign",
+		StartAt:     timestamppb.New(now.Add(-1 * time.Hour)),
+		EndAt:       timestamppb.New(now.Add(1 * time.Hour)),
+		Discount: &couponv1.Discount{Kind: &couponv1.Discount_Percentage_{Percentage: &couponv1.Discount_Percentage{
+			BasisPoints: 1000,
+			Cap:         krw(2000),
+		}}},
+		Budget: &couponv1.Budget{Amount: krw(10000)},
+	}))
+	require.NoError(t, err)
+	campId := resp.Msg.Campaign.Id
+	coup := issueTestCoupon(t, srv, campId)
+
+	redeem := func(amount *couponv1.Money) error {
+		_, err := srv.RedeemCoupon(ctx, connect.NewRequest(&couponv1.RedeemCouponRequest{
+			Code:           coup.Code,
+			DiscountAmount: amount,
+		}))
+		return err
+	}
+	assert.EqualError(t, redeem(nil), "discount amount is required for the campaign's budget")
+	assert.EqualError(t, redeem(&couponv1.Money{Currency: "USD", Amount: 3}), "discount amount must be in KRW")
+	assert.EqualError(t, redeem(krw(-1)), "discount amount must not be negative")
+
+	// The discount amount given is spent up to the cap of the percentage
+	require.NoError(t, redeem(krw(5000)))
+	assert.Equal(t, int64(2000), getTestCampaign(t, srv, campId).BudgetStats.Spent.Amount)
+}
+
+func TestBudget_Expired(t *testing.T) {
+	srv := NewCouponIssuanceServer()
+	ctx := context.Background()
+	now := time.Now().UTC()
+	krw := func(amount int64) *couponv1.Money {
+		return &couponv1.Money{Currency: "KRW", Amount: amount}
+	}
+	resp, err := srv.CreateCampaign(ctx, connect.NewRequest(&couponv1.CreateCampaignRequest{
+		CouponLimit:  10,
+		Name:         "Expiring Budget Test Campaign",
+		StartAt:      timestamppb.New(now.Add(-1 * time.Hour)),
+		EndAt:        timestamppb.New(now.Add(24 * time.Hour)),
+		ExpiryPolicy: &couponv1.ExpiryPolicy{Policy: &couponv1.ExpiryPolicy_Ttl{Ttl: durationpb.New(time.Hour)}},
+		Budget:       &couponv1.Budget{Amount: krw(10000), ProjectedCost: krw(5000)},
+	}))
+	require.NoError(t, err)
+	campId := resp.Msg.Campaign.Id
+
+	issueTestCoupon(t, srv, campId)
+	issueTestCoupon(t, srv, campId)
+	_, err = srv.IssueCoupon(ctx, connect.NewRequest(&couponv1.IssueCouponRequest{CampaignId: campId}))
+	assert.Error(t, err)
+	assert.Equal(t, couponv1.CampaignState_CAMPAIGN_STATE_SOLD_OUT, getTestCampaign(t, srv, campId).State)
+
+	// Coupons expiring unused give back what they reserved, although the campaign has no waitlist
+	returnExpiredSlots(now.Add(2 * time.Hour))
+	campMsg := getTestCampaign(t, srv, campId)
+	assert.Equal(t, int64(0), campMsg.BudgetStats.Reserved.Amount)
+	assert.Equal(t, couponv1.CampaignState_CAMPAIGN_STATE_ACTIVE, campMsg.State)
+	assert.Empty(t, campMsg.History)
+	issueTestCoupon(t, srv, campId)
+}
@@ -0,0 +1,163 @@
+package server
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"connectrpc.com/connect"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/jackgihokim/coupon-issuance-system/handlers/campaign"
+	"github.com/jackgihokim/coupon-issuance-system/handlers/coupon"
+	couponv1 "github.com/jackgihokim/coupon-issuance-system/protos/coupon/v1"
+)
+
+// CreateBundle defines a bundle issuing one coupon of each of the campaigns together.
+// Returns the bundle or an error if a campaign is unknown, listed twice or cannot be bundled.
+func (s *CouponIssuanceServer) CreateBundle(
+	ctx context.Context,
+	req *connect.Request[couponv1.CreateBundleRequest],
+) (*connect.Response[couponv1.CreateBundleResponse], error) {
+	b, err := campaign.NewBundle(req.Msg.Name, req.Msg.Description, req.Msg.CampaignIds)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := connect.NewResponse(&couponv1.CreateBundleResponse{
+		Bundle: newBundleMessage(b),
+	})
+	return resp, nil
+}
+
+// IssueBundle issues a coupon of each campaign of the bundle to the user, all or nothing. Every campaign must be
+// able to issue, and the user must be allowed and eligible for each of them, before any slot is taken, and then the
+// slots of all the campaigns are taken at once, so no capacity is consumed unless every coupon is issued.
+// Returns the coupons in the order of the bundle's campaigns, or an error naming the campaign which cannot issue.
+func (s *CouponIssuanceServer) IssueBundle(
+	ctx context.Context,
+	req *connect.Request[couponv1.IssueBundleRequest],
+) (*connect.Response[couponv1.IssueBundleResponse], error) {
+	b, err := campaign.GetBundle(req.Msg.BundleId)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC() // must use UTC for being the same as timestamppb.
+	camps := make([]*campaign.Campaign, len(b.CampaignIds))
+	for i, campId := range b.CampaignIds {
+		camp, err := campaign.GetCampaign(campId)
+		if err == nil {
+			err = s.checkBundled(ctx, camp, req.Msg, now)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("campaign %d: %w", campId, err)
+		}
+		camps[i] = camp
+	}
+
+	slots := make([]coupon.Slot, 0, len(camps))
+	discard := func() {
+		for _, slot := range slots {
+			coupon.Discard(slot.Coupon.Code)
+		}
+	}
+	var opts []coupon.Option
+	if req.Msg.Channel != "" {
+		opts = append(opts, coupon.WithChannel(req.Msg.Channel))
+	}
+	for _, camp := range camps {
+		coup, err := newCampaignCoupon(camp, req.Msg.UserId, now, opts...)
+		if err != nil {
+			discard()
+			return nil, err
+		}
+		slot := coupon.Slot{Coupons: camp.Coupons, Coupon: coup}
+		if camp.Recurrence != nil {
+			start, end, _ := camp.Occurrence(now)
+			slot.Occurrence = &coupon.Occurrence{StartAt: start, EndAt: end}
+		}
+		slots = append(slots, slot)
+	}
+	unhold, err := holdStates(camps, now)
+	if err != nil {
+		discard()
+		return nil, err
+	}
+	err = coupon.AddAll(slots)
+	unhold()
+	if err != nil {
+		discard()
+		var quotaErr *coupon.SliceQuotaError
+		if errors.As(err, &quotaErr) {
+			return nil, newThrottledError(quotaErr)
+		}
+		return nil, err
+	}
+
+	coupons := make([]*couponv1.Coupon, len(slots))
+	for i, slot := range slots {
+		coupons[i] = slot.Coupon
+	}
+	resp := connect.NewResponse(&couponv1.IssueBundleResponse{
+		Coupons: coupons,
+	})
+	return resp, nil
+}
+
+// checkBundled checks that the campaign can issue a coupon of the bundle to the user at now.
+// Returns an error if the campaign cannot issue or the user is not allowed or eligible.
+func (s *CouponIssuanceServer) checkBundled(
+	ctx context.Context, camp *campaign.Campaign, req *couponv1.IssueBundleRequest, now time.Time,
+) error {
+	if err := validateState(camp, now); err != nil {
+		return err
+	}
+	if camp.Recurrence != nil {
+		if _, _, ok := camp.Occurrence(now); !ok {
+			return errors.New("campaign is not started yet")
+		}
+	}
+	if err := camp.CheckUser(req.UserId); err != nil {
+		return err
+	}
+	return s.checkEligibility(ctx, camp, req.UserId, req.UserAttributes)
+}
+
+// holdStates holds the states of the campaigns in the order of their IDs, so bundles holding the same campaigns
+// cannot deadlock, and checks again that each can issue coupons at now.
+// Returns the function releasing the holds, or an error naming the campaign which cannot issue.
+func holdStates(camps []*campaign.Campaign, now time.Time) (func(), error) {
+	sorted := slices.SortedFunc(slices.Values(camps), func(a, b *campaign.Campaign) int {
+		return cmp.Compare(a.Id, b.Id)
+	})
+	releases := make([]func(), 0, len(sorted))
+	release := func() {
+		for _, r := range releases {
+			r()
+		}
+	}
+	for _, camp := range sorted {
+		r, err := holdState(camp, now)
+		if err != nil {
+			release()
+			return nil, fmt.Errorf("campaign %d: %w", camp.Id, err)
+		}
+		releases = append(releases, r)
+	}
+	return release, nil
+}
+
+// newBundleMessage converts the bundle into its protobuf message.
+func newBundleMessage(b *campaign.Bundle) *couponv1.Bundle {
+	return &couponv1.Bundle{
+		Id:          b.Id,
+		Name:        b.Name,
+		Description: b.Description,
+		CampaignIds: b.CampaignIds,
+		CreatedAt:   timestamppb.New(b.CreatedAt),
+	}
+}
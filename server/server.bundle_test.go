@@ -0,0 +1,70 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	couponv1 "github.com/jackgihokim/coupon-issuance-system/protos/coupon/v1"
+)
+
+func TestIssueBundle(t *testing.T) {
+	srv := NewCouponIssuanceServer()
+	ctx := context.Background()
+
+	now := time.Now().UTC()
+	first := createTestCampaign(t, srv, 10)
+	second := createTestCampaign(t, srv, 1)
+	eligResp, err := srv.CreateCampaign(ctx, connect.NewRequest(&couponv1.CreateCampaignRequest{
+		CouponLimit: 10,
+		Name:        "New Users Test Campaign",
+		StartAt:     timestamppb.New(now.Add(-1 * time.Hour)),
+		EndAt:       timestamppb.New(now.Add(1 * time.Hour)),
+		Eligibility: `new_user`,
+	}))
+	require.NoError(t, err)
+	third := eligResp.Msg.Campaign.Id
+
+	bundleResp, err := srv.CreateBundle(ctx, connect.NewRequest(&couponv1.CreateBundleRequest{
+		Name:        "Welcome Pack",
+		CampaignIds: []uint32{first, second, third},
+	}))
+	require.NoError(t, err)
+	bundleId := bundleResp.Msg.Bundle.Id
+
+	issue := func(userId string, newUser bool) (*couponv1.IssueBundleResponse, error) {
+		resp, err := srv.IssueBundle(ctx, connect.NewRequest(&couponv1.IssueBundleRequest{
+			BundleId:       bundleId,
+			UserId:         userId,
+			UserAttributes: &couponv1.UserAttributes{NewUser: newUser},
+		}))
+		if err != nil {
+			return nil, err
+		}
+		return resp.Msg, nil
+	}
+
+	// An ineligible user is issued nothing
+	_, err = issue("old-user", false)
+	assert.EqualError(t, err, fmt.Sprintf("campaign %d: user is not eligible for the campaign", third))
+
+	resp, err := issue("new-user", true)
+	require.NoError(t, err)
+	require.Len(t, resp.Coupons, 3)
+	for i, campId := range []uint32{first, second, third} {
+		assert.Equal(t, campId, resp.Coupons[i].CampaignId)
+		assert.Equal(t, "new-user", resp.Coupons[i].UserId)
+	}
+
+	// The second campaign is sold out, so no slot of the others is taken
+	_, err = issue("another-user", true)
+	assert.EqualError(t, err, fmt.Sprintf("campaign %d: no more coupon", second))
+	assert.Len(t, getTestCampaign(t, srv, first).Coupons, 1)
+	assert.Len(t, getTestCampaign(t, srv, third).Coupons, 1)
+}
@@ -0,0 +1,79 @@
+package server
+
+import (
+	"context"
+	"time"
+
+	"connectrpc.com/connect"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/jackgihokim/coupon-issuance-system/handlers/campaign"
+	couponv1 "github.com/jackgihokim/coupon-issuance-system/protos/coupon/v1"
+)
+
+// PauseCampaign stops issuing coupons of a scheduled, active or sold out campaign until it is resumed.
+// Returns the paused campaign or an error if the campaign cannot be paused.
+func (s *CouponIssuanceServer) PauseCampaign(
+	ctx context.Context,
+	req *connect.Request[couponv1.PauseCampaignRequest],
+) (*connect.Response[couponv1.PauseCampaignResponse], error) {
+	msg, err := transitCampaign(req.Msg.CampaignId, req.Msg.Reason, couponv1.CampaignEventType_CAMPAIGN_EVENT_TYPE_PAUSED,
+		(*campaign.Campaign).Pause)
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(&couponv1.PauseCampaignResponse{Campaign: msg}), nil
+}
+
+// ResumeCampaign resumes a paused campaign or publishes a draft.
+// Returns the resumed campaign or an error if the campaign cannot be resumed.
+func (s *CouponIssuanceServer) ResumeCampaign(
+	ctx context.Context,
+	req *connect.Request[couponv1.ResumeCampaignRequest],
+) (*connect.Response[couponv1.ResumeCampaignResponse], error) {
+	msg, err := transitCampaign(req.Msg.CampaignId, req.Msg.Reason, couponv1.CampaignEventType_CAMPAIGN_EVENT_TYPE_RESUMED,
+		(*campaign.Campaign).Resume)
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(&couponv1.ResumeCampaignResponse{Campaign: msg}), nil
+}
+
+// CloseCampaign stops a campaign for good, after which its issued coupons are no longer valid.
+// Returns the closed campaign or an error if the campaign is already over.
+func (s *CouponIssuanceServer) CloseCampaign(
+	ctx context.Context,
+	req *connect.Request[couponv1.CloseCampaignRequest],
+) (*connect.Response[couponv1.CloseCampaignResponse], error) {
+	msg, err := transitCampaign(req.Msg.CampaignId, req.Msg.Reason, couponv1.CampaignEventType_CAMPAIGN_EVENT_TYPE_CLOSED,
+		(*campaign.Campaign).Close)
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(&couponv1.CloseCampaignResponse{Campaign: msg}), nil
+}
+
+// transitCampaign applies the transition to the campaign and records it in the campaign's history.
+// Returns the campaign message after the transition or an error if the campaign is not found or the transition fails.
+func transitCampaign(
+	campaignId uint32, reason string, eventType couponv1.CampaignEventType,
+	transit func(*campaign.Campaign, time.Time) error,
+) (*couponv1.Campaign, error) {
+	camp, err := campaign.GetCampaign(campaignId)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now().UTC() // must use UTC for being the same as timestamppb.
+	if err := transit(camp, now); err != nil {
+		return nil, err
+	}
+	camp.History.Record(&couponv1.CampaignEvent{
+		Type:       eventType,
+		Reason:     reason,
+		OccurredAt: timestamppb.New(now),
+	})
+
+	msg := newCampaignMessage(camp)
+	msg.History = camp.History.List()
+	return msg, nil
+}
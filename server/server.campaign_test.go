@@ -0,0 +1,140 @@
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	couponv1 "github.com/jackgihokim/coupon-issuance-system/protos/coupon/v1"
+)
+
+func TestCampaignLifecycle(t *testing.T) {
+	srv := NewCouponIssuanceServer()
+	ctx := context.Background()
+
+	now := time.Now().UTC()
+	createResp, err := srv.CreateCampaign(ctx, connect.NewRequest(&couponv1.CreateCampaignRequest{
+		CouponLimit: 10,
+		Name:        "Lifecycle Test Campaign",
+		StartAt:     timestamppb.New(now.Add(-1 * time.Hour)),
+		EndAt:       timestamppb.New(now.Add(1 * time.Hour)),
+		Draft:       true,
+	}))
+	require.NoError(t, err)
+	campId := createResp.Msg.Campaign.Id
+	assert.Equal(t, couponv1.CampaignState_CAMPAIGN_STATE_DRAFT, createResp.Msg.Campaign.State)
+
+	issue := func() error {
+		_, err := srv.IssueCoupon(ctx, connect.NewRequest(&couponv1.IssueCouponRequest{CampaignId: campId}))
+		return err
+	}
+	assert.EqualError(t, issue(), "campaign is not published yet")
+
+	resumeResp, err := srv.ResumeCampaign(ctx, connect.NewRequest(&couponv1.ResumeCampaignRequest{CampaignId: campId}))
+	require.NoError(t, err)
+	assert.Equal(t, couponv1.CampaignState_CAMPAIGN_STATE_ACTIVE, resumeResp.Msg.Campaign.State)
+	coup := issueTestCoupon(t, srv, campId)
+
+	pauseResp, err := srv.PauseCampaign(ctx, connect.NewRequest(&couponv1.PauseCampaignRequest{
+		CampaignId: campId,
+		Reason:     "abuse detected",
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, couponv1.CampaignState_CAMPAIGN_STATE_PAUSED, pauseResp.Msg.Campaign.State)
+	assert.EqualError(t, issue(), "campaign is paused")
+
+	// Coupons issued before the pause stay valid
+	validResp, err := srv.ValidateCoupon(ctx, connect.NewRequest(&couponv1.ValidateCouponRequest{Code: coup.Code}))
+	require.NoError(t, err)
+	assert.True(t, validResp.Msg.Valid)
+
+	closeResp, err := srv.CloseCampaign(ctx, connect.NewRequest(&couponv1.CloseCampaignRequest{
+		CampaignId: campId,
+		Reason:     "abuse confirmed",
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, couponv1.CampaignState_CAMPAIGN_STATE_ENDED, closeResp.Msg.Campaign.State)
+	assert.NotNil(t, closeResp.Msg.Campaign.ClosedAt)
+	assert.EqualError(t, issue(), "campaign is over")
+
+	validResp, err = srv.ValidateCoupon(ctx, connect.NewRequest(&couponv1.ValidateCouponRequest{Code: coup.Code}))
+	require.NoError(t, err)
+	assert.Equal(t, couponv1.ValidationReason_VALIDATION_REASON_CAMPAIGN_ENDED, validResp.Msg.Reason)
+
+	_, err = srv.ResumeCampaign(ctx, connect.NewRequest(&couponv1.ResumeCampaignRequest{CampaignId: campId}))
+	assert.EqualError(t, err, "cannot resume a campaign which is ended")
+
+	history := closeResp.Msg.Campaign.History
+	require.Len(t, history, 3)
+	assert.Equal(t, couponv1.CampaignEventType_CAMPAIGN_EVENT_TYPE_RESUMED, history[0].Type)
+	assert.Equal(t, couponv1.CampaignEventType_CAMPAIGN_EVENT_TYPE_PAUSED, history[1].Type)
+	assert.Equal(t, "abuse detected", history[1].Reason)
+	assert.Equal(t, couponv1.CampaignEventType_CAMPAIGN_EVENT_TYPE_CLOSED, history[2].Type)
+}
+
+func TestIssueCoupon_SoldOut(t *testing.T) {
+	srv := NewCouponIssuanceServer()
+	campId := createTestCampaign(t, srv, 1)
+	issueTestCoupon(t, srv, campId)
+
+	getResp, err := srv.GetCampaign(context.Background(), connect.NewRequest(&couponv1.GetCampaignRequest{CampaignId: campId}))
+	require.NoError(t, err)
+	assert.Equal(t, couponv1.CampaignState_CAMPAIGN_STATE_SOLD_OUT, getResp.Msg.Campaign.State)
+
+	_, err = srv.IssueCoupon(context.Background(), connect.NewRequest(&couponv1.IssueCouponRequest{CampaignId: campId}))
+	assert.EqualError(t, err, "no more coupon")
+}
+
+func TestIssueCoupon_Recurrence(t *testing.T) {
+	srv := NewCouponIssuanceServer()
+	ctx := context.Background()
+
+	now := time.Now().UTC()
+	createResp, err := srv.CreateCampaign(ctx, connect.NewRequest(&couponv1.CreateCampaignRequest{
+		CouponLimit: 2,
+		Name:        "Daily Drop Test Campaign",
+		StartAt:     timestamppb.New(now.Add(-48 * time.Hour)),
+		EndAt:       timestamppb.New(now.Add(72 * time.Hour)),
+		Recurrence:  &couponv1.Recurrence{Schedule: "0 0 * * *", TimeZone: "UTC"},
+	}))
+	require.NoError(t, err)
+	campId := createResp.Msg.Campaign.Id
+
+	issueTestCoupon(t, srv, campId)
+	issueTestCoupon(t, srv, campId)
+	_, err = srv.IssueCoupon(ctx, connect.NewRequest(&couponv1.IssueCouponRequest{CampaignId: campId}))
+	assert.EqualError(t, err, "no more coupon")
+
+	getResp, err := srv.GetCampaign(ctx, connect.NewRequest(&couponv1.GetCampaignRequest{CampaignId: campId}))
+	require.NoError(t, err)
+	camp := getResp.Msg.Campaign
+	assert.Equal(t, couponv1.CampaignState_CAMPAIGN_STATE_SOLD_OUT, camp.State)
+
+	today := now.Truncate(24 * time.Hour)
+	require.NotNil(t, camp.CurrentOccurrence)
+	assert.Equal(t, today, camp.CurrentOccurrence.StartAt.AsTime())
+	assert.Equal(t, today.Add(24*time.Hour), camp.CurrentOccurrence.EndAt.AsTime())
+	assert.Equal(t, uint32(2), camp.CurrentOccurrence.Issued)
+	assert.Equal(t, uint32(0), camp.CurrentOccurrence.Remaining)
+
+	require.NotNil(t, camp.NextOccurrence)
+	assert.Equal(t, today.Add(24*time.Hour), camp.NextOccurrence.StartAt.AsTime())
+	assert.Equal(t, uint32(2), camp.NextOccurrence.Remaining)
+
+	require.Len(t, camp.Occurrences, 1)
+	assert.Equal(t, uint32(2), camp.Occurrences[0].Issued)
+
+	_, err = srv.CreateCampaign(ctx, connect.NewRequest(&couponv1.CreateCampaignRequest{
+		CouponLimit: 2,
+		Name:        "Invalid Recurrence Test Campaign",
+		StartAt:     timestamppb.New(now.Add(-1 * time.Hour)),
+		EndAt:       timestamppb.New(now.Add(1 * time.Hour)),
+		Recurrence:  &couponv1.Recurrence{Schedule: "0 10 * *"},
+	}))
+	assert.Error(t, err)
+}
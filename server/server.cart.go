@@ -0,0 +1,74 @@
+package server
+
+import (
+	"context"
+	"time"
+
+	"connectrpc.com/connect"
+
+	"github.com/jackgihokim/coupon-issuance-system/handlers/coupon"
+	"github.com/jackgihokim/coupon-issuance-system/handlers/discount"
+	couponv1 "github.com/jackgihokim/coupon-issuance-system/protos/coupon/v1"
+)
+
+// EvaluateCart applies the coupon codes to the line items without redeeming them.
+// Each coupon applies only to the line items, channel and payment method its campaign's applicability allows.
+// Coupons are combined as their campaigns' stacking policies allow, picking the combination with the best discount.
+// Returns the discount per line and in total, along with the codes which are rejected or conflict with others.
+func (s *CouponIssuanceServer) EvaluateCart(
+	ctx context.Context,
+	req *connect.Request[couponv1.EvaluateCartRequest],
+) (*connect.Response[couponv1.EvaluateCartResponse], error) {
+	now := time.Now().UTC() // must use UTC for being the same as timestamppb.
+
+	var (
+		candidates []discount.Candidate
+		rejected   []*couponv1.RejectedCoupon
+		seen       = make(map[string]bool)
+	)
+	for _, code := range req.Msg.Codes {
+		if seen[code] {
+			rejected = append(rejected, &couponv1.RejectedCoupon{
+				Code:    code,
+				Reason:  couponv1.RejectionReason_REJECTION_REASON_DUPLICATE_CODE,
+				Message: "code is presented more than once",
+			})
+			continue
+		}
+		seen[code] = true
+
+		coup, camp, reason := validateCoupon(code, now)
+		if err := coupon.ReasonError(reason); err != nil {
+			rejected = append(rejected, &couponv1.RejectedCoupon{
+				Code:             code,
+				Reason:           couponv1.RejectionReason_REJECTION_REASON_INVALID_COUPON,
+				ValidationReason: reason,
+				Message:          err.Error(),
+			})
+			continue
+		}
+		if reason, message := checkApplicability(camp, req.Msg.Channel, req.Msg.PaymentMethod); message != "" {
+			rejected = append(rejected, &couponv1.RejectedCoupon{
+				Code:             code,
+				Reason:           couponv1.RejectionReason_REJECTION_REASON_INVALID_COUPON,
+				ValidationReason: reason,
+				Message:          message,
+			})
+			continue
+		}
+		candidates = append(candidates, discount.Candidate{
+			Code:          code,
+			CampaignId:    coup.CampaignId,
+			Discount:      coup.Discount,
+			Applicability: camp.Applicability,
+			Stacking:      camp.Stacking,
+		})
+	}
+
+	msg, err := discount.Evaluate(req.Msg.Items, req.Msg.Shipping, candidates)
+	if err != nil {
+		return nil, err
+	}
+	msg.Rejected = append(rejected, msg.Rejected...)
+	return connect.NewResponse(msg), nil
+}
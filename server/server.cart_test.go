@@ -0,0 +1,222 @@
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	couponv1 "github.com/jackgihokim/coupon-issuance-system/protos/coupon/v1"
+)
+
+// issueDiscountCoupon creates an active campaign with the discount and issues a coupon of it.
+func issueDiscountCoupon(t *testing.T, srv *CouponIssuanceServer, d *couponv1.Discount) *couponv1.Coupon {
+	return issueStackingCoupon(t, srv, d, nil)
+}
+
+// issueStackingCoupon creates an active campaign with the discount and stacking policy and issues a coupon of it.
+func issueStackingCoupon(
+	t *testing.T, srv *CouponIssuanceServer, d *couponv1.Discount, p *couponv1.StackingPolicy,
+) *couponv1.Coupon {
+	now := time.Now().UTC()
+	resp, err := srv.CreateCampaign(context.Background(), connect.NewRequest(&couponv1.CreateCampaignRequest{
+		CouponLimit: 10,
+		Name:        "Cart Test Campaign",
+		StartAt:     timestamppb.New(now.Add(-1 * time.Hour)),
+		EndAt:       timestamppb.New(now.Add(1 * time.Hour)),
+		Discount:    d,
+		Stacking:    p,
+	}))
+	require.NoError(t, err)
+	return issueTestCoupon(t, srv, resp.Msg.Campaign.Id)
+}
+
+func TestEvaluateCart(t *testing.T) {
+	srv := NewCouponIssuanceServer()
+	krw := func(amount int64) *couponv1.Money { return &couponv1.Money{Currency: "KRW", Amount: amount} }
+
+	percent := issueDiscountCoupon(t, srv, &couponv1.Discount{
+		Kind: &couponv1.Discount_Percentage_{Percentage: &couponv1.Discount_Percentage{BasisPoints: 1000}},
+	})
+	fixed := issueDiscountCoupon(t, srv, &couponv1.Discount{
+		Kind: &couponv1.Discount_FixedAmount_{FixedAmount: &couponv1.Discount_FixedAmount{Amount: krw(1000)}},
+	})
+	redeemed := issueDiscountCoupon(t, srv, &couponv1.Discount{
+		Kind: &couponv1.Discount_FreeShipping_{FreeShipping: &couponv1.Discount_FreeShipping{}},
+	})
+	_, err := srv.RedeemCoupon(context.Background(), connect.NewRequest(&couponv1.RedeemCouponRequest{
+		Code: redeemed.Code,
+	}))
+	require.NoError(t, err)
+
+	resp, err := srv.EvaluateCart(context.Background(), connect.NewRequest(&couponv1.EvaluateCartRequest{
+		Items: []*couponv1.LineItem{
+			{Sku: "A", Category: "shoes", UnitPrice: krw(25000), Quantity: 1},
+			{Sku: "B", Category: "socks", UnitPrice: krw(2500), Quantity: 2},
+		},
+		Codes:    []string{percent.Code, fixed.Code, redeemed.Code, percent.Code, "unknown"},
+		Shipping: krw(3000),
+	}))
+	require.NoError(t, err)
+
+	require.Len(t, resp.Msg.Applied, 2)
+	assert.Equal(t, percent.Code, resp.Msg.Applied[0].Code)
+	assert.Equal(t, int64(3000), resp.Msg.Applied[0].Discount.Amount)
+	assert.Equal(t, fixed.Code, resp.Msg.Applied[1].Code)
+	assert.Equal(t, int64(1000), resp.Msg.Applied[1].Discount.Amount)
+
+	require.Len(t, resp.Msg.Rejected, 3)
+	assert.Equal(t, couponv1.RejectionReason_REJECTION_REASON_INVALID_COUPON, resp.Msg.Rejected[0].Reason)
+	assert.Equal(t, couponv1.ValidationReason_VALIDATION_REASON_REDEEMED, resp.Msg.Rejected[0].ValidationReason)
+	assert.Equal(t, couponv1.RejectionReason_REJECTION_REASON_DUPLICATE_CODE, resp.Msg.Rejected[1].Reason)
+	assert.Equal(t, couponv1.ValidationReason_VALIDATION_REASON_UNKNOWN_CODE, resp.Msg.Rejected[2].ValidationReason)
+
+	assert.Equal(t, int64(30000), resp.Msg.Subtotal.Amount)
+	assert.Equal(t, int64(4000), resp.Msg.DiscountTotal.Amount)
+	assert.Equal(t, int64(29000), resp.Msg.Total.Amount)
+
+	// Evaluation does not redeem the coupons
+	validateResp, err := srv.ValidateCoupon(context.Background(), connect.NewRequest(&couponv1.ValidateCouponRequest{
+		Code: percent.Code,
+	}))
+	require.NoError(t, err)
+	assert.True(t, validateResp.Msg.Valid)
+}
+
+func TestEvaluateCart_InvalidCart(t *testing.T) {
+	srv := NewCouponIssuanceServer()
+
+	_, err := srv.EvaluateCart(context.Background(), connect.NewRequest(&couponv1.EvaluateCartRequest{}))
+	assert.EqualError(t, err, "cart is empty")
+}
+
+func TestApplicability(t *testing.T) {
+	srv := NewCouponIssuanceServer()
+	krw := func(amount int64) *couponv1.Money { return &couponv1.Money{Currency: "KRW", Amount: amount} }
+
+	now := time.Now().UTC()
+	createCampResp, err := srv.CreateCampaign(context.Background(), connect.NewRequest(&couponv1.CreateCampaignRequest{
+		CouponLimit: 10,
+		Name:        "Applicability Test Campaign",
+		StartAt:     timestamppb.New(now.Add(-1 * time.Hour)),
+		EndAt:       timestamppb.New(now.Add(1 * time.Hour)),
+		Discount: &couponv1.Discount{
+			Kind: &couponv1.Discount_Percentage_{Percentage: &couponv1.Discount_Percentage{BasisPoints: 1000}},
+		},
+		Applicability: &couponv1.Applicability{
+			IncludeCategories: []string{"shoes"},
+			ExcludeBrands:     []string{"premium"},
+			Channels:          []couponv1.Channel{couponv1.Channel_CHANNEL_APP},
+			PaymentMethods:    []string{"card"},
+		},
+	}))
+	require.NoError(t, err)
+	coup := issueTestCoupon(t, srv, createCampResp.Msg.Campaign.Id)
+
+	t.Run("ValidateCoupon enforces channel and payment method", func(t *testing.T) {
+		resp, err := srv.ValidateCoupon(context.Background(), connect.NewRequest(&couponv1.ValidateCouponRequest{
+			Code:    coup.Code,
+			Channel: couponv1.Channel_CHANNEL_WEB,
+		}))
+		require.NoError(t, err)
+		assert.False(t, resp.Msg.Valid)
+		assert.Equal(t, couponv1.ValidationReason_VALIDATION_REASON_CHANNEL_NOT_ALLOWED, resp.Msg.Reason)
+		assert.Equal(t, "channel CHANNEL_WEB is not allowed", resp.Msg.Message)
+
+		resp, err = srv.ValidateCoupon(context.Background(), connect.NewRequest(&couponv1.ValidateCouponRequest{
+			Code:          coup.Code,
+			Channel:       couponv1.Channel_CHANNEL_APP,
+			PaymentMethod: "cash",
+		}))
+		require.NoError(t, err)
+		assert.Equal(t, couponv1.ValidationReason_VALIDATION_REASON_PAYMENT_METHOD_NOT_ALLOWED, resp.Msg.Reason)
+
+		resp, err = srv.ValidateCoupon(context.Background(), connect.NewRequest(&couponv1.ValidateCouponRequest{
+			Code:          coup.Code,
+			Channel:       couponv1.Channel_CHANNEL_APP,
+			PaymentMethod: "card",
+		}))
+		require.NoError(t, err)
+		assert.True(t, resp.Msg.Valid)
+	})
+
+	t.Run("EvaluateCart discounts only eligible items", func(t *testing.T) {
+		resp, err := srv.EvaluateCart(context.Background(), connect.NewRequest(&couponv1.EvaluateCartRequest{
+			Items: []*couponv1.LineItem{
+				{Sku: "A", Category: "shoes", Brand: "basic", UnitPrice: krw(20000), Quantity: 1},
+				{Sku: "B", Category: "shoes", Brand: "premium", UnitPrice: krw(50000), Quantity: 1},
+				{Sku: "C", Category: "socks", Brand: "basic", UnitPrice: krw(3000), Quantity: 1},
+			},
+			Codes:   []string{coup.Code},
+			Channel: couponv1.Channel_CHANNEL_APP,
+		}))
+		require.NoError(t, err)
+		require.Len(t, resp.Msg.Applied, 1)
+		assert.Equal(t, int64(2000), resp.Msg.Lines[0].Discount.Amount)
+		assert.Equal(t, int64(0), resp.Msg.Lines[1].Discount.Amount)
+		assert.Equal(t, int64(0), resp.Msg.Lines[2].Discount.Amount)
+	})
+
+	t.Run("EvaluateCart explains the failed rule", func(t *testing.T) {
+		resp, err := srv.EvaluateCart(context.Background(), connect.NewRequest(&couponv1.EvaluateCartRequest{
+			Items: []*couponv1.LineItem{
+				{Sku: "C", Category: "socks", Brand: "basic", UnitPrice: krw(3000), Quantity: 1},
+			},
+			Codes: []string{coup.Code},
+		}))
+		require.NoError(t, err)
+		require.Len(t, resp.Msg.Rejected, 1)
+		assert.Equal(t, couponv1.RejectionReason_REJECTION_REASON_NOT_APPLICABLE, resp.Msg.Rejected[0].Reason)
+		assert.Equal(t, `no item is eligible: C: category "socks" is not included`, resp.Msg.Rejected[0].Message)
+
+		resp, err = srv.EvaluateCart(context.Background(), connect.NewRequest(&couponv1.EvaluateCartRequest{
+			Items: []*couponv1.LineItem{
+				{Sku: "A", Category: "shoes", Brand: "basic", UnitPrice: krw(20000), Quantity: 1},
+			},
+			Codes:   []string{coup.Code},
+			Channel: couponv1.Channel_CHANNEL_STORE,
+		}))
+		require.NoError(t, err)
+		require.Len(t, resp.Msg.Rejected, 1)
+		assert.Equal(t, couponv1.RejectionReason_REJECTION_REASON_INVALID_COUPON, resp.Msg.Rejected[0].Reason)
+		assert.Equal(t, couponv1.ValidationReason_VALIDATION_REASON_CHANNEL_NOT_ALLOWED, resp.Msg.Rejected[0].ValidationReason)
+	})
+}
+
+func TestEvaluateCart_Stacking(t *testing.T) {
+	srv := NewCouponIssuanceServer()
+	fixed := func(amount int64) *couponv1.Discount {
+		return &couponv1.Discount{Kind: &couponv1.Discount_FixedAmount_{
+			FixedAmount: &couponv1.Discount_FixedAmount{Amount: &couponv1.Money{Currency: "KRW", Amount: amount}},
+		}}
+	}
+
+	exclusive := issueStackingCoupon(t, srv, fixed(15000), &couponv1.StackingPolicy{
+		Mode: couponv1.StackingMode_STACKING_MODE_EXCLUSIVE,
+	})
+	member1 := issueStackingCoupon(t, srv, fixed(10000), &couponv1.StackingPolicy{
+		Mode: couponv1.StackingMode_STACKING_MODE_STACKABLE_WITH_GROUP, Group: "members",
+	})
+	member2 := issueStackingCoupon(t, srv, fixed(10000), &couponv1.StackingPolicy{
+		Mode: couponv1.StackingMode_STACKING_MODE_STACKABLE_WITH_GROUP, Group: "members",
+	})
+
+	resp, err := srv.EvaluateCart(context.Background(), connect.NewRequest(&couponv1.EvaluateCartRequest{
+		Items: []*couponv1.LineItem{
+			{Sku: "A", Category: "shoes", UnitPrice: &couponv1.Money{Currency: "KRW", Amount: 100000}, Quantity: 1},
+		},
+		Codes: []string{exclusive.Code, member1.Code, member2.Code},
+	}))
+	require.NoError(t, err)
+
+	require.Len(t, resp.Msg.Applied, 2)
+	assert.ElementsMatch(t, []string{member1.Code, member2.Code}, []string{resp.Msg.Applied[0].Code, resp.Msg.Applied[1].Code})
+	assert.Equal(t, int64(20000), resp.Msg.DiscountTotal.Amount)
+
+	require.Len(t, resp.Msg.Conflicts, 1)
+	assert.Equal(t, exclusive.Code, resp.Msg.Conflicts[0].Code)
+}
@@ -0,0 +1,226 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"connectrpc.com/connect"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/jackgihokim/coupon-issuance-system/handlers/campaign"
+	"github.com/jackgihokim/coupon-issuance-system/handlers/coupon"
+	"github.com/jackgihokim/coupon-issuance-system/handlers/discount"
+	couponv1 "github.com/jackgihokim/coupon-issuance-system/protos/coupon/v1"
+)
+
+// ValidateCoupon checks whether a coupon code can be used now without redeeming it.
+// The channel and payment method are checked against the campaign's applicability if they are given.
+// Returns the coupon with its campaign and status, or the reason why the code is not valid.
+func (s *CouponIssuanceServer) ValidateCoupon(
+	ctx context.Context,
+	req *connect.Request[couponv1.ValidateCouponRequest],
+) (*connect.Response[couponv1.ValidateCouponResponse], error) {
+	now := time.Now().UTC() // must use UTC for being the same as timestamppb.
+	coup, camp, reason := validateCoupon(req.Msg.Code, now)
+
+	var message string
+	if err := coupon.ReasonError(reason); err != nil {
+		message = err.Error()
+	} else {
+		reason, message = checkApplicability(camp, req.Msg.Channel, req.Msg.PaymentMethod)
+	}
+
+	msg := &couponv1.ValidateCouponResponse{
+		Valid:   reason == couponv1.ValidationReason_VALIDATION_REASON_UNSPECIFIED,
+		Reason:  reason,
+		Message: message,
+	}
+	if coup != nil {
+		msg.Coupon = coup
+		msg.Status = coup.Status
+		msg.ExpireAt = coup.ExpireAt
+	}
+	if camp != nil {
+		msg.Campaign = newCampaignMessage(camp)
+	}
+	return connect.NewResponse(msg), nil
+}
+
+// RedeemCoupon redeems a valid coupon code, so it cannot be used again. A coupon held by a reservation is
+// redeemed by committing the reservation instead.
+// What the coupon discounted is spent from the campaign's budget if it has one.
+// Returns the redeemed coupon or an error describing why the code is not valid.
+func (s *CouponIssuanceServer) RedeemCoupon(
+	ctx context.Context,
+	req *connect.Request[couponv1.RedeemCouponRequest],
+) (*connect.Response[couponv1.RedeemCouponResponse], error) {
+	now := time.Now().UTC() // must use UTC for being the same as timestamppb.
+	found, camp, reason := validateCoupon(req.Msg.Code, now)
+	if err := coupon.ReasonError(reason); err != nil {
+		return nil, err
+	}
+	cost, err := redemptionCost(camp, found, req.Msg.DiscountAmount)
+	if err != nil {
+		return nil, err
+	}
+
+	coup, err := coupon.Redeem(req.Msg.Code, req.Msg.OrderId, now)
+	if err != nil {
+		return nil, err
+	}
+	spendBudget(camp, coup, coup.RedemptionId, cost, now)
+	camp.Coupons.RecordRedemption(coup)
+	confirmReferral(coup, now)
+
+	resp := connect.NewResponse(&couponv1.RedeemCouponResponse{
+		Coupon: coup,
+	})
+	return resp, nil
+}
+
+// RevokeCoupon cancels an issued coupon for the given reason, so it fails validation and redemption.
+// The slot is given back to the campaign if requested, and both are recorded in the campaign's history.
+// A campaign with a waitlist issues the slot to the next waitlisted user instead.
+// Returns the revoked coupon or an error if the coupon cannot be revoked.
+func (s *CouponIssuanceServer) RevokeCoupon(
+	ctx context.Context,
+	req *connect.Request[couponv1.RevokeCouponRequest],
+) (*connect.Response[couponv1.RevokeCouponResponse], error) {
+	if req.Msg.Reason == "" {
+		return nil, errors.New("reason is required")
+	}
+	found, err := coupon.Lookup(req.Msg.Code)
+	if err != nil {
+		return nil, err
+	}
+	camp, err := campaign.GetCampaign(found.CampaignId)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC() // must use UTC for being the same as timestamppb.
+	coup, err := coupon.Revoke(req.Msg.Code, req.Msg.Reason, now)
+	if err != nil {
+		return nil, err
+	}
+	camp.History.Record(&couponv1.CampaignEvent{
+		Type:       couponv1.CampaignEventType_CAMPAIGN_EVENT_TYPE_COUPON_REVOKED,
+		Code:       coup.Code,
+		Reason:     req.Msg.Reason,
+		OccurredAt: timestamppb.New(now),
+	})
+	camp.Coupons.ReleaseBudget(coup.Code)
+
+	if req.Msg.ReturnToPool {
+		returnSlot(camp, coup, req.Msg.Reason, now)
+	}
+
+	resp := connect.NewResponse(&couponv1.RevokeCouponResponse{
+		Coupon: coup,
+	})
+	return resp, nil
+}
+
+// returnSlot gives the slot of the coupon back to the campaign, once, whether the coupon was revoked back to the
+// pool, expired unused or reversed without being restored. A campaign with a waitlist which can issue coupons
+// issues the slot to the next waitlisted user at once, so nobody else can take it first, and the user finds the
+// coupon in their waitlist status. The slot goes back to the pool if nobody is waiting or the coupon could not be
+// issued. Both are recorded in the campaign's history.
+func returnSlot(camp *campaign.Campaign, coup *couponv1.Coupon, reason string, now time.Time) {
+	if camp.Waitlist == nil {
+		if camp.Coupons.Release(coup) {
+			recordSlotReturned(camp, coup, reason, now)
+		}
+		return
+	}
+
+	unhold := camp.HoldState()
+	defer unhold()
+	switch camp.State(now) {
+	case couponv1.CampaignState_CAMPAIGN_STATE_ACTIVE, couponv1.CampaignState_CAMPAIGN_STATE_SOLD_OUT:
+	default:
+		if camp.Coupons.Release(coup) {
+			recordSlotReturned(camp, coup, reason, now)
+		}
+		return
+	}
+
+	released := false
+	var issued *couponv1.Coupon
+	userId, ok, _ := camp.Waitlist.IssueNext(func(userId string) (string, error) {
+		next, err := newCampaignCoupon(camp, userId, now)
+		if err != nil {
+			return "", err
+		}
+		released, err = camp.Coupons.Reissue(coup, next)
+		if err != nil {
+			coupon.Discard(next.Code)
+			return "", err
+		}
+		issued = next
+		return next.Code, nil
+	})
+	if !ok {
+		// Nobody is waiting, or the slot stays given back if the coupon could not be issued in it
+		released = camp.Coupons.Release(coup) || released
+	}
+	if released {
+		recordSlotReturned(camp, coup, reason, now)
+	}
+	if ok {
+		camp.History.Record(&couponv1.CampaignEvent{
+			Type:       couponv1.CampaignEventType_CAMPAIGN_EVENT_TYPE_WAITLIST_ISSUED,
+			Code:       issued.Code,
+			OccurredAt: timestamppb.New(now),
+			UserId:     userId,
+		})
+	}
+}
+
+// recordSlotReturned records in the campaign's history that the slot of the coupon was given back.
+func recordSlotReturned(camp *campaign.Campaign, coup *couponv1.Coupon, reason string, now time.Time) {
+	camp.History.Record(&couponv1.CampaignEvent{
+		Type:       couponv1.CampaignEventType_CAMPAIGN_EVENT_TYPE_SLOT_RETURNED,
+		Code:       coup.Code,
+		Reason:     reason,
+		OccurredAt: timestamppb.New(now),
+	})
+}
+
+// validateCoupon looks up the coupon code in the global code index and checks it together with its campaign at now.
+// Returns whatever could be found along with the reason why the code is not valid, or VALIDATION_REASON_UNSPECIFIED.
+func validateCoupon(code string, now time.Time) (*couponv1.Coupon, *campaign.Campaign, couponv1.ValidationReason) {
+	coup, err := coupon.Lookup(code)
+	if err != nil {
+		return nil, nil, couponv1.ValidationReason_VALIDATION_REASON_UNKNOWN_CODE
+	}
+	camp, err := campaign.GetCampaign(coup.CampaignId)
+	if err != nil {
+		return coup, nil, couponv1.ValidationReason_VALIDATION_REASON_UNKNOWN_CODE
+	}
+
+	if reason := coupon.Reason(coup, now); reason != couponv1.ValidationReason_VALIDATION_REASON_UNSPECIFIED {
+		return coup, camp, reason
+	}
+	// Coupons outlive a campaign which ends at EndAt until their own expiration, which the expiry policy may set
+	// later. A campaign closed early takes them with it, except a lottery's, which issues its coupons once it is over.
+	if camp.Lottery == nil && camp.Ended(now) && !camp.ClosedAt().IsZero() {
+		return coup, camp, couponv1.ValidationReason_VALIDATION_REASON_CAMPAIGN_ENDED
+	}
+	return coup, camp, couponv1.ValidationReason_VALIDATION_REASON_UNSPECIFIED
+}
+
+// checkApplicability checks the sales channel and payment method against the campaign's applicability.
+// Returns the reason with a message describing the failed rule, or VALIDATION_REASON_UNSPECIFIED.
+func checkApplicability(
+	camp *campaign.Campaign, channel couponv1.Channel, paymentMethod string,
+) (couponv1.ValidationReason, string) {
+	if message := discount.CheckChannel(camp.Applicability, channel); message != "" {
+		return couponv1.ValidationReason_VALIDATION_REASON_CHANNEL_NOT_ALLOWED, message
+	}
+	if message := discount.CheckPaymentMethod(camp.Applicability, paymentMethod); message != "" {
+		return couponv1.ValidationReason_VALIDATION_REASON_PAYMENT_METHOD_NOT_ALLOWED, message
+	}
+	return couponv1.ValidationReason_VALIDATION_REASON_UNSPECIFIED, ""
+}
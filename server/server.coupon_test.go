@@ -0,0 +1,181 @@
+package server
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	couponv1 "github.com/jackgihokim/coupon-issuance-system/protos/coupon/v1"
+)
+
+// createTestCampaign creates an active campaign that ends in an hour and returns its ID.
+func createTestCampaign(t *testing.T, srv *CouponIssuanceServer, limit uint32) uint32 {
+	now := time.Now().UTC()
+	resp, err := srv.CreateCampaign(context.Background(), connect.NewRequest(&couponv1.CreateCampaignRequest{
+		CouponLimit: limit,
+		Name:        "Test Campaign",
+		StartAt:     timestamppb.New(now.Add(-1 * time.Hour)),
+		EndAt:       timestamppb.New(now.Add(1 * time.Hour)),
+	}))
+	require.NoError(t, err)
+	return resp.Msg.Campaign.Id
+}
+
+// issueTestCoupon issues a coupon of the campaign and returns it.
+func issueTestCoupon(t *testing.T, srv *CouponIssuanceServer, campId uint32) *couponv1.Coupon {
+	resp, err := srv.IssueCoupon(context.Background(), connect.NewRequest(&couponv1.IssueCouponRequest{
+		CampaignId: campId,
+	}))
+	require.NoError(t, err)
+	return resp.Msg.Coupon
+}
+
+func TestValidateCoupon(t *testing.T) {
+	srv := NewCouponIssuanceServer()
+	campId := createTestCampaign(t, srv, 10)
+	coup := issueTestCoupon(t, srv, campId)
+
+	t.Run("Valid code without campaign ID", func(t *testing.T) {
+		resp, err := srv.ValidateCoupon(context.Background(), connect.NewRequest(&couponv1.ValidateCouponRequest{
+			Code: coup.Code,
+		}))
+		require.NoError(t, err)
+		assert.True(t, resp.Msg.Valid)
+		assert.Equal(t, couponv1.ValidationReason_VALIDATION_REASON_UNSPECIFIED, resp.Msg.Reason)
+		assert.Equal(t, couponv1.CouponStatus_COUPON_STATUS_ACTIVE, resp.Msg.Status)
+		assert.Equal(t, campId, resp.Msg.Campaign.Id)
+		assert.Empty(t, resp.Msg.Campaign.Coupons, "Issued coupons should be omitted")
+		assert.True(t, coup.ExpireAt.AsTime().Equal(resp.Msg.ExpireAt.AsTime()))
+	})
+
+	t.Run("Unknown code", func(t *testing.T) {
+		resp, err := srv.ValidateCoupon(context.Background(), connect.NewRequest(&couponv1.ValidateCouponRequest{
+			Code: "unknown",
+		}))
+		require.NoError(t, err)
+		assert.False(t, resp.Msg.Valid)
+		assert.Equal(t, couponv1.ValidationReason_VALIDATION_REASON_UNKNOWN_CODE, resp.Msg.Reason)
+		assert.Nil(t, resp.Msg.Coupon)
+	})
+
+	t.Run("Redeemed code", func(t *testing.T) {
+		_, err := srv.RedeemCoupon(context.Background(), connect.NewRequest(&couponv1.RedeemCouponRequest{
+			Code: coup.Code,
+		}))
+		require.NoError(t, err)
+
+		resp, err := srv.ValidateCoupon(context.Background(), connect.NewRequest(&couponv1.ValidateCouponRequest{
+			Code: coup.Code,
+		}))
+		require.NoError(t, err)
+		assert.False(t, resp.Msg.Valid)
+		assert.Equal(t, couponv1.ValidationReason_VALIDATION_REASON_REDEEMED, resp.Msg.Reason)
+		assert.Equal(t, couponv1.CouponStatus_COUPON_STATUS_REDEEMED, resp.Msg.Status)
+	})
+}
+
+func TestRedeemCoupon(t *testing.T) {
+	srv := NewCouponIssuanceServer()
+	campId := createTestCampaign(t, srv, 10)
+	coup := issueTestCoupon(t, srv, campId)
+
+	resp, err := srv.RedeemCoupon(context.Background(), connect.NewRequest(&couponv1.RedeemCouponRequest{
+		Code: coup.Code,
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, couponv1.CouponStatus_COUPON_STATUS_REDEEMED, resp.Msg.Coupon.Status)
+	assert.NotNil(t, resp.Msg.Coupon.RedeemedAt)
+
+	_, err = srv.RedeemCoupon(context.Background(), connect.NewRequest(&couponv1.RedeemCouponRequest{
+		Code: coup.Code,
+	}))
+	assert.EqualError(t, err, "coupon is already redeemed")
+}
+
+func TestRedeemCoupon_ConcurrentGetCampaign(t *testing.T) {
+	srv := NewCouponIssuanceServer()
+	campId := createTestCampaign(t, srv, 10)
+	coup := issueTestCoupon(t, srv, campId)
+
+	// The campaign's coupons are snapshots, so marshaling them does not race the redemption
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		_, err := srv.RedeemCoupon(context.Background(), connect.NewRequest(&couponv1.RedeemCouponRequest{Code: coup.Code}))
+		assert.NoError(t, err)
+	}()
+	go func() {
+		defer wg.Done()
+		resp, err := srv.GetCampaign(context.Background(), connect.NewRequest(&couponv1.GetCampaignRequest{CampaignId: campId}))
+		if assert.NoError(t, err) {
+			_, err = proto.Marshal(resp.Msg)
+			assert.NoError(t, err)
+		}
+	}()
+	wg.Wait()
+
+	coupons := getTestCampaign(t, srv, campId).Coupons
+	require.Len(t, coupons, 1)
+	assert.Equal(t, couponv1.CouponStatus_COUPON_STATUS_REDEEMED, coupons[0].Status)
+}
+
+func TestRevokeCoupon(t *testing.T) {
+	srv := NewCouponIssuanceServer()
+	campId := createTestCampaign(t, srv, 1)
+	coup := issueTestCoupon(t, srv, campId)
+
+	t.Run("Reason is required", func(t *testing.T) {
+		_, err := srv.RevokeCoupon(context.Background(), connect.NewRequest(&couponv1.RevokeCouponRequest{
+			Code: coup.Code,
+		}))
+		assert.EqualError(t, err, "reason is required")
+	})
+
+	t.Run("Revoke and return to pool", func(t *testing.T) {
+		resp, err := srv.RevokeCoupon(context.Background(), connect.NewRequest(&couponv1.RevokeCouponRequest{
+			Code:         coup.Code,
+			Reason:       "issued by mistake",
+			ReturnToPool: true,
+		}))
+		require.NoError(t, err)
+		assert.Equal(t, couponv1.CouponStatus_COUPON_STATUS_REVOKED, resp.Msg.Coupon.Status)
+		assert.Equal(t, "issued by mistake", resp.Msg.Coupon.RevokeReason)
+
+		// The campaign was sold out, but the returned slot can be issued again
+		issueTestCoupon(t, srv, campId)
+	})
+
+	t.Run("Revoked code fails validation and redemption", func(t *testing.T) {
+		validateResp, err := srv.ValidateCoupon(context.Background(), connect.NewRequest(&couponv1.ValidateCouponRequest{
+			Code: coup.Code,
+		}))
+		require.NoError(t, err)
+		assert.False(t, validateResp.Msg.Valid)
+		assert.Equal(t, couponv1.ValidationReason_VALIDATION_REASON_REVOKED, validateResp.Msg.Reason)
+
+		_, err = srv.RedeemCoupon(context.Background(), connect.NewRequest(&couponv1.RedeemCouponRequest{
+			Code: coup.Code,
+		}))
+		assert.EqualError(t, err, "coupon is revoked")
+	})
+
+	t.Run("Revocation is recorded in the campaign's history", func(t *testing.T) {
+		resp, err := srv.GetCampaign(context.Background(), connect.NewRequest(&couponv1.GetCampaignRequest{
+			CampaignId: campId,
+		}))
+		require.NoError(t, err)
+		require.Len(t, resp.Msg.Campaign.History, 2)
+		assert.Equal(t, couponv1.CampaignEventType_CAMPAIGN_EVENT_TYPE_COUPON_REVOKED, resp.Msg.Campaign.History[0].Type)
+		assert.Equal(t, coup.Code, resp.Msg.Campaign.History[0].Code)
+		assert.Equal(t, "issued by mistake", resp.Msg.Campaign.History[0].Reason)
+		assert.Equal(t, couponv1.CampaignEventType_CAMPAIGN_EVENT_TYPE_SLOT_RETURNED, resp.Msg.Campaign.History[1].Type)
+	})
+}
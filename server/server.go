@@ -2,9 +2,12 @@ package server
 
 import (
 	"context"
+	"crypto/rand"
 	"errors"
 	"log"
+	"math"
 	"net/http"
+	"strconv"
 	"time"
 
 	"connectrpc.com/connect"
@@ -14,21 +17,63 @@ import (
 
 	"github.com/jackgihokim/coupon-issuance-system/handlers/campaign"
 	"github.com/jackgihokim/coupon-issuance-system/handlers/coupon"
+	"github.com/jackgihokim/coupon-issuance-system/handlers/eligibility"
+	"github.com/jackgihokim/coupon-issuance-system/handlers/lottery"
+	"github.com/jackgihokim/coupon-issuance-system/handlers/waitingroom"
 	couponv1 "github.com/jackgihokim/coupon-issuance-system/protos/coupon/v1"
 	"github.com/jackgihokim/coupon-issuance-system/protos/coupon/v1/couponv1connect"
 )
 
-type CouponIssuanceServer struct{}
+type CouponIssuanceServer struct {
+	// attributes looks up the user attributes for eligibility rules when the issue request does not carry them.
+	attributes eligibility.AttributeProvider
+	// signer signs and verifies the admission tokens of waiting rooms.
+	signer *waitingroom.Signer
+}
 
 const httpAddr = "localhost:8080"
 
+// Option configures optional dependencies of the server.
+type Option func(*CouponIssuanceServer)
+
+// WithAttributeProvider sets the provider which looks up the user attributes for eligibility rules.
+func WithAttributeProvider(p eligibility.AttributeProvider) Option {
+	return func(s *CouponIssuanceServer) {
+		s.attributes = p
+	}
+}
+
+// WithAdmissionSigner sets the signer of waiting room admission tokens. Servers behind a load balancer
+// must share the signer's key to accept each other's tokens.
+func WithAdmissionSigner(signer *waitingroom.Signer) Option {
+	return func(s *CouponIssuanceServer) {
+		s.signer = signer
+	}
+}
+
 // NewCouponIssuanceServer initializes and returns a new instance of CouponIssuanceServer.
-func NewCouponIssuanceServer() *CouponIssuanceServer {
-	return &CouponIssuanceServer{}
+// Admission tokens are signed with a random key unless a signer is given.
+func NewCouponIssuanceServer(opts ...Option) *CouponIssuanceServer {
+	s := &CouponIssuanceServer{}
+	for _, opt := range opts {
+		opt(s)
+	}
+	if s.signer == nil {
+		key := make([]byte, 32)
+		if _, err := rand.Read(key); err != nil {
+			log.Fatalln(err)
+		}
+		s.signer, _ = waitingroom.NewSigner(key)
+	}
+	return s
 }
 
 // Start initializes the HTTP server, sets up routes for the CouponIssuanceService, and begins listening for requests.
+// It also starts the sweeper which releases expired coupon reservations and the slots of coupons expiring unused,
+// and draws the lotteries of the campaigns which ended.
 func (s *CouponIssuanceServer) Start() {
+	go sweep(sweepInterval)
+
 	mux := http.NewServeMux()
 	path, handler := couponv1connect.NewCouponIssuanceServiceHandler(s)
 	mux.Handle(path, handler)
@@ -44,29 +89,92 @@ func (s *CouponIssuanceServer) CreateCampaign(
 	ctx context.Context,
 	req *connect.Request[couponv1.CreateCampaignRequest],
 ) (*connect.Response[couponv1.CreateCampaignResponse], error) {
+	var opts []campaign.Option
+	if req.Msg.ExpiryPolicy != nil {
+		opts = append(opts, campaign.WithExpiryPolicy(req.Msg.ExpiryPolicy))
+	}
+	if req.Msg.Discount != nil {
+		opts = append(opts, campaign.WithDiscount(req.Msg.Discount))
+	}
+	if req.Msg.StoredValue != nil {
+		opts = append(opts, campaign.WithStoredValue(req.Msg.StoredValue))
+	}
+	if req.Msg.RestorePolicy != couponv1.RestorePolicy_RESTORE_POLICY_UNSPECIFIED {
+		opts = append(opts, campaign.WithRestorePolicy(req.Msg.RestorePolicy))
+	}
+	if req.Msg.Transfer != nil {
+		opts = append(opts, campaign.WithTransfer(req.Msg.Transfer))
+	}
+	if req.Msg.Referral != nil {
+		opts = append(opts, campaign.WithReferral(req.Msg.Referral))
+	}
+	if len(req.Msg.Tiers) > 0 {
+		opts = append(opts, campaign.WithTiers(req.Msg.Tiers))
+	}
+	if len(req.Msg.Variants) > 0 {
+		opts = append(opts, campaign.WithVariants(req.Msg.Variants))
+	}
+	if req.Msg.Budget != nil {
+		opts = append(opts, campaign.WithBudget(req.Msg.Budget))
+	}
+	if req.Msg.Allocation != nil {
+		opts = append(opts, campaign.WithAllocation(req.Msg.Allocation))
+	}
+	if req.Msg.Applicability != nil {
+		opts = append(opts, campaign.WithApplicability(req.Msg.Applicability))
+	}
+	if req.Msg.Stacking != nil {
+		opts = append(opts, campaign.WithStacking(req.Msg.Stacking))
+	}
+	if req.Msg.Eligibility != "" {
+		rule, err := eligibility.Parse(req.Msg.Eligibility)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, campaign.WithEligibility(rule))
+	}
+	if req.Msg.Recurrence != nil {
+		opts = append(opts, campaign.WithRecurrence(req.Msg.Recurrence))
+	}
+	if req.Msg.Throttle != nil {
+		opts = append(opts, campaign.WithThrottle(req.Msg.Throttle))
+	}
+	if req.Msg.WaitingRoom != nil {
+		opts = append(opts, campaign.WithWaitingRoom(req.Msg.WaitingRoom))
+	}
+	if req.Msg.Lottery {
+		l, err := lottery.New()
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, campaign.WithLottery(l))
+	}
+	if req.Msg.Waitlist {
+		opts = append(opts, campaign.WithWaitlist())
+	}
+	if req.Msg.Draft {
+		opts = append(opts, campaign.WithDraft())
+	}
+
 	camp, err := campaign.NewCampaign(
 		req.Msg.CouponLimit, req.Msg.Name, req.Msg.Description, req.Msg.StartAt.AsTime(), req.Msg.EndAt.AsTime(),
+		opts...,
 	)
 	if err != nil {
 		return nil, err
 	}
 
+	msg := newCampaignMessage(camp)
+	msg.Coupons = camp.Coupons.List()
+	msg.History = camp.History.List()
 	resp := connect.NewResponse(&couponv1.CreateCampaignResponse{
-		Campaign: &couponv1.Campaign{
-			Id:          camp.Id,
-			CouponLimit: camp.CouponLimit,
-			Name:        camp.Name,
-			Description: camp.Description,
-			CreatedAt:   timestamppb.New(camp.CreatedAt),
-			StartAt:     timestamppb.New(camp.StartAt),
-			EndAt:       timestamppb.New(camp.EndAt),
-			Coupons:     camp.Coupons.List(),
-		},
+		Campaign: msg,
 	})
 	return resp, nil
 }
 
 // GetCampaign retrieves the details of a specific campaign using the provided campaign ID.
+// The lottery of a lottery campaign is drawn first if it is due.
 // Returns a response containing the campaign details or an error if the campaign is not found.
 func (s *CouponIssuanceServer) GetCampaign(
 	ctx context.Context,
@@ -76,59 +184,340 @@ func (s *CouponIssuanceServer) GetCampaign(
 	if err != nil {
 		return nil, err
 	}
+	err = drawLottery(camp, time.Now().UTC())
+	if err != nil {
+		return nil, err
+	}
 
+	msg := newCampaignMessage(camp)
+	msg.Coupons = camp.Coupons.List()
+	msg.History = camp.History.List()
 	resp := connect.NewResponse(&couponv1.GetCampaignResponse{
-		Campaign: &couponv1.Campaign{
-			Id:          camp.Id,
-			CouponLimit: camp.CouponLimit,
-			Name:        camp.Name,
-			Description: camp.Description,
-			CreatedAt:   timestamppb.New(camp.CreatedAt),
-			StartAt:     timestamppb.New(camp.StartAt),
-			EndAt:       timestamppb.New(camp.EndAt),
-			Coupons:     camp.Coupons.List(),
-		},
+		Campaign: msg,
 	})
 	return resp, nil
 }
 
-// IssueCoupon handles the issuance of a new coupon for a specific campaign, validating campaign status and period.
+// IssueCoupon handles the issuance of a new coupon for a specific campaign, validating campaign state and period.
+// A lottery campaign issues its coupons only by its draw, and a campaign with a waiting room requires
+// the admission token of an admitted ticket, which issues a single coupon. A referral campaign requires
+// a referral code the user can be referred with.
+// Users who are not allowed by the campaign's user lists or don't satisfy its eligibility rule are rejected
+// before a slot is taken.
+// A recurring campaign issues coupons up to its limit in each occurrence, and a throttled one up to the quota
+// of each time slice. A throttled request fails with resource exhausted, telling when the next slice opens.
+// An allocated campaign issues the coupon out of the allocation of the request's channel.
+// The coupon expires as the campaign's expiry policy decides at issue time.
 // Returns a response containing the issued coupon or an error if the operation fails.
 func (s *CouponIssuanceServer) IssueCoupon(
 	ctx context.Context,
 	req *connect.Request[couponv1.IssueCouponRequest],
 ) (*connect.Response[couponv1.IssueCouponResponse], error) {
 	camp, err := campaign.GetCampaign(req.Msg.CampaignId)
+	if err != nil {
+		return nil, err
+	}
+	if camp.Lottery != nil {
+		return nil, errors.New("coupons of a lottery campaign are issued by its draw")
+	}
 	now := time.Now().UTC() // must use UTC for being the same as timestamppb.
-	err = validatePeriod(camp, now)
+	err = validateState(camp, now)
 	if err != nil {
 		return nil, err
 	}
-
-	coup, err := coupon.NewCoupon(camp.EndAt.UTC(), now) // must use UTC for being the same as timestamppb.
+	release, err := s.admit(camp, req.Msg, now)
+	if err != nil {
+		return nil, err
+	}
+	issued := false
+	defer func() {
+		if !issued {
+			release()
+		}
+	}()
+	err = camp.CheckUser(req.Msg.UserId)
+	if err != nil {
+		return nil, err
+	}
+	err = s.checkEligibility(ctx, camp, req.Msg.UserId, req.Msg.UserAttributes)
 	if err != nil {
 		return nil, err
 	}
 
-	err = camp.Coupons.Add(coup)
+	var opts []coupon.Option
+	if req.Msg.Channel != "" {
+		opts = append(opts, coupon.WithChannel(req.Msg.Channel))
+	}
+	unhold, err := holdState(camp, now)
+	if err != nil {
+		return nil, err
+	}
+	defer unhold()
+	var coup *couponv1.Coupon
+	if camp.Referrals != nil {
+		opts = append(opts, coupon.WithReferralCode(req.Msg.ReferralCode))
+		err = camp.Referrals.Refer(req.Msg.ReferralCode, req.Msg.UserId, func() (string, error) {
+			var err error
+			coup, err = issueCampaignCoupon(camp, req.Msg.UserId, now, opts...)
+			if err != nil {
+				return "", err
+			}
+			return coup.Code, nil
+		})
+	} else {
+		coup, err = issueCampaignCoupon(camp, req.Msg.UserId, now, opts...)
+	}
 	if err != nil {
 		return nil, err
 	}
 
+	issued = true
 	resp := connect.NewResponse(&couponv1.IssueCouponResponse{
 		Coupon: coup,
 	})
 	return resp, nil
 }
 
-// validatePeriod checks if the given campaign is currently active based on its start and end times relative to now.
-// Returns an error if the campaign has not started or has already ended.
-func validatePeriod(camp *campaign.Campaign, now time.Time) error {
-	if camp.StartAt.After(now) {
-		return errors.New("campaign is not started yet")
+// issueCampaignCoupon issues a new coupon of the campaign to the user at now, taking a slot of the campaign,
+// or of its occurrence open at now if it recurs.
+// Returns the coupon or an error if no slot is left, which is a resource exhausted error if the campaign is throttled.
+func issueCampaignCoupon(camp *campaign.Campaign, userId string, now time.Time, opts ...coupon.Option) (*couponv1.Coupon, error) {
+	coup, err := newCampaignCoupon(camp, userId, now, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	if camp.Recurrence != nil {
+		err = addInOccurrence(camp, now, coup)
+	} else {
+		err = camp.Coupons.Add(coup)
 	}
-	if camp.EndAt.Before(now) {
+	if err != nil {
+		coupon.Discard(coup.Code)
+		var quotaErr *coupon.SliceQuotaError
+		if errors.As(err, &quotaErr) {
+			return nil, newThrottledError(quotaErr)
+		}
+		return nil, err
+	}
+	return coup, nil
+}
+
+// holdState holds the state of the campaign and checks again that it can issue coupons at now, so it cannot be
+// paused or closed before the coupons are issued. Returns the function releasing the hold, or an error if the
+// campaign cannot issue, in which case nothing is held.
+func holdState(camp *campaign.Campaign, now time.Time) (func(), error) {
+	release := camp.HoldState()
+	if err := validateState(camp, now); err != nil {
+		release()
+		return nil, err
+	}
+	return release, nil
+}
+
+// validateState checks if the given campaign can issue coupons at now, which it can only while it is active.
+// Returns an error describing why the campaign cannot issue coupons.
+func validateState(camp *campaign.Campaign, now time.Time) error {
+	switch camp.State(now) {
+	case couponv1.CampaignState_CAMPAIGN_STATE_DRAFT:
+		return errors.New("campaign is not published yet")
+	case couponv1.CampaignState_CAMPAIGN_STATE_SCHEDULED:
+		return errors.New("campaign is not started yet")
+	case couponv1.CampaignState_CAMPAIGN_STATE_PAUSED:
+		return errors.New("campaign is paused")
+	case couponv1.CampaignState_CAMPAIGN_STATE_SOLD_OUT:
+		return errors.New("no more coupon")
+	case couponv1.CampaignState_CAMPAIGN_STATE_ENDED:
 		return errors.New("campaign is over")
+	case couponv1.CampaignState_CAMPAIGN_STATE_CANCELLED:
+		return errors.New("campaign is cancelled")
 	}
 	return nil
 }
+
+// newThrottledError converts the slice quota error into a resource exhausted error, with the time the next slice
+// opens as an IssueThrottled detail and in the Retry-After header.
+func newThrottledError(quotaErr *coupon.SliceQuotaError) *connect.Error {
+	err := connect.NewError(connect.CodeResourceExhausted, quotaErr)
+	if detail, detailErr := connect.NewErrorDetail(&couponv1.IssueThrottled{
+		NextSliceAt: timestamppb.New(quotaErr.NextSliceAt),
+	}); detailErr == nil {
+		err.AddDetail(detail)
+	}
+	retryAfter := max(int64(math.Ceil(time.Until(quotaErr.NextSliceAt).Seconds())), 0)
+	err.Meta().Set("Retry-After", strconv.FormatInt(retryAfter, 10))
+	return err
+}
+
+// newCampaignCoupon creates a coupon of the campaign issued to the user at issuedAt, which expires as the campaign's
+// expiry policy decides and keeps a snapshot of its discount, or starts with its stored value. The user ID may be empty.
+// The options are applied after those of the campaign.
+func newCampaignCoupon(camp *campaign.Campaign, userId string, issuedAt time.Time, extra ...coupon.Option) (*couponv1.Coupon, error) {
+	expiration, err := coupon.Expiration(camp.ExpiryPolicy, issuedAt, camp.EndAt.UTC()) // must use UTC for being the same as timestamppb.
+	if err != nil {
+		return nil, err
+	}
+
+	var opts []coupon.Option
+	if camp.Discount != nil {
+		opts = append(opts, coupon.WithDiscount(camp.Discount))
+	}
+	if camp.StoredValue != nil {
+		opts = append(opts, coupon.WithBalance(camp.StoredValue))
+	}
+	if userId != "" {
+		opts = append(opts, coupon.WithUserId(userId))
+	}
+	opts = append(opts, extra...)
+	return coupon.NewCoupon(camp.Id, expiration, issuedAt, opts...)
+}
+
+// addInOccurrence adds the coupon into the occurrence of the recurring campaign open at now.
+// Returns an error if no occurrence is open or it has no more coupons.
+func addInOccurrence(camp *campaign.Campaign, now time.Time, coup *couponv1.Coupon) error {
+	start, end, ok := camp.Occurrence(now)
+	if !ok {
+		return errors.New("campaign is not started yet")
+	}
+	return camp.Coupons.AddInOccurrence(start, end, coup)
+}
+
+// checkEligibility checks the user against the campaign's eligibility rule.
+// The attributes of the request take precedence, and the attribute provider looks them up by user ID otherwise.
+// Returns an error if the attributes are not available or the user is not eligible.
+func (s *CouponIssuanceServer) checkEligibility(
+	ctx context.Context, camp *campaign.Campaign, userId string, attrs *couponv1.UserAttributes,
+) error {
+	if camp.Eligibility == nil {
+		return nil
+	}
+
+	if attrs == nil {
+		if s.attributes == nil || userId == "" {
+			return errors.New("user attributes are required for the campaign")
+		}
+		var err error
+		attrs, err = s.attributes.Attributes(ctx, userId)
+		if err != nil {
+			return err
+		}
+	}
+
+	if !camp.Eligibility.Eval(attrs) {
+		return errors.New("user is not eligible for the campaign")
+	}
+	return nil
+}
+
+// newCampaignMessage converts the campaign into its protobuf message without the issued coupons and history.
+// The state and occurrences are as of now.
+func newCampaignMessage(camp *campaign.Campaign) *couponv1.Campaign {
+	now := time.Now().UTC() // must use UTC for being the same as timestamppb.
+	msg := &couponv1.Campaign{
+		Id:            camp.Id,
+		CouponLimit:   camp.CouponLimit,
+		Name:          camp.Name,
+		Description:   camp.Description,
+		CreatedAt:     timestamppb.New(camp.CreatedAt),
+		StartAt:       timestamppb.New(camp.StartAt),
+		EndAt:         timestamppb.New(camp.EndAt),
+		ExpiryPolicy:  camp.ExpiryPolicy,
+		Discount:      camp.Discount,
+		StoredValue:   camp.StoredValue,
+		RestorePolicy: camp.RestorePolicy,
+		Transfer:      camp.Transfer,
+		Referral:      camp.Referral,
+		Tiers:         camp.Tiers,
+		Variants:      camp.Variants,
+		Budget:        camp.Budget,
+		Allocation:    camp.Allocation,
+		Applicability: camp.Applicability,
+		Stacking:      camp.Stacking,
+		State:         camp.State(now),
+		Recurrence:    camp.Recurrence,
+		Throttle:      camp.Throttle,
+		WaitingRoom:   camp.WaitingRoom,
+	}
+	if camp.Recurrence != nil {
+		setOccurrences(msg, camp, now)
+	}
+	if closedAt := camp.ClosedAt(); !closedAt.IsZero() {
+		msg.ClosedAt = timestamppb.New(closedAt)
+	}
+	if camp.Eligibility != nil {
+		msg.Eligibility = camp.Eligibility.String()
+	}
+	if camp.Lottery != nil {
+		msg.Lottery = newLotteryMessage(camp.Lottery)
+	}
+	for _, stats := range camp.Coupons.TierStats() {
+		msg.TierStats = append(msg.TierStats, &couponv1.TierStats{
+			Name:      stats.Name,
+			Issued:    stats.Issued,
+			Remaining: stats.Remaining,
+		})
+	}
+	for _, stats := range camp.Coupons.VariantStats() {
+		msg.VariantStats = append(msg.VariantStats, &couponv1.VariantStats{
+			Name:      stats.Name,
+			Issued:    stats.Issued,
+			Redeemed:  stats.Redeemed,
+			Remaining: stats.Remaining,
+		})
+	}
+	for _, stats := range camp.Coupons.AllocationStats(now) {
+		msg.AllocationStats = append(msg.AllocationStats, &couponv1.AllocationStats{
+			Channel:   stats.Channel,
+			Issued:    stats.Issued,
+			Remaining: stats.Remaining,
+		})
+	}
+	if stats, ok := camp.Coupons.BudgetStats(); ok {
+		msg.BudgetStats = newBudgetStatsMessage(stats)
+	}
+	if camp.Waitlist != nil {
+		msg.Waitlist = &couponv1.Waitlist{
+			Waiting: camp.Waitlist.Waiting(),
+			Issued:  camp.Waitlist.Issued(),
+		}
+	}
+	if list := camp.UserList(couponv1.UserListKind_USER_LIST_KIND_ALLOWLIST); list != nil {
+		msg.Allowlist = newUserListMessage(couponv1.UserListKind_USER_LIST_KIND_ALLOWLIST, list)
+	}
+	if list := camp.UserList(couponv1.UserListKind_USER_LIST_KIND_BLOCKLIST); list != nil {
+		msg.Blocklist = newUserListMessage(couponv1.UserListKind_USER_LIST_KIND_BLOCKLIST, list)
+	}
+	return msg
+}
+
+// setOccurrences sets the current and next occurrences of the recurring campaign at now,
+// and the issuance stats of its past occurrences.
+func setOccurrences(msg *couponv1.Campaign, camp *campaign.Campaign, now time.Time) {
+	stats := camp.Coupons.Occurrences()
+	for _, o := range stats {
+		msg.Occurrences = append(msg.Occurrences, &couponv1.Occurrence{
+			StartAt: timestamppb.New(o.StartAt),
+			EndAt:   timestamppb.New(o.EndAt),
+			Issued:  o.Issued,
+		})
+	}
+
+	if start, end, ok := camp.Occurrence(now); ok {
+		current := &couponv1.Occurrence{
+			StartAt:   timestamppb.New(start),
+			EndAt:     timestamppb.New(end),
+			Remaining: camp.Coupons.RemainingInOccurrence(start),
+		}
+		if len(stats) > 0 && stats[len(stats)-1].StartAt.Equal(start) {
+			current.Issued = stats[len(stats)-1].Issued
+		}
+		msg.CurrentOccurrence = current
+	}
+	if start, end, ok := camp.NextOccurrence(now); ok {
+		msg.NextOccurrence = &couponv1.Occurrence{
+			StartAt:   timestamppb.New(start),
+			EndAt:     timestamppb.New(end),
+			Remaining: camp.CouponLimit,
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package server
+
+import (
+	"context"
+	"time"
+
+	"connectrpc.com/connect"
+
+	"github.com/jackgihokim/coupon-issuance-system/handlers/coupon"
+	couponv1 "github.com/jackgihokim/coupon-issuance-system/protos/coupon/v1"
+)
+
+// RedeemAmount takes an amount off the balance of a valid stored-value coupon for an order, atomically so
+// concurrent orders never overdraw it. The coupon is redeemed once its balance runs out.
+// Returns the coupon with its balance and the ledger entry, or an error if the code is not valid or
+// the amount cannot be taken off the balance.
+func (s *CouponIssuanceServer) RedeemAmount(
+	ctx context.Context,
+	req *connect.Request[couponv1.RedeemAmountRequest],
+) (*connect.Response[couponv1.RedeemAmountResponse], error) {
+	now := time.Now().UTC() // must use UTC for being the same as timestamppb.
+	_, camp, reason := validateCoupon(req.Msg.Code, now)
+	if err := coupon.ReasonError(reason); err != nil {
+		return nil, err
+	}
+
+	coup, entry, err := coupon.RedeemAmount(req.Msg.Code, req.Msg.Amount, req.Msg.OrderId, now)
+	if err != nil {
+		return nil, err
+	}
+	spendBudget(camp, coup, entry.Id, entry.Amount.Amount, now)
+	confirmReferral(coup, now)
+
+	resp := connect.NewResponse(&couponv1.RedeemAmountResponse{
+		Coupon: coup,
+		Entry:  entry,
+	})
+	return resp, nil
+}
+
+// ListLedgerEntries lists the redemptions and refunds of a stored-value coupon in the order they occurred.
+// Returns an error if the coupon is unknown or has no stored value.
+func (s *CouponIssuanceServer) ListLedgerEntries(
+	ctx context.Context,
+	req *connect.Request[couponv1.ListLedgerEntriesRequest],
+) (*connect.Response[couponv1.ListLedgerEntriesResponse], error) {
+	entries, err := coupon.Ledger(req.Msg.Code)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := connect.NewResponse(&couponv1.ListLedgerEntriesResponse{
+		Entries: entries,
+	})
+	return resp, nil
+}
@@ -0,0 +1,73 @@
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	couponv1 "github.com/jackgihokim/coupon-issuance-system/protos/coupon/v1"
+)
+
+func TestRedeemAmount(t *testing.T) {
+	srv := NewCouponIssuanceServer()
+	ctx := context.Background()
+
+	now := time.Now().UTC()
+	createResp, err := srv.CreateCampaign(ctx, connect.NewRequest(&couponv1.CreateCampaignRequest{
+		CouponLimit: 10,
+		Name:        "Gift Card Test Campaign",
+		StartAt:     timestamppb.New(now.Add(-1 * time.Hour)),
+		EndAt:       timestamppb.New(now.Add(1 * time.Hour)),
+		StoredValue: &couponv1.Money{Currency: "KRW", Amount: 50000},
+	}))
+	require.NoError(t, err)
+	coup := issueTestCoupon(t, srv, createResp.Msg.Campaign.Id)
+	assert.Equal(t, int64(50000), coup.Balance.Amount)
+
+	redeem := func(amount int64, orderId string) (*couponv1.RedeemAmountResponse, error) {
+		resp, err := srv.RedeemAmount(ctx, connect.NewRequest(&couponv1.RedeemAmountRequest{
+			Code:    coup.Code,
+			Amount:  &couponv1.Money{Currency: "KRW", Amount: amount},
+			OrderId: orderId,
+		}))
+		if err != nil {
+			return nil, err
+		}
+		return resp.Msg, nil
+	}
+
+	resp, err := redeem(20000, "order-1")
+	require.NoError(t, err)
+	assert.Equal(t, int64(30000), resp.Coupon.Balance.Amount)
+	assert.Equal(t, couponv1.CouponStatus_COUPON_STATUS_ACTIVE, resp.Coupon.Status)
+	assert.Equal(t, "order-1", resp.Entry.OrderId)
+
+	_, err = redeem(30001, "order-2")
+	assert.EqualError(t, err, "amount exceeds the balance")
+	_, err = srv.RedeemCoupon(ctx, connect.NewRequest(&couponv1.RedeemCouponRequest{Code: coup.Code}))
+	assert.EqualError(t, err, "stored-value coupon is redeemed by amount")
+
+	resp, err = redeem(30000, "order-2")
+	require.NoError(t, err)
+	assert.Equal(t, couponv1.CouponStatus_COUPON_STATUS_REDEEMED, resp.Coupon.Status)
+	_, err = redeem(1, "order-3")
+	assert.EqualError(t, err, "coupon is already redeemed")
+
+	ledgerResp, err := srv.ListLedgerEntries(ctx, connect.NewRequest(&couponv1.ListLedgerEntriesRequest{Code: coup.Code}))
+	require.NoError(t, err)
+	require.Len(t, ledgerResp.Msg.Entries, 2)
+	for i, want := range []int64{30000, 0} {
+		assert.Equal(t, couponv1.LedgerEntryType_LEDGER_ENTRY_TYPE_REDEMPTION, ledgerResp.Msg.Entries[i].Type)
+		assert.Equal(t, want, ledgerResp.Msg.Entries[i].Balance.Amount)
+	}
+
+	// Coupons giving a discount have no balance
+	plain := issueTestCoupon(t, srv, createTestCampaign(t, srv, 10))
+	_, err = srv.ListLedgerEntries(ctx, connect.NewRequest(&couponv1.ListLedgerEntriesRequest{Code: plain.Code}))
+	assert.EqualError(t, err, "coupon has no stored value")
+}
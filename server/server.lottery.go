@@ -0,0 +1,154 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"connectrpc.com/connect"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/jackgihokim/coupon-issuance-system/handlers/campaign"
+	"github.com/jackgihokim/coupon-issuance-system/handlers/coupon"
+	"github.com/jackgihokim/coupon-issuance-system/handlers/lottery"
+	couponv1 "github.com/jackgihokim/coupon-issuance-system/protos/coupon/v1"
+)
+
+// EnterLottery enters a user into the lottery of a campaign, once per user while the campaign is active.
+// Users who are not allowed by the campaign's user lists or don't satisfy its eligibility rule cannot enter.
+// Returns the number of entries so far or an error if the campaign is not a lottery or the user cannot enter.
+func (s *CouponIssuanceServer) EnterLottery(
+	ctx context.Context,
+	req *connect.Request[couponv1.EnterLotteryRequest],
+) (*connect.Response[couponv1.EnterLotteryResponse], error) {
+	camp, err := getLotteryCampaign(req.Msg.CampaignId)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now().UTC() // must use UTC for being the same as timestamppb.
+	err = validateState(camp, now)
+	if err != nil {
+		return nil, err
+	}
+	err = camp.CheckUser(req.Msg.UserId)
+	if err != nil {
+		return nil, err
+	}
+	err = s.checkEligibility(ctx, camp, req.Msg.UserId, req.Msg.UserAttributes)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := camp.Lottery.Enter(req.Msg.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := connect.NewResponse(&couponv1.EnterLotteryResponse{
+		Entries: entries,
+	})
+	return resp, nil
+}
+
+// GetLotteryResult tells a user who entered the lottery of a campaign whether they won, drawing it first if due.
+// Returns the coupon issued to a winner, or an error if the campaign is not a lottery, the user did not enter it
+// or the campaign was closed without a draw.
+func (s *CouponIssuanceServer) GetLotteryResult(
+	ctx context.Context,
+	req *connect.Request[couponv1.GetLotteryResultRequest],
+) (*connect.Response[couponv1.GetLotteryResultResponse], error) {
+	camp, err := getLotteryCampaign(req.Msg.CampaignId)
+	if err != nil {
+		return nil, err
+	}
+	err = drawLottery(camp, time.Now().UTC())
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := camp.Lottery.Result(req.Msg.UserId)
+	if err != nil {
+		return nil, err
+	}
+	if !result.Drawn && !camp.ClosedAt().IsZero() {
+		return nil, errors.New("lottery was closed without a draw")
+	}
+
+	msg := &couponv1.GetLotteryResultResponse{
+		Drawn:   result.Drawn,
+		Won:     result.Won,
+		Lottery: newLotteryMessage(camp.Lottery),
+	}
+	if result.Code != "" {
+		msg.Coupon, err = coupon.Lookup(result.Code)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return connect.NewResponse(msg), nil
+}
+
+// getLotteryCampaign returns the campaign if it draws its coupons by lottery.
+func getLotteryCampaign(campaignId uint32) (*campaign.Campaign, error) {
+	camp, err := campaign.GetCampaign(campaignId)
+	if err != nil {
+		return nil, err
+	}
+	if camp.Lottery == nil {
+		return nil, errors.New("campaign is not a lottery")
+	}
+	return camp, nil
+}
+
+// drawLottery draws the lottery of the campaign if it is due at now, and issues the coupons of the winners
+// as of EndAt, so the coupons do not depend on when the draw happens to run.
+// Returns an error if a coupon could not be issued, which the next draw retries.
+func drawLottery(camp *campaign.Campaign, now time.Time) error {
+	if !camp.LotteryDue(now) {
+		return nil
+	}
+
+	issuedAt := camp.EndAt.UTC() // must use UTC for being the same as timestamppb.
+	drew, err := camp.Lottery.Draw(camp.CouponLimit, now, func(userId string) (string, error) {
+		coup, err := newCampaignCoupon(camp, userId, issuedAt)
+		if err != nil {
+			return "", err
+		}
+		if err := camp.Coupons.Add(coup); err != nil {
+			coupon.Discard(coup.Code)
+			return "", err
+		}
+		return coup.Code, nil
+	})
+	if drew {
+		camp.History.Record(&couponv1.CampaignEvent{
+			Type:       couponv1.CampaignEventType_CAMPAIGN_EVENT_TYPE_LOTTERY_DRAWN,
+			Reason:     fmt.Sprintf("%d winners of %d entries", camp.Lottery.WinnerCount(), camp.Lottery.Entries()),
+			OccurredAt: timestamppb.New(now),
+		})
+	}
+	return err
+}
+
+// drawLotteries draws the lotteries of the campaigns which are due at now, so the winners get their coupons at EndAt
+// whether or not anyone asks for the results. A draw failing to issue a coupon is retried the next time.
+func drawLotteries(now time.Time) {
+	for _, camp := range campaign.ListCampaigns() {
+		_ = drawLottery(camp, now)
+	}
+}
+
+// newLotteryMessage converts the lottery into its protobuf message, with the seed once drawn.
+func newLotteryMessage(l *lottery.Lottery) *couponv1.Lottery {
+	msg := &couponv1.Lottery{
+		SeedHash: l.SeedHash(),
+		Seed:     l.Seed(),
+		Entries:  l.Entries(),
+		Winners:  l.WinnerCount(),
+	}
+	if drawnAt := l.DrawnAt(); !drawnAt.IsZero() {
+		msg.DrawnAt = timestamppb.New(drawnAt)
+	}
+	return msg
+}
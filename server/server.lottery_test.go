@@ -0,0 +1,176 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/jackgihokim/coupon-issuance-system/handlers/campaign"
+	"github.com/jackgihokim/coupon-issuance-system/handlers/lottery"
+	couponv1 "github.com/jackgihokim/coupon-issuance-system/protos/coupon/v1"
+)
+
+func TestLottery(t *testing.T) {
+	srv := NewCouponIssuanceServer()
+	ctx := context.Background()
+
+	now := time.Now().UTC()
+	endAt := now.Add(300 * time.Millisecond)
+	createResp, err := srv.CreateCampaign(ctx, connect.NewRequest(&couponv1.CreateCampaignRequest{
+		CouponLimit:  2,
+		Name:         "Lottery Test Campaign",
+		StartAt:      timestamppb.New(now.Add(-1 * time.Hour)),
+		EndAt:        timestamppb.New(endAt),
+		ExpiryPolicy: &couponv1.ExpiryPolicy{Policy: &couponv1.ExpiryPolicy_Ttl{Ttl: durationpb.New(72 * time.Hour)}},
+		Lottery:      true,
+	}))
+	require.NoError(t, err)
+	campId := createResp.Msg.Campaign.Id
+	seedHash := createResp.Msg.Campaign.Lottery.SeedHash
+	assert.NotEmpty(t, seedHash)
+	assert.Empty(t, createResp.Msg.Campaign.Lottery.Seed)
+
+	enter := func(userId string) error {
+		_, err := srv.EnterLottery(ctx, connect.NewRequest(&couponv1.EnterLotteryRequest{
+			CampaignId: campId,
+			UserId:     userId,
+		}))
+		return err
+	}
+	var userIds []string
+	for i := 0; i < 5; i++ {
+		userId := fmt.Sprintf("user-%d", i)
+		userIds = append(userIds, userId)
+		require.NoError(t, enter(userId))
+	}
+	assert.EqualError(t, enter("user-0"), "user already entered the lottery")
+
+	_, err = srv.IssueCoupon(ctx, connect.NewRequest(&couponv1.IssueCouponRequest{CampaignId: campId, UserId: "user-0"}))
+	assert.EqualError(t, err, "coupons of a lottery campaign are issued by its draw")
+
+	// The lottery is drawn once the campaign is over
+	time.Sleep(time.Until(endAt) + 10*time.Millisecond)
+	assert.EqualError(t, enter("late"), "campaign is over")
+
+	var winners []string
+	for _, userId := range userIds {
+		resp, err := srv.GetLotteryResult(ctx, connect.NewRequest(&couponv1.GetLotteryResultRequest{
+			CampaignId: campId,
+			UserId:     userId,
+		}))
+		require.NoError(t, err)
+		assert.True(t, resp.Msg.Drawn)
+		if !resp.Msg.Won {
+			assert.Nil(t, resp.Msg.Coupon)
+			continue
+		}
+		winners = append(winners, userId)
+		require.NotNil(t, resp.Msg.Coupon)
+		assert.Equal(t, userId, resp.Msg.Coupon.UserId)
+
+		// Winners' coupons stay valid although the campaign is over
+		validResp, err := srv.ValidateCoupon(ctx, connect.NewRequest(&couponv1.ValidateCouponRequest{
+			Code: resp.Msg.Coupon.Code,
+		}))
+		require.NoError(t, err)
+		assert.True(t, validResp.Msg.Valid)
+	}
+	assert.Len(t, winners, 2)
+
+	_, err = srv.GetLotteryResult(ctx, connect.NewRequest(&couponv1.GetLotteryResultRequest{
+		CampaignId: campId,
+		UserId:     "stranger",
+	}))
+	assert.EqualError(t, err, "user did not enter the lottery")
+
+	// The revealed seed matches the published hash and reproduces the winners
+	getResp, err := srv.GetCampaign(ctx, connect.NewRequest(&couponv1.GetCampaignRequest{CampaignId: campId}))
+	require.NoError(t, err)
+	l := getResp.Msg.Campaign.Lottery
+	assert.Equal(t, seedHash, l.SeedHash)
+	assert.Equal(t, uint64(5), l.Entries)
+	assert.Equal(t, uint32(2), l.Winners)
+	assert.NotNil(t, l.DrawnAt)
+	drawn := lottery.Winners(l.Seed, userIds, 2)
+	slices.Sort(drawn)
+	assert.Equal(t, winners, drawn)
+	assert.Len(t, getResp.Msg.Campaign.Coupons, 2)
+	require.Len(t, getResp.Msg.Campaign.History, 1)
+	assert.Equal(t, couponv1.CampaignEventType_CAMPAIGN_EVENT_TYPE_LOTTERY_DRAWN, getResp.Msg.Campaign.History[0].Type)
+}
+
+func TestLottery_ClosedEarly(t *testing.T) {
+	srv := NewCouponIssuanceServer()
+	ctx := context.Background()
+
+	now := time.Now().UTC()
+	createResp, err := srv.CreateCampaign(ctx, connect.NewRequest(&couponv1.CreateCampaignRequest{
+		CouponLimit:  2,
+		Name:         "Closed Lottery Test Campaign",
+		StartAt:      timestamppb.New(now.Add(-1 * time.Hour)),
+		EndAt:        timestamppb.New(now.Add(1 * time.Hour)),
+		ExpiryPolicy: &couponv1.ExpiryPolicy{Policy: &couponv1.ExpiryPolicy_Ttl{Ttl: durationpb.New(72 * time.Hour)}},
+		Lottery:      true,
+	}))
+	require.NoError(t, err)
+	campId := createResp.Msg.Campaign.Id
+
+	_, err = srv.EnterLottery(ctx, connect.NewRequest(&couponv1.EnterLotteryRequest{CampaignId: campId, UserId: "user-0"}))
+	require.NoError(t, err)
+	_, err = srv.CloseCampaign(ctx, connect.NewRequest(&couponv1.CloseCampaignRequest{CampaignId: campId}))
+	require.NoError(t, err)
+
+	_, err = srv.GetLotteryResult(ctx, connect.NewRequest(&couponv1.GetLotteryResultRequest{
+		CampaignId: campId,
+		UserId:     "user-0",
+	}))
+	assert.EqualError(t, err, "lottery was closed without a draw")
+
+	// Campaigns issuing first come, first served are not lotteries
+	_, err = srv.EnterLottery(ctx, connect.NewRequest(&couponv1.EnterLotteryRequest{
+		CampaignId: createTestCampaign(t, srv, 10),
+		UserId:     "user-0",
+	}))
+	assert.EqualError(t, err, "campaign is not a lottery")
+}
+
+func TestLottery_DrawnBySweeper(t *testing.T) {
+	srv := NewCouponIssuanceServer()
+	ctx := context.Background()
+
+	now := time.Now().UTC()
+	endAt := now.Add(100 * time.Millisecond)
+	createResp, err := srv.CreateCampaign(ctx, connect.NewRequest(&couponv1.CreateCampaignRequest{
+		CouponLimit:  2,
+		Name:         "Swept Lottery Test Campaign",
+		StartAt:      timestamppb.New(now.Add(-1 * time.Hour)),
+		EndAt:        timestamppb.New(endAt),
+		ExpiryPolicy: &couponv1.ExpiryPolicy{Policy: &couponv1.ExpiryPolicy_Ttl{Ttl: durationpb.New(72 * time.Hour)}},
+		Lottery:      true,
+	}))
+	require.NoError(t, err)
+	campId := createResp.Msg.Campaign.Id
+	for _, userId := range []string{"user-0", "user-1", "user-2"} {
+		_, err = srv.EnterLottery(ctx, connect.NewRequest(&couponv1.EnterLotteryRequest{CampaignId: campId, UserId: userId}))
+		require.NoError(t, err)
+	}
+
+	camp, err := campaign.GetCampaign(campId)
+	require.NoError(t, err)
+	drawLotteries(time.Now().UTC())
+	assert.True(t, camp.Lottery.DrawnAt().IsZero())
+
+	// Once the campaign is over, the sweeper issues the winners' coupons without anyone asking for the results
+	time.Sleep(time.Until(endAt) + 10*time.Millisecond)
+	drawLotteries(time.Now().UTC())
+	assert.False(t, camp.Lottery.DrawnAt().IsZero())
+	assert.Len(t, camp.Coupons.List(), 2)
+}
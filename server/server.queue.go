@@ -0,0 +1,139 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"connectrpc.com/connect"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/jackgihokim/coupon-issuance-system/handlers/campaign"
+	"github.com/jackgihokim/coupon-issuance-system/handlers/waitingroom"
+	couponv1 "github.com/jackgihokim/coupon-issuance-system/protos/coupon/v1"
+)
+
+// EnterQueue puts a ticket for a user into the waiting room of a campaign. A user entering again gets the same
+// ticket until its admission expires.
+// Returns the place of the ticket, with an admission token once it is admitted, or an error if the campaign
+// has no waiting room, the user ID is empty or the waiting room is full.
+func (s *CouponIssuanceServer) EnterQueue(
+	ctx context.Context,
+	req *connect.Request[couponv1.EnterQueueRequest],
+) (*connect.Response[couponv1.EnterQueueResponse], error) {
+	camp, err := getQueuedCampaign(req.Msg.CampaignId)
+	if err != nil {
+		return nil, err
+	}
+	status, err := camp.Queue.Enter(req.Msg.UserId, time.Now().UTC())
+	if err != nil {
+		return nil, err
+	}
+
+	resp := connect.NewResponse(&couponv1.EnterQueueResponse{
+		Status: s.newQueueStatus(camp, status),
+	})
+	return resp, nil
+}
+
+// WatchQueue streams the place of a ticket in the waiting room of a campaign whenever it moves,
+// and ends once the ticket is admitted with its admission token.
+// Returns an error if the campaign has no waiting room or the ticket is unknown.
+func (s *CouponIssuanceServer) WatchQueue(
+	ctx context.Context,
+	req *connect.Request[couponv1.WatchQueueRequest],
+	stream *connect.ServerStream[couponv1.QueueStatus],
+) error {
+	camp, err := getQueuedCampaign(req.Msg.CampaignId)
+	if err != nil {
+		return err
+	}
+
+	sent := uint64(0)
+	for {
+		status, err := camp.Queue.Status(req.Msg.Ticket, time.Now().UTC())
+		if err != nil {
+			return err
+		}
+		if sent == 0 || status.Position != sent {
+			if err := stream.Send(s.newQueueStatus(camp, status)); err != nil {
+				return err
+			}
+			sent = status.Position
+		}
+		if status.Admitted() {
+			return nil
+		}
+
+		// The position only moves when a ticket is admitted
+		timer := time.NewTimer(max(time.Until(camp.Queue.NextAdmissionAt()), time.Millisecond))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// getQueuedCampaign returns the campaign if it has a waiting room.
+func getQueuedCampaign(campaignId uint32) (*campaign.Campaign, error) {
+	camp, err := campaign.GetCampaign(campaignId)
+	if err != nil {
+		return nil, err
+	}
+	if camp.Queue == nil {
+		return nil, errors.New("campaign has no waiting room")
+	}
+	return camp, nil
+}
+
+// newQueueStatus converts the status of a ticket into its protobuf message, signing an admission token
+// which expires the token TTL after the admission if the ticket is admitted.
+func (s *CouponIssuanceServer) newQueueStatus(camp *campaign.Campaign, status waitingroom.Status) *couponv1.QueueStatus {
+	msg := &couponv1.QueueStatus{
+		Ticket:   status.Ticket,
+		Position: status.Position,
+		Admitted: status.Admitted(),
+	}
+	if status.Admitted() {
+		expireAt := status.AdmittedAt.Add(waitingroom.TokenTTL(camp.WaitingRoom))
+		msg.AdmissionToken = s.signer.Sign(waitingroom.Admission{
+			CampaignId: camp.Id,
+			Ticket:     status.Ticket,
+			UserId:     status.UserId,
+			ExpireAt:   expireAt,
+		})
+		msg.TokenExpireAt = timestamppb.New(expireAt)
+	}
+	return msg
+}
+
+// admit verifies the admission token of the request if the campaign has a waiting room, and claims its ticket.
+// Returns a function which gives the admission back if no coupon is issued after all,
+// or an error if the token is missing, invalid, for another campaign or user, or already used.
+func (s *CouponIssuanceServer) admit(
+	camp *campaign.Campaign, req *couponv1.IssueCouponRequest, now time.Time,
+) (func(), error) {
+	if camp.Queue == nil {
+		return func() {}, nil
+	}
+	if req.AdmissionToken == "" {
+		return nil, errors.New("admission token is required for the campaign")
+	}
+
+	admission, err := s.signer.Verify(req.AdmissionToken, now)
+	if err != nil {
+		return nil, err
+	}
+	if admission.CampaignId != camp.Id {
+		return nil, errors.New("admission token is for another campaign")
+	}
+	if admission.UserId != req.UserId {
+		return nil, errors.New("admission token is for another user")
+	}
+	if err := camp.Queue.Claim(admission.Ticket, now); err != nil {
+		return nil, err
+	}
+	return func() { camp.Queue.Unclaim(admission.Ticket) }, nil
+}
@@ -0,0 +1,88 @@
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	couponv1 "github.com/jackgihokim/coupon-issuance-system/protos/coupon/v1"
+)
+
+func TestWaitingRoom(t *testing.T) {
+	srv := NewCouponIssuanceServer()
+	client := newTestClient(t, srv)
+	ctx := context.Background()
+
+	now := time.Now().UTC()
+	createResp, err := srv.CreateCampaign(ctx, connect.NewRequest(&couponv1.CreateCampaignRequest{
+		CouponLimit: 10,
+		Name:        "Waiting Room Test Campaign",
+		StartAt:     timestamppb.New(now.Add(-1 * time.Hour)),
+		EndAt:       timestamppb.New(now.Add(1 * time.Hour)),
+		WaitingRoom: &couponv1.WaitingRoom{AdmissionsPerSecond: 10},
+	}))
+	require.NoError(t, err)
+	campId := createResp.Msg.Campaign.Id
+
+	enter := func(userId string) *couponv1.QueueStatus {
+		resp, err := srv.EnterQueue(ctx, connect.NewRequest(&couponv1.EnterQueueRequest{
+			CampaignId: campId,
+			UserId:     userId,
+		}))
+		require.NoError(t, err)
+		return resp.Msg.Status
+	}
+	issue := func(userId, token string) error {
+		_, err := srv.IssueCoupon(ctx, connect.NewRequest(&couponv1.IssueCouponRequest{
+			CampaignId:     campId,
+			UserId:         userId,
+			AdmissionToken: token,
+		}))
+		return err
+	}
+
+	// The first user is admitted at once and the second one waits for the next admission
+	first := enter("user-1")
+	assert.True(t, first.Admitted)
+	assert.NotEmpty(t, first.AdmissionToken)
+	second := enter("user-2")
+	assert.False(t, second.Admitted)
+	assert.Equal(t, uint64(1), second.Position)
+	assert.Equal(t, second.Ticket, enter("user-2").Ticket)
+
+	stream, err := client.WatchQueue(ctx, connect.NewRequest(&couponv1.WatchQueueRequest{
+		CampaignId: campId,
+		Ticket:     second.Ticket,
+	}))
+	require.NoError(t, err)
+	var last *couponv1.QueueStatus
+	for stream.Receive() {
+		last = stream.Msg()
+	}
+	require.NoError(t, stream.Err())
+	require.NotNil(t, last)
+	assert.True(t, last.Admitted)
+	assert.Equal(t, uint64(0), last.Position)
+
+	assert.EqualError(t, issue("user-2", ""), "admission token is required for the campaign")
+	assert.EqualError(t, issue("user-2", "forged"), "invalid admission token")
+	assert.EqualError(t, issue("user-2", first.AdmissionToken), "admission token is for another user")
+	assert.NoError(t, issue("user-2", last.AdmissionToken))
+	assert.EqualError(t, issue("user-2", last.AdmissionToken), "admission is already used")
+	assert.NoError(t, issue("user-1", first.AdmissionToken))
+
+	// Every ticket belongs to a user, so nobody can fill the queue with anonymous ones
+	_, err = srv.EnterQueue(ctx, connect.NewRequest(&couponv1.EnterQueueRequest{CampaignId: campId}))
+	assert.EqualError(t, err, "user ID is required")
+
+	// Campaigns without a waiting room have no queue
+	_, err = srv.EnterQueue(ctx, connect.NewRequest(&couponv1.EnterQueueRequest{
+		CampaignId: createTestCampaign(t, srv, 10),
+	}))
+	assert.EqualError(t, err, "campaign has no waiting room")
+}
@@ -0,0 +1,100 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"connectrpc.com/connect"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/jackgihokim/coupon-issuance-system/handlers/campaign"
+	"github.com/jackgihokim/coupon-issuance-system/handlers/coupon"
+	couponv1 "github.com/jackgihokim/coupon-issuance-system/protos/coupon/v1"
+)
+
+// CreateReferralCode returns the referral code a user shares to refer others to a referral campaign, creating it
+// on the first request. Rewards owed to the user which could not be issued before are issued first.
+// Users who are not allowed by the campaign's user lists cannot refer others.
+// Returns the referral code with its referrals and rewards, or an error if the campaign is not a referral campaign.
+func (s *CouponIssuanceServer) CreateReferralCode(
+	ctx context.Context,
+	req *connect.Request[couponv1.CreateReferralCodeRequest],
+) (*connect.Response[couponv1.CreateReferralCodeResponse], error) {
+	camp, err := campaign.GetCampaign(req.Msg.CampaignId)
+	if err != nil {
+		return nil, err
+	}
+	if camp.Referrals == nil {
+		return nil, errors.New("campaign is not a referral campaign")
+	}
+	err = camp.CheckUser(req.Msg.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	code, err := camp.Referrals.Code(camp.Id, req.Msg.UserId)
+	if err != nil {
+		return nil, err
+	}
+	err = rewardReferrer(camp, code.Code, time.Now().UTC())
+	if err != nil {
+		return nil, err
+	}
+	// Read it again to count the rewards just issued
+	code, err = camp.Referrals.Code(camp.Id, req.Msg.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := connect.NewResponse(&couponv1.CreateReferralCodeResponse{
+		ReferralCode: code,
+	})
+	return resp, nil
+}
+
+// confirmReferral rewards the referrer of a redeemed coupon issued for a referral, if it is the first redemption of
+// the coupon. A reward which fails to be issued is issued when the referrer next gets their referral code.
+func confirmReferral(coup *couponv1.Coupon, now time.Time) {
+	if coup.ReferralCode == "" {
+		return
+	}
+	camp, err := campaign.GetCampaign(coup.CampaignId)
+	if err != nil || camp.Referrals == nil {
+		return
+	}
+	if code, ok := camp.Referrals.Confirm(coup.Code); ok {
+		_ = rewardReferrer(camp, code, now)
+	}
+}
+
+// rewardReferrer issues the reward coupons owed to the referrer of the referral code at now, which are worth the
+// reward of the campaign's referral policy, and records them in the campaign's history. Rewards are not counted
+// against the coupon limit, but the campaign's budget must cover them.
+// Returns the first error of the issuance.
+func rewardReferrer(camp *campaign.Campaign, code string, now time.Time) error {
+	issued, userId, err := camp.Referrals.Reward(code, func(userId string) (string, error) {
+		opts := []coupon.Option{coupon.WithReferralCode(code)}
+		if camp.Referral.Reward != nil {
+			opts = append(opts, coupon.WithDiscount(camp.Referral.Reward))
+		}
+		coup, err := newCampaignCoupon(camp, userId, now, opts...)
+		if err != nil {
+			return "", err
+		}
+		if err := camp.Coupons.AddReward(coup); err != nil {
+			coupon.Discard(coup.Code)
+			return "", err
+		}
+		return coup.Code, nil
+	})
+	for _, couponCode := range issued {
+		camp.History.Record(&couponv1.CampaignEvent{
+			Type:       couponv1.CampaignEventType_CAMPAIGN_EVENT_TYPE_REFERRAL_REWARDED,
+			Code:       couponCode,
+			OccurredAt: timestamppb.New(now),
+			UserId:     userId,
+		})
+	}
+	return err
+}
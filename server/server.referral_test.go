@@ -0,0 +1,93 @@
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	couponv1 "github.com/jackgihokim/coupon-issuance-system/protos/coupon/v1"
+)
+
+func TestReferral(t *testing.T) {
+	srv := NewCouponIssuanceServer()
+	ctx := context.Background()
+
+	now := time.Now().UTC()
+	tenPercent := &couponv1.Discount{Kind: &couponv1.Discount_Percentage_{Percentage: &couponv1.Discount_Percentage{BasisPoints: 1000}}}
+	createResp, err := srv.CreateCampaign(ctx, connect.NewRequest(&couponv1.CreateCampaignRequest{
+		CouponLimit: 10,
+		Name:        "Referral Test Campaign",
+		StartAt:     timestamppb.New(now.Add(-1 * time.Hour)),
+		EndAt:       timestamppb.New(now.Add(1 * time.Hour)),
+		Discount:    tenPercent,
+		Eligibility: `new_user`,
+		Referral:    &couponv1.ReferralPolicy{MaxReferrals: 1},
+	}))
+	require.NoError(t, err)
+	campId := createResp.Msg.Campaign.Id
+
+	codeResp, err := srv.CreateReferralCode(ctx, connect.NewRequest(&couponv1.CreateReferralCodeRequest{
+		CampaignId: campId,
+		UserId:     "alice",
+	}))
+	require.NoError(t, err)
+	code := codeResp.Msg.ReferralCode.Code
+
+	issue := func(userId, referralCode string, newUser bool) (*couponv1.Coupon, error) {
+		resp, err := srv.IssueCoupon(ctx, connect.NewRequest(&couponv1.IssueCouponRequest{
+			CampaignId:     campId,
+			UserId:         userId,
+			UserAttributes: &couponv1.UserAttributes{NewUser: newUser},
+			ReferralCode:   referralCode,
+		}))
+		if err != nil {
+			return nil, err
+		}
+		return resp.Msg.Coupon, nil
+	}
+
+	_, err = issue("bob", "", true)
+	assert.EqualError(t, err, "referral code is required for the campaign")
+	_, err = issue("alice", code, true)
+	assert.EqualError(t, err, "cannot use your own referral code")
+	_, err = issue("bob", code, false)
+	assert.EqualError(t, err, "user is not eligible for the campaign")
+	referee, err := issue("bob", code, true)
+	require.NoError(t, err)
+	assert.Equal(t, code, referee.ReferralCode)
+	_, err = issue("carol", code, true)
+	assert.EqualError(t, err, "referrer reached the maximum number of referrals")
+
+	// The referrer is rewarded once the referee first redeems the coupon
+	assert.Len(t, getTestCampaign(t, srv, campId).History, 0)
+	_, err = srv.RedeemCoupon(ctx, connect.NewRequest(&couponv1.RedeemCouponRequest{Code: referee.Code}))
+	require.NoError(t, err)
+	campMsg := getTestCampaign(t, srv, campId)
+	require.Len(t, campMsg.History, 1)
+	assert.Equal(t, couponv1.CampaignEventType_CAMPAIGN_EVENT_TYPE_REFERRAL_REWARDED, campMsg.History[0].Type)
+	assert.Equal(t, "alice", campMsg.History[0].UserId)
+	require.Len(t, campMsg.Coupons, 2)
+	reward := campMsg.Coupons[1]
+	assert.Equal(t, "alice", reward.UserId)
+	assert.Equal(t, code, reward.ReferralCode)
+
+	codeResp, err = srv.CreateReferralCode(ctx, connect.NewRequest(&couponv1.CreateReferralCodeRequest{
+		CampaignId: campId,
+		UserId:     "alice",
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, code, codeResp.Msg.ReferralCode.Code)
+	assert.Equal(t, uint32(1), codeResp.Msg.ReferralCode.Referrals)
+	assert.Equal(t, uint32(1), codeResp.Msg.ReferralCode.Rewards)
+
+	_, err = srv.CreateReferralCode(ctx, connect.NewRequest(&couponv1.CreateReferralCodeRequest{
+		CampaignId: createTestCampaign(t, srv, 10),
+		UserId:     "alice",
+	}))
+	assert.EqualError(t, err, "campaign is not a referral campaign")
+}
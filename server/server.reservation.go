@@ -0,0 +1,111 @@
+package server
+
+import (
+	"context"
+	"time"
+
+	"connectrpc.com/connect"
+
+	"github.com/jackgihokim/coupon-issuance-system/handlers/campaign"
+	"github.com/jackgihokim/coupon-issuance-system/handlers/coupon"
+	couponv1 "github.com/jackgihokim/coupon-issuance-system/protos/coupon/v1"
+)
+
+// sweepInterval is how often the reservations which expired are released, and the slots of the coupons which
+// expired unused given back.
+const sweepInterval = 10 * time.Second
+
+// ReserveCoupon holds a valid coupon code for a checkout for the TTL of the request, at most 15 minutes,
+// so it cannot be redeemed elsewhere until the reservation is committed, released or expires.
+// Returns the reserved coupon with its reservation or an error describing why the code is not valid.
+func (s *CouponIssuanceServer) ReserveCoupon(
+	ctx context.Context,
+	req *connect.Request[couponv1.ReserveCouponRequest],
+) (*connect.Response[couponv1.ReserveCouponResponse], error) {
+	ttl, err := coupon.ReservationTTL(req.Msg.Ttl)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now().UTC() // must use UTC for being the same as timestamppb.
+	_, _, reason := validateCoupon(req.Msg.Code, now)
+	if err := coupon.ReasonError(reason); err != nil {
+		return nil, err
+	}
+
+	coup, err := coupon.Reserve(req.Msg.Code, ttl, now)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := connect.NewResponse(&couponv1.ReserveCouponResponse{
+		Coupon: coup,
+	})
+	return resp, nil
+}
+
+// CommitReservation redeems the coupon held by a reservation which has not expired.
+// The discount amount of the request is spent from the campaign's budget if it has one.
+// Returns the redeemed coupon or an error if the reservation does not hold the coupon any more.
+func (s *CouponIssuanceServer) CommitReservation(
+	ctx context.Context,
+	req *connect.Request[couponv1.CommitReservationRequest],
+) (*connect.Response[couponv1.CommitReservationResponse], error) {
+	found, err := coupon.Lookup(req.Msg.Code)
+	if err != nil {
+		return nil, err
+	}
+	camp, err := campaign.GetCampaign(found.CampaignId)
+	if err != nil {
+		return nil, err
+	}
+	cost, err := redemptionCost(camp, found, req.Msg.DiscountAmount)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC() // must use UTC for being the same as timestamppb.
+	coup, err := coupon.CommitReservation(req.Msg.Code, req.Msg.ReservationId, req.Msg.OrderId, now)
+	if err != nil {
+		return nil, err
+	}
+	spendBudget(camp, coup, coup.RedemptionId, cost, now)
+	camp.Coupons.RecordRedemption(coup)
+	confirmReferral(coup, now)
+
+	resp := connect.NewResponse(&couponv1.CommitReservationResponse{
+		Coupon: coup,
+	})
+	return resp, nil
+}
+
+// ReleaseReservation ends a reservation which has not expired, so the coupon can be used again.
+// Returns the released coupon or an error if the reservation does not hold the coupon any more.
+func (s *CouponIssuanceServer) ReleaseReservation(
+	ctx context.Context,
+	req *connect.Request[couponv1.ReleaseReservationRequest],
+) (*connect.Response[couponv1.ReleaseReservationResponse], error) {
+	now := time.Now().UTC() // must use UTC for being the same as timestamppb.
+	coup, err := coupon.ReleaseReservation(req.Msg.Code, req.Msg.ReservationId, now)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := connect.NewResponse(&couponv1.ReleaseReservationResponse{
+		Coupon: coup,
+	})
+	return resp, nil
+}
+
+// sweep releases the reservations which expired every interval, for as long as the server runs, and then gives back
+// the budgets and slots of the coupons which expired unused, including those whose reservations were just released,
+// and draws the lotteries which are due. Expired reservations do not hold their coupons anyway, so the sweeper only brings their
+// status up to date.
+func sweep(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for now := range ticker.C {
+		coupon.SweepReservations(now.UTC())
+		returnExpiredSlots(now.UTC())
+		drawLotteries(now.UTC())
+	}
+}
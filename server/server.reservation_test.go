@@ -0,0 +1,74 @@
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	couponv1 "github.com/jackgihokim/coupon-issuance-system/protos/coupon/v1"
+)
+
+func TestReservation(t *testing.T) {
+	srv := NewCouponIssuanceServer()
+	ctx := context.Background()
+	campId := createTestCampaign(t, srv, 10)
+	coup := issueTestCoupon(t, srv, campId)
+
+	reserve := func(ttl *durationpb.Duration) (*couponv1.Coupon, error) {
+		resp, err := srv.ReserveCoupon(ctx, connect.NewRequest(&couponv1.ReserveCouponRequest{Code: coup.Code, Ttl: ttl}))
+		if err != nil {
+			return nil, err
+		}
+		return resp.Msg.Coupon, nil
+	}
+	redeem := func() error {
+		_, err := srv.RedeemCoupon(ctx, connect.NewRequest(&couponv1.RedeemCouponRequest{Code: coup.Code}))
+		return err
+	}
+
+	_, err := reserve(durationpb.New(time.Hour))
+	assert.EqualError(t, err, "reservation TTL must be positive and at most 15 minutes")
+
+	reserved, err := reserve(nil)
+	require.NoError(t, err)
+	assert.Equal(t, couponv1.CouponStatus_COUPON_STATUS_RESERVED, reserved.Status)
+	assert.Equal(t, 15*time.Minute, reserved.Reservation.ExpireAt.AsTime().Sub(reserved.Reservation.ReservedAt.AsTime()))
+
+	// A held coupon is not valid elsewhere until it is released
+	assert.EqualError(t, redeem(), "coupon is reserved")
+	validResp, err := srv.ValidateCoupon(ctx, connect.NewRequest(&couponv1.ValidateCouponRequest{Code: coup.Code}))
+	require.NoError(t, err)
+	assert.Equal(t, couponv1.ValidationReason_VALIDATION_REASON_RESERVED, validResp.Msg.Reason)
+
+	releaseResp, err := srv.ReleaseReservation(ctx, connect.NewRequest(&couponv1.ReleaseReservationRequest{
+		Code:          coup.Code,
+		ReservationId: reserved.Reservation.Id,
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, couponv1.CouponStatus_COUPON_STATUS_ACTIVE, releaseResp.Msg.Coupon.Status)
+
+	// An expired hold cannot be committed and the coupon becomes available again
+	reserved, err = reserve(durationpb.New(10 * time.Millisecond))
+	require.NoError(t, err)
+	time.Sleep(20 * time.Millisecond)
+	_, err = srv.CommitReservation(ctx, connect.NewRequest(&couponv1.CommitReservationRequest{
+		Code:          coup.Code,
+		ReservationId: reserved.Reservation.Id,
+	}))
+	assert.EqualError(t, err, "reservation is expired")
+
+	reserved, err = reserve(nil)
+	require.NoError(t, err)
+	commitResp, err := srv.CommitReservation(ctx, connect.NewRequest(&couponv1.CommitReservationRequest{
+		Code:          coup.Code,
+		ReservationId: reserved.Reservation.Id,
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, couponv1.CouponStatus_COUPON_STATUS_REDEEMED, commitResp.Msg.Coupon.Status)
+	assert.EqualError(t, redeem(), "coupon is already redeemed")
+}
@@ -0,0 +1,101 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"connectrpc.com/connect"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/jackgihokim/coupon-issuance-system/handlers/campaign"
+	"github.com/jackgihokim/coupon-issuance-system/handlers/coupon"
+	couponv1 "github.com/jackgihokim/coupon-issuance-system/protos/coupon/v1"
+)
+
+// ReverseRedemption reverses a redemption, or all the redemptions of an order, e.g. when the order is refunded.
+// Each coupon is restored as the restore policy of its campaign decides, and the reversal is recorded in the
+// coupon's and the campaign's history. A campaign with a waitlist takes back the slot of a coupon which is not restored.
+// Reversing a redemption again returns the same reversal without changing anything.
+// Returns the reversals or an error if the redemption or order is unknown.
+func (s *CouponIssuanceServer) ReverseRedemption(
+	ctx context.Context,
+	req *connect.Request[couponv1.ReverseRedemptionRequest],
+) (*connect.Response[couponv1.ReverseRedemptionResponse], error) {
+	var ids []string
+	switch key := req.Msg.Key.(type) {
+	case *couponv1.ReverseRedemptionRequest_RedemptionId:
+		ids = []string{key.RedemptionId}
+	case *couponv1.ReverseRedemptionRequest_OrderId:
+		found, err := coupon.OrderRedemptions(key.OrderId)
+		if err != nil {
+			return nil, err
+		}
+		ids = found
+	default:
+		return nil, errors.New("redemption ID or order ID is required")
+	}
+
+	now := time.Now().UTC() // must use UTC for being the same as timestamppb.
+	reversals := make([]*couponv1.Reversal, 0, len(ids))
+	for _, id := range ids {
+		reversal, err := reverseRedemption(id, req.Msg.Reason, now)
+		if err != nil {
+			return nil, err
+		}
+		reversals = append(reversals, reversal)
+	}
+
+	resp := connect.NewResponse(&couponv1.ReverseRedemptionResponse{
+		Reversals: reversals,
+	})
+	return resp, nil
+}
+
+// reverseRedemption reverses the redemption at now under the restore policy of the coupon's campaign,
+// and records it in the coupon's and the campaign's history unless it was already reversed.
+// Returns the reversal or an error if the redemption or its campaign is unknown.
+func reverseRedemption(redemptionId, reason string, now time.Time) (*couponv1.Reversal, error) {
+	coup, err := coupon.Redeemed(redemptionId)
+	if err != nil {
+		return nil, err
+	}
+	camp, err := campaign.GetCampaign(coup.CampaignId)
+	if err != nil {
+		return nil, err
+	}
+
+	reversal, reversed, err := coupon.Reverse(redemptionId, camp.RestorePolicy, now)
+	if err != nil {
+		return nil, err
+	}
+	if reversed {
+		camp.Coupons.RefundBudget(redemptionId)
+		camp.History.Record(&couponv1.CampaignEvent{
+			Type:       couponv1.CampaignEventType_CAMPAIGN_EVENT_TYPE_REDEMPTION_REVERSED,
+			Code:       reversal.Code,
+			Reason:     reason,
+			OccurredAt: timestamppb.New(now),
+		})
+		if camp.Waitlist != nil {
+			returnReversedSlot(camp, reversal, reason, now)
+		}
+	}
+	return reversal, nil
+}
+
+// returnReversedSlot gives the slot of a coupon which the reversal did not restore back to the campaign with
+// a waitlist, as the coupon can no longer be used. A restored coupon is watched for expiring unused again.
+func returnReversedSlot(camp *campaign.Campaign, reversal *couponv1.Reversal, reason string, now time.Time) {
+	coup, err := coupon.Lookup(reversal.Code)
+	if err != nil {
+		return
+	}
+	if reversal.Restored {
+		camp.Coupons.Watch(coup)
+		return
+	}
+	if coup.Status == couponv1.CouponStatus_COUPON_STATUS_REDEEMED {
+		returnSlot(camp, coup, reason, now)
+	}
+}
@@ -0,0 +1,103 @@
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	couponv1 "github.com/jackgihokim/coupon-issuance-system/protos/coupon/v1"
+)
+
+func TestReverseRedemption(t *testing.T) {
+	srv := NewCouponIssuanceServer()
+	ctx := context.Background()
+
+	now := time.Now().UTC()
+	giftResp, err := srv.CreateCampaign(ctx, connect.NewRequest(&couponv1.CreateCampaignRequest{
+		CouponLimit: 10,
+		Name:        "Gift Card Test Campaign",
+		StartAt:     timestamppb.New(now.Add(-1 * time.Hour)),
+		EndAt:       timestamppb.New(now.Add(1 * time.Hour)),
+		StoredValue: &couponv1.Money{Currency: "KRW", Amount: 50000},
+	}))
+	require.NoError(t, err)
+	neverResp, err := srv.CreateCampaign(ctx, connect.NewRequest(&couponv1.CreateCampaignRequest{
+		CouponLimit:   10,
+		Name:          "No Restore Test Campaign",
+		StartAt:       timestamppb.New(now.Add(-1 * time.Hour)),
+		EndAt:         timestamppb.New(now.Add(1 * time.Hour)),
+		RestorePolicy: couponv1.RestorePolicy_RESTORE_POLICY_NEVER,
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, couponv1.RestorePolicy_RESTORE_POLICY_NEVER, neverResp.Msg.Campaign.RestorePolicy)
+
+	// An order paid with a coupon of each campaign and part of a gift card
+	campId := createTestCampaign(t, srv, 10)
+	coup := issueTestCoupon(t, srv, campId)
+	never := issueTestCoupon(t, srv, neverResp.Msg.Campaign.Id)
+	gift := issueTestCoupon(t, srv, giftResp.Msg.Campaign.Id)
+	for _, code := range []string{coup.Code, never.Code} {
+		_, err := srv.RedeemCoupon(ctx, connect.NewRequest(&couponv1.RedeemCouponRequest{Code: code, OrderId: "refunded-order"}))
+		require.NoError(t, err)
+	}
+	_, err = srv.RedeemAmount(ctx, connect.NewRequest(&couponv1.RedeemAmountRequest{
+		Code:    gift.Code,
+		Amount:  &couponv1.Money{Currency: "KRW", Amount: 20000},
+		OrderId: "refunded-order",
+	}))
+	require.NoError(t, err)
+
+	reverse := func() []*couponv1.Reversal {
+		resp, err := srv.ReverseRedemption(ctx, connect.NewRequest(&couponv1.ReverseRedemptionRequest{
+			Key:    &couponv1.ReverseRedemptionRequest_OrderId{OrderId: "refunded-order"},
+			Reason: "order refunded",
+		}))
+		require.NoError(t, err)
+		return resp.Msg.Reversals
+	}
+	reversals := reverse()
+	require.Len(t, reversals, 3)
+	assert.True(t, reversals[0].Restored)
+	assert.False(t, reversals[1].Restored)
+	assert.True(t, reversals[2].Restored)
+	assert.Equal(t, int64(50000), reversals[2].Refund.Balance.Amount)
+
+	validateResp, err := srv.ValidateCoupon(ctx, connect.NewRequest(&couponv1.ValidateCouponRequest{Code: coup.Code}))
+	require.NoError(t, err)
+	assert.True(t, validateResp.Msg.Valid)
+
+	// Each coupon records its reversal in its own history, whether restored or not
+	for i, code := range []string{coup.Code, never.Code, gift.Code} {
+		resp, err := srv.ValidateCoupon(ctx, connect.NewRequest(&couponv1.ValidateCouponRequest{Code: code}))
+		require.NoError(t, err)
+		history := resp.Msg.Coupon.History
+		require.Len(t, history, 1)
+		assert.Equal(t, couponv1.CouponEventType_COUPON_EVENT_TYPE_REDEMPTION_REVERSED, history[0].Type)
+		assert.Equal(t, reversals[i].RedemptionId, history[0].RedemptionId)
+		assert.Equal(t, "refunded-order", history[0].OrderId)
+		assert.Equal(t, reversals[i].Restored, history[0].Restored)
+		assert.Equal(t, reversals[i].ReversedAt.AsTime(), history[0].OccurredAt.AsTime())
+	}
+
+	// Reversing the order again returns the same reversals and records nothing more
+	assert.Equal(t, reversals[0].ReversedAt.AsTime(), reverse()[0].ReversedAt.AsTime())
+	validateResp, err = srv.ValidateCoupon(ctx, connect.NewRequest(&couponv1.ValidateCouponRequest{Code: coup.Code}))
+	require.NoError(t, err)
+	assert.Len(t, validateResp.Msg.Coupon.History, 1)
+	history := getTestCampaign(t, srv, campId).History
+	require.Len(t, history, 1)
+	assert.Equal(t, couponv1.CampaignEventType_CAMPAIGN_EVENT_TYPE_REDEMPTION_REVERSED, history[0].Type)
+	assert.Equal(t, "order refunded", history[0].Reason)
+
+	_, err = srv.ReverseRedemption(ctx, connect.NewRequest(&couponv1.ReverseRedemptionRequest{
+		Key: &couponv1.ReverseRedemptionRequest_RedemptionId{RedemptionId: "unknown"},
+	}))
+	assert.EqualError(t, err, "redemption not found")
+	_, err = srv.ReverseRedemption(ctx, connect.NewRequest(&couponv1.ReverseRedemptionRequest{}))
+	assert.EqualError(t, err, "redemption ID or order ID is required")
+}
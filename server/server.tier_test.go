@@ -0,0 +1,50 @@
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	couponv1 "github.com/jackgihokim/coupon-issuance-system/protos/coupon/v1"
+)
+
+func TestIssueCoupon_Tiers(t *testing.T) {
+	srv := NewCouponIssuanceServer()
+	now := time.Now().UTC()
+	percentage := func(basisPoints uint32) *couponv1.Discount {
+		return &couponv1.Discount{Kind: &couponv1.Discount_Percentage_{Percentage: &couponv1.Discount_Percentage{BasisPoints: basisPoints}}}
+	}
+	resp, err := srv.CreateCampaign(context.Background(), connect.NewRequest(&couponv1.CreateCampaignRequest{
+		CouponLimit: 5,
+		Name:        "Tiered Test Campaign",
+		StartAt:     timestamppb.New(now.Add(-1 * time.Hour)),
+		EndAt:       timestamppb.New(now.Add(1 * time.Hour)),
+		Tiers: []*couponv1.Tier{
+			{Name: "first", Limit: 1, Discount: percentage(5000)},
+			{Name: "early", Limit: 2, Discount: percentage(2000)},
+			{Name: "rest", Discount: percentage(1000)},
+		},
+	}))
+	require.NoError(t, err)
+	campId := resp.Msg.Campaign.Id
+
+	for _, want := range []struct {
+		tier        string
+		basisPoints uint32
+	}{{"first", 5000}, {"early", 2000}, {"early", 2000}, {"rest", 1000}} {
+		coup := issueTestCoupon(t, srv, campId)
+		assert.Equal(t, want.tier, coup.Tier)
+		assert.Equal(t, want.basisPoints, coup.Discount.GetPercentage().GetBasisPoints())
+	}
+
+	stats := getTestCampaign(t, srv, campId).TierStats
+	require.Len(t, stats, 3)
+	assert.Equal(t, uint32(0), stats[1].Remaining)
+	assert.Equal(t, uint32(1), stats[2].Issued)
+	assert.Equal(t, uint32(1), stats[2].Remaining)
+}
@@ -0,0 +1,125 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"connectrpc.com/connect"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/jackgihokim/coupon-issuance-system/handlers/campaign"
+	"github.com/jackgihokim/coupon-issuance-system/handlers/coupon"
+	couponv1 "github.com/jackgihokim/coupon-issuance-system/protos/coupon/v1"
+)
+
+// TransferCoupon transfers a valid coupon from its owner to another user under the campaign's transfer policy,
+// or offers it with a claim link the recipient accepts by ClaimCoupon if no recipient is given.
+// The recipient must be allowed and eligible if the policy checks eligibility, and each transfer is recorded in
+// the coupon's ownership chain and the campaign's history.
+// Returns the coupon and the one-time token of a claim link, or an error if the coupon cannot be transferred.
+func (s *CouponIssuanceServer) TransferCoupon(
+	ctx context.Context,
+	req *connect.Request[couponv1.TransferCouponRequest],
+) (*connect.Response[couponv1.TransferCouponResponse], error) {
+	now := time.Now().UTC() // must use UTC for being the same as timestamppb.
+	camp, err := getTransferableCampaign(req.Msg.Code, now)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Msg.ToUserId == "" {
+		coup, token, err := coupon.Offer(req.Msg.Code, req.Msg.FromUserId, camp.Transfer.MaxTransfers, coupon.ClaimTTL(camp.Transfer), now)
+		if err != nil {
+			return nil, err
+		}
+		resp := connect.NewResponse(&couponv1.TransferCouponResponse{
+			Coupon:     coup,
+			ClaimToken: token,
+		})
+		return resp, nil
+	}
+
+	err = s.checkRecipient(ctx, camp, req.Msg.ToUserId, req.Msg.ToUserAttributes)
+	if err != nil {
+		return nil, err
+	}
+	coup, err := coupon.Transfer(req.Msg.Code, req.Msg.FromUserId, req.Msg.ToUserId, camp.Transfer.MaxTransfers, now)
+	if err != nil {
+		return nil, err
+	}
+	recordTransfer(camp, coup, now)
+
+	resp := connect.NewResponse(&couponv1.TransferCouponResponse{
+		Coupon: coup,
+	})
+	return resp, nil
+}
+
+// ClaimCoupon accepts a coupon offered with a claim link, using up its one-time token. The user must be allowed
+// and eligible if the campaign's transfer policy checks eligibility.
+// Returns the transferred coupon or an error if the token is not valid or the coupon cannot be transferred.
+func (s *CouponIssuanceServer) ClaimCoupon(
+	ctx context.Context,
+	req *connect.Request[couponv1.ClaimCouponRequest],
+) (*connect.Response[couponv1.ClaimCouponResponse], error) {
+	now := time.Now().UTC() // must use UTC for being the same as timestamppb.
+	camp, err := getTransferableCampaign(req.Msg.Code, now)
+	if err != nil {
+		return nil, err
+	}
+	err = s.checkRecipient(ctx, camp, req.Msg.UserId, req.Msg.UserAttributes)
+	if err != nil {
+		return nil, err
+	}
+
+	coup, err := coupon.Claim(req.Msg.Code, req.Msg.ClaimToken, req.Msg.UserId, now)
+	if err != nil {
+		return nil, err
+	}
+	recordTransfer(camp, coup, now)
+
+	resp := connect.NewResponse(&couponv1.ClaimCouponResponse{
+		Coupon: coup,
+	})
+	return resp, nil
+}
+
+// getTransferableCampaign validates the coupon code at now and returns the campaign of the coupon.
+// Returns an error if the code is not valid or the campaign's coupons are not transferable.
+func getTransferableCampaign(code string, now time.Time) (*campaign.Campaign, error) {
+	_, camp, reason := validateCoupon(code, now)
+	if err := coupon.ReasonError(reason); err != nil {
+		return nil, err
+	}
+	if camp.Transfer == nil {
+		return nil, errors.New("coupons of the campaign are not transferable")
+	}
+	return camp, nil
+}
+
+// checkRecipient checks the recipient of a transfer against the campaign's user lists and eligibility rule,
+// if its transfer policy checks eligibility. Returns an error if the recipient cannot be given the coupon.
+func (s *CouponIssuanceServer) checkRecipient(
+	ctx context.Context, camp *campaign.Campaign, userId string, attrs *couponv1.UserAttributes,
+) error {
+	if !camp.Transfer.CheckEligibility || userId == "" {
+		return nil
+	}
+	if err := camp.CheckUser(userId); err != nil {
+		return err
+	}
+	return s.checkEligibility(ctx, camp, userId, attrs)
+}
+
+// recordTransfer records the latest transfer of the coupon in the campaign's history.
+func recordTransfer(camp *campaign.Campaign, coup *couponv1.Coupon, now time.Time) {
+	transfer := coup.Transfers[len(coup.Transfers)-1]
+	camp.History.Record(&couponv1.CampaignEvent{
+		Type:       couponv1.CampaignEventType_CAMPAIGN_EVENT_TYPE_COUPON_TRANSFERRED,
+		Code:       coup.Code,
+		Reason:     "transferred by " + transfer.FromUserId,
+		OccurredAt: timestamppb.New(now),
+		UserId:     transfer.ToUserId,
+	})
+}
@@ -0,0 +1,97 @@
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	couponv1 "github.com/jackgihokim/coupon-issuance-system/protos/coupon/v1"
+)
+
+func TestTransferCoupon(t *testing.T) {
+	srv := NewCouponIssuanceServer()
+	ctx := context.Background()
+
+	now := time.Now().UTC()
+	createResp, err := srv.CreateCampaign(ctx, connect.NewRequest(&couponv1.CreateCampaignRequest{
+		CouponLimit: 10,
+		Name:        "Gift Test Campaign",
+		StartAt:     timestamppb.New(now.Add(-1 * time.Hour)),
+		EndAt:       timestamppb.New(now.Add(1 * time.Hour)),
+		Eligibility: `country == "KR"`,
+		Transfer:    &couponv1.TransferPolicy{MaxTransfers: 2, CheckEligibility: true},
+	}))
+	require.NoError(t, err)
+	campId := createResp.Msg.Campaign.Id
+	issueResp, err := srv.IssueCoupon(ctx, connect.NewRequest(&couponv1.IssueCouponRequest{
+		CampaignId:     campId,
+		UserId:         "alice",
+		UserAttributes: &couponv1.UserAttributes{Country: "KR"},
+	}))
+	require.NoError(t, err)
+	code := issueResp.Msg.Coupon.Code
+
+	transfer := func(from, to, country string) (*couponv1.TransferCouponResponse, error) {
+		resp, err := srv.TransferCoupon(ctx, connect.NewRequest(&couponv1.TransferCouponRequest{
+			Code:             code,
+			FromUserId:       from,
+			ToUserId:         to,
+			ToUserAttributes: &couponv1.UserAttributes{Country: country},
+		}))
+		if err != nil {
+			return nil, err
+		}
+		return resp.Msg, nil
+	}
+
+	// The recipient must be eligible for the campaign
+	_, err = transfer("alice", "bob", "US")
+	assert.EqualError(t, err, "user is not eligible for the campaign")
+	resp, err := transfer("alice", "bob", "KR")
+	require.NoError(t, err)
+	assert.Equal(t, "bob", resp.Coupon.UserId)
+	assert.Empty(t, resp.ClaimToken)
+
+	// Bob offers it with a claim link, which Carol accepts
+	resp, err = transfer("bob", "", "")
+	require.NoError(t, err)
+	require.NotEmpty(t, resp.ClaimToken)
+	assert.Equal(t, "bob", resp.Coupon.TransferOffer.FromUserId)
+	claimResp, err := srv.ClaimCoupon(ctx, connect.NewRequest(&couponv1.ClaimCouponRequest{
+		Code:           code,
+		ClaimToken:     resp.ClaimToken,
+		UserId:         "carol",
+		UserAttributes: &couponv1.UserAttributes{Country: "KR"},
+	}))
+	require.NoError(t, err)
+	chain := claimResp.Msg.Coupon.Transfers
+	require.Len(t, chain, 2)
+	assert.Equal(t, []string{"alice", "bob", "carol"}, []string{chain[0].FromUserId, chain[1].FromUserId, chain[1].ToUserId})
+	assert.True(t, chain[1].Claimed)
+
+	_, err = transfer("carol", "dave", "KR")
+	assert.EqualError(t, err, "coupon reached the maximum number of transfers")
+
+	history := getTestCampaign(t, srv, campId).History
+	require.Len(t, history, 2)
+	assert.Equal(t, couponv1.CampaignEventType_CAMPAIGN_EVENT_TYPE_COUPON_TRANSFERRED, history[1].Type)
+	assert.Equal(t, "carol", history[1].UserId)
+
+	// Coupons of campaigns without a transfer policy stay with their owner
+	plain, err := srv.IssueCoupon(ctx, connect.NewRequest(&couponv1.IssueCouponRequest{
+		CampaignId: createTestCampaign(t, srv, 10),
+		UserId:     "alice",
+	}))
+	require.NoError(t, err)
+	_, err = srv.TransferCoupon(ctx, connect.NewRequest(&couponv1.TransferCouponRequest{
+		Code:       plain.Msg.Coupon.Code,
+		FromUserId: "alice",
+		ToUserId:   "bob",
+	}))
+	assert.EqualError(t, err, "coupons of the campaign are not transferable")
+}
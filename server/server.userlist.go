@@ -0,0 +1,81 @@
+package server
+
+import (
+	"context"
+	"errors"
+
+	"connectrpc.com/connect"
+
+	"github.com/jackgihokim/coupon-issuance-system/handlers/campaign"
+	"github.com/jackgihokim/coupon-issuance-system/handlers/userlist"
+	couponv1 "github.com/jackgihokim/coupon-issuance-system/protos/coupon/v1"
+)
+
+// UploadUserList attaches an allowlist or blocklist of user IDs streamed in chunks to a campaign.
+// The campaign keeps its previous list of the kind until the whole upload succeeds.
+// Returns a summary of the uploaded list or an error if the upload is invalid.
+func (s *CouponIssuanceServer) UploadUserList(
+	ctx context.Context,
+	stream *connect.ClientStream[couponv1.UploadUserListRequest],
+) (*connect.Response[couponv1.UploadUserListResponse], error) {
+	if !stream.Receive() {
+		if err := stream.Err(); err != nil {
+			return nil, err
+		}
+		return nil, errors.New("user list upload is empty")
+	}
+	first := stream.Msg()
+
+	camp, err := campaign.GetCampaign(first.CampaignId)
+	if err != nil {
+		return nil, err
+	}
+	if first.Kind == couponv1.UserListKind_USER_LIST_KIND_UNSPECIFIED {
+		return nil, errors.New("user list kind must be specified")
+	}
+	list, err := userlist.New(first.BloomFilter)
+	if err != nil {
+		return nil, err
+	}
+
+	err = addUserIds(list, first.UserIds)
+	for err == nil && stream.Receive() {
+		err = addUserIds(list, stream.Msg().UserIds)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := stream.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := camp.SetUserList(first.Kind, list); err != nil {
+		return nil, err
+	}
+
+	resp := connect.NewResponse(&couponv1.UploadUserListResponse{
+		CampaignId: camp.Id,
+		List:       newUserListMessage(first.Kind, list),
+	})
+	return resp, nil
+}
+
+// addUserIds adds the user IDs of a chunk to the list. Returns an error at the first invalid ID.
+func addUserIds(list *userlist.List, userIds []string) error {
+	for _, userId := range userIds {
+		if err := list.Add(userId); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// newUserListMessage summarizes the user list of the kind as its protobuf message.
+func newUserListMessage(kind couponv1.UserListKind, list *userlist.List) *couponv1.UserList {
+	return &couponv1.UserList{
+		Kind:        kind,
+		UserCount:   list.Len(),
+		SizeBytes:   list.SizeBytes(),
+		BloomFilter: list.BloomFilter(),
+	}
+}
@@ -0,0 +1,103 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	couponv1 "github.com/jackgihokim/coupon-issuance-system/protos/coupon/v1"
+	"github.com/jackgihokim/coupon-issuance-system/protos/coupon/v1/couponv1connect"
+)
+
+// newTestClient serves the server over HTTP for streaming RPCs and returns a client of it.
+func newTestClient(t *testing.T, srv *CouponIssuanceServer) couponv1connect.CouponIssuanceServiceClient {
+	mux := http.NewServeMux()
+	mux.Handle(couponv1connect.NewCouponIssuanceServiceHandler(srv))
+	ts := httptest.NewUnstartedServer(mux)
+	ts.EnableHTTP2 = true
+	ts.StartTLS()
+	t.Cleanup(ts.Close)
+	return couponv1connect.NewCouponIssuanceServiceClient(ts.Client(), ts.URL)
+}
+
+// uploadUserList streams the user IDs in chunks of the size and returns the response.
+func uploadUserList(
+	client couponv1connect.CouponIssuanceServiceClient, first *couponv1.UploadUserListRequest, userIds []string, chunk int,
+) (*connect.Response[couponv1.UploadUserListResponse], error) {
+	stream := client.UploadUserList(context.Background())
+	if err := stream.Send(first); err != nil {
+		return stream.CloseAndReceive()
+	}
+	for i := 0; i < len(userIds); i += chunk {
+		if err := stream.Send(&couponv1.UploadUserListRequest{UserIds: userIds[i:min(i+chunk, len(userIds))]}); err != nil {
+			break
+		}
+	}
+	return stream.CloseAndReceive()
+}
+
+func TestUploadUserList(t *testing.T) {
+	srv := NewCouponIssuanceServer()
+	client := newTestClient(t, srv)
+	campId := createTestCampaign(t, srv, 10)
+
+	userIds := make([]string, 10000)
+	for i := range userIds {
+		userIds[i] = fmt.Sprintf("crm-%d", i)
+	}
+	resp, err := uploadUserList(client, &couponv1.UploadUserListRequest{
+		CampaignId: campId,
+		Kind:       couponv1.UserListKind_USER_LIST_KIND_ALLOWLIST,
+	}, append(userIds, "crm-0"), 1000)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(10000), resp.Msg.List.UserCount)
+
+	_, err = uploadUserList(client, &couponv1.UploadUserListRequest{
+		CampaignId:  campId,
+		Kind:        couponv1.UserListKind_USER_LIST_KIND_BLOCKLIST,
+		BloomFilter: &couponv1.BloomFilter{ExpectedUsers: 1000, FalsePositiveRate: 0.001},
+		UserIds:     []string{"crm-1"},
+	}, nil, 1)
+	require.NoError(t, err)
+
+	issue := func(userId string) error {
+		_, err := srv.IssueCoupon(context.Background(), connect.NewRequest(&couponv1.IssueCouponRequest{
+			CampaignId: campId,
+			UserId:     userId,
+		}))
+		return err
+	}
+	assert.NoError(t, issue("crm-0"))
+	assert.EqualError(t, issue("crm-1"), "user is blocked from the campaign")
+	assert.EqualError(t, issue("someone-else"), "user is not on the campaign's allowlist")
+	assert.EqualError(t, issue(""), "user ID is required for the campaign")
+
+	getResp, err := srv.GetCampaign(context.Background(), connect.NewRequest(&couponv1.GetCampaignRequest{CampaignId: campId}))
+	require.NoError(t, err)
+	assert.Equal(t, uint64(10000), getResp.Msg.Campaign.Allowlist.UserCount)
+	assert.NotNil(t, getResp.Msg.Campaign.Blocklist.BloomFilter)
+
+	t.Run("failed upload keeps the previous list", func(t *testing.T) {
+		_, err := uploadUserList(client, &couponv1.UploadUserListRequest{
+			CampaignId: campId,
+			Kind:       couponv1.UserListKind_USER_LIST_KIND_ALLOWLIST,
+		}, []string{"crm-new", ""}, 1)
+		assert.Error(t, err)
+		assert.NoError(t, issue("crm-2"))
+	})
+
+	t.Run("invalid uploads", func(t *testing.T) {
+		_, err := uploadUserList(client, &couponv1.UploadUserListRequest{CampaignId: campId}, nil, 1)
+		assert.Error(t, err)
+		_, err = uploadUserList(client, &couponv1.UploadUserListRequest{
+			CampaignId: 0, Kind: couponv1.UserListKind_USER_LIST_KIND_ALLOWLIST,
+		}, nil, 1)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,78 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	couponv1 "github.com/jackgihokim/coupon-issuance-system/protos/coupon/v1"
+)
+
+func TestIssueCoupon_Variants(t *testing.T) {
+	srv := NewCouponIssuanceServer()
+	ctx := context.Background()
+	now := time.Now().UTC()
+	percentage := func(basisPoints uint32) *couponv1.Discount {
+		return &couponv1.Discount{Kind: &couponv1.Discount_Percentage_{Percentage: &couponv1.Discount_Percentage{BasisPoints: basisPoints}}}
+	}
+	resp, err := srv.CreateCampaign(ctx, connect.NewRequest(&couponv1.CreateCampaignRequest{
+		CouponLimit: 20,
+		Name:        "A/B Test Campaign",
+		StartAt:     timestamppb.New(now.Add(-1 * time.Hour)),
+		EndAt:       timestamppb.New(now.Add(1 * time.Hour)),
+		Variants: []*couponv1.Variant{
+			{Name: "ten", Weight: 1, Discount: percentage(1000)},
+			{Name: "twenty", Weight: 1, Discount: percentage(2000), Limit: 10},
+		},
+	}))
+	require.NoError(t, err)
+	campId := resp.Msg.Campaign.Id
+
+	issue := func(userId string) *couponv1.Coupon {
+		resp, err := srv.IssueCoupon(ctx, connect.NewRequest(&couponv1.IssueCouponRequest{
+			CampaignId: campId,
+			UserId:     userId,
+		}))
+		require.NoError(t, err)
+		return resp.Msg.Coupon
+	}
+
+	issued := make(map[string]uint32)
+	var first *couponv1.Coupon
+	for i := 0; i < 5; i++ {
+		coup := issue(fmt.Sprintf("ab-user-%d", i))
+		wantBasisPoints := map[string]uint32{"ten": 1000, "twenty": 2000}[coup.Variant]
+		require.NotZero(t, wantBasisPoints, "unexpected variant %q", coup.Variant)
+		assert.Equal(t, wantBasisPoints, coup.Discount.GetPercentage().GetBasisPoints())
+		issued[coup.Variant]++
+		if first == nil {
+			first = coup
+		}
+	}
+
+	_, err = srv.RedeemCoupon(ctx, connect.NewRequest(&couponv1.RedeemCouponRequest{Code: first.Code}))
+	require.NoError(t, err)
+
+	// Retries by the same user stay in the same variant
+	assert.Equal(t, first.Variant, issue(first.UserId).Variant)
+	issued[first.Variant]++
+
+	_, err = srv.IssueCoupon(ctx, connect.NewRequest(&couponv1.IssueCouponRequest{CampaignId: campId}))
+	assert.Error(t, err)
+
+	stats := getTestCampaign(t, srv, campId).VariantStats
+	require.Len(t, stats, 2)
+	for _, s := range stats {
+		assert.Equal(t, issued[s.Name], s.Issued)
+		if s.Name == first.Variant {
+			assert.Equal(t, uint32(1), s.Redeemed)
+		}
+	}
+	assert.Equal(t, uint32(10-issued["twenty"]), stats[1].Remaining)
+}
@@ -0,0 +1,161 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"connectrpc.com/connect"
+
+	"github.com/jackgihokim/coupon-issuance-system/handlers/campaign"
+	"github.com/jackgihokim/coupon-issuance-system/handlers/coupon"
+	couponv1 "github.com/jackgihokim/coupon-issuance-system/protos/coupon/v1"
+)
+
+// JoinWaitlist puts a user on the waitlist of a sold out campaign, once per user. The user is issued a coupon
+// as soon as a slot is given back to the campaign and everyone ahead has been issued one, which WatchWaitlist
+// streams and GetWaitlistStatus tells.
+// Users who are not allowed by the campaign's user lists or don't satisfy its eligibility rule cannot join.
+// Returns the position of the user or an error if the campaign has no waitlist, is not sold out,
+// or the user cannot join.
+func (s *CouponIssuanceServer) JoinWaitlist(
+	ctx context.Context,
+	req *connect.Request[couponv1.JoinWaitlistRequest],
+) (*connect.Response[couponv1.JoinWaitlistResponse], error) {
+	camp, err := getWaitlistedCampaign(req.Msg.CampaignId)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now().UTC() // must use UTC for being the same as timestamppb.
+	if camp.State(now) != couponv1.CampaignState_CAMPAIGN_STATE_SOLD_OUT {
+		return nil, errors.New("waitlist opens once the campaign is sold out")
+	}
+	err = camp.CheckUser(req.Msg.UserId)
+	if err != nil {
+		return nil, err
+	}
+	err = s.checkEligibility(ctx, camp, req.Msg.UserId, req.Msg.UserAttributes)
+	if err != nil {
+		return nil, err
+	}
+
+	position, err := camp.Waitlist.Join(req.Msg.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := connect.NewResponse(&couponv1.JoinWaitlistResponse{
+		Position: position,
+	})
+	return resp, nil
+}
+
+// GetWaitlistStatus tells a user who joined the waitlist of a campaign their position, or the coupon issued to
+// them from the waitlist once a slot was given back.
+// Returns an error if the campaign has no waitlist or the user did not join it.
+func (s *CouponIssuanceServer) GetWaitlistStatus(
+	ctx context.Context,
+	req *connect.Request[couponv1.GetWaitlistStatusRequest],
+) (*connect.Response[couponv1.GetWaitlistStatusResponse], error) {
+	camp, err := getWaitlistedCampaign(req.Msg.CampaignId)
+	if err != nil {
+		return nil, err
+	}
+	status, err := camp.Waitlist.Status(req.Msg.UserId)
+	if err != nil {
+		return nil, err
+	}
+	msg, err := newWaitlistStatus(status)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := connect.NewResponse(&couponv1.GetWaitlistStatusResponse{
+		Position: msg.Position,
+		Coupon:   msg.Coupon,
+	})
+	return resp, nil
+}
+
+// WatchWaitlist streams the place of a user on the waitlist of a campaign whenever it moves,
+// and ends once the user is issued a coupon from the waitlist, with the coupon.
+// Returns an error if the campaign has no waitlist or the user did not join it.
+func (s *CouponIssuanceServer) WatchWaitlist(
+	ctx context.Context,
+	req *connect.Request[couponv1.WatchWaitlistRequest],
+	stream *connect.ServerStream[couponv1.WaitlistStatus],
+) error {
+	camp, err := getWaitlistedCampaign(req.Msg.CampaignId)
+	if err != nil {
+		return err
+	}
+
+	sent := uint64(0)
+	for {
+		status, moved, err := camp.Waitlist.Watch(req.Msg.UserId)
+		if err != nil {
+			return err
+		}
+		if sent == 0 || status.Position != sent {
+			msg, err := newWaitlistStatus(status)
+			if err != nil {
+				return err
+			}
+			if err := stream.Send(msg); err != nil {
+				return err
+			}
+			sent = status.Position
+		}
+		if status.Code != "" {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-moved:
+		}
+	}
+}
+
+// getWaitlistedCampaign returns the campaign if it has a waitlist.
+func getWaitlistedCampaign(campaignId uint32) (*campaign.Campaign, error) {
+	camp, err := campaign.GetCampaign(campaignId)
+	if err != nil {
+		return nil, err
+	}
+	if camp.Waitlist == nil {
+		return nil, errors.New("campaign has no waitlist")
+	}
+	return camp, nil
+}
+
+// newWaitlistStatus converts the place of a user on a waitlist into its protobuf message, with the coupon issued
+// to the user as the code index has it now.
+func newWaitlistStatus(status campaign.WaitlistStatus) (*couponv1.WaitlistStatus, error) {
+	msg := &couponv1.WaitlistStatus{
+		Position: status.Position,
+	}
+	if status.Code != "" {
+		coup, err := coupon.Lookup(status.Code)
+		if err != nil {
+			return nil, err
+		}
+		msg.Coupon = coup
+	}
+	return msg, nil
+}
+
+// returnExpiredSlots releases what the coupons which expired unused by now reserved of the budget, and gives back
+// their slots to the campaigns with a waitlist. Only the campaigns with a budget or a waitlist watch their coupons
+// for it.
+func returnExpiredSlots(now time.Time) {
+	for _, camp := range campaign.ListCampaigns() {
+		for _, coup := range camp.Coupons.Expired(now) {
+			camp.Coupons.ReleaseBudget(coup.Code)
+			if camp.Waitlist != nil {
+				returnSlot(camp, coup, "expired unused", now)
+			}
+		}
+	}
+}
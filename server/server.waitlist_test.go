@@ -0,0 +1,234 @@
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	couponv1 "github.com/jackgihokim/coupon-issuance-system/protos/coupon/v1"
+)
+
+func TestWaitlist(t *testing.T) {
+	srv := NewCouponIssuanceServer()
+	ctx := context.Background()
+	campId := createTestCampaign(t, srv, 1)
+	camp := getTestCampaign(t, srv, campId)
+	assert.Nil(t, camp.Waitlist)
+	_, err := srv.JoinWaitlist(ctx, connect.NewRequest(&couponv1.JoinWaitlistRequest{CampaignId: campId, UserId: "a"}))
+	assert.EqualError(t, err, "campaign has no waitlist")
+
+	createResp, err := srv.CreateCampaign(ctx, connect.NewRequest(&couponv1.CreateCampaignRequest{
+		CouponLimit: 1,
+		Name:        "Waitlist Test Campaign",
+		StartAt:     camp.StartAt,
+		EndAt:       camp.EndAt,
+		Waitlist:    true,
+	}))
+	require.NoError(t, err)
+	campId = createResp.Msg.Campaign.Id
+
+	join := func(userId string) (uint64, error) {
+		resp, err := srv.JoinWaitlist(ctx, connect.NewRequest(&couponv1.JoinWaitlistRequest{
+			CampaignId: campId,
+			UserId:     userId,
+		}))
+		if err != nil {
+			return 0, err
+		}
+		return resp.Msg.Position, nil
+	}
+	revoke := func(code string) {
+		_, err := srv.RevokeCoupon(ctx, connect.NewRequest(&couponv1.RevokeCouponRequest{
+			Code:         code,
+			Reason:       "fraud",
+			ReturnToPool: true,
+		}))
+		require.NoError(t, err)
+	}
+
+	_, err = join("a")
+	assert.EqualError(t, err, "waitlist opens once the campaign is sold out")
+	first := issueTestCoupon(t, srv, campId)
+
+	for i, userId := range []string{"a", "b"} {
+		pos, err := join(userId)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(i+1), pos)
+	}
+	_, err = join("a")
+	assert.EqualError(t, err, "user already joined the waitlist")
+
+	// Each returned slot goes to the next waitlisted user, and the campaign stays sold out meanwhile
+	revoke(first.Code)
+	camp = getTestCampaign(t, srv, campId)
+	assert.Equal(t, couponv1.CampaignState_CAMPAIGN_STATE_SOLD_OUT, camp.State)
+	issued := camp.History[len(camp.History)-1]
+	assert.Equal(t, couponv1.CampaignEventType_CAMPAIGN_EVENT_TYPE_WAITLIST_ISSUED, issued.Type)
+	assert.Equal(t, "a", issued.UserId)
+	assert.Equal(t, &couponv1.Waitlist{Waiting: 1, Issued: 1}, camp.Waitlist)
+	_, err = srv.IssueCoupon(ctx, connect.NewRequest(&couponv1.IssueCouponRequest{CampaignId: campId}))
+	assert.EqualError(t, err, "no more coupon")
+
+	status, err := srv.GetWaitlistStatus(ctx, connect.NewRequest(&couponv1.GetWaitlistStatusRequest{
+		CampaignId: campId,
+		UserId:     "a",
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, uint64(0), status.Msg.Position)
+	assert.Equal(t, issued.Code, status.Msg.Coupon.Code)
+	assert.Equal(t, "a", status.Msg.Coupon.UserId)
+	status, err = srv.GetWaitlistStatus(ctx, connect.NewRequest(&couponv1.GetWaitlistStatusRequest{
+		CampaignId: campId,
+		UserId:     "b",
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), status.Msg.Position)
+	assert.Nil(t, status.Msg.Coupon)
+
+	revoke(issued.Code)
+	camp = getTestCampaign(t, srv, campId)
+	issued = camp.History[len(camp.History)-1]
+	assert.Equal(t, "b", issued.UserId)
+
+	// With nobody waiting the slot goes back to the pool
+	revoke(issued.Code)
+	camp = getTestCampaign(t, srv, campId)
+	assert.Equal(t, couponv1.CampaignState_CAMPAIGN_STATE_ACTIVE, camp.State)
+	assert.Equal(t, &couponv1.Waitlist{Waiting: 0, Issued: 2}, camp.Waitlist)
+}
+
+func TestWaitlist_Watch(t *testing.T) {
+	srv := NewCouponIssuanceServer()
+	client := newTestClient(t, srv)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	now := time.Now().UTC()
+	createResp, err := srv.CreateCampaign(ctx, connect.NewRequest(&couponv1.CreateCampaignRequest{
+		CouponLimit: 1,
+		Name:        "Waitlist Watch Test Campaign",
+		StartAt:     timestamppb.New(now.Add(-time.Hour)),
+		EndAt:       timestamppb.New(now.Add(time.Hour)),
+		Waitlist:    true,
+	}))
+	require.NoError(t, err)
+	campId := createResp.Msg.Campaign.Id
+
+	issued := issueTestCoupon(t, srv, campId)
+	for _, userId := range []string{"a", "b"} {
+		_, err = srv.JoinWaitlist(ctx, connect.NewRequest(&couponv1.JoinWaitlistRequest{CampaignId: campId, UserId: userId}))
+		require.NoError(t, err)
+	}
+	revoke := func(code string) {
+		_, err := srv.RevokeCoupon(ctx, connect.NewRequest(&couponv1.RevokeCouponRequest{
+			Code:         code,
+			Reason:       "fraud",
+			ReturnToPool: true,
+		}))
+		require.NoError(t, err)
+	}
+
+	stream, err := client.WatchWaitlist(ctx, connect.NewRequest(&couponv1.WatchWaitlistRequest{
+		CampaignId: campId,
+		UserId:     "b",
+	}))
+	require.NoError(t, err)
+	require.True(t, stream.Receive())
+	assert.Equal(t, uint64(2), stream.Msg().Position)
+
+	// The user is told of every move, and of the coupon as soon as it is issued to them
+	revoke(issued.Code)
+	require.True(t, stream.Receive())
+	assert.Equal(t, uint64(1), stream.Msg().Position)
+
+	a, err := srv.GetWaitlistStatus(ctx, connect.NewRequest(&couponv1.GetWaitlistStatusRequest{CampaignId: campId, UserId: "a"}))
+	require.NoError(t, err)
+	revoke(a.Msg.Coupon.Code)
+	require.True(t, stream.Receive())
+	assert.Equal(t, uint64(0), stream.Msg().Position)
+	require.NotNil(t, stream.Msg().Coupon)
+	assert.Equal(t, "b", stream.Msg().Coupon.UserId)
+	assert.False(t, stream.Receive())
+	require.NoError(t, stream.Err())
+
+	stream, err = client.WatchWaitlist(ctx, connect.NewRequest(&couponv1.WatchWaitlistRequest{CampaignId: campId, UserId: "c"}))
+	require.NoError(t, err)
+	assert.False(t, stream.Receive())
+	assert.ErrorContains(t, stream.Err(), "user is not on the waitlist")
+}
+
+func TestWaitlist_ExpiredAndReversed(t *testing.T) {
+	srv := NewCouponIssuanceServer()
+	ctx := context.Background()
+	now := time.Now().UTC()
+	createResp, err := srv.CreateCampaign(ctx, connect.NewRequest(&couponv1.CreateCampaignRequest{
+		CouponLimit:   1,
+		Name:          "Waitlist Expiry Test Campaign",
+		StartAt:       timestamppb.New(now.Add(-time.Hour)),
+		EndAt:         timestamppb.New(now.Add(24 * time.Hour)),
+		ExpiryPolicy:  &couponv1.ExpiryPolicy{Policy: &couponv1.ExpiryPolicy_Ttl{Ttl: durationpb.New(time.Hour)}},
+		RestorePolicy: couponv1.RestorePolicy_RESTORE_POLICY_NEVER,
+		Waitlist:      true,
+	}))
+	require.NoError(t, err)
+	campId := createResp.Msg.Campaign.Id
+
+	first := issueTestCoupon(t, srv, campId)
+	for _, userId := range []string{"a", "b"} {
+		_, err = srv.JoinWaitlist(ctx, connect.NewRequest(&couponv1.JoinWaitlistRequest{CampaignId: campId, UserId: userId}))
+		require.NoError(t, err)
+	}
+	waitlisted := func(userId string) *couponv1.Coupon {
+		resp, err := srv.GetWaitlistStatus(ctx, connect.NewRequest(&couponv1.GetWaitlistStatusRequest{
+			CampaignId: campId,
+			UserId:     userId,
+		}))
+		require.NoError(t, err)
+		return resp.Msg.Coupon
+	}
+
+	// The slot of a coupon which expired unused goes to the first waitlisted user, once
+	returnExpiredSlots(now)
+	assert.Nil(t, waitlisted("a"))
+	returnExpiredSlots(now.Add(2 * time.Hour))
+	returnExpiredSlots(now.Add(2 * time.Hour))
+	issued := waitlisted("a")
+	require.NotNil(t, issued)
+	assert.Nil(t, waitlisted("b"))
+	assert.Equal(t, &couponv1.Waitlist{Waiting: 1, Issued: 1}, getTestCampaign(t, srv, campId).Waitlist)
+
+	// Revoking the expired coupon back to the pool gives nothing back again
+	_, err = srv.RevokeCoupon(ctx, connect.NewRequest(&couponv1.RevokeCouponRequest{
+		Code:         first.Code,
+		Reason:       "fraud",
+		ReturnToPool: true,
+	}))
+	require.NoError(t, err)
+	assert.Nil(t, waitlisted("b"))
+
+	// A reversal which does not restore the coupon gives its slot to the next waitlisted user
+	redeemResp, err := srv.RedeemCoupon(ctx, connect.NewRequest(&couponv1.RedeemCouponRequest{
+		Code:    issued.Code,
+		OrderId: "waitlist-refunded-order",
+	}))
+	require.NoError(t, err)
+	_, err = srv.ReverseRedemption(ctx, connect.NewRequest(&couponv1.ReverseRedemptionRequest{
+		Key:    &couponv1.ReverseRedemptionRequest_RedemptionId{RedemptionId: redeemResp.Msg.Coupon.RedemptionId},
+		Reason: "refund",
+	}))
+	require.NoError(t, err)
+	require.NotNil(t, waitlisted("b"))
+	assert.Equal(t, couponv1.CampaignState_CAMPAIGN_STATE_SOLD_OUT, getTestCampaign(t, srv, campId).State)
+}
+
+// getTestCampaign returns the campaign with its coupons and history.
+func getTestCampaign(t *testing.T, srv *CouponIssuanceServer, campId uint32) *couponv1.Campaign {
+	resp, err := srv.GetCampaign(context.Background(), connect.NewRequest(&couponv1.GetCampaignRequest{CampaignId: campId}))
+	require.NoError(t, err)
+	return resp.Msg.Campaign
+}
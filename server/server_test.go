@@ -11,6 +11,7 @@ import (
 	"connectrpc.com/connect"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/durationpb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
 	couponv1 "github.com/jackgihokim/coupon-issuance-system/protos/coupon/v1"
@@ -249,3 +250,172 @@ func TestHighTrafficCouponIssuance(t *testing.T) {
 		assert.Equal(t, 0, duplicates, "No duplicate campaign IDs should be generated")
 	})
 }
+
+// TestIssueCoupon_ExpiryPolicy verifies that the campaign's expiry policy decides the expiration of issued coupons.
+func TestIssueCoupon_ExpiryPolicy(t *testing.T) {
+	srv := NewCouponIssuanceServer()
+
+	now := time.Now().UTC()
+	createCampResp, err := srv.CreateCampaign(context.Background(), connect.NewRequest(&couponv1.CreateCampaignRequest{
+		CouponLimit: 10,
+		Name:        "Expiry Policy Test Campaign",
+		StartAt:     timestamppb.New(now.Add(-1 * time.Hour)),
+		EndAt:       timestamppb.New(now.Add(24 * time.Hour)),
+		ExpiryPolicy: &couponv1.ExpiryPolicy{
+			Policy: &couponv1.ExpiryPolicy_Ttl{Ttl: durationpb.New(72 * time.Hour)},
+		},
+	}))
+	require.NoError(t, err)
+	assert.NotNil(t, createCampResp.Msg.Campaign.ExpiryPolicy)
+
+	issueResp, err := srv.IssueCoupon(context.Background(), connect.NewRequest(&couponv1.IssueCouponRequest{
+		CampaignId: createCampResp.Msg.Campaign.Id,
+	}))
+	require.NoError(t, err)
+
+	coup := issueResp.Msg.Coupon
+	assert.Equal(t, 72*time.Hour, coup.ExpireAt.AsTime().Sub(coup.IssuedAt.AsTime()))
+
+	// The coupon stays valid after the campaign ends, until its own expiration
+	_, _, reason := validateCoupon(coup.Code, now.Add(48*time.Hour))
+	assert.Equal(t, couponv1.ValidationReason_VALIDATION_REASON_UNSPECIFIED, reason)
+	_, _, reason = validateCoupon(coup.Code, now.Add(96*time.Hour))
+	assert.Equal(t, couponv1.ValidationReason_VALIDATION_REASON_EXPIRED, reason)
+
+	_, err = srv.CreateCampaign(context.Background(), connect.NewRequest(&couponv1.CreateCampaignRequest{
+		CouponLimit:  10,
+		Name:         "Invalid Expiry Policy Test Campaign",
+		StartAt:      timestamppb.New(now.Add(-1 * time.Hour)),
+		EndAt:        timestamppb.New(now.Add(24 * time.Hour)),
+		ExpiryPolicy: &couponv1.ExpiryPolicy{},
+	}))
+	assert.Error(t, err)
+}
+
+// TestIssueCoupon_Discount verifies that issued coupons carry a snapshot of the campaign's discount.
+func TestIssueCoupon_Discount(t *testing.T) {
+	srv := NewCouponIssuanceServer()
+
+	now := time.Now().UTC()
+	createCampResp, err := srv.CreateCampaign(context.Background(), connect.NewRequest(&couponv1.CreateCampaignRequest{
+		CouponLimit: 10,
+		Name:        "Discount Test Campaign",
+		StartAt:     timestamppb.New(now.Add(-1 * time.Hour)),
+		EndAt:       timestamppb.New(now.Add(24 * time.Hour)),
+		Discount: &couponv1.Discount{
+			Kind: &couponv1.Discount_Percentage_{Percentage: &couponv1.Discount_Percentage{
+				BasisPoints: 1000,
+				Cap:         &couponv1.Money{Currency: "KRW", Amount: 10000},
+			}},
+			MinOrderAmount: &couponv1.Money{Currency: "KRW", Amount: 30000},
+		},
+	}))
+	require.NoError(t, err)
+
+	issueResp, err := srv.IssueCoupon(context.Background(), connect.NewRequest(&couponv1.IssueCouponRequest{
+		CampaignId: createCampResp.Msg.Campaign.Id,
+	}))
+	require.NoError(t, err)
+
+	d := issueResp.Msg.Coupon.Discount
+	require.NotNil(t, d)
+	assert.Equal(t, uint32(1000), d.GetPercentage().BasisPoints)
+	assert.Equal(t, int64(10000), d.GetPercentage().Cap.Amount)
+	assert.Equal(t, int64(30000), d.MinOrderAmount.Amount)
+}
+
+// attributeProviderFunc adapts a function to eligibility.AttributeProvider.
+type attributeProviderFunc func(ctx context.Context, userID string) (*couponv1.UserAttributes, error)
+
+func (f attributeProviderFunc) Attributes(ctx context.Context, userID string) (*couponv1.UserAttributes, error) {
+	return f(ctx, userID)
+}
+
+// TestIssueCoupon_Eligibility verifies that only users satisfying the campaign's eligibility rule are issued coupons,
+// and that ineligible users don't take a slot.
+func TestIssueCoupon_Eligibility(t *testing.T) {
+	srv := NewCouponIssuanceServer(WithAttributeProvider(attributeProviderFunc(
+		func(ctx context.Context, userID string) (*couponv1.UserAttributes, error) {
+			if userID == "gold-user" {
+				return &couponv1.UserAttributes{Tier: "gold", Country: "KR"}, nil
+			}
+			return nil, fmt.Errorf("unknown user %s", userID)
+		},
+	)))
+
+	now := time.Now().UTC()
+	createCampResp, err := srv.CreateCampaign(context.Background(), connect.NewRequest(&couponv1.CreateCampaignRequest{
+		CouponLimit: 1,
+		Name:        "Eligibility Test Campaign",
+		StartAt:     timestamppb.New(now.Add(-1 * time.Hour)),
+		EndAt:       timestamppb.New(now.Add(24 * time.Hour)),
+		Eligibility: `tier >= "gold" && country == "KR"`,
+	}))
+	require.NoError(t, err)
+	campId := createCampResp.Msg.Campaign.Id
+	assert.Equal(t, `tier >= "gold" && country == "KR"`, createCampResp.Msg.Campaign.Eligibility)
+
+	_, err = srv.IssueCoupon(context.Background(), connect.NewRequest(&couponv1.IssueCouponRequest{
+		CampaignId:     campId,
+		UserId:         "silver-user",
+		UserAttributes: &couponv1.UserAttributes{Tier: "silver", Country: "KR"},
+	}))
+	assert.EqualError(t, err, "user is not eligible for the campaign")
+
+	_, err = srv.IssueCoupon(context.Background(), connect.NewRequest(&couponv1.IssueCouponRequest{
+		CampaignId: campId,
+		UserId:     "unknown-user",
+	}))
+	assert.EqualError(t, err, "unknown user unknown-user")
+
+	issueResp, err := srv.IssueCoupon(context.Background(), connect.NewRequest(&couponv1.IssueCouponRequest{
+		CampaignId: campId,
+		UserId:     "gold-user",
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, "gold-user", issueResp.Msg.Coupon.UserId)
+
+	_, err = srv.CreateCampaign(context.Background(), connect.NewRequest(&couponv1.CreateCampaignRequest{
+		CouponLimit: 1,
+		Name:        "Invalid Eligibility Test Campaign",
+		StartAt:     timestamppb.New(now.Add(-1 * time.Hour)),
+		EndAt:       timestamppb.New(now.Add(24 * time.Hour)),
+		Eligibility: `tier >= 3`,
+	}))
+	assert.Error(t, err)
+}
+
+// TestIssueCoupon_Throttle verifies that a throttled campaign issues up to the quota of each time slice,
+// and tells when the next slice opens.
+func TestIssueCoupon_Throttle(t *testing.T) {
+	srv := NewCouponIssuanceServer()
+
+	now := time.Now().UTC()
+	startAt := now.Add(-30 * time.Minute)
+	createCampResp, err := srv.CreateCampaign(context.Background(), connect.NewRequest(&couponv1.CreateCampaignRequest{
+		CouponLimit: 10,
+		Name:        "Throttle Test Campaign",
+		StartAt:     timestamppb.New(startAt),
+		EndAt:       timestamppb.New(now.Add(24 * time.Hour)),
+		Throttle:    &couponv1.Throttle{Slice: durationpb.New(1 * time.Hour), Quota: 1},
+	}))
+	require.NoError(t, err)
+	campId := createCampResp.Msg.Campaign.Id
+
+	_, err = srv.IssueCoupon(context.Background(), connect.NewRequest(&couponv1.IssueCouponRequest{CampaignId: campId}))
+	require.NoError(t, err)
+
+	_, err = srv.IssueCoupon(context.Background(), connect.NewRequest(&couponv1.IssueCouponRequest{CampaignId: campId}))
+	var connectErr *connect.Error
+	require.ErrorAs(t, err, &connectErr)
+	assert.Equal(t, connect.CodeResourceExhausted, connectErr.Code())
+	require.Len(t, connectErr.Details(), 1)
+	detail, err := connectErr.Details()[0].Value()
+	require.NoError(t, err)
+	assert.Equal(t, startAt.Add(1*time.Hour), detail.(*couponv1.IssueThrottled).NextSliceAt.AsTime())
+	assert.NotEmpty(t, connectErr.Meta().Get("Retry-After"))
+
+	getResp, err := srv.GetCampaign(context.Background(), connect.NewRequest(&couponv1.GetCampaignRequest{CampaignId: campId}))
+	require.NoError(t, err)
+	assert.Len(t, getResp.Msg.Campaign.Coupons, 1)
+}